@@ -27,6 +27,8 @@ const (
 	// NodeDomainPrefix represents the node domain prefix
 	NodeDomainPrefix = "node.koordinator.sh"
 	PodDomainPrefix  = "pod.koordinator.sh"
+	// DeschedulerDomainPrefix represents the descheduler domain prefix
+	DeschedulerDomainPrefix = "descheduler.koordinator.sh"
 
 	LabelPodQoS      = DomainPrefix + "qosClass"
 	LabelPodPriority = DomainPrefix + "priority"
@@ -56,3 +58,18 @@ const (
 	P90 AggregationType = "p90"
 	P50 AggregationType = "p50"
 )
+
+// KnownAggregationTypes is the registry of the aggregation types reported in the NodeMetric.
+// The validation of the aggregation types in the component configurations is based on it as well,
+// so a new aggregation type takes effect only after it is added here.
+var KnownAggregationTypes = []AggregationType{AVG, P50, P90, P95, P99}
+
+// IsKnownAggregationType checks whether the aggregation type is in KnownAggregationTypes.
+func IsKnownAggregationType(aggregationType AggregationType) bool {
+	for _, t := range KnownAggregationTypes {
+		if t == aggregationType {
+			return true
+		}
+	}
+	return false
+}
@@ -37,6 +37,25 @@ const (
 	AnnotationEvictionCost = SchedulingDomainPrefix + "/eviction-cost"
 )
 
+const (
+	// LabelNodeQuarantine indicates the node is suspected to be bad and should be drained by the descheduler.
+	// It only takes effect when set to "true" and the descheduling plugin enables the quarantine draining.
+	LabelNodeQuarantine = DeschedulerDomainPrefix + "/quarantine"
+)
+
+const (
+	// AnnotationMigrationDisabled exempts the pod from migration when set to "true",
+	// the migration controller skips the pod regardless of the limiters, the selectors and the evict annotation.
+	// The value must be a boolean.
+	AnnotationMigrationDisabled = "migration.koordinator.sh/disabled"
+)
+
+const (
+	// AnnotationNoEvict exempts the pod from the RemoveIdleGPUPods descheduling plugin when set to "true",
+	// e.g. the interactive workloads which hold the GPUs while waiting for the users.
+	AnnotationNoEvict = DeschedulerDomainPrefix + "/no-evict"
+)
+
 const (
 	// AnnotationSoftEviction indicates custom eviction. It can be used to set to an "true".
 	AnnotationSoftEviction = SchedulingDomainPrefix + "/soft-eviction"
@@ -83,6 +102,20 @@ func GetEvictionCost(annotations map[string]string) (int32, error) {
 	return 0, nil
 }
 
+// IsMigrationDisabled returns whether the pod is exempted from migration by the annotation,
+// an error is returned if the annotation is not a boolean.
+func IsMigrationDisabled(annotations map[string]string) (bool, error) {
+	value, ok := annotations[AnnotationMigrationDisabled]
+	if !ok {
+		return false, nil
+	}
+	disabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q, must be a boolean", value)
+	}
+	return disabled, nil
+}
+
 func validFirstDigit(str string) bool {
 	if len(str) == 0 {
 		return false
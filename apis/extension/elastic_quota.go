@@ -19,6 +19,7 @@ package extension
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiserver/pkg/quota/v1"
@@ -56,6 +57,15 @@ const (
 	AnnotationNonPreemptibleUsed         = QuotaKoordinatorPrefix + "/non-preemptible-used"
 	AnnotationAdmission                  = QuotaKoordinatorPrefix + "/admission"
 	AnnotationMaxStrictCheckResourceKeys = QuotaKoordinatorPrefix + "/max-strict-check-resource-keys"
+	// AnnotationQuotaTempMax temporarily raises the quota's max for a bounded time, e.g. for a planned event.
+	// The value is a QuotaTempMax in JSON format.
+	AnnotationQuotaTempMax = DomainPrefix + "temp-max"
+	// AnnotationSharedWeightPinned indicates the shared weight of the quota is intentionally set by the user
+	// and should not be aligned with the keys of Spec.Max automatically.
+	AnnotationSharedWeightPinned = DomainPrefix + "shared-weight-pinned"
+	// AnnotationSharedWeightResourceKeys lists the resources participating in the shared weight fairness in JSON format,
+	// e.g. `["cpu"]`. The keys of the shared weight are aligned with the listed resources instead of all the keys of Spec.Max.
+	AnnotationSharedWeightResourceKeys = DomainPrefix + "shared-weight-resource-keys"
 )
 
 func GetParentQuotaName(quota *v1alpha1.ElasticQuota) string {
@@ -102,6 +112,10 @@ func GetSharedWeight(quota *v1alpha1.ElasticQuota) corev1.ResourceList {
 	return quota.Spec.Max.DeepCopy() //default equals to max
 }
 
+func IsSharedWeightPinned(quota *v1alpha1.ElasticQuota) bool {
+	return quota.Annotations[AnnotationSharedWeightPinned] == "true"
+}
+
 func IsForbiddenModify(quota *v1alpha1.ElasticQuota) (bool, error) {
 	if quota.Name == SystemQuotaName || quota.Name == RootQuotaName {
 		// can't modify SystemQuotaGroup
@@ -130,6 +144,30 @@ func GetAnnotationQuotaNamespaces(quota *v1alpha1.ElasticQuota) []string {
 	return namespaces
 }
 
+// QuotaTempMax is the temporarily raised max of a quota, which takes effect until the ExpireTime in RFC3339 format.
+type QuotaTempMax struct {
+	Max        corev1.ResourceList `json:"max"`
+	ExpireTime string              `json:"expireTime"`
+}
+
+// GetQuotaTempMax returns the temporarily raised max and its expire time of the quota.
+// It returns a nil max if the quota has no AnnotationQuotaTempMax.
+func GetQuotaTempMax(quota *v1alpha1.ElasticQuota) (corev1.ResourceList, time.Time, error) {
+	value := quota.Annotations[AnnotationQuotaTempMax]
+	if value == "" {
+		return nil, time.Time{}, nil
+	}
+	tempMax := &QuotaTempMax{}
+	if err := json.Unmarshal([]byte(value), tempMax); err != nil {
+		return nil, time.Time{}, err
+	}
+	expireTime, err := time.Parse(time.RFC3339, tempMax.ExpireTime)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid expireTime %q, err: %w", tempMax.ExpireTime, err)
+	}
+	return tempMax.Max, expireTime, nil
+}
+
 func GetNonPreemptibleRequest(quota *v1alpha1.ElasticQuota) (corev1.ResourceList, error) {
 	nonPreemptibleRequest := corev1.ResourceList{}
 	if quota.Annotations[AnnotationNonPreemptibleRequest] != "" {
@@ -230,3 +268,16 @@ func GetMaxStrictCheckResourceKeys(quota *v1alpha1.ElasticQuota) ([]corev1.Resou
 	}
 	return resources, nil
 }
+
+// GetSharedWeightResourceKeys returns the resources participating in the shared weight fairness.
+// It returns nil if all the resources of Spec.Max participate.
+func GetSharedWeightResourceKeys(quota *v1alpha1.ElasticQuota) ([]corev1.ResourceName, error) {
+	if quota.Annotations[AnnotationSharedWeightResourceKeys] == "" {
+		return nil, nil
+	}
+	resources := []corev1.ResourceName{}
+	if err := json.Unmarshal([]byte(quota.Annotations[AnnotationSharedWeightResourceKeys]), &resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
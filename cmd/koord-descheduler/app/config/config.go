@@ -27,6 +27,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
 )
 
 // Config has all the context to run a Scheduler
@@ -41,6 +42,10 @@ type Config struct {
 	InsecureMetricsServing *apiserver.DeprecatedInsecureServingInfo // non-nil if metrics should be served independently
 	SecureServing          *apiserver.SecureServingInfo
 
+	// Authentication and Authorization protect the admin endpoints served on the secure port.
+	Authentication apiserver.AuthenticationInfo
+	Authorization  apiserver.AuthorizationInfo
+
 	Manager            ctrl.Manager
 	Client             clientset.Interface
 	KubeConfig         *restclient.Config
@@ -52,6 +57,9 @@ type Config struct {
 
 	// LeaderElection is optional.
 	LeaderElection *leaderelection.LeaderElectionConfig
+
+	// SafeMode is optional. It is set if SafeModeEvictionRate is configured.
+	SafeMode *evictions.SafeMode
 }
 
 type completedConfig struct {
@@ -26,10 +26,15 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	genericapifilters "k8s.io/apiserver/pkg/endpoints/filters"
 	apirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/server"
@@ -38,6 +43,7 @@ import (
 	"k8s.io/apiserver/pkg/server/mux"
 	"k8s.io/apiserver/pkg/server/routes"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/record"
@@ -49,6 +55,7 @@ import (
 	"k8s.io/component-base/metrics/features"
 	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/component-base/term"
+	"k8s.io/component-base/tracing"
 	"k8s.io/component-base/version"
 	"k8s.io/component-base/version/verflag"
 	"k8s.io/klog/v2"
@@ -64,6 +71,7 @@ import (
 	deschedulercontrollersoptions "github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/options"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/loadaware"
 	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
 	"github.com/koordinator-sh/koordinator/pkg/util/transformer"
 )
@@ -164,13 +172,13 @@ func Run(ctx context.Context, cc *deschedulerappconfig.CompletedConfig, desched
 
 	// Start up the healthz server.
 	if cc.InsecureServing != nil {
-		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, checks...))
+		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, cc.SafeMode, desched, nil, checks...))
 		if err := cc.InsecureServing.Serve(handler, 0, ctx.Done()); err != nil {
 			return fmt.Errorf("failed to start healthz server: %v", err)
 		}
 	}
 	if cc.InsecureMetricsServing != nil {
-		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, checks...))
+		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, cc.SafeMode, desched, nil, checks...))
 		if err := cc.InsecureMetricsServing.Serve(handler, 0, ctx.Done()); err != nil {
 			return fmt.Errorf("failed to start metrics server: %v", err)
 		}
@@ -179,7 +187,8 @@ func Run(ctx context.Context, cc *deschedulerappconfig.CompletedConfig, desched
 	// Start up the healthz server.
 	gracefulShutdownSecureServer := func() {}
 	if cc.SecureServing != nil {
-		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, checks...))
+		adminFilter := newAdminFilter(cc.Authentication.Authenticator, cc.Authorization.Authorizer)
+		handler := buildHandlerChain(newHealthzAndMetricsHandler(&cc.ComponentConfig, cc.SafeMode, desched, adminFilter, checks...))
 		internalStopCh := make(chan struct{})
 		shutdownTimeout := 5 * time.Second
 		stoppedCh, listenerStoppedCh, err := cc.SecureServing.Serve(handler, shutdownTimeout, internalStopCh)
@@ -258,23 +267,89 @@ func buildHandlerChain(handler http.Handler) http.Handler {
 	return handler
 }
 
+// newAdminFilter returns the filter protecting the admin endpoints, which change the state of the descheduler
+// or expose the cluster state. The requests are authenticated by TokenReview and authorized by SubjectAccessReview
+// against the non-resource URL, e.g. the verb post on /safemode/reset. It returns nil if either is not configured,
+// and the admin endpoints are not served then.
+func newAdminFilter(authn authenticator.Request, authz authorizer.Authorizer) func(http.Handler) http.Handler {
+	if authn == nil || authz == nil {
+		return nil
+	}
+	failedHandler := genericapifilters.Unauthorized(scheme.Codecs)
+	return func(handler http.Handler) http.Handler {
+		handler = genericapifilters.WithAuthorization(handler, authz, scheme.Codecs)
+		handler = genericapifilters.WithAuthentication(handler, authn, failedHandler, nil, nil)
+		return handler
+	}
+}
+
 func installMetricHandler(pathRecorderMux *mux.PathRecorderMux) {
 	configz.InstallHandler(pathRecorderMux)
 	pathRecorderMux.Handle("/metrics", legacyregistry.HandlerWithReset())
 }
 
+// installSafeModeHandler installs the endpoint to leave the safe mode manually.
+func installSafeModeHandler(pathRecorderMux *mux.PathRecorderMux, safeMode *evictions.SafeMode, adminFilter func(http.Handler) http.Handler) {
+	pathRecorderMux.Handle("/safemode/reset", adminFilter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		safeMode.Reset()
+		w.WriteHeader(http.StatusOK)
+	})))
+}
+
+// installPauseHandler installs the endpoints to pause and resume descheduling without restarting.
+func installPauseHandler(pathRecorderMux *mux.PathRecorderMux, desched *descheduler.Descheduler, adminFilter func(http.Handler) http.Handler) {
+	pathRecorderMux.Handle("/pause", adminFilter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		desched.Pause()
+		w.WriteHeader(http.StatusOK)
+	})))
+	pathRecorderMux.Handle("/resume", adminFilter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		desched.Resume()
+		w.WriteHeader(http.StatusOK)
+	})))
+}
+
+// installSnapshotHandler installs the endpoints to dump the node utilization view of the LowNodeLoad plugins
+// and to query whether a pod is an eviction candidate of them, e.g. /debug/wouldevict?namespace=default&name=foo.
+func installSnapshotHandler(pathRecorderMux *mux.PathRecorderMux, adminFilter func(http.Handler) http.Handler) {
+	loadaware.EnableSnapshot()
+	pathRecorderMux.Handle("/debug/snapshot", adminFilter(loadaware.SnapshotHandler()))
+	pathRecorderMux.Handle("/debug/wouldevict", adminFilter(loadaware.WouldEvictHandler()))
+}
+
 // newHealthzAndMetricsHandler creates a healthz server from the config, and will also
-// embed the metrics handler.
-func newHealthzAndMetricsHandler(config *deschedulerconfig.DeschedulerConfiguration, checks ...healthz.HealthChecker) http.Handler {
+// embed the metrics handler. The admin endpoints are installed only if the adminFilter is given.
+func newHealthzAndMetricsHandler(config *deschedulerconfig.DeschedulerConfiguration, safeMode *evictions.SafeMode,
+	desched *descheduler.Descheduler, adminFilter func(http.Handler) http.Handler, checks ...healthz.HealthChecker) http.Handler {
 	pathRecorderMux := mux.NewPathRecorderMux("koord-descheduler")
 	healthz.InstallHandler(pathRecorderMux, checks...)
 	installMetricHandler(pathRecorderMux)
+	if safeMode != nil && adminFilter != nil {
+		installSafeModeHandler(pathRecorderMux, safeMode, adminFilter)
+	}
+	if desched != nil && adminFilter != nil {
+		installPauseHandler(pathRecorderMux, desched, adminFilter)
+	}
 	if config.EnableProfiling {
 		routes.Profiling{}.Install(pathRecorderMux)
 		if config.EnableContentionProfiling {
 			goruntime.SetBlockProfileRate(1)
 		}
 		routes.DebugFlags{}.Install(pathRecorderMux, "v", routes.StringFlagPutHandler(logs.GlogSetter))
+		if adminFilter != nil {
+			installSnapshotHandler(pathRecorderMux, adminFilter)
+		}
 	}
 	return pathRecorderMux
 }
@@ -323,7 +398,50 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 		cc.ComponentConfig.MaxNoOfPodsToEvictPerNode,
 		cc.ComponentConfig.MaxNoOfPodsToEvictPerNamespace,
 		cc.ComponentConfig.MaxNoOfPodsToEvictTotal)
+	if len(cc.ComponentConfig.MaxNoOfPodsToEvictPerQuota) > 0 {
+		evictionLimiter.SetMaxPodsToEvictPerQuota(cc.ComponentConfig.MaxNoOfPodsToEvictPerQuota)
+	}
+	if cc.ComponentConfig.SafeModeEvictionRate > 0 {
+		cc.SafeMode = evictions.NewSafeMode(cc.ComponentConfig.SafeModeEvictionRate, cc.ComponentConfig.SafeModeCooldown.Duration)
+		evictionLimiter.SetSafeMode(cc.SafeMode)
+	}
+	if cc.ComponentConfig.MaxEvictionFractionPerCycle != nil {
+		podLister := cc.InformerFactory.Core().V1().Pods().Lister()
+		evictionLimiter.SetMaxEvictionFraction(cc.ComponentConfig.MaxEvictionFractionPerCycle.FloatValue(), func() (int, error) {
+			pods, err := podLister.List(labels.Everything())
+			if err != nil {
+				return 0, err
+			}
+			count := 0
+			for _, pod := range pods {
+				if pod.Status.Phase == corev1.PodRunning {
+					count++
+				}
+			}
+			return count, nil
+		})
+	}
 
+	tracerProvider, err := tracing.NewProvider(ctx, cc.ComponentConfig.Tracing, nil,
+		[]resource.Option{resource.WithAttributes(semconv.ServiceNameKey.String("koord-descheduler"))})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create tracer provider, err: %w", err)
+	}
+	go func() {
+		<-ctx.Done()
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			klog.ErrorS(err, "Failed to shutdown tracer provider")
+		}
+	}()
+
+	var maxCycleDuration time.Duration
+	if cc.ComponentConfig.MaxCycleDuration != nil {
+		maxCycleDuration = cc.ComponentConfig.MaxCycleDuration.Duration
+	}
+	var observationPeriod time.Duration
+	if cc.ComponentConfig.InitialObservationPeriod != nil {
+		observationPeriod = cc.ComponentConfig.InitialObservationPeriod.Duration
+	}
 	desched, err := descheduler.New(
 		cc.Client,
 		cc.InformerFactory,
@@ -335,9 +453,19 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 		descheduler.WithProfiles(cc.ComponentConfig.Profiles...),
 		descheduler.WithFrameworkOutOfTreeRegistry(outOfTreeRegistry),
 		descheduler.WithDryRun(cc.ComponentConfig.DryRun),
+		descheduler.WithDisabled(cc.ComponentConfig.Disabled),
+		descheduler.WithEvictOrphanPods(cc.ComponentConfig.EvictOrphanPods),
+		descheduler.WithCountTerminatingPods(cc.ComponentConfig.CountTerminatingPods),
+		descheduler.WithEvictSystemCriticalPriorityClassPods(cc.ComponentConfig.EvictSystemCriticalPriorityClassPods),
 		descheduler.WithDeschedulingInterval(cc.ComponentConfig.DeschedulingInterval.Duration),
+		descheduler.WithStartupGracePeriod(cc.ComponentConfig.StartupGracePeriod.Duration),
+		descheduler.WithInitialObservationPeriod(observationPeriod),
+		descheduler.WithMaxConcurrentProfiles(int(cc.ComponentConfig.MaxConcurrentProfiles)),
+		descheduler.WithMaxCycleDuration(maxCycleDuration),
 		descheduler.WithNodeSelector(cc.ComponentConfig.NodeSelector),
 		descheduler.WithEvictionLimiter(evictionLimiter),
+		descheduler.WithEvictionEventRate(cc.ComponentConfig.EvictionEventRate),
+		descheduler.WithTracerProvider(tracerProvider),
 		descheduler.WithPodAssignedToNodeFn(podAssignedToNode(cc.Manager.GetClient())),
 		descheduler.WithBuildFrameworkCapturer(func(profile deschedulerconfig.DeschedulerProfile) {
 			completedProfiles = append(completedProfiles, profile)
@@ -48,6 +48,7 @@ import (
 	kmmetrics "github.com/koordinator-sh/koordinator/pkg/util/metrics/koordmanager"
 	"github.com/koordinator-sh/koordinator/pkg/util/sloconfig"
 	"github.com/koordinator-sh/koordinator/pkg/webhook"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/elasticquota"
 	podvalidating "github.com/koordinator-sh/koordinator/pkg/webhook/pod/validating"
 	// +kubebuilder:scaffold:imports
 )
@@ -83,6 +84,7 @@ func main() {
 	opts.InitFlags(flag.CommandLine)
 	sloconfig.InitFlags(flag.CommandLine)
 	podvalidating.InitFlags(flag.CommandLine)
+	elasticquota.InitFlags(flag.CommandLine)
 	utilfeature.DefaultMutableFeatureGate.AddFlag(pflag.CommandLine)
 	klog.InitFlags(nil)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
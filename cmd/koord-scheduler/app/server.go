@@ -65,6 +65,7 @@ import (
 
 	schedulerserverconfig "github.com/koordinator-sh/koordinator/cmd/koord-scheduler/app/config"
 	"github.com/koordinator-sh/koordinator/cmd/koord-scheduler/app/options"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/validation"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/defaultprofile"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/eventhandlers"
@@ -386,6 +387,10 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 	// Get the completed config
 	cc := c.Complete()
 
+	if err := validation.ValidateProfilesReservationArgs(cc.ComponentConfig.Profiles); err != nil {
+		return nil, nil, nil, err
+	}
+
 	defaultprofile.AppendDefaultPlugins(cc.ComponentConfig.Profiles)
 
 	informer.SetupCustomInformers(cc.InformerFactory)
@@ -48,6 +48,16 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DeschedulerConfiguration{},
 		&MigrationControllerArgs{},
 		&LowNodeLoadArgs{},
+		&DrainScaleDownCandidatesArgs{},
+		&RemovePodsWithOutdatedRequestsArgs{},
+		&RemoveIdleGPUPodsArgs{},
+		&RemovePodsViolatingNodeSelectorArgs{},
+		&RemovePodsViolatingNodeTaintEffectsArgs{},
+		&BalanceAcrossZonesArgs{},
+		&RemoveFailedReadinessPodsArgs{},
+		&RemovePodsWithMissingReferencesArgs{},
+		&RebalanceForQuotaFairnessArgs{},
+		&RemovePodsViolatingRequestLimitRatioArgs{},
 	)
 	return nil
 }
@@ -21,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/component-base/config"
+	tracingapi "k8s.io/component-base/tracing/api/v1"
 )
 
 const (
@@ -56,9 +57,32 @@ type DeschedulerConfiguration struct {
 	// Dry run
 	DryRun bool
 
+	// Disabled is a kill switch of descheduling. If true, all profiles only collect the candidates
+	// in dry-run mode without evicting any pod, regardless of the profile's Disabled.
+	Disabled bool
+
+	// EvictOrphanPods allows the pods without any ownerReferences to be evicted.
+	// Note that an evicted orphan pod is lost permanently since no controller recreates it.
+	// If false, the orphan pods are skipped by all the profiles regardless of the evictor's arguments.
+	EvictOrphanPods bool
+
+	// CountTerminatingPods allows the pods already terminating, i.e. with a deletionTimestamp, to be the candidates
+	// of the plugins and to count against the eviction limits.
+	// If false, the terminating pods are skipped by all the profiles since they are going away anyway.
+	CountTerminatingPods bool
+
+	// EvictSystemCriticalPriorityClassPods allows the pods with the system-cluster-critical or system-node-critical
+	// priority class to be evicted. It requires MaxNoOfPodsToEvictTotal or MaxEvictionFractionPerCycle to be set.
+	// If false, these pods are skipped by all the profiles regardless of the evictor's arguments.
+	EvictSystemCriticalPriorityClassPods bool
+
 	// Profiles are descheduling profiles that koord-descheduler supports.
 	Profiles []DeschedulerProfile
 
+	// MaxConcurrentProfiles is the maximum number of profiles running at the same time,
+	// and the rest are queued. Defaults to 1, which means profiles are executed one by one.
+	MaxConcurrentProfiles int32
+
 	// NodeSelector for a set of nodes to operate over
 	NodeSelector *metav1.LabelSelector
 
@@ -68,8 +92,50 @@ type DeschedulerConfiguration struct {
 	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace.
 	MaxNoOfPodsToEvictPerNamespace *uint
 
+	// MaxNoOfPodsToEvictPerQuota restricts maximum of pods to be evicted per ElasticQuota, keyed by the quota name.
+	// The quota of a pod is resolved by the quota.scheduling.koordinator.sh/name label, and the pods without
+	// the label or of a quota not in the map are not restricted by it.
+	MaxNoOfPodsToEvictPerQuota map[string]uint
+
 	// MaxNoOfPodsToTotal restricts maximum of pods to be evicted total.
 	MaxNoOfPodsToEvictTotal *uint
+
+	// MaxEvictionFractionPerCycle restricts the pods to be evicted total in a descheduling cycle
+	// to a fraction in (0, 1] of the running pods in the cluster, which is counted at the beginning
+	// of every cycle. The smaller one of it and MaxNoOfPodsToEvictTotal takes effect.
+	MaxEvictionFractionPerCycle *Float64OrString
+
+	// StartupGracePeriod is the duration a freshly started or elected descheduler waits
+	// to collect fresh metrics before descheduling. Zero means no waiting.
+	StartupGracePeriod metav1.Duration
+
+	// InitialObservationPeriod is the duration after the StartupGracePeriod during which the descheduling cycles
+	// run in dry-run mode, so that the decisions made on cold caches are only exposed in the logs and metrics
+	// without evicting any pod. Unlike the StartupGracePeriod, the cycles do run. It is disabled if it is nil.
+	InitialObservationPeriod *metav1.Duration
+
+	// SafeModeEvictionRate is the maximum number of evictions per minute across all the profiles.
+	// Once it is exceeded, the descheduler enters the safe mode and stops evicting for SafeModeCooldown.
+	// Zero means the safe mode is disabled.
+	SafeModeEvictionRate int32
+
+	// SafeModeCooldown is the duration the descheduler stops evicting after entering the safe mode.
+	// Defaults to 10 minutes if SafeModeEvictionRate is set.
+	SafeModeCooldown metav1.Duration
+
+	// EvictionEventRate is the maximum number of Events per minute emitted on the evicted pods
+	// describing the plugin, the profile and the reason of the eviction. The excess events are dropped.
+	// Zero means no such event is emitted.
+	EvictionEventRate int32
+
+	// MaxCycleDuration is the maximum duration of a descheduling cycle. Once it is exceeded, the cycle stops
+	// after finishing the current node, and the unprocessed nodes are processed first in the next cycle.
+	// The cycle is not limited if it is nil.
+	MaxCycleDuration *metav1.Duration
+
+	// Tracing holds the configuration of OpenTelemetry tracing for descheduling cycles.
+	// Tracing is disabled if it is nil.
+	Tracing *tracingapi.TracingConfiguration
 }
 
 // DeschedulerProfile is a descheduling profile.
@@ -78,8 +144,28 @@ type DeschedulerProfile struct {
 	PluginConfig []PluginConfig
 	Plugins      *Plugins
 	NodeSelector *metav1.LabelSelector
+	// NamespaceSelector selects the namespaces whose pods are considered by the profile.
+	// Nil means all the namespaces.
+	NamespaceSelector *metav1.LabelSelector
+	// Disabled means the profile only collects the candidates in dry-run mode without evicting any pod.
+	Disabled bool
+	// ExtensionPointOrder is the order to run the Deschedule and Balance extension points of the profile
+	// in a descheduling cycle, each of them can appear at most once. The omitted ones run after the specified
+	// ones in the default order, which is Deschedule and then Balance.
+	ExtensionPointOrder []string
 }
 
+const (
+	ExtensionPointDeschedule = "Deschedule"
+	ExtensionPointBalance    = "Balance"
+)
+
+// DefaultExtensionPointOrder is the default order to run the extension points of a profile.
+var DefaultExtensionPointOrder = []string{ExtensionPointDeschedule, ExtensionPointBalance}
+
+// Plugins include multiple extension points. A plugin should be enabled in at most one of
+// Deschedule and Balance, otherwise it runs twice in a descheduling cycle and double-counts the
+// evictions. Evict and Filter are separate concerns and can be enabled alongside either of them.
 type Plugins struct {
 	Deschedule PluginSet
 	Balance    PluginSet
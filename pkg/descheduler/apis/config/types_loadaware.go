@@ -18,6 +18,7 @@ package config
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -41,11 +42,47 @@ type LowNodeLoadArgs struct {
 	// By default, NumberOfNodes is set to zero.
 	NumberOfNodes int32
 
+	// MinNodesInScope is the minimum number of nodes selected by a node pool for the strategy to run.
+	// When fewer nodes are in scope, rebalancing is meaningless and the node pool is skipped.
+	// By default, MinNodesInScope is set to zero.
+	MinNodesInScope int32
+
+	// EvictQuarantinedNodes enables draining the nodes labeled with `descheduler.koordinator.sh/quarantine: "true"`.
+	// All the evictable pods on the quarantined nodes are evicted regardless of the utilization thresholds.
+	// Default is false
+	EvictQuarantinedNodes bool
+
+	// ThrottledNodeConditionType is the type of a custom NodeCondition reporting the node is throttled, e.g. `ThermalThrottle`.
+	// The nodes with the condition in status True are considered overutilized once their usage exceeds the low thresholds,
+	// so they are relieved regardless of the high thresholds, and they are never considered underutilized.
+	// Empty means the node conditions are not considered.
+	ThrottledNodeConditionType corev1.NodeConditionType
+
+	// ConsolidationTargetUtilization enables consolidating the underutilized nodes to let the cluster autoscaler scale them down.
+	// The nodes under the low thresholds are drained from the least utilized one, as long as all their evictable pods
+	// fit on the remaining nodes without exceeding the target utilization percentages of the remaining nodes.
+	// Empty means the underutilized nodes are not consolidated.
+	ConsolidationTargetUtilization ResourceThresholds
+
+	// CriticalThresholds defines the usage percentages of the safety-critical node resources, e.g. ephemeral-storage,
+	// above which the node is at risk of failure. The pods on the nodes above any critical threshold are evicted
+	// beyond MaxNoOfPodsToEvictPerNode, while the other eviction limits and the PodDisruptionBudgets still apply.
+	// It only takes effect for the resources with thresholds in the node pool, and must not be lower than the high thresholds.
+	CriticalThresholds ResourceThresholds
+
 	// NodeMetricExpirationSeconds indicates the NodeMetric expiration in seconds.
 	// When NodeMetrics expired, the node is considered abnormal, and should not be considered by deschedule plugin.
+	// NodeMetrics are reported by koordlet every 60 seconds by default, and shared with the LoadAwareScheduling plugin
+	// of koord-scheduler. It is recommended to be at least three times the report interval and to be consistent with
+	// the NodeMetricExpirationSeconds of LoadAwareSchedulingArgs.
 	// Default is 180 seconds.
 	NodeMetricExpirationSeconds *int64
 
+	// NodeMetricExpirationByResource overrides NodeMetricExpirationSeconds for the specified resources,
+	// e.g. to tolerate memory metrics which are updated less frequently than cpu metrics.
+	// The resources not specified fall back to NodeMetricExpirationSeconds.
+	NodeMetricExpirationByResource map[corev1.ResourceName]int64
+
 	// Naming this one differently since namespaces are still
 	// considered while considering resoures used by pods
 	// but then filtered out before eviction
@@ -57,10 +94,41 @@ type LowNodeLoadArgs struct {
 	// PodSelectors selects the pods that matched labelSelector
 	PodSelectors []LowNodeLoadPodSelector
 
+	// PodSelectorMatchMode decides how multiple PodSelectors are combined.
+	// Any means a pod is selected if it matches any of the selectors, All means it must match all of them.
+	// Default is Any.
+	PodSelectorMatchMode PodSelectorMatchMode
+
+	// NodeProcessingOrder decides the order in which the overutilized nodes are processed,
+	// which affects the nodes favored under the eviction limits. Default is MostOverutilizedFirst.
+	NodeProcessingOrder NodeProcessingOrder
+
+	// OwnerKindEvictionPriority orders the candidate pods by the kind of their top-level owner before the other tie-breakers,
+	// the pods owned by the kinds in front are evicted first, e.g. ["Job", "Deployment"] evicts transient Jobs before Deployments.
+	// The pods owned by the kinds not listed are evicted after the listed ones.
+	OwnerKindEvictionPriority []string
+
+	// CandidateOrderSeed makes the eviction order of the candidate nodes and pods with equal scores reproducible.
+	// The candidates are shuffled with the seed and then sorted stably, so the same seed produces the same
+	// eviction order given the same input, e.g. for dry-runs. If it is not set, the order of ties is undefined.
+	CandidateOrderSeed *int64
+
+	// EvictionAggressiveness is the fraction in (0, 1] of the usage above the high thresholds to relieve in one
+	// descheduling cycle, e.g. 0.5 evicts pods until half of the excess is relieved, so that the overutilized
+	// nodes converge to the thresholds over multiple cycles instead of evicting many pods at once.
+	// Default is 1, which relieves all the excess in one cycle.
+	EvictionAggressiveness *Float64OrString
+
 	// NodeFit if enabled, it will check whether the candidate Pods have suitable nodes, including NodeAffinity, TaintTolerance, and whether resources are sufficient.
 	// by default, NodeFit is set to true.
 	NodeFit bool
 
+	// EmitDestinationHint if enabled, the node found by NodeFit for the evicted pod is passed to the evictor as a hint
+	// of the destination, e.g. the MigrationController prefers the node when scheduling the reservation of the pod.
+	// The hint is only a preference, so the pod can still be scheduled to other nodes if the hint becomes stale.
+	// It requires NodeFit to be enabled.
+	EmitDestinationHint bool
+
 	// If UseDeviationThresholds is set to `true`, the thresholds are considered as percentage deviations from mean resource usage.
 	// `LowThresholds` will be deducted from the mean among all nodes and `HighThresholds` will be added to the mean.
 	// A resource consumption above (resp. below) this window is considered as overutilization (resp. underutilization).
@@ -72,6 +140,17 @@ type LowNodeLoadArgs struct {
 	// LowThresholds defines the low usage threshold of node resources
 	LowThresholds ResourceThresholds
 
+	// AbsoluteHighThresholds defines the target usage threshold of node resources in absolute quantities,
+	// a resource can be only set in one of HighThresholds/LowThresholds and AbsoluteHighThresholds/AbsoluteLowThresholds.
+	AbsoluteHighThresholds map[corev1.ResourceName]resource.Quantity
+
+	// AbsoluteLowThresholds defines the low usage threshold of node resources in absolute quantities
+	AbsoluteLowThresholds map[corev1.ResourceName]resource.Quantity
+
+	// MinAbsoluteUsage defines the usage floor of node resources, a node is not considered overutilized for a resource
+	// unless its usage exceeds the floor regardless of the thresholds, which avoids flagging small nodes with tiny usage.
+	MinAbsoluteUsage map[corev1.ResourceName]resource.Quantity
+
 	// ProdHighThresholds defines the target usage threshold of Prod resources
 	ProdHighThresholds ResourceThresholds
 
@@ -87,7 +166,8 @@ type LowNodeLoadArgs struct {
 	// it is determined that the node is abnormal, and the Pods need to be migrated to reduce the load.
 	AnomalyCondition *LoadAnomalyCondition
 
-	// DetectorCacheTimeout indicates the cache expiration time of nodeAnomalyDetectors, the default is 5 minutes
+	// DetectorCacheTimeout indicates the cache expiration time of nodeAnomalyDetectors, the default is 5 minutes if nil.
+	// It must be positive when AnomalyCondition is set.
 	DetectorCacheTimeout *metav1.Duration
 
 	// NodePools supports multiple different types of batch nodes to configure different strategies
@@ -95,9 +175,9 @@ type LowNodeLoadArgs struct {
 }
 
 type LowNodeLoadNodePool struct {
-	// Name represents the name of pool
+	// Name represents the name of pool, it must be unique among the node pools
 	Name string
-	// NodeSelector selects the nodes that matched labelSelector
+	// NodeSelector selects the nodes that matched labelSelector, it is required and an empty selector selects all nodes
 	NodeSelector *metav1.LabelSelector
 	// If UseDeviationThresholds is set to `true`, the thresholds are considered as percentage deviations from mean resource usage.
 	// `LowThresholds` will be deducted from the mean among all nodes and `HighThresholds` will be added to the mean.
@@ -110,6 +190,17 @@ type LowNodeLoadNodePool struct {
 	// LowThresholds defines the low usage threshold of node resources
 	LowThresholds ResourceThresholds
 
+	// AbsoluteHighThresholds defines the target usage threshold of node resources in absolute quantities,
+	// a resource can be only set in one of HighThresholds/LowThresholds and AbsoluteHighThresholds/AbsoluteLowThresholds.
+	AbsoluteHighThresholds map[corev1.ResourceName]resource.Quantity
+
+	// AbsoluteLowThresholds defines the low usage threshold of node resources in absolute quantities
+	AbsoluteLowThresholds map[corev1.ResourceName]resource.Quantity
+
+	// MinAbsoluteUsage defines the usage floor of node resources, a node is not considered overutilized for a resource
+	// unless its usage exceeds the floor regardless of the thresholds, which avoids flagging small nodes with tiny usage.
+	MinAbsoluteUsage map[corev1.ResourceName]resource.Quantity
+
 	// ProdHighThresholds defines the target usage threshold of Prod resources
 	ProdHighThresholds ResourceThresholds `json:"prodHighThresholds,omitempty"`
 
@@ -117,7 +208,8 @@ type LowNodeLoadNodePool struct {
 	ProdLowThresholds ResourceThresholds `json:"prodLowThresholds,omitempty"`
 
 	// ResourceWeights indicates the weights of resources.
-	// The weights of resources are both 1 by default.
+	// The weights of the resources in HighThresholds/LowThresholds are 1 by default,
+	// once specified, the weights must cover all these resources.
 	ResourceWeights map[corev1.ResourceName]int64
 
 	// AnomalyCondition indicates the node load anomaly thresholds,
@@ -126,6 +218,28 @@ type LowNodeLoadNodePool struct {
 	AnomalyCondition *LoadAnomalyCondition
 }
 
+// PodSelectorMatchMode defines how multiple pod selectors are combined.
+type PodSelectorMatchMode string
+
+const (
+	// PodSelectorMatchModeAny selects the pods that matched any of the selectors.
+	PodSelectorMatchModeAny PodSelectorMatchMode = "Any"
+	// PodSelectorMatchModeAll selects the pods that matched all of the selectors.
+	PodSelectorMatchModeAll PodSelectorMatchMode = "All"
+)
+
+// NodeProcessingOrder defines the order in which the overutilized nodes are processed.
+type NodeProcessingOrder string
+
+const (
+	// NodeProcessingOrderMostOverutilizedFirst processes the nodes with the highest usage first.
+	NodeProcessingOrderMostOverutilizedFirst NodeProcessingOrder = "MostOverutilizedFirst"
+	// NodeProcessingOrderLeastFirst processes the nodes with the lowest usage first.
+	NodeProcessingOrderLeastFirst NodeProcessingOrder = "LeastFirst"
+	// NodeProcessingOrderByName processes the nodes in the alphabetical order of the node names.
+	NodeProcessingOrderByName NodeProcessingOrder = "ByName"
+)
+
 type LowNodeLoadPodSelector struct {
 	Name string
 
@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsViolatingNodeTaintEffectsArgs holds arguments used to configure the RemovePodsViolatingNodeTaintEffects plugin.
+type RemovePodsViolatingNodeTaintEffectsArgs struct {
+	metav1.TypeMeta
+
+	// Paused indicates whether the RemovePodsViolatingNodeTaintEffects should to work or not.
+	Paused bool
+
+	// NodeFit if enabled, it will check whether the pod fits any other node before evicting it.
+	NodeFit bool
+
+	// TaintEffects are the effects of the node taints to be enforced,
+	// the pods not tolerating a taint of these effects on their nodes are evicted.
+	TaintEffects []corev1.TaintEffect
+
+	// ExcludedTaints are the node taints ignored by the plugin, each one is a taint key or a key=value pair.
+	ExcludedTaints []string
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	EvictableNamespaces *Namespaces
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RebalanceForQuotaFairnessArgs holds arguments used to configure the RebalanceForQuotaFairness plugin.
+type RebalanceForQuotaFairnessArgs struct {
+	metav1.TypeMeta
+
+	// Paused indicates whether the RebalanceForQuotaFairness should to work or not.
+	Paused bool
+
+	// DryRun means only execute the entire rebalance logic but don't evict Pod
+	DryRun bool
+
+	// Resources are the resources compared to find the starved and the over-share quotas.
+	Resources []corev1.ResourceName
+
+	// MaxEvictionsPerQuota is the maximum number of pods evicted from one over-share quota in a cycle.
+	MaxEvictionsPerQuota int32
+
+	// Namespaces carries a list of included/excluded namespaces
+	Namespaces *Namespaces
+}
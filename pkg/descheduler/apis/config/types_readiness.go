@@ -0,0 +1,37 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemoveFailedReadinessPodsArgs holds arguments used to configure the RemoveFailedReadinessPods plugin.
+type RemoveFailedReadinessPodsArgs struct {
+	metav1.TypeMeta
+
+	// Paused indicates whether the RemoveFailedReadinessPods should to work or not.
+	Paused bool
+
+	// MinUnreadyDuration is how long the readiness of a pod has to fail continuously before it is evicted.
+	MinUnreadyDuration metav1.Duration
+
+	// Namespaces carries a list of included/excluded namespaces
+	Namespaces *Namespaces
+}
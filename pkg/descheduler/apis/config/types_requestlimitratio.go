@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsViolatingRequestLimitRatioArgs holds arguments used to configure the RemovePodsViolatingRequestLimitRatio plugin.
+type RemovePodsViolatingRequestLimitRatioArgs struct {
+	metav1.TypeMeta
+
+	// Paused indicates whether the RemovePodsViolatingRequestLimitRatio should to work or not.
+	Paused bool
+
+	// NodeFit if enabled, it will check whether the pod fits any other node before evicting it.
+	NodeFit bool
+
+	// MinRequestLimitRatios are the lower bounds of the request/limit percentage of the containers per resource.
+	MinRequestLimitRatios ResourceThresholds
+
+	// MaxRequestLimitRatios are the upper bounds of the request/limit percentage of the containers per resource.
+	MaxRequestLimitRatios ResourceThresholds
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	EvictableNamespaces *Namespaces
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha2
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/conversion"
 
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
@@ -27,23 +29,90 @@ func Convert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in *LowNodeLoadA
 		return err
 	}
 
+	for i := range out.NodePools {
+		inheritThresholds(&out.NodePools[i], out)
+	}
+
 	pool := config.LowNodeLoadNodePool{
 		Name:                   "__default_node_pool__",
 		NodeSelector:           out.NodeSelector,
 		UseDeviationThresholds: out.UseDeviationThresholds,
 		HighThresholds:         out.HighThresholds,
 		LowThresholds:          out.LowThresholds,
+		AbsoluteHighThresholds: out.AbsoluteHighThresholds,
+		AbsoluteLowThresholds:  out.AbsoluteLowThresholds,
+		MinAbsoluteUsage:       out.MinAbsoluteUsage,
 		ProdHighThresholds:     out.ProdHighThresholds,
 		ProdLowThresholds:      out.ProdLowThresholds,
 		ResourceWeights:        out.ResourceWeights,
 		AnomalyCondition:       out.AnomalyCondition,
 	}
+	if pool.NodeSelector == nil {
+		// the default node pool selects all nodes unless the NodeSelector is set
+		pool.NodeSelector = &metav1.LabelSelector{}
+	}
 	out.NodePools = append([]config.LowNodeLoadNodePool{pool}, out.NodePools...)
 	out.NodeSelector = nil
 	out.UseDeviationThresholds = false
 	out.HighThresholds = nil
 	out.LowThresholds = nil
+	out.AbsoluteHighThresholds = nil
+	out.AbsoluteLowThresholds = nil
+	out.MinAbsoluteUsage = nil
 	out.ResourceWeights = nil
 	out.AnomalyCondition = nil
 	return nil
 }
+
+// inheritThresholds fills the thresholds omitted by the node pool with the global ones per resource, so that
+// the node pool only overrides the thresholds it sets, e.g. a node pool setting only the highThresholds inherits
+// the global lowThresholds. The inherited resources are weighted as the global ones unless weighted by the node pool.
+// Nothing is inherited across the percentage and deviation thresholds since they are not comparable.
+func inheritThresholds(nodePool *config.LowNodeLoadNodePool, args *config.LowNodeLoadArgs) {
+	if nodePool.UseDeviationThresholds != args.UseDeviationThresholds {
+		return
+	}
+	inherited := map[corev1.ResourceName]bool{}
+	nodePool.LowThresholds = mergeThresholds(args.LowThresholds, nodePool.LowThresholds, inherited)
+	nodePool.HighThresholds = mergeThresholds(args.HighThresholds, nodePool.HighThresholds, inherited)
+	nodePool.ProdLowThresholds = mergeThresholds(args.ProdLowThresholds, nodePool.ProdLowThresholds, inherited)
+	nodePool.ProdHighThresholds = mergeThresholds(args.ProdHighThresholds, nodePool.ProdHighThresholds, inherited)
+	if len(inherited) == 0 {
+		return
+	}
+
+	resourceWeights := make(map[corev1.ResourceName]int64, len(nodePool.ResourceWeights)+len(inherited))
+	for resourceName, weight := range nodePool.ResourceWeights {
+		resourceWeights[resourceName] = weight
+	}
+	for resourceName := range inherited {
+		if _, ok := resourceWeights[resourceName]; ok {
+			continue
+		}
+		if weight, ok := args.ResourceWeights[resourceName]; ok {
+			resourceWeights[resourceName] = weight
+		} else {
+			resourceWeights[resourceName] = 1
+		}
+	}
+	nodePool.ResourceWeights = resourceWeights
+}
+
+// mergeThresholds returns the global thresholds overridden by the ones of the node pool, and records the resources
+// inherited from the global thresholds. The thresholds are copied since they may be shared with the versioned args.
+func mergeThresholds(global, overrides config.ResourceThresholds, inherited map[corev1.ResourceName]bool) config.ResourceThresholds {
+	if len(global) == 0 {
+		return overrides
+	}
+	merged := make(config.ResourceThresholds, len(global)+len(overrides))
+	for resourceName, percentage := range global {
+		if _, ok := overrides[resourceName]; !ok {
+			inherited[resourceName] = true
+		}
+		merged[resourceName] = percentage
+	}
+	for resourceName, percentage := range overrides {
+		merged[resourceName] = percentage
+	}
+	return merged
+}
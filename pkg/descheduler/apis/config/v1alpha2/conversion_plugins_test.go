@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestConvert_v1alpha2_LowNodeLoadArgs_InheritThresholds(t *testing.T) {
+	in := &LowNodeLoadArgs{
+		LowThresholds:   ResourceThresholds{corev1.ResourceCPU: 30, corev1.ResourceMemory: 40},
+		HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 70, corev1.ResourceMemory: 80},
+		ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1, corev1.ResourceMemory: 2},
+		NodePools: []LowNodeLoadNodePool{
+			{
+				Name: "without-thresholds",
+			},
+			{
+				Name:            "override-high",
+				HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 60},
+				ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 3},
+			},
+			{
+				Name:                   "deviation",
+				UseDeviationThresholds: true,
+				HighThresholds:         ResourceThresholds{corev1.ResourceCPU: 10},
+				ResourceWeights:        map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+			},
+		},
+	}
+	out := &config.LowNodeLoadArgs{}
+	assert.NoError(t, Convert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in, out, nil))
+	assert.Len(t, out.NodePools, 4)
+	assert.Equal(t, "__default_node_pool__", out.NodePools[0].Name)
+
+	withoutThresholds := out.NodePools[1]
+	assert.Equal(t, config.ResourceThresholds{corev1.ResourceCPU: 30, corev1.ResourceMemory: 40}, withoutThresholds.LowThresholds)
+	assert.Equal(t, config.ResourceThresholds{corev1.ResourceCPU: 70, corev1.ResourceMemory: 80}, withoutThresholds.HighThresholds)
+	assert.Equal(t, map[corev1.ResourceName]int64{corev1.ResourceCPU: 1, corev1.ResourceMemory: 2}, withoutThresholds.ResourceWeights)
+
+	overrideHigh := out.NodePools[2]
+	assert.Equal(t, config.ResourceThresholds{corev1.ResourceCPU: 30, corev1.ResourceMemory: 40}, overrideHigh.LowThresholds)
+	assert.Equal(t, config.ResourceThresholds{corev1.ResourceCPU: 60, corev1.ResourceMemory: 80}, overrideHigh.HighThresholds)
+	assert.Equal(t, map[corev1.ResourceName]int64{corev1.ResourceCPU: 3, corev1.ResourceMemory: 2}, overrideHigh.ResourceWeights)
+
+	// nothing is inherited across the percentage and deviation thresholds
+	deviation := out.NodePools[3]
+	assert.Nil(t, deviation.LowThresholds)
+	assert.Equal(t, config.ResourceThresholds{corev1.ResourceCPU: 10}, deviation.HighThresholds)
+	assert.Equal(t, map[corev1.ResourceName]int64{corev1.ResourceCPU: 1}, deviation.ResourceWeights)
+
+	// the versioned args are not modified
+	assert.Equal(t, ResourceThresholds{corev1.ResourceCPU: 60}, in.NodePools[1].HighThresholds)
+	assert.Equal(t, map[corev1.ResourceName]int64{corev1.ResourceCPU: 3}, in.NodePools[1].ResourceWeights)
+}
@@ -46,6 +46,20 @@ const (
 	defaultSchedulerSupportReservation = "koord-scheduler"
 	defaultArbitrationInterval         = 500 * time.Millisecond
 	defaultDetectorCacheTimeout        = 5 * time.Minute
+	defaultSafeModeCooldown            = 10 * time.Minute
+
+	defaultOutdatedRequestsDivergenceThreshold Percentage = 20
+
+	defaultBalanceAcrossZonesMaxSkew = 1
+
+	defaultMinUnreadyDuration = 10 * time.Minute
+
+	defaultMinFailingDuration = 10 * time.Minute
+
+	defaultMaxEvictionsPerQuota = 5
+
+	defaultIdleGPUUtilizationThreshold = 5
+	defaultGPUIdleDuration             = 30 * time.Minute
 )
 
 var (
@@ -56,6 +70,10 @@ var (
 		// namespace object limiter is disabled as default
 	}
 
+	defaultOutdatedRequestsResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+	defaultQuotaFairnessResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
 	defaultLoadAnomalyCondition = &LoadAnomalyCondition{
 		Timeout:                  &metav1.Duration{Duration: 1 * time.Minute},
 		ConsecutiveAbnormalities: 5,
@@ -90,6 +108,10 @@ func setDefaults_Profile(prof *DeschedulerProfile) {
 	// Set default plugins.
 	prof.Plugins = mergePlugins(getDefaultPlugins(), prof.Plugins)
 
+	if len(prof.ExtensionPointOrder) == 0 {
+		prof.ExtensionPointOrder = append([]string{}, config.DefaultExtensionPointOrder...)
+	}
+
 	// Set default plugin configs.
 	scheme := GetPluginArgConversionScheme()
 	existingConfigs := sets.NewString()
@@ -139,6 +161,14 @@ func SetDefaults_DeschedulerConfiguration(obj *DeschedulerConfiguration) {
 		setDefaults_Profile(prof)
 	}
 
+	if obj.MaxConcurrentProfiles == nil {
+		obj.MaxConcurrentProfiles = pointer.Int32(1)
+	}
+
+	if obj.SafeModeEvictionRate > 0 && obj.SafeModeCooldown.Duration == 0 {
+		obj.SafeModeCooldown = metav1.Duration{Duration: defaultSafeModeCooldown}
+	}
+
 	if len(obj.LeaderElection.ResourceLock) == 0 {
 		// Use lease-based leader election to reduce cost.
 		// We migrated for EndpointsLease lock in 1.17 and starting in 1.20 we
@@ -233,6 +263,9 @@ func SetDefaults_MigrationControllerArgs(obj *MigrationControllerArgs) {
 	if obj.EvictionPolicy == "" {
 		obj.EvictionPolicy = defaultMigrationJobEvictionPolicy
 	}
+	if obj.EvictionAgePreference == "" {
+		obj.EvictionAgePreference = EvictionAgePreferenceNone
+	}
 	if obj.EvictQPS == nil {
 		obj.EvictQPS = &config.Float64OrString{
 			Type:     config.Float,
@@ -260,10 +293,43 @@ func SetDefaults_LowNodeLoadArgs(obj *LowNodeLoadArgs) {
 	if obj.NodeFit == nil {
 		obj.NodeFit = pointer.Bool(true)
 	}
+	if obj.PodSelectorMatchMode == "" {
+		obj.PodSelectorMatchMode = PodSelectorMatchModeAny
+	}
+	if obj.NodeProcessingOrder == "" {
+		obj.NodeProcessingOrder = NodeProcessingOrderMostOverutilizedFirst
+	}
 	if obj.AnomalyCondition == nil {
 		obj.AnomalyCondition = defaultLoadAnomalyCondition
-	} else if obj.AnomalyCondition.ConsecutiveAbnormalities == 0 {
-		obj.AnomalyCondition.ConsecutiveAbnormalities = defaultLoadAnomalyCondition.ConsecutiveAbnormalities
+	} else {
+		if obj.AnomalyCondition.ConsecutiveAbnormalities == 0 {
+			obj.AnomalyCondition.ConsecutiveAbnormalities = defaultLoadAnomalyCondition.ConsecutiveAbnormalities
+		}
+		if obj.AnomalyCondition.ConsecutiveNormalities == 0 {
+			obj.AnomalyCondition.ConsecutiveNormalities = defaultLoadAnomalyCondition.ConsecutiveNormalities
+		}
+	}
+	for i := range obj.NodePools {
+		if cond := obj.NodePools[i].AnomalyCondition; cond != nil {
+			if cond.ConsecutiveAbnormalities == 0 {
+				cond.ConsecutiveAbnormalities = defaultLoadAnomalyCondition.ConsecutiveAbnormalities
+			}
+			if cond.ConsecutiveNormalities == 0 {
+				cond.ConsecutiveNormalities = defaultLoadAnomalyCondition.ConsecutiveNormalities
+			}
+		}
+		// the weights of a node pool are either all defaulted or all specified,
+		// the specified weights must cover the thresholded resources which is checked by the validation.
+		nodePool := &obj.NodePools[i]
+		if len(nodePool.ResourceWeights) == 0 && (len(nodePool.LowThresholds) > 0 || len(nodePool.HighThresholds) > 0) {
+			nodePool.ResourceWeights = map[corev1.ResourceName]int64{}
+			for resourceName := range nodePool.LowThresholds {
+				nodePool.ResourceWeights[resourceName] = 1
+			}
+			for resourceName := range nodePool.HighThresholds {
+				nodePool.ResourceWeights[resourceName] = 1
+			}
+		}
 	}
 	if obj.DetectorCacheTimeout == nil {
 		obj.DetectorCacheTimeout = &metav1.Duration{Duration: defaultDetectorCacheTimeout}
@@ -293,3 +359,88 @@ func SetDefaults_LowNodeLoadArgs(obj *LowNodeLoadArgs) {
 		}
 	}
 }
+
+func SetDefaults_DrainScaleDownCandidatesArgs(obj *DrainScaleDownCandidatesArgs) {
+	if len(obj.MarkerKeys) == 0 {
+		obj.MarkerKeys = []string{ToBeDeletedByClusterAutoscalerTaintKey, DeletionCandidateOfClusterAutoscalerTaintKey}
+	}
+}
+
+func SetDefaults_RemovePodsWithOutdatedRequestsArgs(obj *RemovePodsWithOutdatedRequestsArgs) {
+	if obj.DivergenceThreshold == nil {
+		threshold := defaultOutdatedRequestsDivergenceThreshold
+		obj.DivergenceThreshold = &threshold
+	}
+	if len(obj.Resources) == 0 {
+		obj.Resources = append([]corev1.ResourceName{}, defaultOutdatedRequestsResources...)
+	}
+	if obj.NodeFit == nil {
+		obj.NodeFit = pointer.Bool(true)
+	}
+}
+
+func SetDefaults_RemovePodsViolatingNodeSelectorArgs(obj *RemovePodsViolatingNodeSelectorArgs) {
+	if obj.NodeFit == nil {
+		obj.NodeFit = pointer.Bool(true)
+	}
+}
+
+func SetDefaults_RemovePodsViolatingNodeTaintEffectsArgs(obj *RemovePodsViolatingNodeTaintEffectsArgs) {
+	if obj.NodeFit == nil {
+		obj.NodeFit = pointer.Bool(true)
+	}
+	if len(obj.TaintEffects) == 0 {
+		obj.TaintEffects = []corev1.TaintEffect{corev1.TaintEffectNoSchedule}
+	}
+}
+
+func SetDefaults_BalanceAcrossZonesArgs(obj *BalanceAcrossZonesArgs) {
+	if obj.ZoneLabelKey == "" {
+		obj.ZoneLabelKey = corev1.LabelTopologyZone
+	}
+	if obj.MaxSkew == nil {
+		obj.MaxSkew = pointer.Int32(defaultBalanceAcrossZonesMaxSkew)
+	}
+	if obj.NodeFit == nil {
+		obj.NodeFit = pointer.Bool(true)
+	}
+}
+
+func SetDefaults_RemoveFailedReadinessPodsArgs(obj *RemoveFailedReadinessPodsArgs) {
+	if obj.MinUnreadyDuration == nil {
+		obj.MinUnreadyDuration = &metav1.Duration{Duration: defaultMinUnreadyDuration}
+	}
+}
+
+func SetDefaults_RemoveIdleGPUPodsArgs(obj *RemoveIdleGPUPodsArgs) {
+	if obj.UtilizationThreshold == nil {
+		obj.UtilizationThreshold = pointer.Int32(defaultIdleGPUUtilizationThreshold)
+	}
+	if obj.IdleDuration == nil {
+		obj.IdleDuration = &metav1.Duration{Duration: defaultGPUIdleDuration}
+	}
+	if obj.NodeMetricExpirationSeconds == nil {
+		obj.NodeMetricExpirationSeconds = pointer.Int64(defaultNodeMetricExpirationSeconds)
+	}
+}
+
+func SetDefaults_RemovePodsWithMissingReferencesArgs(obj *RemovePodsWithMissingReferencesArgs) {
+	if obj.MinFailingDuration == nil {
+		obj.MinFailingDuration = &metav1.Duration{Duration: defaultMinFailingDuration}
+	}
+}
+
+func SetDefaults_RebalanceForQuotaFairnessArgs(obj *RebalanceForQuotaFairnessArgs) {
+	if len(obj.Resources) == 0 {
+		obj.Resources = append([]corev1.ResourceName{}, defaultQuotaFairnessResources...)
+	}
+	if obj.MaxEvictionsPerQuota == nil {
+		obj.MaxEvictionsPerQuota = pointer.Int32(defaultMaxEvictionsPerQuota)
+	}
+}
+
+func SetDefaults_RemovePodsViolatingRequestLimitRatioArgs(obj *RemovePodsViolatingRequestLimitRatioArgs) {
+	if obj.NodeFit == nil {
+		obj.NodeFit = pointer.Bool(true)
+	}
+}
@@ -40,6 +40,8 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 			expected: &LowNodeLoadArgs{
 				NodeFit:                     pointer.Bool(false),
 				NodeMetricExpirationSeconds: pointer.Int64(defaultNodeMetricExpirationSeconds),
+				PodSelectorMatchMode:        PodSelectorMatchModeAny,
+				NodeProcessingOrder:         NodeProcessingOrderMostOverutilizedFirst,
 				AnomalyCondition:            defaultLoadAnomalyCondition,
 				DetectorCacheTimeout:        &metav1.Duration{Duration: 5 * time.Minute},
 				ResourceWeights: map[corev1.ResourceName]int64{
@@ -56,6 +58,8 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 			expected: &LowNodeLoadArgs{
 				NodeFit:                     pointer.Bool(true),
 				NodeMetricExpirationSeconds: pointer.Int64(defaultNodeMetricExpirationSeconds),
+				PodSelectorMatchMode:        PodSelectorMatchModeAny,
+				NodeProcessingOrder:         NodeProcessingOrderMostOverutilizedFirst,
 				AnomalyCondition:            defaultLoadAnomalyCondition,
 				DetectorCacheTimeout:        &metav1.Duration{Duration: 10 * time.Minute},
 				ResourceWeights: map[corev1.ResourceName]int64{
@@ -76,6 +80,8 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 			expected: &LowNodeLoadArgs{
 				NodeFit:                     pointer.Bool(true),
 				NodeMetricExpirationSeconds: pointer.Int64(defaultNodeMetricExpirationSeconds),
+				PodSelectorMatchMode:        PodSelectorMatchModeAny,
+				NodeProcessingOrder:         NodeProcessingOrderMostOverutilizedFirst,
 				AnomalyCondition: &LoadAnomalyCondition{
 					Timeout:                  &metav1.Duration{Duration: 10 * time.Second},
 					ConsecutiveAbnormalities: defaultLoadAnomalyCondition.ConsecutiveAbnormalities,
@@ -88,6 +94,40 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "set nodePool anomalyCondition",
+			args: &LowNodeLoadArgs{
+				NodePools: []LowNodeLoadNodePool{
+					{
+						Name: "test",
+						AnomalyCondition: &LoadAnomalyCondition{
+							ConsecutiveAbnormalities: 2,
+						},
+					},
+				},
+			},
+			expected: &LowNodeLoadArgs{
+				NodeFit:                     pointer.Bool(true),
+				NodeMetricExpirationSeconds: pointer.Int64(defaultNodeMetricExpirationSeconds),
+				PodSelectorMatchMode:        PodSelectorMatchModeAny,
+				NodeProcessingOrder:         NodeProcessingOrderMostOverutilizedFirst,
+				AnomalyCondition:            defaultLoadAnomalyCondition,
+				DetectorCacheTimeout:        &metav1.Duration{Duration: 5 * time.Minute},
+				ResourceWeights: map[corev1.ResourceName]int64{
+					corev1.ResourceCPU:    1,
+					corev1.ResourceMemory: 1,
+				},
+				NodePools: []LowNodeLoadNodePool{
+					{
+						Name: "test",
+						AnomalyCondition: &LoadAnomalyCondition{
+							ConsecutiveAbnormalities: 2,
+							ConsecutiveNormalities:   defaultLoadAnomalyCondition.ConsecutiveNormalities,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "set weights",
 			args: &LowNodeLoadArgs{
@@ -104,6 +144,8 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 			expected: &LowNodeLoadArgs{
 				NodeFit:                     pointer.Bool(true),
 				NodeMetricExpirationSeconds: pointer.Int64(defaultNodeMetricExpirationSeconds),
+				PodSelectorMatchMode:        PodSelectorMatchModeAny,
+				NodeProcessingOrder:         NodeProcessingOrderMostOverutilizedFirst,
 				AnomalyCondition:            defaultLoadAnomalyCondition,
 				DetectorCacheTimeout:        &metav1.Duration{Duration: 5 * time.Minute},
 				LowThresholds: ResourceThresholds{
@@ -118,6 +160,51 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "default nodePool weights of thresholded resources",
+			args: &LowNodeLoadArgs{
+				NodePools: []LowNodeLoadNodePool{
+					{
+						Name:           "default",
+						LowThresholds:  ResourceThresholds{corev1.ResourceCPU: 30},
+						HighThresholds: ResourceThresholds{corev1.ResourceCPU: 60, corev1.ResourceMemory: 60},
+					},
+					{
+						Name:            "weighted",
+						HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 60},
+						ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 2},
+					},
+				},
+			},
+			expected: &LowNodeLoadArgs{
+				NodeFit:                     pointer.Bool(true),
+				NodeMetricExpirationSeconds: pointer.Int64(defaultNodeMetricExpirationSeconds),
+				PodSelectorMatchMode:        PodSelectorMatchModeAny,
+				NodeProcessingOrder:         NodeProcessingOrderMostOverutilizedFirst,
+				AnomalyCondition:            defaultLoadAnomalyCondition,
+				DetectorCacheTimeout:        &metav1.Duration{Duration: 5 * time.Minute},
+				ResourceWeights: map[corev1.ResourceName]int64{
+					corev1.ResourceCPU:    1,
+					corev1.ResourceMemory: 1,
+				},
+				NodePools: []LowNodeLoadNodePool{
+					{
+						Name:           "default",
+						LowThresholds:  ResourceThresholds{corev1.ResourceCPU: 30},
+						HighThresholds: ResourceThresholds{corev1.ResourceCPU: 60, corev1.ResourceMemory: 60},
+						ResourceWeights: map[corev1.ResourceName]int64{
+							corev1.ResourceCPU:    1,
+							corev1.ResourceMemory: 1,
+						},
+					},
+					{
+						Name:            "weighted",
+						HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 60},
+						ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 2},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -126,3 +213,318 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDefaults_DrainScaleDownCandidatesArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *DrainScaleDownCandidatesArgs
+		expected *DrainScaleDownCandidatesArgs
+	}{
+		{
+			name: "set markerKeys",
+			args: &DrainScaleDownCandidatesArgs{},
+			expected: &DrainScaleDownCandidatesArgs{
+				MarkerKeys: []string{ToBeDeletedByClusterAutoscalerTaintKey, DeletionCandidateOfClusterAutoscalerTaintKey},
+			},
+		},
+		{
+			name: "keep configured markerKeys",
+			args: &DrainScaleDownCandidatesArgs{
+				MarkerKeys: []string{"example.com/scale-down"},
+			},
+			expected: &DrainScaleDownCandidatesArgs{
+				MarkerKeys: []string{"example.com/scale-down"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_DrainScaleDownCandidatesArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_RemovePodsWithOutdatedRequestsArgs(t *testing.T) {
+	defaultThreshold := Percentage(20)
+	customThreshold := Percentage(50)
+	tests := []struct {
+		name     string
+		args     *RemovePodsWithOutdatedRequestsArgs
+		expected *RemovePodsWithOutdatedRequestsArgs
+	}{
+		{
+			name: "set defaults",
+			args: &RemovePodsWithOutdatedRequestsArgs{},
+			expected: &RemovePodsWithOutdatedRequestsArgs{
+				DivergenceThreshold: &defaultThreshold,
+				Resources:           []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory},
+				NodeFit:             pointer.Bool(true),
+			},
+		},
+		{
+			name: "keep configured values",
+			args: &RemovePodsWithOutdatedRequestsArgs{
+				DivergenceThreshold: &customThreshold,
+				Resources:           []corev1.ResourceName{corev1.ResourceMemory},
+				NodeFit:             pointer.Bool(false),
+			},
+			expected: &RemovePodsWithOutdatedRequestsArgs{
+				DivergenceThreshold: &customThreshold,
+				Resources:           []corev1.ResourceName{corev1.ResourceMemory},
+				NodeFit:             pointer.Bool(false),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_RemovePodsWithOutdatedRequestsArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_RemovePodsViolatingNodeSelectorArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *RemovePodsViolatingNodeSelectorArgs
+		expected *RemovePodsViolatingNodeSelectorArgs
+	}{
+		{
+			name: "set nodeFit",
+			args: &RemovePodsViolatingNodeSelectorArgs{},
+			expected: &RemovePodsViolatingNodeSelectorArgs{
+				NodeFit: pointer.Bool(true),
+			},
+		},
+		{
+			name: "keep configured nodeFit",
+			args: &RemovePodsViolatingNodeSelectorArgs{
+				NodeFit: pointer.Bool(false),
+			},
+			expected: &RemovePodsViolatingNodeSelectorArgs{
+				NodeFit: pointer.Bool(false),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_RemovePodsViolatingNodeSelectorArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_RemovePodsViolatingNodeTaintEffectsArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *RemovePodsViolatingNodeTaintEffectsArgs
+		expected *RemovePodsViolatingNodeTaintEffectsArgs
+	}{
+		{
+			name: "set nodeFit and taintEffects",
+			args: &RemovePodsViolatingNodeTaintEffectsArgs{},
+			expected: &RemovePodsViolatingNodeTaintEffectsArgs{
+				NodeFit:      pointer.Bool(true),
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name: "keep configured nodeFit and taintEffects",
+			args: &RemovePodsViolatingNodeTaintEffectsArgs{
+				NodeFit:      pointer.Bool(false),
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectPreferNoSchedule},
+			},
+			expected: &RemovePodsViolatingNodeTaintEffectsArgs{
+				NodeFit:      pointer.Bool(false),
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectPreferNoSchedule},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_RemovePodsViolatingNodeTaintEffectsArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_BalanceAcrossZonesArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *BalanceAcrossZonesArgs
+		expected *BalanceAcrossZonesArgs
+	}{
+		{
+			name: "set defaults",
+			args: &BalanceAcrossZonesArgs{},
+			expected: &BalanceAcrossZonesArgs{
+				ZoneLabelKey: corev1.LabelTopologyZone,
+				MaxSkew:      pointer.Int32(1),
+				NodeFit:      pointer.Bool(true),
+			},
+		},
+		{
+			name: "keep configured values",
+			args: &BalanceAcrossZonesArgs{
+				ZoneLabelKey: "example.com/zone",
+				MaxSkew:      pointer.Int32(3),
+				NodeFit:      pointer.Bool(false),
+			},
+			expected: &BalanceAcrossZonesArgs{
+				ZoneLabelKey: "example.com/zone",
+				MaxSkew:      pointer.Int32(3),
+				NodeFit:      pointer.Bool(false),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_BalanceAcrossZonesArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_RemoveFailedReadinessPodsArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *RemoveFailedReadinessPodsArgs
+		expected *RemoveFailedReadinessPodsArgs
+	}{
+		{
+			name: "set minUnreadyDuration",
+			args: &RemoveFailedReadinessPodsArgs{},
+			expected: &RemoveFailedReadinessPodsArgs{
+				MinUnreadyDuration: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+		},
+		{
+			name: "keep configured minUnreadyDuration",
+			args: &RemoveFailedReadinessPodsArgs{
+				MinUnreadyDuration: &metav1.Duration{Duration: time.Hour},
+			},
+			expected: &RemoveFailedReadinessPodsArgs{
+				MinUnreadyDuration: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_RemoveFailedReadinessPodsArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_RemoveIdleGPUPodsArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *RemoveIdleGPUPodsArgs
+		expected *RemoveIdleGPUPodsArgs
+	}{
+		{
+			name: "set defaults",
+			args: &RemoveIdleGPUPodsArgs{},
+			expected: &RemoveIdleGPUPodsArgs{
+				UtilizationThreshold:        pointer.Int32(defaultIdleGPUUtilizationThreshold),
+				IdleDuration:                &metav1.Duration{Duration: defaultGPUIdleDuration},
+				NodeMetricExpirationSeconds: pointer.Int64(defaultNodeMetricExpirationSeconds),
+			},
+		},
+		{
+			name: "keep configured values",
+			args: &RemoveIdleGPUPodsArgs{
+				UtilizationThreshold:        pointer.Int32(10),
+				IdleDuration:                &metav1.Duration{Duration: time.Hour},
+				NodeMetricExpirationSeconds: pointer.Int64(60),
+			},
+			expected: &RemoveIdleGPUPodsArgs{
+				UtilizationThreshold:        pointer.Int32(10),
+				IdleDuration:                &metav1.Duration{Duration: time.Hour},
+				NodeMetricExpirationSeconds: pointer.Int64(60),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_RemoveIdleGPUPodsArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_RemovePodsWithMissingReferencesArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *RemovePodsWithMissingReferencesArgs
+		expected *RemovePodsWithMissingReferencesArgs
+	}{
+		{
+			name: "set minFailingDuration",
+			args: &RemovePodsWithMissingReferencesArgs{},
+			expected: &RemovePodsWithMissingReferencesArgs{
+				MinFailingDuration: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+		},
+		{
+			name: "keep configured minFailingDuration",
+			args: &RemovePodsWithMissingReferencesArgs{
+				MinFailingDuration: &metav1.Duration{Duration: time.Hour},
+			},
+			expected: &RemovePodsWithMissingReferencesArgs{
+				MinFailingDuration: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_RemovePodsWithMissingReferencesArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_RebalanceForQuotaFairnessArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *RebalanceForQuotaFairnessArgs
+		expected *RebalanceForQuotaFairnessArgs
+	}{
+		{
+			name: "set defaults",
+			args: &RebalanceForQuotaFairnessArgs{},
+			expected: &RebalanceForQuotaFairnessArgs{
+				Resources:            []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory},
+				MaxEvictionsPerQuota: pointer.Int32(5),
+			},
+		},
+		{
+			name: "keep configured values",
+			args: &RebalanceForQuotaFairnessArgs{
+				Resources:            []corev1.ResourceName{corev1.ResourceCPU},
+				MaxEvictionsPerQuota: pointer.Int32(1),
+			},
+			expected: &RebalanceForQuotaFairnessArgs{
+				Resources:            []corev1.ResourceName{corev1.ResourceCPU},
+				MaxEvictionsPerQuota: pointer.Int32(1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_RebalanceForQuotaFairnessArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_RemovePodsViolatingRequestLimitRatioArgs(t *testing.T) {
+	args := &RemovePodsViolatingRequestLimitRatioArgs{}
+	SetDefaults_RemovePodsViolatingRequestLimitRatioArgs(args)
+	assert.Equal(t, &RemovePodsViolatingRequestLimitRatioArgs{NodeFit: pointer.Bool(true)}, args)
+
+	args = &RemovePodsViolatingRequestLimitRatioArgs{NodeFit: pointer.Bool(false)}
+	SetDefaults_RemovePodsViolatingRequestLimitRatioArgs(args)
+	assert.Equal(t, &RemovePodsViolatingRequestLimitRatioArgs{NodeFit: pointer.Bool(false)}, args)
+}
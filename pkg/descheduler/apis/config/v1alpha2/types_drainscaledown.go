@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ToBeDeletedByClusterAutoscalerTaintKey is the taint added by cluster-autoscaler to the nodes it is deleting.
+	ToBeDeletedByClusterAutoscalerTaintKey = "ToBeDeletedByClusterAutoscaler"
+	// DeletionCandidateOfClusterAutoscalerTaintKey is the taint added by cluster-autoscaler to the scale-down candidates.
+	DeletionCandidateOfClusterAutoscalerTaintKey = "DeletionCandidateOfClusterAutoscaler"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DrainScaleDownCandidatesArgs holds arguments used to configure the DrainScaleDownCandidates plugin.
+type DrainScaleDownCandidatesArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the DrainScaleDownCandidates should to work or not.
+	// Default is false
+	Paused *bool `json:"paused,omitempty"`
+
+	// MarkerKeys are the taint or annotation keys marking a node as going to be removed by the autoscaler.
+	// A node carrying a taint or an annotation with any of the keys is drained.
+	// Default is ToBeDeletedByClusterAutoscaler and DeletionCandidateOfClusterAutoscaler.
+	MarkerKeys []string `json:"markerKeys,omitempty"`
+
+	// StaleHeartbeatThreshold drains the nodes whose kubelet has not heartbeated for longer than the threshold,
+	// even if they are not marked for scale-down, since they may hold zombie pods before turning NotReady.
+	// Default is nil, which means the nodes are not drained for the stale heartbeats.
+	StaleHeartbeatThreshold *metav1.Duration `json:"staleHeartbeatThreshold,omitempty"`
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+}
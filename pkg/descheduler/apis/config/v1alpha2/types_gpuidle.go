@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemoveIdleGPUPodsArgs holds arguments used to configure the RemoveIdleGPUPods plugin.
+type RemoveIdleGPUPodsArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the RemoveIdleGPUPods should to work or not.
+	// Default is false
+	Paused *bool `json:"paused,omitempty"`
+
+	// UtilizationThreshold is the GPU core utilization percentage in (0, 100],
+	// a pod is idle if the utilization of every GPU it holds is below the threshold.
+	// Default is 5.
+	UtilizationThreshold *int32 `json:"utilizationThreshold,omitempty"`
+
+	// IdleDuration is how long a pod has to be idle continuously before it is evicted.
+	// Default is 30 minutes.
+	IdleDuration *metav1.Duration `json:"idleDuration,omitempty"`
+
+	// NodeMetricExpirationSeconds indicates the NodeMetric expiration in seconds,
+	// the pods on the nodes with expired NodeMetric are not considered idle.
+	// Default is 180 seconds.
+	NodeMetricExpirationSeconds *int64 `json:"nodeMetricExpirationSeconds,omitempty"`
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsWithOutdatedRequestsArgs holds arguments used to configure the RemovePodsWithOutdatedRequests plugin.
+type RemovePodsWithOutdatedRequestsArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the RemovePodsWithOutdatedRequests should to work or not.
+	// Default is false
+	Paused *bool `json:"paused,omitempty"`
+
+	// DivergenceThreshold is the percentage by which the request of a container may differ from
+	// the target recommended by the VerticalPodAutoscaler before the pod is evicted for recreation.
+	// Default is 20.
+	DivergenceThreshold *Percentage `json:"divergenceThreshold,omitempty"`
+
+	// Resources are the resources whose requests are compared with the recommendation.
+	// Default is cpu and memory.
+	Resources []corev1.ResourceName `json:"resources,omitempty"`
+
+	// NodeFit if enabled, it will check whether the pod with the recommended requests fits any node.
+	// Default is true.
+	NodeFit *bool `json:"nodeFit,omitempty"`
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+}
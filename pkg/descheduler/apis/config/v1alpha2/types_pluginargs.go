@@ -100,7 +100,7 @@ type MigrationControllerArgs struct {
 	// Default is PodMigrationJobModeReservationFirst
 	DefaultJobMode string `json:"defaultJobMode,omitempty"`
 
-	// DefaultJobTTL represents the default TTL of the PodMigrationJob
+	// DefaultJobTTL represents the default TTL of the PodMigrationJob, it must be greater than 0.
 	// Default is 5 minute
 	DefaultJobTTL *metav1.Duration `json:"defaultJobTTL,omitempty"`
 
@@ -118,8 +118,42 @@ type MigrationControllerArgs struct {
 
 	// ArbitrationArgs defines the control parameters of the Arbitration Mechanism.
 	ArbitrationArgs *ArbitrationArgs `json:"arbitrationArgs,omitempty"`
+
+	// NodePoolLabelKey is the node label key used to group nodes into pools.
+	// If set, the Reservation created by PodMigrationJob only selects nodes in the same pool as the source node,
+	// so that Pods never migrate across node pools. It only takes effect in ReservationFirst mode.
+	NodePoolLabelKey string `json:"nodePoolLabelKey,omitempty"`
+
+	// NodePoolSelector selects the node pools whose Pods can be migrated, and is matched against the labels of the node
+	// where the Pod is located. It only takes effect when NodePoolLabelKey is set.
+	NodePoolSelector *metav1.LabelSelector `json:"nodePoolSelector,omitempty"`
+
+	// StartupWarmup is the period after the controller starts during which the PodMigrationJobs are processed
+	// but the Pods are not evicted, so that the migration decisions are not made on incomplete caches.
+	StartupWarmup *metav1.Duration `json:"startupWarmup,omitempty"`
+
+	// AllowedReasons is the allowlist of the PodMigrationJobs to process, matched against the eviction reason
+	// or the eviction trigger annotated on the job. Empty means all the jobs are processed.
+	AllowedReasons []string `json:"allowedReasons,omitempty"`
+
+	// EvictionAgePreference breaks the ties by age when choosing among the Pods of a workload to migrate,
+	// after the Pods are compared by priority, QoS, deletion cost and eviction cost.
+	// Default is None, which keeps the default order.
+	EvictionAgePreference EvictionAgePreference `json:"evictionAgePreference,omitempty"`
 }
 
+// EvictionAgePreference defines which Pods are preferred by age when choosing the Pods to migrate.
+type EvictionAgePreference string
+
+const (
+	// EvictionAgePreferenceNone means no age preference.
+	EvictionAgePreferenceNone EvictionAgePreference = "None"
+	// EvictionAgePreferenceNewestFirst prefers the newest Pods, which are the least settled.
+	EvictionAgePreferenceNewestFirst EvictionAgePreference = "NewestFirst"
+	// EvictionAgePreferenceOldestFirst prefers the oldest Pods, so that the Pods are rotated.
+	EvictionAgePreferenceOldestFirst EvictionAgePreference = "OldestFirst"
+)
+
 type MigrationLimitObjectType string
 
 const (
@@ -149,4 +183,25 @@ type ArbitrationArgs struct {
 	// Interval defines the running interval (ms) of the Arbitration Mechanism.
 	// Default is 500 ms
 	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// DisruptionCostWeights defines the weights of the inputs of the disruption cost of the Pods,
+	// the PodMigrationJobs of the Pods cheaper to disrupt are arbitrated first.
+	// If it is nil, the disruption cost is not considered.
+	DisruptionCostWeights *DisruptionCostWeights `json:"disruptionCostWeights,omitempty"`
+}
+
+// DisruptionCostWeights defines the weights of the inputs of the disruption cost of a Pod.
+// The arbitration score of a Pod is the weighted sum of the inputs, a higher score means cheaper to disrupt.
+type DisruptionCostWeights struct {
+	// AvailableReplicas is the weight of the number of the available replicas of the Pod's workload,
+	// the more replicas are available, the cheaper the Pod is to disrupt.
+	AvailableReplicas int64 `json:"availableReplicas,omitempty"`
+
+	// RestartCount is the weight of the total restart count of the Pod's containers,
+	// the Pods restarting frequently are already disrupted and cheaper to migrate.
+	RestartCount int64 `json:"restartCount,omitempty"`
+
+	// EvictionCost is the weight of the eviction cost annotated by `scheduling.koordinator.sh/eviction-cost`,
+	// the Pods with lower eviction cost are cheaper to disrupt.
+	EvictionCost int64 `json:"evictionCost,omitempty"`
 }
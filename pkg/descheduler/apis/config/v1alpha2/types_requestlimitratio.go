@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsViolatingRequestLimitRatioArgs holds arguments used to configure the RemovePodsViolatingRequestLimitRatio plugin.
+// A container violates the policy if the ratio of its request to its limit of a resource falls outside the band,
+// the containers without limit of the resource are not checked.
+type RemovePodsViolatingRequestLimitRatioArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the RemovePodsViolatingRequestLimitRatio should to work or not.
+	// Default is false
+	Paused *bool `json:"paused,omitempty"`
+
+	// NodeFit if enabled, it will check whether the pod fits any other node before evicting it.
+	// Default is true.
+	NodeFit *bool `json:"nodeFit,omitempty"`
+
+	// MinRequestLimitRatios are the lower bounds of the request/limit percentage of the containers per resource,
+	// e.g. {"memory": 50} means the memory request of a container must be at least half of its limit.
+	MinRequestLimitRatios ResourceThresholds `json:"minRequestLimitRatios,omitempty"`
+
+	// MaxRequestLimitRatios are the upper bounds of the request/limit percentage of the containers per resource.
+	MaxRequestLimitRatios ResourceThresholds `json:"maxRequestLimitRatios,omitempty"`
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+}
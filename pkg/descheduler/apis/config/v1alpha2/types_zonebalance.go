@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BalanceAcrossZonesArgs holds arguments used to configure the BalanceAcrossZones plugin.
+type BalanceAcrossZonesArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the BalanceAcrossZones should to work or not.
+	// Default is false
+	Paused *bool `json:"paused,omitempty"`
+
+	// ZoneLabelKey is the node label key whose value identifies the zone of the node.
+	// Default is topology.kubernetes.io/zone.
+	ZoneLabelKey string `json:"zoneLabelKey,omitempty"`
+
+	// MaxSkew is the maximum permitted difference between the number of pods of a workload
+	// in the most populated zone and in the least populated zone.
+	// Default is 1.
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+
+	// NodeFit if enabled, it will check whether the pod fits any node in the least populated zone before evicting it.
+	// Default is true.
+	NodeFit *bool `json:"nodeFit,omitempty"`
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+}
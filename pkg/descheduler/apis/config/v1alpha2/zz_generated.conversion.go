@@ -26,11 +26,13 @@ import (
 
 	config "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	corev1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 	v1alpha1 "k8s.io/component-base/config/v1alpha1"
+	apiv1 "k8s.io/component-base/tracing/api/v1"
 )
 
 func init() {
@@ -50,6 +52,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*BalanceAcrossZonesArgs)(nil), (*config.BalanceAcrossZonesArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BalanceAcrossZonesArgs_To_config_BalanceAcrossZonesArgs(a.(*BalanceAcrossZonesArgs), b.(*config.BalanceAcrossZonesArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.BalanceAcrossZonesArgs)(nil), (*BalanceAcrossZonesArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_BalanceAcrossZonesArgs_To_v1alpha2_BalanceAcrossZonesArgs(a.(*config.BalanceAcrossZonesArgs), b.(*BalanceAcrossZonesArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DeschedulerProfile)(nil), (*config.DeschedulerProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DeschedulerProfile_To_config_DeschedulerProfile(a.(*DeschedulerProfile), b.(*config.DeschedulerProfile), scope)
 	}); err != nil {
@@ -60,6 +72,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DisruptionCostWeights)(nil), (*config.DisruptionCostWeights)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DisruptionCostWeights_To_config_DisruptionCostWeights(a.(*DisruptionCostWeights), b.(*config.DisruptionCostWeights), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.DisruptionCostWeights)(nil), (*DisruptionCostWeights)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_DisruptionCostWeights_To_v1alpha2_DisruptionCostWeights(a.(*config.DisruptionCostWeights), b.(*DisruptionCostWeights), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DrainScaleDownCandidatesArgs)(nil), (*config.DrainScaleDownCandidatesArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DrainScaleDownCandidatesArgs_To_config_DrainScaleDownCandidatesArgs(a.(*DrainScaleDownCandidatesArgs), b.(*config.DrainScaleDownCandidatesArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.DrainScaleDownCandidatesArgs)(nil), (*DrainScaleDownCandidatesArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_DrainScaleDownCandidatesArgs_To_v1alpha2_DrainScaleDownCandidatesArgs(a.(*config.DrainScaleDownCandidatesArgs), b.(*DrainScaleDownCandidatesArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LoadAnomalyCondition)(nil), (*config.LoadAnomalyCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(a.(*LoadAnomalyCondition), b.(*config.LoadAnomalyCondition), scope)
 	}); err != nil {
@@ -175,6 +207,86 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RebalanceForQuotaFairnessArgs)(nil), (*config.RebalanceForQuotaFairnessArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RebalanceForQuotaFairnessArgs_To_config_RebalanceForQuotaFairnessArgs(a.(*RebalanceForQuotaFairnessArgs), b.(*config.RebalanceForQuotaFairnessArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RebalanceForQuotaFairnessArgs)(nil), (*RebalanceForQuotaFairnessArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RebalanceForQuotaFairnessArgs_To_v1alpha2_RebalanceForQuotaFairnessArgs(a.(*config.RebalanceForQuotaFairnessArgs), b.(*RebalanceForQuotaFairnessArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemoveFailedReadinessPodsArgs)(nil), (*config.RemoveFailedReadinessPodsArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RemoveFailedReadinessPodsArgs_To_config_RemoveFailedReadinessPodsArgs(a.(*RemoveFailedReadinessPodsArgs), b.(*config.RemoveFailedReadinessPodsArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RemoveFailedReadinessPodsArgs)(nil), (*RemoveFailedReadinessPodsArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RemoveFailedReadinessPodsArgs_To_v1alpha2_RemoveFailedReadinessPodsArgs(a.(*config.RemoveFailedReadinessPodsArgs), b.(*RemoveFailedReadinessPodsArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemoveIdleGPUPodsArgs)(nil), (*config.RemoveIdleGPUPodsArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RemoveIdleGPUPodsArgs_To_config_RemoveIdleGPUPodsArgs(a.(*RemoveIdleGPUPodsArgs), b.(*config.RemoveIdleGPUPodsArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RemoveIdleGPUPodsArgs)(nil), (*RemoveIdleGPUPodsArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RemoveIdleGPUPodsArgs_To_v1alpha2_RemoveIdleGPUPodsArgs(a.(*config.RemoveIdleGPUPodsArgs), b.(*RemoveIdleGPUPodsArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemovePodsViolatingNodeSelectorArgs)(nil), (*config.RemovePodsViolatingNodeSelectorArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RemovePodsViolatingNodeSelectorArgs_To_config_RemovePodsViolatingNodeSelectorArgs(a.(*RemovePodsViolatingNodeSelectorArgs), b.(*config.RemovePodsViolatingNodeSelectorArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RemovePodsViolatingNodeSelectorArgs)(nil), (*RemovePodsViolatingNodeSelectorArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RemovePodsViolatingNodeSelectorArgs_To_v1alpha2_RemovePodsViolatingNodeSelectorArgs(a.(*config.RemovePodsViolatingNodeSelectorArgs), b.(*RemovePodsViolatingNodeSelectorArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemovePodsViolatingNodeTaintEffectsArgs)(nil), (*config.RemovePodsViolatingNodeTaintEffectsArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RemovePodsViolatingNodeTaintEffectsArgs_To_config_RemovePodsViolatingNodeTaintEffectsArgs(a.(*RemovePodsViolatingNodeTaintEffectsArgs), b.(*config.RemovePodsViolatingNodeTaintEffectsArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RemovePodsViolatingNodeTaintEffectsArgs)(nil), (*RemovePodsViolatingNodeTaintEffectsArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RemovePodsViolatingNodeTaintEffectsArgs_To_v1alpha2_RemovePodsViolatingNodeTaintEffectsArgs(a.(*config.RemovePodsViolatingNodeTaintEffectsArgs), b.(*RemovePodsViolatingNodeTaintEffectsArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemovePodsViolatingRequestLimitRatioArgs)(nil), (*config.RemovePodsViolatingRequestLimitRatioArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RemovePodsViolatingRequestLimitRatioArgs_To_config_RemovePodsViolatingRequestLimitRatioArgs(a.(*RemovePodsViolatingRequestLimitRatioArgs), b.(*config.RemovePodsViolatingRequestLimitRatioArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RemovePodsViolatingRequestLimitRatioArgs)(nil), (*RemovePodsViolatingRequestLimitRatioArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RemovePodsViolatingRequestLimitRatioArgs_To_v1alpha2_RemovePodsViolatingRequestLimitRatioArgs(a.(*config.RemovePodsViolatingRequestLimitRatioArgs), b.(*RemovePodsViolatingRequestLimitRatioArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemovePodsWithMissingReferencesArgs)(nil), (*config.RemovePodsWithMissingReferencesArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RemovePodsWithMissingReferencesArgs_To_config_RemovePodsWithMissingReferencesArgs(a.(*RemovePodsWithMissingReferencesArgs), b.(*config.RemovePodsWithMissingReferencesArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RemovePodsWithMissingReferencesArgs)(nil), (*RemovePodsWithMissingReferencesArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RemovePodsWithMissingReferencesArgs_To_v1alpha2_RemovePodsWithMissingReferencesArgs(a.(*config.RemovePodsWithMissingReferencesArgs), b.(*RemovePodsWithMissingReferencesArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemovePodsWithOutdatedRequestsArgs)(nil), (*config.RemovePodsWithOutdatedRequestsArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RemovePodsWithOutdatedRequestsArgs_To_config_RemovePodsWithOutdatedRequestsArgs(a.(*RemovePodsWithOutdatedRequestsArgs), b.(*config.RemovePodsWithOutdatedRequestsArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RemovePodsWithOutdatedRequestsArgs)(nil), (*RemovePodsWithOutdatedRequestsArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RemovePodsWithOutdatedRequestsArgs_To_v1alpha2_RemovePodsWithOutdatedRequestsArgs(a.(*config.RemovePodsWithOutdatedRequestsArgs), b.(*RemovePodsWithOutdatedRequestsArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*config.DeschedulerConfiguration)(nil), (*DeschedulerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_config_DeschedulerConfiguration_To_v1alpha2_DeschedulerConfiguration(a.(*config.DeschedulerConfiguration), b.(*DeschedulerConfiguration), scope)
 	}); err != nil {
@@ -196,6 +308,7 @@ func RegisterConversions(s *runtime.Scheme) error {
 func autoConvert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(in *ArbitrationArgs, out *config.ArbitrationArgs, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	out.DisruptionCostWeights = (*config.DisruptionCostWeights)(unsafe.Pointer(in.DisruptionCostWeights))
 	return nil
 }
 
@@ -207,6 +320,7 @@ func Convert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(in *ArbitrationA
 func autoConvert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in *config.ArbitrationArgs, out *ArbitrationArgs, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	out.DisruptionCostWeights = (*DisruptionCostWeights)(unsafe.Pointer(in.DisruptionCostWeights))
 	return nil
 }
 
@@ -215,6 +329,46 @@ func Convert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in *config.Arbit
 	return autoConvert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in, out, s)
 }
 
+func autoConvert_v1alpha2_BalanceAcrossZonesArgs_To_config_BalanceAcrossZonesArgs(in *BalanceAcrossZonesArgs, out *config.BalanceAcrossZonesArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	out.ZoneLabelKey = in.ZoneLabelKey
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxSkew, &out.MaxSkew, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_v1alpha2_BalanceAcrossZonesArgs_To_config_BalanceAcrossZonesArgs is an autogenerated conversion function.
+func Convert_v1alpha2_BalanceAcrossZonesArgs_To_config_BalanceAcrossZonesArgs(in *BalanceAcrossZonesArgs, out *config.BalanceAcrossZonesArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BalanceAcrossZonesArgs_To_config_BalanceAcrossZonesArgs(in, out, s)
+}
+
+func autoConvert_config_BalanceAcrossZonesArgs_To_v1alpha2_BalanceAcrossZonesArgs(in *config.BalanceAcrossZonesArgs, out *BalanceAcrossZonesArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	out.ZoneLabelKey = in.ZoneLabelKey
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxSkew, &out.MaxSkew, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_config_BalanceAcrossZonesArgs_To_v1alpha2_BalanceAcrossZonesArgs is an autogenerated conversion function.
+func Convert_config_BalanceAcrossZonesArgs_To_v1alpha2_BalanceAcrossZonesArgs(in *config.BalanceAcrossZonesArgs, out *BalanceAcrossZonesArgs, s conversion.Scope) error {
+	return autoConvert_config_BalanceAcrossZonesArgs_To_v1alpha2_BalanceAcrossZonesArgs(in, out, s)
+}
+
 func autoConvert_v1alpha2_DeschedulerConfiguration_To_config_DeschedulerConfiguration(in *DeschedulerConfiguration, out *config.DeschedulerConfiguration, s conversion.Scope) error {
 	if err := v1alpha1.Convert_v1alpha1_LeaderElectionConfiguration_To_config_LeaderElectionConfiguration(&in.LeaderElection, &out.LeaderElection, s); err != nil {
 		return err
@@ -233,6 +387,10 @@ func autoConvert_v1alpha2_DeschedulerConfiguration_To_config_DeschedulerConfigur
 	}
 	out.DeschedulingInterval = in.DeschedulingInterval
 	out.DryRun = in.DryRun
+	out.Disabled = in.Disabled
+	out.EvictOrphanPods = in.EvictOrphanPods
+	out.CountTerminatingPods = in.CountTerminatingPods
+	out.EvictSystemCriticalPriorityClassPods = in.EvictSystemCriticalPriorityClassPods
 	if in.Profiles != nil {
 		in, out := &in.Profiles, &out.Profiles
 		*out = make([]config.DeschedulerProfile, len(*in))
@@ -244,10 +402,22 @@ func autoConvert_v1alpha2_DeschedulerConfiguration_To_config_DeschedulerConfigur
 	} else {
 		out.Profiles = nil
 	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxConcurrentProfiles, &out.MaxConcurrentProfiles, s); err != nil {
+		return err
+	}
 	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
 	out.MaxNoOfPodsToEvictPerNode = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
 	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.MaxNoOfPodsToEvictPerQuota = *(*map[string]uint)(unsafe.Pointer(&in.MaxNoOfPodsToEvictPerQuota))
 	out.MaxNoOfPodsToEvictTotal = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictTotal))
+	out.MaxEvictionFractionPerCycle = (*config.Float64OrString)(unsafe.Pointer(in.MaxEvictionFractionPerCycle))
+	out.StartupGracePeriod = in.StartupGracePeriod
+	out.InitialObservationPeriod = (*v1.Duration)(unsafe.Pointer(in.InitialObservationPeriod))
+	out.SafeModeEvictionRate = in.SafeModeEvictionRate
+	out.SafeModeCooldown = in.SafeModeCooldown
+	out.EvictionEventRate = in.EvictionEventRate
+	out.MaxCycleDuration = (*v1.Duration)(unsafe.Pointer(in.MaxCycleDuration))
+	out.Tracing = (*apiv1.TracingConfiguration)(unsafe.Pointer(in.Tracing))
 	return nil
 }
 
@@ -269,6 +439,10 @@ func autoConvert_config_DeschedulerConfiguration_To_v1alpha2_DeschedulerConfigur
 	}
 	out.DeschedulingInterval = in.DeschedulingInterval
 	out.DryRun = in.DryRun
+	out.Disabled = in.Disabled
+	out.EvictOrphanPods = in.EvictOrphanPods
+	out.CountTerminatingPods = in.CountTerminatingPods
+	out.EvictSystemCriticalPriorityClassPods = in.EvictSystemCriticalPriorityClassPods
 	if in.Profiles != nil {
 		in, out := &in.Profiles, &out.Profiles
 		*out = make([]DeschedulerProfile, len(*in))
@@ -280,10 +454,22 @@ func autoConvert_config_DeschedulerConfiguration_To_v1alpha2_DeschedulerConfigur
 	} else {
 		out.Profiles = nil
 	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxConcurrentProfiles, &out.MaxConcurrentProfiles, s); err != nil {
+		return err
+	}
 	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
 	out.MaxNoOfPodsToEvictPerNode = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
 	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.MaxNoOfPodsToEvictPerQuota = *(*map[string]uint)(unsafe.Pointer(&in.MaxNoOfPodsToEvictPerQuota))
 	out.MaxNoOfPodsToEvictTotal = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictTotal))
+	out.MaxEvictionFractionPerCycle = (*config.Float64OrString)(unsafe.Pointer(in.MaxEvictionFractionPerCycle))
+	out.StartupGracePeriod = in.StartupGracePeriod
+	out.InitialObservationPeriod = (*v1.Duration)(unsafe.Pointer(in.InitialObservationPeriod))
+	out.SafeModeEvictionRate = in.SafeModeEvictionRate
+	out.SafeModeCooldown = in.SafeModeCooldown
+	out.EvictionEventRate = in.EvictionEventRate
+	out.MaxCycleDuration = (*v1.Duration)(unsafe.Pointer(in.MaxCycleDuration))
+	out.Tracing = (*apiv1.TracingConfiguration)(unsafe.Pointer(in.Tracing))
 	return nil
 }
 
@@ -302,6 +488,9 @@ func autoConvert_v1alpha2_DeschedulerProfile_To_config_DeschedulerProfile(in *De
 	}
 	out.Plugins = (*config.Plugins)(unsafe.Pointer(in.Plugins))
 	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.Disabled = in.Disabled
+	out.ExtensionPointOrder = *(*[]string)(unsafe.Pointer(&in.ExtensionPointOrder))
 	return nil
 }
 
@@ -325,6 +514,9 @@ func autoConvert_config_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *co
 	}
 	out.Plugins = (*Plugins)(unsafe.Pointer(in.Plugins))
 	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.Disabled = in.Disabled
+	out.ExtensionPointOrder = *(*[]string)(unsafe.Pointer(&in.ExtensionPointOrder))
 	return nil
 }
 
@@ -333,6 +525,60 @@ func Convert_config_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *config
 	return autoConvert_config_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in, out, s)
 }
 
+func autoConvert_v1alpha2_DisruptionCostWeights_To_config_DisruptionCostWeights(in *DisruptionCostWeights, out *config.DisruptionCostWeights, s conversion.Scope) error {
+	out.AvailableReplicas = in.AvailableReplicas
+	out.RestartCount = in.RestartCount
+	out.EvictionCost = in.EvictionCost
+	return nil
+}
+
+// Convert_v1alpha2_DisruptionCostWeights_To_config_DisruptionCostWeights is an autogenerated conversion function.
+func Convert_v1alpha2_DisruptionCostWeights_To_config_DisruptionCostWeights(in *DisruptionCostWeights, out *config.DisruptionCostWeights, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DisruptionCostWeights_To_config_DisruptionCostWeights(in, out, s)
+}
+
+func autoConvert_config_DisruptionCostWeights_To_v1alpha2_DisruptionCostWeights(in *config.DisruptionCostWeights, out *DisruptionCostWeights, s conversion.Scope) error {
+	out.AvailableReplicas = in.AvailableReplicas
+	out.RestartCount = in.RestartCount
+	out.EvictionCost = in.EvictionCost
+	return nil
+}
+
+// Convert_config_DisruptionCostWeights_To_v1alpha2_DisruptionCostWeights is an autogenerated conversion function.
+func Convert_config_DisruptionCostWeights_To_v1alpha2_DisruptionCostWeights(in *config.DisruptionCostWeights, out *DisruptionCostWeights, s conversion.Scope) error {
+	return autoConvert_config_DisruptionCostWeights_To_v1alpha2_DisruptionCostWeights(in, out, s)
+}
+
+func autoConvert_v1alpha2_DrainScaleDownCandidatesArgs_To_config_DrainScaleDownCandidatesArgs(in *DrainScaleDownCandidatesArgs, out *config.DrainScaleDownCandidatesArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	out.MarkerKeys = *(*[]string)(unsafe.Pointer(&in.MarkerKeys))
+	out.StaleHeartbeatThreshold = (*v1.Duration)(unsafe.Pointer(in.StaleHeartbeatThreshold))
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_v1alpha2_DrainScaleDownCandidatesArgs_To_config_DrainScaleDownCandidatesArgs is an autogenerated conversion function.
+func Convert_v1alpha2_DrainScaleDownCandidatesArgs_To_config_DrainScaleDownCandidatesArgs(in *DrainScaleDownCandidatesArgs, out *config.DrainScaleDownCandidatesArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DrainScaleDownCandidatesArgs_To_config_DrainScaleDownCandidatesArgs(in, out, s)
+}
+
+func autoConvert_config_DrainScaleDownCandidatesArgs_To_v1alpha2_DrainScaleDownCandidatesArgs(in *config.DrainScaleDownCandidatesArgs, out *DrainScaleDownCandidatesArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	out.MarkerKeys = *(*[]string)(unsafe.Pointer(&in.MarkerKeys))
+	out.StaleHeartbeatThreshold = (*v1.Duration)(unsafe.Pointer(in.StaleHeartbeatThreshold))
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_config_DrainScaleDownCandidatesArgs_To_v1alpha2_DrainScaleDownCandidatesArgs is an autogenerated conversion function.
+func Convert_config_DrainScaleDownCandidatesArgs_To_v1alpha2_DrainScaleDownCandidatesArgs(in *config.DrainScaleDownCandidatesArgs, out *DrainScaleDownCandidatesArgs, s conversion.Scope) error {
+	return autoConvert_config_DrainScaleDownCandidatesArgs_To_v1alpha2_DrainScaleDownCandidatesArgs(in, out, s)
+}
+
 func autoConvert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(in *LoadAnomalyCondition, out *config.LoadAnomalyCondition, s conversion.Scope) error {
 	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.Timeout, &out.Timeout, s); err != nil {
 		return err
@@ -371,18 +617,39 @@ func autoConvert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in *LowNodeL
 	if err := v1.Convert_Pointer_int32_To_int32(&in.NumberOfNodes, &out.NumberOfNodes, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MinNodesInScope, &out.MinNodesInScope, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EvictQuarantinedNodes, &out.EvictQuarantinedNodes, s); err != nil {
+		return err
+	}
+	out.ThrottledNodeConditionType = corev1.NodeConditionType(in.ThrottledNodeConditionType)
+	out.ConsolidationTargetUtilization = *(*config.ResourceThresholds)(unsafe.Pointer(&in.ConsolidationTargetUtilization))
+	out.CriticalThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.CriticalThresholds))
 	out.NodeMetricExpirationSeconds = (*int64)(unsafe.Pointer(in.NodeMetricExpirationSeconds))
+	out.NodeMetricExpirationByResource = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.NodeMetricExpirationByResource))
 	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
 	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
 	out.PodSelectors = *(*[]config.LowNodeLoadPodSelector)(unsafe.Pointer(&in.PodSelectors))
+	out.PodSelectorMatchMode = config.PodSelectorMatchMode(in.PodSelectorMatchMode)
+	out.NodeProcessingOrder = config.NodeProcessingOrder(in.NodeProcessingOrder)
+	out.OwnerKindEvictionPriority = *(*[]string)(unsafe.Pointer(&in.OwnerKindEvictionPriority))
+	out.CandidateOrderSeed = (*int64)(unsafe.Pointer(in.CandidateOrderSeed))
+	out.EvictionAggressiveness = (*config.Float64OrString)(unsafe.Pointer(in.EvictionAggressiveness))
 	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EmitDestinationHint, &out.EmitDestinationHint, s); err != nil {
+		return err
+	}
 	if err := v1.Convert_Pointer_bool_To_bool(&in.UseDeviationThresholds, &out.UseDeviationThresholds, s); err != nil {
 		return err
 	}
 	out.HighThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.HighThresholds))
 	out.LowThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.LowThresholds))
+	out.AbsoluteHighThresholds = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.AbsoluteHighThresholds))
+	out.AbsoluteLowThresholds = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.AbsoluteLowThresholds))
+	out.MinAbsoluteUsage = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.MinAbsoluteUsage))
 	out.ProdHighThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.ProdHighThresholds))
 	out.ProdLowThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.ProdLowThresholds))
 	out.ResourceWeights = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.ResourceWeights))
@@ -420,18 +687,39 @@ func autoConvert_config_LowNodeLoadArgs_To_v1alpha2_LowNodeLoadArgs(in *config.L
 	if err := v1.Convert_int32_To_Pointer_int32(&in.NumberOfNodes, &out.NumberOfNodes, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MinNodesInScope, &out.MinNodesInScope, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EvictQuarantinedNodes, &out.EvictQuarantinedNodes, s); err != nil {
+		return err
+	}
+	out.ThrottledNodeConditionType = corev1.NodeConditionType(in.ThrottledNodeConditionType)
+	out.ConsolidationTargetUtilization = *(*ResourceThresholds)(unsafe.Pointer(&in.ConsolidationTargetUtilization))
+	out.CriticalThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.CriticalThresholds))
 	out.NodeMetricExpirationSeconds = (*int64)(unsafe.Pointer(in.NodeMetricExpirationSeconds))
+	out.NodeMetricExpirationByResource = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.NodeMetricExpirationByResource))
 	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
 	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
 	out.PodSelectors = *(*[]LowNodeLoadPodSelector)(unsafe.Pointer(&in.PodSelectors))
+	out.PodSelectorMatchMode = PodSelectorMatchMode(in.PodSelectorMatchMode)
+	out.NodeProcessingOrder = NodeProcessingOrder(in.NodeProcessingOrder)
+	out.OwnerKindEvictionPriority = *(*[]string)(unsafe.Pointer(&in.OwnerKindEvictionPriority))
+	out.CandidateOrderSeed = (*int64)(unsafe.Pointer(in.CandidateOrderSeed))
+	out.EvictionAggressiveness = (*config.Float64OrString)(unsafe.Pointer(in.EvictionAggressiveness))
 	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EmitDestinationHint, &out.EmitDestinationHint, s); err != nil {
+		return err
+	}
 	if err := v1.Convert_bool_To_Pointer_bool(&in.UseDeviationThresholds, &out.UseDeviationThresholds, s); err != nil {
 		return err
 	}
 	out.HighThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.HighThresholds))
 	out.LowThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.LowThresholds))
+	out.AbsoluteHighThresholds = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.AbsoluteHighThresholds))
+	out.AbsoluteLowThresholds = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.AbsoluteLowThresholds))
+	out.MinAbsoluteUsage = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.MinAbsoluteUsage))
 	out.ProdHighThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.ProdHighThresholds))
 	out.ProdLowThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.ProdLowThresholds))
 	out.ResourceWeights = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.ResourceWeights))
@@ -470,6 +758,9 @@ func autoConvert_v1alpha2_LowNodeLoadNodePool_To_config_LowNodeLoadNodePool(in *
 	out.UseDeviationThresholds = in.UseDeviationThresholds
 	out.HighThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.HighThresholds))
 	out.LowThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.LowThresholds))
+	out.AbsoluteHighThresholds = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.AbsoluteHighThresholds))
+	out.AbsoluteLowThresholds = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.AbsoluteLowThresholds))
+	out.MinAbsoluteUsage = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.MinAbsoluteUsage))
 	out.ProdHighThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.ProdHighThresholds))
 	out.ProdLowThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.ProdLowThresholds))
 	out.ResourceWeights = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.ResourceWeights))
@@ -496,6 +787,9 @@ func autoConvert_config_LowNodeLoadNodePool_To_v1alpha2_LowNodeLoadNodePool(in *
 	out.UseDeviationThresholds = in.UseDeviationThresholds
 	out.HighThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.HighThresholds))
 	out.LowThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.LowThresholds))
+	out.AbsoluteHighThresholds = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.AbsoluteHighThresholds))
+	out.AbsoluteLowThresholds = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.AbsoluteLowThresholds))
+	out.MinAbsoluteUsage = *(*map[corev1.ResourceName]resource.Quantity)(unsafe.Pointer(&in.MinAbsoluteUsage))
 	out.ProdHighThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.ProdHighThresholds))
 	out.ProdLowThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.ProdLowThresholds))
 	out.ResourceWeights = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.ResourceWeights))
@@ -572,6 +866,11 @@ func autoConvert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerA
 	out.EvictionPolicy = in.EvictionPolicy
 	out.DefaultDeleteOptions = (*v1.DeleteOptions)(unsafe.Pointer(in.DefaultDeleteOptions))
 	out.ArbitrationArgs = (*config.ArbitrationArgs)(unsafe.Pointer(in.ArbitrationArgs))
+	out.NodePoolLabelKey = in.NodePoolLabelKey
+	out.NodePoolSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodePoolSelector))
+	out.StartupWarmup = (*v1.Duration)(unsafe.Pointer(in.StartupWarmup))
+	out.AllowedReasons = *(*[]string)(unsafe.Pointer(&in.AllowedReasons))
+	out.EvictionAgePreference = config.EvictionAgePreference(in.EvictionAgePreference)
 	return nil
 }
 
@@ -614,6 +913,11 @@ func autoConvert_config_MigrationControllerArgs_To_v1alpha2_MigrationControllerA
 	out.DefaultDeleteOptions = (*v1.DeleteOptions)(unsafe.Pointer(in.DefaultDeleteOptions))
 	out.SchedulerNames = *(*[]string)(unsafe.Pointer(&in.SchedulerNames))
 	out.ArbitrationArgs = (*ArbitrationArgs)(unsafe.Pointer(in.ArbitrationArgs))
+	out.NodePoolLabelKey = in.NodePoolLabelKey
+	out.NodePoolSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodePoolSelector))
+	out.StartupWarmup = (*v1.Duration)(unsafe.Pointer(in.StartupWarmup))
+	out.AllowedReasons = *(*[]string)(unsafe.Pointer(&in.AllowedReasons))
+	out.EvictionAgePreference = EvictionAgePreference(in.EvictionAgePreference)
 	return nil
 }
 
@@ -799,3 +1103,291 @@ func autoConvert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in *conf
 func Convert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in *config.PriorityThreshold, out *PriorityThreshold, s conversion.Scope) error {
 	return autoConvert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in, out, s)
 }
+
+func autoConvert_v1alpha2_RebalanceForQuotaFairnessArgs_To_config_RebalanceForQuotaFairnessArgs(in *RebalanceForQuotaFairnessArgs, out *config.RebalanceForQuotaFairnessArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.Resources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.Resources))
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxEvictionsPerQuota, &out.MaxEvictionsPerQuota, s); err != nil {
+		return err
+	}
+	out.Namespaces = (*config.Namespaces)(unsafe.Pointer(in.Namespaces))
+	return nil
+}
+
+// Convert_v1alpha2_RebalanceForQuotaFairnessArgs_To_config_RebalanceForQuotaFairnessArgs is an autogenerated conversion function.
+func Convert_v1alpha2_RebalanceForQuotaFairnessArgs_To_config_RebalanceForQuotaFairnessArgs(in *RebalanceForQuotaFairnessArgs, out *config.RebalanceForQuotaFairnessArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RebalanceForQuotaFairnessArgs_To_config_RebalanceForQuotaFairnessArgs(in, out, s)
+}
+
+func autoConvert_config_RebalanceForQuotaFairnessArgs_To_v1alpha2_RebalanceForQuotaFairnessArgs(in *config.RebalanceForQuotaFairnessArgs, out *RebalanceForQuotaFairnessArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.Resources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.Resources))
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxEvictionsPerQuota, &out.MaxEvictionsPerQuota, s); err != nil {
+		return err
+	}
+	out.Namespaces = (*Namespaces)(unsafe.Pointer(in.Namespaces))
+	return nil
+}
+
+// Convert_config_RebalanceForQuotaFairnessArgs_To_v1alpha2_RebalanceForQuotaFairnessArgs is an autogenerated conversion function.
+func Convert_config_RebalanceForQuotaFairnessArgs_To_v1alpha2_RebalanceForQuotaFairnessArgs(in *config.RebalanceForQuotaFairnessArgs, out *RebalanceForQuotaFairnessArgs, s conversion.Scope) error {
+	return autoConvert_config_RebalanceForQuotaFairnessArgs_To_v1alpha2_RebalanceForQuotaFairnessArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_RemoveFailedReadinessPodsArgs_To_config_RemoveFailedReadinessPodsArgs(in *RemoveFailedReadinessPodsArgs, out *config.RemoveFailedReadinessPodsArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.MinUnreadyDuration, &out.MinUnreadyDuration, s); err != nil {
+		return err
+	}
+	out.Namespaces = (*config.Namespaces)(unsafe.Pointer(in.Namespaces))
+	return nil
+}
+
+// Convert_v1alpha2_RemoveFailedReadinessPodsArgs_To_config_RemoveFailedReadinessPodsArgs is an autogenerated conversion function.
+func Convert_v1alpha2_RemoveFailedReadinessPodsArgs_To_config_RemoveFailedReadinessPodsArgs(in *RemoveFailedReadinessPodsArgs, out *config.RemoveFailedReadinessPodsArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RemoveFailedReadinessPodsArgs_To_config_RemoveFailedReadinessPodsArgs(in, out, s)
+}
+
+func autoConvert_config_RemoveFailedReadinessPodsArgs_To_v1alpha2_RemoveFailedReadinessPodsArgs(in *config.RemoveFailedReadinessPodsArgs, out *RemoveFailedReadinessPodsArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.MinUnreadyDuration, &out.MinUnreadyDuration, s); err != nil {
+		return err
+	}
+	out.Namespaces = (*Namespaces)(unsafe.Pointer(in.Namespaces))
+	return nil
+}
+
+// Convert_config_RemoveFailedReadinessPodsArgs_To_v1alpha2_RemoveFailedReadinessPodsArgs is an autogenerated conversion function.
+func Convert_config_RemoveFailedReadinessPodsArgs_To_v1alpha2_RemoveFailedReadinessPodsArgs(in *config.RemoveFailedReadinessPodsArgs, out *RemoveFailedReadinessPodsArgs, s conversion.Scope) error {
+	return autoConvert_config_RemoveFailedReadinessPodsArgs_To_v1alpha2_RemoveFailedReadinessPodsArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_RemoveIdleGPUPodsArgs_To_config_RemoveIdleGPUPodsArgs(in *RemoveIdleGPUPodsArgs, out *config.RemoveIdleGPUPodsArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.UtilizationThreshold, &out.UtilizationThreshold, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.IdleDuration, &out.IdleDuration, s); err != nil {
+		return err
+	}
+	out.NodeMetricExpirationSeconds = (*int64)(unsafe.Pointer(in.NodeMetricExpirationSeconds))
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_v1alpha2_RemoveIdleGPUPodsArgs_To_config_RemoveIdleGPUPodsArgs is an autogenerated conversion function.
+func Convert_v1alpha2_RemoveIdleGPUPodsArgs_To_config_RemoveIdleGPUPodsArgs(in *RemoveIdleGPUPodsArgs, out *config.RemoveIdleGPUPodsArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RemoveIdleGPUPodsArgs_To_config_RemoveIdleGPUPodsArgs(in, out, s)
+}
+
+func autoConvert_config_RemoveIdleGPUPodsArgs_To_v1alpha2_RemoveIdleGPUPodsArgs(in *config.RemoveIdleGPUPodsArgs, out *RemoveIdleGPUPodsArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.UtilizationThreshold, &out.UtilizationThreshold, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.IdleDuration, &out.IdleDuration, s); err != nil {
+		return err
+	}
+	out.NodeMetricExpirationSeconds = (*int64)(unsafe.Pointer(in.NodeMetricExpirationSeconds))
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_config_RemoveIdleGPUPodsArgs_To_v1alpha2_RemoveIdleGPUPodsArgs is an autogenerated conversion function.
+func Convert_config_RemoveIdleGPUPodsArgs_To_v1alpha2_RemoveIdleGPUPodsArgs(in *config.RemoveIdleGPUPodsArgs, out *RemoveIdleGPUPodsArgs, s conversion.Scope) error {
+	return autoConvert_config_RemoveIdleGPUPodsArgs_To_v1alpha2_RemoveIdleGPUPodsArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_RemovePodsViolatingNodeSelectorArgs_To_config_RemovePodsViolatingNodeSelectorArgs(in *RemovePodsViolatingNodeSelectorArgs, out *config.RemovePodsViolatingNodeSelectorArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_v1alpha2_RemovePodsViolatingNodeSelectorArgs_To_config_RemovePodsViolatingNodeSelectorArgs is an autogenerated conversion function.
+func Convert_v1alpha2_RemovePodsViolatingNodeSelectorArgs_To_config_RemovePodsViolatingNodeSelectorArgs(in *RemovePodsViolatingNodeSelectorArgs, out *config.RemovePodsViolatingNodeSelectorArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RemovePodsViolatingNodeSelectorArgs_To_config_RemovePodsViolatingNodeSelectorArgs(in, out, s)
+}
+
+func autoConvert_config_RemovePodsViolatingNodeSelectorArgs_To_v1alpha2_RemovePodsViolatingNodeSelectorArgs(in *config.RemovePodsViolatingNodeSelectorArgs, out *RemovePodsViolatingNodeSelectorArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_config_RemovePodsViolatingNodeSelectorArgs_To_v1alpha2_RemovePodsViolatingNodeSelectorArgs is an autogenerated conversion function.
+func Convert_config_RemovePodsViolatingNodeSelectorArgs_To_v1alpha2_RemovePodsViolatingNodeSelectorArgs(in *config.RemovePodsViolatingNodeSelectorArgs, out *RemovePodsViolatingNodeSelectorArgs, s conversion.Scope) error {
+	return autoConvert_config_RemovePodsViolatingNodeSelectorArgs_To_v1alpha2_RemovePodsViolatingNodeSelectorArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_RemovePodsViolatingNodeTaintEffectsArgs_To_config_RemovePodsViolatingNodeTaintEffectsArgs(in *RemovePodsViolatingNodeTaintEffectsArgs, out *config.RemovePodsViolatingNodeTaintEffectsArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.TaintEffects = *(*[]corev1.TaintEffect)(unsafe.Pointer(&in.TaintEffects))
+	out.ExcludedTaints = *(*[]string)(unsafe.Pointer(&in.ExcludedTaints))
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_v1alpha2_RemovePodsViolatingNodeTaintEffectsArgs_To_config_RemovePodsViolatingNodeTaintEffectsArgs is an autogenerated conversion function.
+func Convert_v1alpha2_RemovePodsViolatingNodeTaintEffectsArgs_To_config_RemovePodsViolatingNodeTaintEffectsArgs(in *RemovePodsViolatingNodeTaintEffectsArgs, out *config.RemovePodsViolatingNodeTaintEffectsArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RemovePodsViolatingNodeTaintEffectsArgs_To_config_RemovePodsViolatingNodeTaintEffectsArgs(in, out, s)
+}
+
+func autoConvert_config_RemovePodsViolatingNodeTaintEffectsArgs_To_v1alpha2_RemovePodsViolatingNodeTaintEffectsArgs(in *config.RemovePodsViolatingNodeTaintEffectsArgs, out *RemovePodsViolatingNodeTaintEffectsArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.TaintEffects = *(*[]corev1.TaintEffect)(unsafe.Pointer(&in.TaintEffects))
+	out.ExcludedTaints = *(*[]string)(unsafe.Pointer(&in.ExcludedTaints))
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_config_RemovePodsViolatingNodeTaintEffectsArgs_To_v1alpha2_RemovePodsViolatingNodeTaintEffectsArgs is an autogenerated conversion function.
+func Convert_config_RemovePodsViolatingNodeTaintEffectsArgs_To_v1alpha2_RemovePodsViolatingNodeTaintEffectsArgs(in *config.RemovePodsViolatingNodeTaintEffectsArgs, out *RemovePodsViolatingNodeTaintEffectsArgs, s conversion.Scope) error {
+	return autoConvert_config_RemovePodsViolatingNodeTaintEffectsArgs_To_v1alpha2_RemovePodsViolatingNodeTaintEffectsArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_RemovePodsViolatingRequestLimitRatioArgs_To_config_RemovePodsViolatingRequestLimitRatioArgs(in *RemovePodsViolatingRequestLimitRatioArgs, out *config.RemovePodsViolatingRequestLimitRatioArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.MinRequestLimitRatios = *(*config.ResourceThresholds)(unsafe.Pointer(&in.MinRequestLimitRatios))
+	out.MaxRequestLimitRatios = *(*config.ResourceThresholds)(unsafe.Pointer(&in.MaxRequestLimitRatios))
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_v1alpha2_RemovePodsViolatingRequestLimitRatioArgs_To_config_RemovePodsViolatingRequestLimitRatioArgs is an autogenerated conversion function.
+func Convert_v1alpha2_RemovePodsViolatingRequestLimitRatioArgs_To_config_RemovePodsViolatingRequestLimitRatioArgs(in *RemovePodsViolatingRequestLimitRatioArgs, out *config.RemovePodsViolatingRequestLimitRatioArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RemovePodsViolatingRequestLimitRatioArgs_To_config_RemovePodsViolatingRequestLimitRatioArgs(in, out, s)
+}
+
+func autoConvert_config_RemovePodsViolatingRequestLimitRatioArgs_To_v1alpha2_RemovePodsViolatingRequestLimitRatioArgs(in *config.RemovePodsViolatingRequestLimitRatioArgs, out *RemovePodsViolatingRequestLimitRatioArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.MinRequestLimitRatios = *(*ResourceThresholds)(unsafe.Pointer(&in.MinRequestLimitRatios))
+	out.MaxRequestLimitRatios = *(*ResourceThresholds)(unsafe.Pointer(&in.MaxRequestLimitRatios))
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_config_RemovePodsViolatingRequestLimitRatioArgs_To_v1alpha2_RemovePodsViolatingRequestLimitRatioArgs is an autogenerated conversion function.
+func Convert_config_RemovePodsViolatingRequestLimitRatioArgs_To_v1alpha2_RemovePodsViolatingRequestLimitRatioArgs(in *config.RemovePodsViolatingRequestLimitRatioArgs, out *RemovePodsViolatingRequestLimitRatioArgs, s conversion.Scope) error {
+	return autoConvert_config_RemovePodsViolatingRequestLimitRatioArgs_To_v1alpha2_RemovePodsViolatingRequestLimitRatioArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_RemovePodsWithMissingReferencesArgs_To_config_RemovePodsWithMissingReferencesArgs(in *RemovePodsWithMissingReferencesArgs, out *config.RemovePodsWithMissingReferencesArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.MinFailingDuration, &out.MinFailingDuration, s); err != nil {
+		return err
+	}
+	out.Namespaces = (*config.Namespaces)(unsafe.Pointer(in.Namespaces))
+	return nil
+}
+
+// Convert_v1alpha2_RemovePodsWithMissingReferencesArgs_To_config_RemovePodsWithMissingReferencesArgs is an autogenerated conversion function.
+func Convert_v1alpha2_RemovePodsWithMissingReferencesArgs_To_config_RemovePodsWithMissingReferencesArgs(in *RemovePodsWithMissingReferencesArgs, out *config.RemovePodsWithMissingReferencesArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RemovePodsWithMissingReferencesArgs_To_config_RemovePodsWithMissingReferencesArgs(in, out, s)
+}
+
+func autoConvert_config_RemovePodsWithMissingReferencesArgs_To_v1alpha2_RemovePodsWithMissingReferencesArgs(in *config.RemovePodsWithMissingReferencesArgs, out *RemovePodsWithMissingReferencesArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.MinFailingDuration, &out.MinFailingDuration, s); err != nil {
+		return err
+	}
+	out.Namespaces = (*Namespaces)(unsafe.Pointer(in.Namespaces))
+	return nil
+}
+
+// Convert_config_RemovePodsWithMissingReferencesArgs_To_v1alpha2_RemovePodsWithMissingReferencesArgs is an autogenerated conversion function.
+func Convert_config_RemovePodsWithMissingReferencesArgs_To_v1alpha2_RemovePodsWithMissingReferencesArgs(in *config.RemovePodsWithMissingReferencesArgs, out *RemovePodsWithMissingReferencesArgs, s conversion.Scope) error {
+	return autoConvert_config_RemovePodsWithMissingReferencesArgs_To_v1alpha2_RemovePodsWithMissingReferencesArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_RemovePodsWithOutdatedRequestsArgs_To_config_RemovePodsWithOutdatedRequestsArgs(in *RemovePodsWithOutdatedRequestsArgs, out *config.RemovePodsWithOutdatedRequestsArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_float64_To_float64((**float64)(unsafe.Pointer(&in.DivergenceThreshold)), (*float64)(unsafe.Pointer(&out.DivergenceThreshold)), s); err != nil {
+		return err
+	}
+	out.Resources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.Resources))
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_v1alpha2_RemovePodsWithOutdatedRequestsArgs_To_config_RemovePodsWithOutdatedRequestsArgs is an autogenerated conversion function.
+func Convert_v1alpha2_RemovePodsWithOutdatedRequestsArgs_To_config_RemovePodsWithOutdatedRequestsArgs(in *RemovePodsWithOutdatedRequestsArgs, out *config.RemovePodsWithOutdatedRequestsArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RemovePodsWithOutdatedRequestsArgs_To_config_RemovePodsWithOutdatedRequestsArgs(in, out, s)
+}
+
+func autoConvert_config_RemovePodsWithOutdatedRequestsArgs_To_v1alpha2_RemovePodsWithOutdatedRequestsArgs(in *config.RemovePodsWithOutdatedRequestsArgs, out *RemovePodsWithOutdatedRequestsArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_float64_To_Pointer_float64((*float64)(unsafe.Pointer(&in.DivergenceThreshold)), (**float64)(unsafe.Pointer(&out.DivergenceThreshold)), s); err != nil {
+		return err
+	}
+	out.Resources = *(*[]corev1.ResourceName)(unsafe.Pointer(&in.Resources))
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	return nil
+}
+
+// Convert_config_RemovePodsWithOutdatedRequestsArgs_To_v1alpha2_RemovePodsWithOutdatedRequestsArgs is an autogenerated conversion function.
+func Convert_config_RemovePodsWithOutdatedRequestsArgs_To_v1alpha2_RemovePodsWithOutdatedRequestsArgs(in *config.RemovePodsWithOutdatedRequestsArgs, out *RemovePodsWithOutdatedRequestsArgs, s conversion.Scope) error {
+	return autoConvert_config_RemovePodsWithOutdatedRequestsArgs_To_v1alpha2_RemovePodsWithOutdatedRequestsArgs(in, out, s)
+}
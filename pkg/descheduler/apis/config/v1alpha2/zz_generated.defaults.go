@@ -29,16 +29,52 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&BalanceAcrossZonesArgs{}, func(obj interface{}) { SetObjectDefaults_BalanceAcrossZonesArgs(obj.(*BalanceAcrossZonesArgs)) })
 	scheme.AddTypeDefaultingFunc(&DeschedulerConfiguration{}, func(obj interface{}) { SetObjectDefaults_DeschedulerConfiguration(obj.(*DeschedulerConfiguration)) })
+	scheme.AddTypeDefaultingFunc(&DrainScaleDownCandidatesArgs{}, func(obj interface{}) {
+		SetObjectDefaults_DrainScaleDownCandidatesArgs(obj.(*DrainScaleDownCandidatesArgs))
+	})
 	scheme.AddTypeDefaultingFunc(&LowNodeLoadArgs{}, func(obj interface{}) { SetObjectDefaults_LowNodeLoadArgs(obj.(*LowNodeLoadArgs)) })
 	scheme.AddTypeDefaultingFunc(&MigrationControllerArgs{}, func(obj interface{}) { SetObjectDefaults_MigrationControllerArgs(obj.(*MigrationControllerArgs)) })
+	scheme.AddTypeDefaultingFunc(&RebalanceForQuotaFairnessArgs{}, func(obj interface{}) {
+		SetObjectDefaults_RebalanceForQuotaFairnessArgs(obj.(*RebalanceForQuotaFairnessArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&RemoveFailedReadinessPodsArgs{}, func(obj interface{}) {
+		SetObjectDefaults_RemoveFailedReadinessPodsArgs(obj.(*RemoveFailedReadinessPodsArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&RemoveIdleGPUPodsArgs{}, func(obj interface{}) {
+		SetObjectDefaults_RemoveIdleGPUPodsArgs(obj.(*RemoveIdleGPUPodsArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&RemovePodsViolatingNodeSelectorArgs{}, func(obj interface{}) {
+		SetObjectDefaults_RemovePodsViolatingNodeSelectorArgs(obj.(*RemovePodsViolatingNodeSelectorArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&RemovePodsViolatingNodeTaintEffectsArgs{}, func(obj interface{}) {
+		SetObjectDefaults_RemovePodsViolatingNodeTaintEffectsArgs(obj.(*RemovePodsViolatingNodeTaintEffectsArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&RemovePodsViolatingRequestLimitRatioArgs{}, func(obj interface{}) {
+		SetObjectDefaults_RemovePodsViolatingRequestLimitRatioArgs(obj.(*RemovePodsViolatingRequestLimitRatioArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&RemovePodsWithMissingReferencesArgs{}, func(obj interface{}) {
+		SetObjectDefaults_RemovePodsWithMissingReferencesArgs(obj.(*RemovePodsWithMissingReferencesArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&RemovePodsWithOutdatedRequestsArgs{}, func(obj interface{}) {
+		SetObjectDefaults_RemovePodsWithOutdatedRequestsArgs(obj.(*RemovePodsWithOutdatedRequestsArgs))
+	})
 	return nil
 }
 
+func SetObjectDefaults_BalanceAcrossZonesArgs(in *BalanceAcrossZonesArgs) {
+	SetDefaults_BalanceAcrossZonesArgs(in)
+}
+
 func SetObjectDefaults_DeschedulerConfiguration(in *DeschedulerConfiguration) {
 	SetDefaults_DeschedulerConfiguration(in)
 }
 
+func SetObjectDefaults_DrainScaleDownCandidatesArgs(in *DrainScaleDownCandidatesArgs) {
+	SetDefaults_DrainScaleDownCandidatesArgs(in)
+}
+
 func SetObjectDefaults_LowNodeLoadArgs(in *LowNodeLoadArgs) {
 	SetDefaults_LowNodeLoadArgs(in)
 }
@@ -46,3 +82,35 @@ func SetObjectDefaults_LowNodeLoadArgs(in *LowNodeLoadArgs) {
 func SetObjectDefaults_MigrationControllerArgs(in *MigrationControllerArgs) {
 	SetDefaults_MigrationControllerArgs(in)
 }
+
+func SetObjectDefaults_RebalanceForQuotaFairnessArgs(in *RebalanceForQuotaFairnessArgs) {
+	SetDefaults_RebalanceForQuotaFairnessArgs(in)
+}
+
+func SetObjectDefaults_RemoveFailedReadinessPodsArgs(in *RemoveFailedReadinessPodsArgs) {
+	SetDefaults_RemoveFailedReadinessPodsArgs(in)
+}
+
+func SetObjectDefaults_RemoveIdleGPUPodsArgs(in *RemoveIdleGPUPodsArgs) {
+	SetDefaults_RemoveIdleGPUPodsArgs(in)
+}
+
+func SetObjectDefaults_RemovePodsViolatingNodeSelectorArgs(in *RemovePodsViolatingNodeSelectorArgs) {
+	SetDefaults_RemovePodsViolatingNodeSelectorArgs(in)
+}
+
+func SetObjectDefaults_RemovePodsViolatingNodeTaintEffectsArgs(in *RemovePodsViolatingNodeTaintEffectsArgs) {
+	SetDefaults_RemovePodsViolatingNodeTaintEffectsArgs(in)
+}
+
+func SetObjectDefaults_RemovePodsViolatingRequestLimitRatioArgs(in *RemovePodsViolatingRequestLimitRatioArgs) {
+	SetDefaults_RemovePodsViolatingRequestLimitRatioArgs(in)
+}
+
+func SetObjectDefaults_RemovePodsWithMissingReferencesArgs(in *RemovePodsWithMissingReferencesArgs) {
+	SetDefaults_RemovePodsWithMissingReferencesArgs(in)
+}
+
+func SetObjectDefaults_RemovePodsWithOutdatedRequestsArgs(in *RemovePodsWithOutdatedRequestsArgs) {
+	SetDefaults_RemovePodsWithOutdatedRequestsArgs(in)
+}
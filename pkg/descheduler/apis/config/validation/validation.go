@@ -17,19 +17,32 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"reflect"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	componentbasevalidation "k8s.io/component-base/config/validation"
+	tracingapi "k8s.io/component-base/tracing/api/v1"
 
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/names"
 )
 
+// pluginArgsScheme holds the in-tree plugin args types, used to check that the
+// configured args match the kind expected by the plugin.
+var pluginArgsScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(config.AddToScheme(pluginArgsScheme))
+}
+
 func ValidateDeschedulerConfiguration(cc *config.DeschedulerConfiguration) utilerrors.Aggregate {
 	var errs []error
 	errs = append(errs, componentbasevalidation.ValidateClientConnectionConfiguration(&cc.ClientConnection, field.NewPath("clientConnection")).ToAggregate())
@@ -43,6 +56,7 @@ func ValidateDeschedulerConfiguration(cc *config.DeschedulerConfiguration) utile
 			profile := &cc.Profiles[i]
 			path := profilesPath.Index(i)
 			errs = append(errs, validateDeschedulerProfile(path, profile)...)
+			errs = append(errs, validateProfileWithInterval(path, profile, cc.DeschedulingInterval.Duration)...)
 			if idx, ok := existingProfiles[profile.Name]; ok {
 				errs = append(errs, field.Duplicate(path.Child("name"), profilesPath.Index(idx).Child("name")))
 			}
@@ -63,6 +77,55 @@ func ValidateDeschedulerConfiguration(cc *config.DeschedulerConfiguration) utile
 		}
 	}
 
+	errs = append(errs, tracingapi.ValidateTracingConfiguration(cc.Tracing, nil, field.NewPath("tracing")).ToAggregate())
+
+	if cc.MaxConcurrentProfiles < 1 {
+		errs = append(errs, field.Invalid(field.NewPath("maxConcurrentProfiles"), cc.MaxConcurrentProfiles, "must be greater than or equal to 1"))
+	}
+
+	if cc.StartupGracePeriod.Duration < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("startupGracePeriod"), cc.StartupGracePeriod, "must be greater than or equal to 0"))
+	}
+
+	if cc.InitialObservationPeriod != nil && cc.InitialObservationPeriod.Duration < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("initialObservationPeriod"), cc.InitialObservationPeriod, "must be greater than or equal to 0"))
+	}
+
+	if cc.MaxCycleDuration != nil && cc.MaxCycleDuration.Duration <= 0 {
+		errs = append(errs, field.Invalid(field.NewPath("maxCycleDuration"), cc.MaxCycleDuration, "must be greater than 0"))
+	}
+
+	if cc.MaxEvictionFractionPerCycle != nil {
+		if fraction := cc.MaxEvictionFractionPerCycle.FloatValue(); fraction <= 0 || fraction > 1 {
+			errs = append(errs, field.Invalid(field.NewPath("maxEvictionFractionPerCycle"), cc.MaxEvictionFractionPerCycle, "must be greater than 0 and less than or equal to 1"))
+		}
+	}
+
+	if cc.EvictSystemCriticalPriorityClassPods && cc.MaxNoOfPodsToEvictTotal == nil && cc.MaxEvictionFractionPerCycle == nil {
+		errs = append(errs, field.Invalid(field.NewPath("evictSystemCriticalPriorityClassPods"), cc.EvictSystemCriticalPriorityClassPods,
+			"requires maxNoOfPodsToEvictTotal or maxEvictionFractionPerCycle to be set"))
+	}
+
+	for _, quotaName := range sets.StringKeySet(cc.MaxNoOfPodsToEvictPerQuota).List() {
+		quotaPath := field.NewPath("maxNoOfPodsToEvictPerQuota").Key(quotaName)
+		for _, msg := range validation.IsDNS1123Subdomain(quotaName) {
+			errs = append(errs, field.Invalid(quotaPath, quotaName, msg))
+		}
+		if cc.MaxNoOfPodsToEvictPerQuota[quotaName] == 0 {
+			errs = append(errs, field.Invalid(quotaPath, cc.MaxNoOfPodsToEvictPerQuota[quotaName], "must be greater than 0"))
+		}
+	}
+
+	if cc.SafeModeEvictionRate < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("safeModeEvictionRate"), cc.SafeModeEvictionRate, "must be greater than or equal to 0"))
+	}
+	if cc.SafeModeEvictionRate > 0 && cc.SafeModeCooldown.Duration <= 0 {
+		errs = append(errs, field.Invalid(field.NewPath("safeModeCooldown"), cc.SafeModeCooldown, "must be greater than 0 when safeModeEvictionRate is set"))
+	}
+	if cc.EvictionEventRate < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("evictionEventRate"), cc.EvictionEventRate, "must be greater than or equal to 0"))
+	}
+
 	return utilerrors.Flatten(utilerrors.NewAggregate(errs))
 }
 
@@ -71,7 +134,67 @@ func validateDeschedulerProfile(path *field.Path, profile *config.DeschedulerPro
 	if len(profile.Name) == 0 {
 		errs = append(errs, field.Required(path.Child("name"), ""))
 	}
+	if profile.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(profile.NamespaceSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("namespaceSelector"), profile.NamespaceSelector, err.Error()))
+		}
+	}
 	errs = append(errs, validatePluginConfig(path, profile)...)
+	errs = append(errs, validatePluginExtensionPoints(path, profile)...)
+	errs = append(errs, validateExtensionPointOrder(path.Child("extensionPointOrder"), profile.ExtensionPointOrder)...)
+	return errs
+}
+
+// validateExtensionPointOrder checks the order only contains the known extension points, each at most once.
+func validateExtensionPointOrder(path *field.Path, order []string) []error {
+	var errs []error
+	knownExtensionPoints := sets.NewString(config.DefaultExtensionPointOrder...)
+	seen := sets.NewString()
+	for i, extensionPoint := range order {
+		if !knownExtensionPoints.Has(extensionPoint) {
+			errs = append(errs, field.NotSupported(path.Index(i), extensionPoint, config.DefaultExtensionPointOrder))
+			continue
+		}
+		if seen.Has(extensionPoint) {
+			errs = append(errs, field.Duplicate(path.Index(i), extensionPoint))
+		}
+		seen.Insert(extensionPoint)
+	}
+	return errs
+}
+
+// validatePluginExtensionPoints checks that a plugin is enabled in at most one of the Deschedule and Balance
+// extension points, otherwise the plugin runs twice in a descheduling cycle and double-counts the evictions.
+// The Evict and Filter extension points are separate concerns and not checked.
+func validatePluginExtensionPoints(path *field.Path, profile *config.DeschedulerProfile) []error {
+	if profile.Plugins == nil {
+		return nil
+	}
+	var errs []error
+	deschedulePlugins := sets.NewString()
+	for _, plugin := range profile.Plugins.Deschedule.Enabled {
+		deschedulePlugins.Insert(plugin.Name)
+	}
+	for i, plugin := range profile.Plugins.Balance.Enabled {
+		if deschedulePlugins.Has(plugin.Name) {
+			errs = append(errs, field.Invalid(path.Child("plugins", "balance", "enabled").Index(i), plugin.Name,
+				fmt.Sprintf("plugin %q is enabled in both deschedule and balance, it can only be enabled in one of them", plugin.Name)))
+		}
+	}
+	return errs
+}
+
+// validateProfileWithInterval validates the plugin args which depend on the descheduling interval.
+func validateProfileWithInterval(path *field.Path, profile *config.DeschedulerProfile, interval time.Duration) []error {
+	var errs []error
+	for i := range profile.PluginConfig {
+		if args, ok := profile.PluginConfig[i].Args.(*config.LowNodeLoadArgs); ok {
+			argsPath := path.Child("pluginConfig").Index(i).Child("args")
+			if err := ValidateLowLoadUtilizationArgsWithInterval(argsPath, args, interval); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
 	return errs
 }
 
@@ -93,6 +216,10 @@ func validatePluginConfig(path *field.Path, profile *config.DeschedulerProfile)
 		} else {
 			seenPluginConfig.Insert(name)
 		}
+		if err := validatePluginArgsKind(pluginConfigPath.Child("args"), name, args); err != nil {
+			errs = append(errs, err)
+			continue
+		}
 		if validateFunc, ok := m[name]; ok {
 			// type mismatch, no need to validate the `args`.
 			if reflect.TypeOf(args) != reflect.ValueOf(validateFunc).Type().In(1) {
@@ -109,3 +236,27 @@ func validatePluginConfig(path *field.Path, profile *config.DeschedulerProfile)
 	}
 	return errs
 }
+
+// validatePluginArgsKind checks that the args of an in-tree plugin are of the kind
+// registered for that plugin, i.e. "<PluginName>Args". Plugins without a registered
+// args kind and args that were not decoded into a typed object are skipped.
+func validatePluginArgsKind(path *field.Path, name string, args runtime.Object) error {
+	if args == nil {
+		return nil
+	}
+	if _, ok := args.(*runtime.Unknown); ok {
+		return nil
+	}
+	expected := config.SchemeGroupVersion.WithKind(name + "Args")
+	if !pluginArgsScheme.Recognizes(expected) {
+		return nil
+	}
+	actual := reflect.TypeOf(args).String()
+	if gvks, _, err := pluginArgsScheme.ObjectKinds(args); err == nil && len(gvks) > 0 {
+		if gvks[0].Kind == expected.Kind {
+			return nil
+		}
+		actual = gvks[0].Kind
+	}
+	return field.Invalid(path, actual, fmt.Sprintf("plugin %q expects args of kind %q, got %q", name, expected.Kind, actual))
+}
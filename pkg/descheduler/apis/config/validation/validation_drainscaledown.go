@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func ValidateDrainScaleDownCandidatesArgs(path *field.Path, args *deschedulerconfig.DrainScaleDownCandidatesArgs) error {
+	var allErrs field.ErrorList
+
+	if len(args.MarkerKeys) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("markerKeys"), "at least one marker key must be specified"))
+	}
+	seenKeys := sets.NewString()
+	for i, key := range args.MarkerKeys {
+		if key == "" {
+			allErrs = append(allErrs, field.Invalid(path.Child("markerKeys").Index(i), key, "must be non-empty"))
+			continue
+		}
+		if seenKeys.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("markerKeys").Index(i), key))
+		}
+		seenKeys.Insert(key)
+	}
+
+	if args.StaleHeartbeatThreshold != nil && args.StaleHeartbeatThreshold.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("staleHeartbeatThreshold"), args.StaleHeartbeatThreshold, "staleHeartbeatThreshold must be greater than 0"))
+	}
+
+	allErrs = append(allErrs, validateNamespaces(path.Child("evictableNamespaces"), args.EvictableNamespaces)...)
+
+	return allErrs.ToAggregate()
+}
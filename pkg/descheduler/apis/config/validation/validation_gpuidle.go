@@ -0,0 +1,41 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func ValidateRemoveIdleGPUPodsArgs(path *field.Path, args *deschedulerconfig.RemoveIdleGPUPodsArgs) error {
+	var allErrs field.ErrorList
+
+	if args.UtilizationThreshold <= 0 || args.UtilizationThreshold > 100 {
+		allErrs = append(allErrs, field.Invalid(path.Child("utilizationThreshold"), args.UtilizationThreshold, "utilizationThreshold must be in (0, 100]"))
+	}
+	if args.IdleDuration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("idleDuration"), args.IdleDuration, "idleDuration must be greater than 0"))
+	}
+	if args.NodeMetricExpirationSeconds != nil && *args.NodeMetricExpirationSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("nodeMetricExpirationSeconds"), *args.NodeMetricExpirationSeconds, "nodeMetricExpirationSeconds should be a positive value"))
+	}
+
+	allErrs = append(allErrs, validateNamespaces(path.Child("evictableNamespaces"), args.EvictableNamespaces)...)
+
+	return allErrs.ToAggregate()
+}
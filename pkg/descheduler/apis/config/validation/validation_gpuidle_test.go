@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateRemoveIdleGPUPodsArgs(t *testing.T) {
+	validArgs := func() *deschedulerconfig.RemoveIdleGPUPodsArgs {
+		return &deschedulerconfig.RemoveIdleGPUPodsArgs{
+			UtilizationThreshold:        5,
+			IdleDuration:                metav1.Duration{Duration: 30 * time.Minute},
+			NodeMetricExpirationSeconds: pointer.Int64(180),
+		}
+	}
+	tests := []struct {
+		name    string
+		modify  func(args *deschedulerconfig.RemoveIdleGPUPodsArgs)
+		wantErr bool
+	}{
+		{
+			name:   "valid args",
+			modify: func(args *deschedulerconfig.RemoveIdleGPUPodsArgs) {},
+		},
+		{
+			name: "utilizationThreshold of 100",
+			modify: func(args *deschedulerconfig.RemoveIdleGPUPodsArgs) {
+				args.UtilizationThreshold = 100
+			},
+		},
+		{
+			name: "zero utilizationThreshold",
+			modify: func(args *deschedulerconfig.RemoveIdleGPUPodsArgs) {
+				args.UtilizationThreshold = 0
+			},
+			wantErr: true,
+		},
+		{
+			name: "utilizationThreshold greater than 100",
+			modify: func(args *deschedulerconfig.RemoveIdleGPUPodsArgs) {
+				args.UtilizationThreshold = 101
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero idleDuration",
+			modify: func(args *deschedulerconfig.RemoveIdleGPUPodsArgs) {
+				args.IdleDuration = metav1.Duration{}
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative nodeMetricExpirationSeconds",
+			modify: func(args *deschedulerconfig.RemoveIdleGPUPodsArgs) {
+				args.NodeMetricExpirationSeconds = pointer.Int64(-1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "both include and exclude namespaces",
+			modify: func(args *deschedulerconfig.RemoveIdleGPUPodsArgs) {
+				args.EvictableNamespaces = &deschedulerconfig.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := validArgs()
+			tt.modify(args)
+			err := ValidateRemoveIdleGPUPodsArgs(field.NewPath("args"), args)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
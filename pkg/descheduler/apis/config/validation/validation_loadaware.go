@@ -17,12 +17,47 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 )
 
+// MinNodeMetricExpirationSeconds is the floor of a plausible NodeMetricExpirationSeconds.
+// koordlet reports NodeMetrics every 60 seconds by default, the nodes are perpetually considered
+// expired if the expiration is shorter than the report interval.
+var MinNodeMetricExpirationSeconds int64 = 60
+
+// GetLowLoadUtilizationArgsWarnings returns warnings of the LowNodeLoadArgs which are valid but implausible.
+func GetLowLoadUtilizationArgsWarnings(path *field.Path, args *deschedulerconfig.LowNodeLoadArgs) []string {
+	var warnings []string
+	if args.NodeMetricExpirationSeconds != nil && *args.NodeMetricExpirationSeconds > 0 &&
+		*args.NodeMetricExpirationSeconds < MinNodeMetricExpirationSeconds {
+		warnings = append(warnings, fmt.Sprintf("%s: %d is shorter than the NodeMetric report interval %d seconds, nodes may be always considered expired",
+			path.Child("nodeMetricExpirationSeconds"), *args.NodeMetricExpirationSeconds, MinNodeMetricExpirationSeconds))
+	}
+	for resourceName, expirationSeconds := range args.NodeMetricExpirationByResource {
+		if expirationSeconds > 0 && expirationSeconds < MinNodeMetricExpirationSeconds {
+			warnings = append(warnings, fmt.Sprintf("%s: %d is shorter than the NodeMetric report interval %d seconds, nodes may be always considered expired",
+				path.Child("nodeMetricExpirationByResource").Key(string(resourceName)), expirationSeconds, MinNodeMetricExpirationSeconds))
+		}
+	}
+	return warnings
+}
+
+// builtinNodeConditionTypes are the node conditions maintained by Kubernetes, which can not indicate the node is throttled.
+var builtinNodeConditionTypes = sets.NewString(string(corev1.NodeReady), string(corev1.NodeMemoryPressure), string(corev1.NodeDiskPressure),
+	string(corev1.NodePIDPressure), string(corev1.NodeNetworkUnavailable))
+
+// knownOwnerKinds are the workload kinds supported by OwnerKindEvictionPriority.
+var knownOwnerKinds = sets.NewString("CronJob", "DaemonSet", "Deployment", "Job", "ReplicaSet", "ReplicationController", "StatefulSet")
+
 func ValidateLowLoadUtilizationArgs(path *field.Path, args *deschedulerconfig.LowNodeLoadArgs) error {
 	var allErrs field.ErrorList
 
@@ -30,28 +65,107 @@ func ValidateLowLoadUtilizationArgs(path *field.Path, args *deschedulerconfig.Lo
 		allErrs = append(allErrs, field.Invalid(path.Child("numberOfNodes"), args.NumberOfNodes, "must be greater than or equal to 0"))
 	}
 
+	if args.MinNodesInScope < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minNodesInScope"), args.MinNodesInScope, "must be greater than or equal to 0"))
+	}
+
 	if args.NodeMetricExpirationSeconds != nil && *args.NodeMetricExpirationSeconds <= 0 {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("nodeMetricExpiredSeconds"), *args.NodeMetricExpirationSeconds, "nodeMetricExpiredSeconds should be a positive value"))
 	}
 
-	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 && len(args.EvictableNamespaces.Exclude) > 0 {
-		allErrs = append(allErrs, field.Invalid(path.Child("evictableNamespaces"), args.EvictableNamespaces, "only one of Include/Exclude namespaces can be set"))
+	for resourceName, expirationSeconds := range args.NodeMetricExpirationByResource {
+		if expirationSeconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("nodeMetricExpirationByResource").Key(string(resourceName)), expirationSeconds, "should be a positive value"))
+		}
+	}
+
+	if args.EmitDestinationHint && !args.NodeFit {
+		allErrs = append(allErrs, field.Invalid(path.Child("emitDestinationHint"), args.EmitDestinationHint, "requires nodeFit to be enabled"))
 	}
 
+	if args.ThrottledNodeConditionType != "" {
+		conditionTypePath := path.Child("throttledNodeConditionType")
+		for _, msg := range validation.IsQualifiedName(string(args.ThrottledNodeConditionType)) {
+			allErrs = append(allErrs, field.Invalid(conditionTypePath, args.ThrottledNodeConditionType, msg))
+		}
+		if builtinNodeConditionTypes.Has(string(args.ThrottledNodeConditionType)) {
+			allErrs = append(allErrs, field.Invalid(conditionTypePath, args.ThrottledNodeConditionType, "must be a custom node condition type, not a built-in one"))
+		}
+	}
+
+	for resourceName, percentage := range args.ConsolidationTargetUtilization {
+		if percentage <= 0 || percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(path.Child("consolidationTargetUtilization").Key(string(resourceName)), percentage, "percentage must be greater than 0 and less than or equal to 100"))
+		}
+	}
+
+	allErrs = append(allErrs, validateCriticalThresholds(path, args)...)
+
+	allErrs = append(allErrs, validateNamespaces(path.Child("evictableNamespaces"), args.EvictableNamespaces)...)
+
 	for i, v := range args.PodSelectors {
 		if v.Selector != nil {
 			if _, err := metav1.LabelSelectorAsSelector(v.Selector); err != nil {
-				allErrs = append(allErrs, field.Invalid(path.Child("podSelectors").Index(i), v, err.Error()))
+				allErrs = append(allErrs, field.Invalid(path.Child("podSelectors").Index(i).Child("selector"), v.Selector, err.Error()))
 			}
 		}
 	}
 
+	switch args.PodSelectorMatchMode {
+	case "", deschedulerconfig.PodSelectorMatchModeAny, deschedulerconfig.PodSelectorMatchModeAll:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("podSelectorMatchMode"), args.PodSelectorMatchMode,
+			[]string{string(deschedulerconfig.PodSelectorMatchModeAny), string(deschedulerconfig.PodSelectorMatchModeAll)}))
+	}
+
+	switch args.NodeProcessingOrder {
+	case "", deschedulerconfig.NodeProcessingOrderMostOverutilizedFirst, deschedulerconfig.NodeProcessingOrderLeastFirst, deschedulerconfig.NodeProcessingOrderByName:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("nodeProcessingOrder"), args.NodeProcessingOrder,
+			[]string{string(deschedulerconfig.NodeProcessingOrderMostOverutilizedFirst), string(deschedulerconfig.NodeProcessingOrderLeastFirst), string(deschedulerconfig.NodeProcessingOrderByName)}))
+	}
+
+	seenOwnerKinds := sets.NewString()
+	for i, kind := range args.OwnerKindEvictionPriority {
+		if !knownOwnerKinds.Has(kind) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("ownerKindEvictionPriority").Index(i), kind, knownOwnerKinds.List()))
+			continue
+		}
+		if seenOwnerKinds.Has(kind) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("ownerKindEvictionPriority").Index(i), kind))
+		}
+		seenOwnerKinds.Insert(kind)
+	}
+
+	if args.EvictionAggressiveness != nil {
+		if aggressiveness := args.EvictionAggressiveness.FloatValue(); aggressiveness <= 0 || aggressiveness > 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child("evictionAggressiveness"), args.EvictionAggressiveness, "must be greater than 0 and less than or equal to 1"))
+		}
+	}
+
+	if args.AnomalyCondition != nil {
+		allErrs = append(allErrs, validateLoadAnomalyCondition(path.Child("anomalyCondition"), args.AnomalyCondition)...)
+	}
+
+	// nil DetectorCacheTimeout falls back to the default, while a non-positive one defeats the cache of the anomaly detectors
+	if args.DetectorCacheTimeout != nil && args.DetectorCacheTimeout.Duration <= 0 && hasAnomalyCondition(args) {
+		allErrs = append(allErrs, field.Invalid(path.Child("detectorCacheTimeout"), args.DetectorCacheTimeout.Duration.String(),
+			"must be greater than 0 when anomalyCondition is set"))
+	}
+
+	seenNodePools := sets.NewString()
 	for i, nodePool := range args.NodePools {
 		nodePoolPath := path.Child("nodePools").Index(i)
-		if nodePool.NodeSelector != nil {
-			if _, err := metav1.LabelSelectorAsSelector(nodePool.NodeSelector); err != nil {
-				allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("nodeSelector"), nodePool.NodeSelector, err.Error()))
+		if nodePool.Name != "" {
+			if seenNodePools.Has(nodePool.Name) {
+				allErrs = append(allErrs, field.Duplicate(nodePoolPath.Child("name"), nodePool.Name))
 			}
+			seenNodePools.Insert(nodePool.Name)
+		}
+		if nodePool.NodeSelector == nil {
+			allErrs = append(allErrs, field.Required(nodePoolPath.Child("nodeSelector"), "nodeSelector must be set, use an empty selector to select all nodes explicitly"))
+		} else if _, err := metav1.LabelSelectorAsSelector(nodePool.NodeSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("nodeSelector"), nodePool.NodeSelector, err.Error()))
 		}
 
 		for resourceName, percentage := range nodePool.HighThresholds {
@@ -65,6 +179,8 @@ func ValidateLowLoadUtilizationArgs(path *field.Path, args *deschedulerconfig.Lo
 			}
 			if highPercentage, ok := nodePool.HighThresholds[resourceName]; ok && percentage > highPercentage {
 				allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("lowThresholds").Key(string(resourceName)), percentage, "low percentage must be less than or equal to highThresholds"))
+			} else if ok && percentage == highPercentage && !nodePool.UseDeviationThresholds {
+				allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("lowThresholds").Key(string(resourceName)), percentage, emptyAppropriateBandMsg("highThresholds")))
 			}
 		}
 
@@ -82,17 +198,286 @@ func ValidateLowLoadUtilizationArgs(path *field.Path, args *deschedulerconfig.Lo
 			}
 			if highProdPercentage, ok := nodePool.ProdHighThresholds[resourceName]; ok && percentage > highProdPercentage {
 				allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("ProdLowThresholds").Key(string(resourceName)), percentage, "low percentage must be less than or equal to prodHighThresholds"))
+			} else if ok && percentage == highProdPercentage && !nodePool.UseDeviationThresholds {
+				allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("ProdLowThresholds").Key(string(resourceName)), percentage, emptyAppropriateBandMsg("prodHighThresholds")))
+			}
+		}
+
+		allErrs = append(allErrs, validateInheritedThresholds(nodePoolPath, args, &nodePool)...)
+		allErrs = append(allErrs, validateResourceWeightsCoverage(nodePoolPath, &nodePool)...)
+		allErrs = append(allErrs, validateEphemeralStorageWeight(nodePoolPath, &nodePool)...)
+		allErrs = append(allErrs, validateAbsoluteThresholds(nodePoolPath, &nodePool)...)
+		for resourceName, quantity := range nodePool.MinAbsoluteUsage {
+			if quantity.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("minAbsoluteUsage").Key(string(resourceName)), quantity.String(), "quantity must be greater than or equal to 0"))
+			}
+		}
+		allErrs = append(allErrs, validateLoadAnomalyCondition(nodePoolPath.Child("anomalyCondition"), nodePool.AnomalyCondition)...)
+	}
+
+	if !isActionable(args) {
+		allErrs = append(allErrs, field.Required(path.Child("highThresholds"),
+			"at least one of highThresholds/prodHighThresholds/absoluteHighThresholds must be set globally or in a node pool, or evictQuarantinedNodes must be enabled, otherwise LowNodeLoad never evicts any pod"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
+// isActionable returns whether the quarantined nodes are drained, or any high threshold is set globally or in a node pool.
+// Otherwise no node is considered overutilized or drained and the plugin is a no-op.
+func isActionable(args *deschedulerconfig.LowNodeLoadArgs) bool {
+	if args.EvictQuarantinedNodes {
+		return true
+	}
+	if len(args.HighThresholds) > 0 || len(args.ProdHighThresholds) > 0 || len(args.AbsoluteHighThresholds) > 0 {
+		return true
+	}
+	for _, nodePool := range args.NodePools {
+		if len(nodePool.HighThresholds) > 0 || len(nodePool.ProdHighThresholds) > 0 || len(nodePool.AbsoluteHighThresholds) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCriticalThresholds checks the critical thresholds are valid percentages and not lower than the high thresholds
+// globally and in the node pools, otherwise the per-node eviction limit is relaxed before the node is even overutilized.
+// The deviation thresholds are relative to the mean usage, so they are not comparable with the critical thresholds.
+func validateCriticalThresholds(path *field.Path, args *deschedulerconfig.LowNodeLoadArgs) field.ErrorList {
+	var allErrs field.ErrorList
+	for resourceName, percentage := range args.CriticalThresholds {
+		criticalPath := path.Child("criticalThresholds").Key(string(resourceName))
+		if percentage <= 0 || percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(criticalPath, percentage, "percentage must be greater than 0 and less than or equal to 100"))
+			continue
+		}
+		if highPercentage, ok := args.HighThresholds[resourceName]; ok && !args.UseDeviationThresholds && percentage < highPercentage {
+			allErrs = append(allErrs, field.Invalid(criticalPath, percentage, "critical percentage must be greater than or equal to highThresholds"))
+		}
+		for i, nodePool := range args.NodePools {
+			if highPercentage, ok := nodePool.HighThresholds[resourceName]; ok && !nodePool.UseDeviationThresholds && percentage < highPercentage {
+				allErrs = append(allErrs, field.Invalid(criticalPath, percentage,
+					fmt.Sprintf("critical percentage must be greater than or equal to %s", path.Child("nodePools").Index(i).Child("highThresholds").Key(string(resourceName)))))
+			}
+		}
+	}
+	return allErrs
+}
+
+// emptyAppropriateBandMsg describes the degenerate case that low threshold equals to high threshold,
+// which leaves no appropriately utilized band and makes nodes oscillate between underutilized and overutilized.
+func emptyAppropriateBandMsg(highThresholdsName string) string {
+	return fmt.Sprintf("low percentage must be less than %s, otherwise there is no appropriately utilized band", highThresholdsName)
+}
+
+// validateInheritedThresholds checks the low and high thresholds of the node pool are still consistent after
+// the band omitted by the node pool is inherited from the global thresholds, e.g. a node pool only overriding
+// the highThresholds may end up with a high percentage lower than the inherited low percentage. The v1alpha2
+// conversion merges the global thresholds into the node pools, which are then checked with the node pools.
+// The resources thresholded on both bands by the node pool itself are checked with the node pool, and nothing is
+// inherited across the percentage and deviation thresholds since they are not comparable.
+func validateInheritedThresholds(path *field.Path, args *deschedulerconfig.LowNodeLoadArgs, nodePool *deschedulerconfig.LowNodeLoadNodePool) field.ErrorList {
+	if nodePool.UseDeviationThresholds != args.UseDeviationThresholds {
+		return nil
+	}
+	var allErrs field.ErrorList
+	bands := []struct {
+		lowPath, lowName      string
+		highName              string
+		low, high             deschedulerconfig.ResourceThresholds
+		globalLow, globalHigh deschedulerconfig.ResourceThresholds
+	}{
+		{"lowThresholds", "lowThresholds", "highThresholds", nodePool.LowThresholds, nodePool.HighThresholds, args.LowThresholds, args.HighThresholds},
+		{"ProdLowThresholds", "prodLowThresholds", "prodHighThresholds", nodePool.ProdLowThresholds, nodePool.ProdHighThresholds, args.ProdLowThresholds, args.ProdHighThresholds},
+	}
+	for _, band := range bands {
+		for _, resourceName := range inheritedResourceNames(band.low, band.high) {
+			lowPercentage, lowOK := band.low[resourceName]
+			highPercentage, highOK := band.high[resourceName]
+			inheritedName := band.highName
+			if !lowOK {
+				lowPercentage, lowOK = band.globalLow[resourceName]
+				inheritedName = band.lowName
+			} else {
+				highPercentage, highOK = band.globalHigh[resourceName]
+			}
+			if !lowOK || !highOK {
+				continue
 			}
+			fieldPath := path.Child(band.lowPath).Key(string(resourceName))
+			if lowPercentage > highPercentage {
+				allErrs = append(allErrs, field.Invalid(fieldPath, lowPercentage,
+					fmt.Sprintf("low percentage must be less than or equal to %s after inheriting the global %s", band.highName, inheritedName)))
+			} else if lowPercentage == highPercentage && !nodePool.UseDeviationThresholds {
+				allErrs = append(allErrs, field.Invalid(fieldPath, lowPercentage,
+					fmt.Sprintf("%s after inheriting the global %s", emptyAppropriateBandMsg(band.highName), inheritedName)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// inheritedResourceNames returns the sorted resources thresholded by the node pool on exactly one of the bands.
+func inheritedResourceNames(low, high deschedulerconfig.ResourceThresholds) []corev1.ResourceName {
+	names := sets.NewString()
+	for resourceName := range low {
+		if _, ok := high[resourceName]; !ok {
+			names.Insert(string(resourceName))
+		}
+	}
+	for resourceName := range high {
+		if _, ok := low[resourceName]; !ok {
+			names.Insert(string(resourceName))
+		}
+	}
+	resourceNames := make([]corev1.ResourceName, 0, names.Len())
+	for _, name := range names.List() {
+		resourceNames = append(resourceNames, corev1.ResourceName(name))
+	}
+	return resourceNames
+}
+
+// validateResourceWeightsCoverage checks that the specified weights cover all the resources in highThresholds/lowThresholds,
+// since a missing weight is taken as 0 and the resource is silently ignored when sorting the nodes by usage.
+// Empty weights are defaulted to 1 for each thresholded resource, so they are not checked.
+func validateResourceWeightsCoverage(path *field.Path, nodePool *deschedulerconfig.LowNodeLoadNodePool) field.ErrorList {
+	if len(nodePool.ResourceWeights) == 0 {
+		return nil
+	}
+	var allErrs field.ErrorList
+	missing := sets.NewString()
+	for _, thresholds := range []deschedulerconfig.ResourceThresholds{nodePool.HighThresholds, nodePool.LowThresholds} {
+		for resourceName := range thresholds {
+			if _, ok := nodePool.ResourceWeights[resourceName]; !ok {
+				missing.Insert(string(resourceName))
+			}
+		}
+	}
+	for _, resourceName := range missing.List() {
+		allErrs = append(allErrs, field.NotFound(path.Child("resourceWeights"), resourceName))
+	}
+	return allErrs
+}
+
+// validateEphemeralStorageWeight checks the ephemeral-storage has a positive weight once it is thresholded, including
+// in the absolute thresholds, otherwise it is ignored when sorting the nodes and pods by usage under disk pressure.
+// The missing weight of a percentage threshold is reported by validateResourceWeightsCoverage.
+func validateEphemeralStorageWeight(path *field.Path, nodePool *deschedulerconfig.LowNodeLoadNodePool) field.ErrorList {
+	if len(nodePool.ResourceWeights) == 0 {
+		return nil
+	}
+	_, highOK := nodePool.HighThresholds[corev1.ResourceEphemeralStorage]
+	_, lowOK := nodePool.LowThresholds[corev1.ResourceEphemeralStorage]
+	_, absoluteHighOK := nodePool.AbsoluteHighThresholds[corev1.ResourceEphemeralStorage]
+	_, absoluteLowOK := nodePool.AbsoluteLowThresholds[corev1.ResourceEphemeralStorage]
+	if !highOK && !lowOK && !absoluteHighOK && !absoluteLowOK {
+		return nil
+	}
+	weight, ok := nodePool.ResourceWeights[corev1.ResourceEphemeralStorage]
+	if !ok {
+		if highOK || lowOK {
+			return nil
 		}
+		return field.ErrorList{field.NotFound(path.Child("resourceWeights"), string(corev1.ResourceEphemeralStorage))}
+	}
+	if weight <= 0 {
+		return field.ErrorList{field.Invalid(path.Child("resourceWeights").Key(string(corev1.ResourceEphemeralStorage)), weight,
+			"weight must be greater than 0 when ephemeral-storage is thresholded")}
+	}
+	return nil
+}
 
-		if nodePool.AnomalyCondition.ConsecutiveAbnormalities <= 0 {
-			fieldPath := nodePoolPath.Child("anomalyDetectionThresholds").Child("consecutiveAbnormalities")
-			allErrs = append(allErrs, field.Invalid(fieldPath, nodePool.AnomalyCondition.ConsecutiveAbnormalities, "consecutiveAbnormalities must be greater than 0"))
+func validateAbsoluteThresholds(path *field.Path, nodePool *deschedulerconfig.LowNodeLoadNodePool) field.ErrorList {
+	var allErrs field.ErrorList
+	if nodePool.UseDeviationThresholds && (len(nodePool.AbsoluteHighThresholds) > 0 || len(nodePool.AbsoluteLowThresholds) > 0) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("useDeviationThresholds"), "absolute thresholds can not be used with deviation thresholds"))
+	}
+	isPercentageSet := func(resourceName corev1.ResourceName) bool {
+		_, highOK := nodePool.HighThresholds[resourceName]
+		_, lowOK := nodePool.LowThresholds[resourceName]
+		return highOK || lowOK
+	}
+	for resourceName, quantity := range nodePool.AbsoluteHighThresholds {
+		fieldPath := path.Child("absoluteHighThresholds").Key(string(resourceName))
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(fieldPath, quantity.String(), "quantity must be greater than or equal to 0"))
+		}
+		if isPercentageSet(resourceName) {
+			allErrs = append(allErrs, field.Invalid(fieldPath, quantity.String(), "resource can not be set in both percentage thresholds and absolute thresholds"))
 		}
 	}
+	for resourceName, quantity := range nodePool.AbsoluteLowThresholds {
+		fieldPath := path.Child("absoluteLowThresholds").Key(string(resourceName))
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(fieldPath, quantity.String(), "quantity must be greater than or equal to 0"))
+		}
+		if isPercentageSet(resourceName) {
+			allErrs = append(allErrs, field.Invalid(fieldPath, quantity.String(), "resource can not be set in both percentage thresholds and absolute thresholds"))
+		}
+		if highQuantity, ok := nodePool.AbsoluteHighThresholds[resourceName]; ok && quantity.Cmp(highQuantity) >= 0 {
+			allErrs = append(allErrs, field.Invalid(fieldPath, quantity.String(), "low quantity must be less than absoluteHighThresholds, otherwise there is no appropriately utilized band"))
+		}
+	}
+	return allErrs
+}
 
+func validateLoadAnomalyCondition(path *field.Path, condition *deschedulerconfig.LoadAnomalyCondition) field.ErrorList {
+	var allErrs field.ErrorList
+	if condition == nil {
+		return allErrs
+	}
+	if condition.ConsecutiveAbnormalities < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("consecutiveAbnormalities"), condition.ConsecutiveAbnormalities, "consecutiveAbnormalities must be greater than 0"))
+	}
+	if condition.ConsecutiveNormalities < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("consecutiveNormalities"), condition.ConsecutiveNormalities, "consecutiveNormalities must be greater than 0"))
+	}
+	if condition.Timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeout"), condition.Timeout, "timeout must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+// ValidateLowLoadUtilizationArgsWithInterval validates that the anomaly conditions of LowNodeLoadArgs
+// can be reached when the node load is sampled once per sampleInterval.
+func ValidateLowLoadUtilizationArgsWithInterval(path *field.Path, args *deschedulerconfig.LowNodeLoadArgs, sampleInterval time.Duration) error {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateAnomalyConditionWithInterval(path.Child("anomalyCondition"), args.AnomalyCondition, sampleInterval)...)
+	for i, nodePool := range args.NodePools {
+		fieldPath := path.Child("nodePools").Index(i).Child("anomalyCondition")
+		allErrs = append(allErrs, validateAnomalyConditionWithInterval(fieldPath, nodePool.AnomalyCondition, sampleInterval)...)
+	}
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return allErrs.ToAggregate()
 }
+
+func validateAnomalyConditionWithInterval(path *field.Path, condition *deschedulerconfig.LoadAnomalyCondition, sampleInterval time.Duration) field.ErrorList {
+	if condition == nil || sampleInterval <= 0 || condition.Timeout.Duration <= 0 {
+		return nil
+	}
+	maxSamples := int64(condition.Timeout.Duration / sampleInterval)
+	if int64(condition.ConsecutiveAbnormalities) > maxSamples {
+		msg := fmt.Sprintf("consecutiveAbnormalities can never be reached within timeout %v when sampling every %v, at most %d samples are allowed, "+
+			"please decrease consecutiveAbnormalities or increase timeout", condition.Timeout.Duration, sampleInterval, maxSamples)
+		return field.ErrorList{field.Invalid(path.Child("consecutiveAbnormalities"), condition.ConsecutiveAbnormalities, msg)}
+	}
+	return nil
+}
+
+// hasAnomalyCondition returns whether the args or any of its node pools has the AnomalyCondition.
+func hasAnomalyCondition(args *deschedulerconfig.LowNodeLoadArgs) bool {
+	if args.AnomalyCondition != nil {
+		return true
+	}
+	for _, nodePool := range args.NodePools {
+		if nodePool.AnomalyCondition != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -18,11 +18,30 @@ package validation
 
 import (
 	"testing"
+	"time"
 
-	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/v1alpha2"
 )
 
+// newTestNodePools returns node pools with thresholds to make the LowNodeLoadArgs actionable.
+func newTestNodePools() []deschedulerconfig.LowNodeLoadNodePool {
+	return []deschedulerconfig.LowNodeLoadNodePool{
+		{
+			NodeSelector:   &metav1.LabelSelector{},
+			HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70},
+			LowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 30},
+		},
+	}
+}
+
 func TestValidateLowLoadUtilizationArgs_NumerOfNodes(t *testing.T) {
 	testCases := []struct {
 		numOfNodes    int
@@ -53,6 +72,7 @@ func TestValidateLowLoadUtilizationArgs_NumerOfNodes(t *testing.T) {
 	for _, tc := range testCases {
 		args := &deschedulerconfig.LowNodeLoadArgs{
 			NumberOfNodes: int32(tc.numOfNodes),
+			NodePools:     newTestNodePools(),
 		}
 		err := ValidateLowLoadUtilizationArgs(nil, args)
 		if tc.expectedError {
@@ -65,6 +85,231 @@ func TestValidateLowLoadUtilizationArgs_NumerOfNodes(t *testing.T) {
 	}
 }
 
+func TestValidateLowLoadUtilizationArgs_MinNodesInScope(t *testing.T) {
+	testCases := []struct {
+		minNodesInScope int32
+		expectedError   bool
+	}{
+		{
+			minNodesInScope: 0,
+			expectedError:   false,
+		},
+		{
+			minNodesInScope: 2,
+			expectedError:   false,
+		},
+		{
+			minNodesInScope: -1,
+			expectedError:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		args := &deschedulerconfig.LowNodeLoadArgs{
+			MinNodesInScope: tc.minNodesInScope,
+			NodePools:       newTestNodePools(),
+		}
+		err := ValidateLowLoadUtilizationArgs(nil, args)
+		if tc.expectedError {
+			assert.Error(t, err, "Expected an error for invalid MinNodesInScope")
+			assert.Contains(t, err.Error(), "minNodesInScope")
+		} else {
+			assert.Nil(t, err, "Expected no error for valid configuration")
+		}
+	}
+}
+
+func TestGetLowLoadUtilizationArgsWarnings_NodeMetricExpirationSeconds(t *testing.T) {
+	testCases := []struct {
+		name            string
+		expiration      *int64
+		expectedWarning bool
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:            "too short",
+			expiration:      pointer.Int64(10),
+			expectedWarning: true,
+		},
+		{
+			name:       "equal to the floor",
+			expiration: pointer.Int64(MinNodeMetricExpirationSeconds),
+		},
+		{
+			name:       "reasonable",
+			expiration: pointer.Int64(180),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodeMetricExpirationSeconds: tc.expiration,
+			}
+			assert.NoError(t, ValidateLowLoadUtilizationArgs(nil, args))
+			warnings := GetLowLoadUtilizationArgsWarnings(field.NewPath("args"), args)
+			if tc.expectedWarning {
+				assert.Len(t, warnings, 1)
+				assert.Contains(t, warnings[0], "args.nodeMetricExpirationSeconds")
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_NodeMetricExpirationByResource(t *testing.T) {
+	testCases := []struct {
+		name            string
+		expiration      map[corev1.ResourceName]int64
+		expectedError   bool
+		expectedWarning bool
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "valid overrides",
+			expiration: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    180,
+				corev1.ResourceMemory: 600,
+			},
+		},
+		{
+			name: "zero",
+			expiration: map[corev1.ResourceName]int64{
+				corev1.ResourceMemory: 0,
+			},
+			expectedError: true,
+		},
+		{
+			name: "negative",
+			expiration: map[corev1.ResourceName]int64{
+				corev1.ResourceMemory: -1,
+			},
+			expectedError: true,
+		},
+		{
+			name: "too short",
+			expiration: map[corev1.ResourceName]int64{
+				corev1.ResourceMemory: 10,
+			},
+			expectedWarning: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodeMetricExpirationByResource: tc.expiration,
+				NodePools:                      newTestNodePools(),
+			}
+			err := ValidateLowLoadUtilizationArgs(field.NewPath("args"), args)
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "args.nodeMetricExpirationByResource[memory]")
+				return
+			}
+			assert.NoError(t, err)
+			warnings := GetLowLoadUtilizationArgsWarnings(field.NewPath("args"), args)
+			if tc.expectedWarning {
+				assert.Len(t, warnings, 1)
+				assert.Contains(t, warnings[0], "args.nodeMetricExpirationByResource[memory]")
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_OwnerKindEvictionPriority(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ownerKinds    []string
+		expectedError string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:       "known kinds",
+			ownerKinds: []string{"Job", "CronJob", "Deployment", "StatefulSet"},
+		},
+		{
+			name:          "unknown kind",
+			ownerKinds:    []string{"Job", "Foo"},
+			expectedError: "ownerKindEvictionPriority[1]: Unsupported value",
+		},
+		{
+			name:          "duplicated kind",
+			ownerKinds:    []string{"Job", "Job"},
+			expectedError: "ownerKindEvictionPriority[1]: Duplicate value",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				OwnerKindEvictionPriority: tc.ownerKinds,
+				NodePools:                 newTestNodePools(),
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_CriticalThresholds(t *testing.T) {
+	testCases := []struct {
+		name               string
+		criticalThresholds deschedulerconfig.ResourceThresholds
+		highThresholds     deschedulerconfig.ResourceThresholds
+		expectedError      string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:               "valid critical thresholds",
+			criticalThresholds: deschedulerconfig.ResourceThresholds{"cpu": 90, corev1.ResourceEphemeralStorage: 95},
+		},
+		{
+			name:               "out of range",
+			criticalThresholds: deschedulerconfig.ResourceThresholds{"cpu": 101},
+			expectedError:      "criticalThresholds[cpu]: Invalid value",
+		},
+		{
+			name:               "below highThresholds",
+			criticalThresholds: deschedulerconfig.ResourceThresholds{"memory": 60},
+			highThresholds:     deschedulerconfig.ResourceThresholds{"memory": 80},
+			expectedError:      "critical percentage must be greater than or equal to highThresholds",
+		},
+		{
+			name:               "below highThresholds of node pool",
+			criticalThresholds: deschedulerconfig.ResourceThresholds{"cpu": 60},
+			expectedError:      "critical percentage must be greater than or equal to nodePools[0].highThresholds[cpu]",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				CriticalThresholds: tc.criticalThresholds,
+				HighThresholds:     tc.highThresholds,
+				NodePools:          newTestNodePools(),
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
 func TestValidateLowLoadUtilizationArgs_EvictableNamespaces(t *testing.T) {
 	testCases := []struct {
 		include       []string
@@ -106,6 +351,7 @@ func TestValidateLowLoadUtilizationArgs_EvictableNamespaces(t *testing.T) {
 				Include: tc.include,
 				Exclude: tc.exclude,
 			},
+			NodePools: newTestNodePools(),
 		}
 		err := ValidateLowLoadUtilizationArgs(nil, args)
 		if tc.expectedError {
@@ -117,6 +363,279 @@ func TestValidateLowLoadUtilizationArgs_EvictableNamespaces(t *testing.T) {
 	}
 }
 
+func TestValidateLowLoadUtilizationArgs_PodSelectors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		podSelectors  []deschedulerconfig.LowNodeLoadPodSelector
+		expectedError string
+	}{
+		{
+			name: "valid selectors",
+			podSelectors: []deschedulerconfig.LowNodeLoadPodSelector{
+				{
+					Name:     "match-labels",
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				},
+				{
+					Name: "match-expressions",
+					Selector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"test"}},
+						},
+					},
+				},
+				{
+					Name: "nil selector",
+				},
+			},
+		},
+		{
+			name: "unknown operator",
+			podSelectors: []deschedulerconfig.LowNodeLoadPodSelector{
+				{
+					Name:     "valid",
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				},
+				{
+					Name: "invalid",
+					Selector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "app", Operator: "Foo", Values: []string{"test"}},
+						},
+					},
+				},
+			},
+			expectedError: "podSelectors[1].selector: Invalid value",
+		},
+		{
+			name: "invalid label value",
+			podSelectors: []deschedulerconfig.LowNodeLoadPodSelector{
+				{
+					Name:     "invalid",
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "not a valid value"}},
+				},
+			},
+			expectedError: "podSelectors[0].selector: Invalid value",
+		},
+		{
+			name: "values with exists operator",
+			podSelectors: []deschedulerconfig.LowNodeLoadPodSelector{
+				{
+					Name: "invalid",
+					Selector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "app", Operator: metav1.LabelSelectorOpExists, Values: []string{"test"}},
+						},
+					},
+				},
+			},
+			expectedError: "podSelectors[0].selector: Invalid value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				PodSelectors: tc.podSelectors,
+				NodePools:    newTestNodePools(),
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_PodSelectorMatchMode(t *testing.T) {
+	testCases := []struct {
+		matchMode     deschedulerconfig.PodSelectorMatchMode
+		expectedError bool
+	}{
+		{
+			matchMode:     "",
+			expectedError: false,
+		},
+		{
+			matchMode:     deschedulerconfig.PodSelectorMatchModeAny,
+			expectedError: false,
+		},
+		{
+			matchMode:     deschedulerconfig.PodSelectorMatchModeAll,
+			expectedError: false,
+		},
+		{
+			matchMode:     "None",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		args := &deschedulerconfig.LowNodeLoadArgs{
+			PodSelectorMatchMode: tc.matchMode,
+			NodePools:            newTestNodePools(),
+		}
+		err := ValidateLowLoadUtilizationArgs(nil, args)
+		if tc.expectedError {
+			assert.Error(t, err, "Expected an error for invalid PodSelectorMatchMode")
+			assert.Contains(t, err.Error(), "podSelectorMatchMode", "Expected specific error message")
+		} else {
+			assert.Nil(t, err, "Expected no error for valid configuration")
+		}
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_NodeProcessingOrder(t *testing.T) {
+	testCases := []struct {
+		order         deschedulerconfig.NodeProcessingOrder
+		expectedError bool
+	}{
+		{
+			order:         "",
+			expectedError: false,
+		},
+		{
+			order:         deschedulerconfig.NodeProcessingOrderMostOverutilizedFirst,
+			expectedError: false,
+		},
+		{
+			order:         deschedulerconfig.NodeProcessingOrderLeastFirst,
+			expectedError: false,
+		},
+		{
+			order:         deschedulerconfig.NodeProcessingOrderByName,
+			expectedError: false,
+		},
+		{
+			order:         "Random",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		args := &deschedulerconfig.LowNodeLoadArgs{
+			NodeProcessingOrder: tc.order,
+			NodePools:           newTestNodePools(),
+		}
+		err := ValidateLowLoadUtilizationArgs(nil, args)
+		if tc.expectedError {
+			assert.Error(t, err, "Expected an error for invalid NodeProcessingOrder")
+			assert.Contains(t, err.Error(), "nodeProcessingOrder", "Expected specific error message")
+		} else {
+			assert.Nil(t, err, "Expected no error for valid configuration")
+		}
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_ThrottledNodeConditionType(t *testing.T) {
+	testCases := []struct {
+		name          string
+		conditionType corev1.NodeConditionType
+		expectedError string
+	}{
+		{
+			name:          "not set",
+			conditionType: "",
+		},
+		{
+			name:          "custom condition type",
+			conditionType: "ThermalThrottle",
+		},
+		{
+			name:          "prefixed custom condition type",
+			conditionType: "example.com/PowerThrottle",
+		},
+		{
+			name:          "invalid condition type",
+			conditionType: "Thermal Throttle",
+			expectedError: "args.throttledNodeConditionType: Invalid value: \"Thermal Throttle\"",
+		},
+		{
+			name:          "built-in condition type",
+			conditionType: corev1.NodeMemoryPressure,
+			expectedError: "args.throttledNodeConditionType: Invalid value: \"MemoryPressure\": must be a custom node condition type, not a built-in one",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				ThrottledNodeConditionType: tc.conditionType,
+				NodePools:                  newTestNodePools(),
+			}
+			err := ValidateLowLoadUtilizationArgs(field.NewPath("args"), args)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_EvictionAggressiveness(t *testing.T) {
+	testCases := []struct {
+		name           string
+		aggressiveness *deschedulerconfig.Float64OrString
+		expectedError  bool
+	}{
+		{
+			name:           "not set",
+			aggressiveness: nil,
+			expectedError:  false,
+		},
+		{
+			name:           "float in range",
+			aggressiveness: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.Float, FloatVal: 0.5},
+			expectedError:  false,
+		},
+		{
+			name:           "string in range",
+			aggressiveness: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.String, StrVal: "0.25"},
+			expectedError:  false,
+		},
+		{
+			name:           "upper bound",
+			aggressiveness: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.Float, FloatVal: 1},
+			expectedError:  false,
+		},
+		{
+			name:           "zero",
+			aggressiveness: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.Float, FloatVal: 0},
+			expectedError:  true,
+		},
+		{
+			name:           "greater than 1",
+			aggressiveness: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.Float, FloatVal: 1.5},
+			expectedError:  true,
+		},
+		{
+			name:           "invalid string",
+			aggressiveness: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.String, StrVal: "half"},
+			expectedError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				EvictionAggressiveness: tc.aggressiveness,
+				NodePools:              newTestNodePools(),
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "evictionAggressiveness")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateLowLoadUtilizationArgs_NodePoolThresholds(t *testing.T) {
 	testCases := []struct {
 		highThresholds   int
@@ -132,7 +651,7 @@ func TestValidateLowLoadUtilizationArgs_NodePoolThresholds(t *testing.T) {
 		{
 			highThresholds: 0,
 			lowThresholds:  0,
-			expectedError:  false,
+			expectedError:  true,
 		},
 		{
 			highThresholds: 0,
@@ -173,12 +692,14 @@ func TestValidateLowLoadUtilizationArgs_NodePoolThresholds(t *testing.T) {
 		{
 			highThresholds: 120, // we do not check threshold larger than 100
 			lowThresholds:  120,
-			expectedError:  false,
+			expectedError:  true,
 		},
 	}
 
 	for _, tc := range testCases {
-		anomalyCondition := &deschedulerconfig.LoadAnomalyCondition{}
+		anomalyCondition := &deschedulerconfig.LoadAnomalyCondition{
+			ConsecutiveNormalities: 3,
+		}
 		if tc.anomalyCondition != nil {
 			anomalyCondition.ConsecutiveAbnormalities = tc.anomalyCondition.ConsecutiveAbnormalities
 		} else {
@@ -187,6 +708,7 @@ func TestValidateLowLoadUtilizationArgs_NodePoolThresholds(t *testing.T) {
 		args := &deschedulerconfig.LowNodeLoadArgs{
 			NodePools: []deschedulerconfig.LowNodeLoadNodePool{
 				{
+					NodeSelector:     &metav1.LabelSelector{},
 					HighThresholds:   deschedulerconfig.ResourceThresholds{"cpu": deschedulerconfig.Percentage(tc.highThresholds)},
 					LowThresholds:    deschedulerconfig.ResourceThresholds{"cpu": deschedulerconfig.Percentage(tc.lowThresholds)},
 					AnomalyCondition: anomalyCondition,
@@ -201,3 +723,804 @@ func TestValidateLowLoadUtilizationArgs_NodePoolThresholds(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateLowLoadUtilizationArgs_EqualThresholds(t *testing.T) {
+	testCases := []struct {
+		name          string
+		nodePool      deschedulerconfig.LowNodeLoadNodePool
+		expectedError string
+	}{
+		{
+			name: "low less than high",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:   &metav1.LabelSelector{},
+				HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70, "memory": 80},
+				LowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 30, "memory": 40},
+			},
+		},
+		{
+			name: "low equals high",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:   &metav1.LabelSelector{},
+				HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70, "memory": 80},
+				LowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 30, "memory": 80},
+			},
+			expectedError: `nodePools[0].lowThresholds[memory]: Invalid value: 80: low percentage must be less than highThresholds, otherwise there is no appropriately utilized band`,
+		},
+		{
+			name: "prod low equals prod high",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:       &metav1.LabelSelector{},
+				HighThresholds:     deschedulerconfig.ResourceThresholds{"cpu": 70},
+				LowThresholds:      deschedulerconfig.ResourceThresholds{"cpu": 30},
+				ProdHighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 60},
+				ProdLowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 60},
+			},
+			expectedError: `nodePools[0].ProdLowThresholds[cpu]: Invalid value: 60: low percentage must be less than prodHighThresholds, otherwise there is no appropriately utilized band`,
+		},
+		{
+			name: "equal deviation thresholds still have an appropriate band",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:           &metav1.LabelSelector{},
+				UseDeviationThresholds: true,
+				HighThresholds:         deschedulerconfig.ResourceThresholds{"cpu": 10},
+				LowThresholds:          deschedulerconfig.ResourceThresholds{"cpu": 10},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{tc.nodePool},
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_EphemeralStorageWeight(t *testing.T) {
+	testCases := []struct {
+		name          string
+		nodePool      deschedulerconfig.LowNodeLoadNodePool
+		expectedError string
+	}{
+		{
+			name: "ephemeral-storage thresholds with weight",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:    &metav1.LabelSelector{},
+				HighThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 70, "ephemeral-storage": 80},
+				LowThresholds:   deschedulerconfig.ResourceThresholds{"cpu": 30, "ephemeral-storage": 40},
+				ResourceWeights: map[corev1.ResourceName]int64{"cpu": 1, "ephemeral-storage": 2},
+			},
+		},
+		{
+			name: "empty weights are defaulted",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:   &metav1.LabelSelector{},
+				HighThresholds: deschedulerconfig.ResourceThresholds{"ephemeral-storage": 80},
+			},
+		},
+		{
+			name: "zero weight of ephemeral-storage",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:    &metav1.LabelSelector{},
+				HighThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 70, "ephemeral-storage": 80},
+				ResourceWeights: map[corev1.ResourceName]int64{"cpu": 1, "ephemeral-storage": 0},
+			},
+			expectedError: `nodePools[0].resourceWeights[ephemeral-storage]: Invalid value: 0: weight must be greater than 0 when ephemeral-storage is thresholded`,
+		},
+		{
+			name: "weight of absolute ephemeral-storage threshold missing",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:           &metav1.LabelSelector{},
+				HighThresholds:         deschedulerconfig.ResourceThresholds{"cpu": 70},
+				AbsoluteHighThresholds: map[corev1.ResourceName]resource.Quantity{"ephemeral-storage": resource.MustParse("100Gi")},
+				ResourceWeights:        map[corev1.ResourceName]int64{"cpu": 1},
+			},
+			expectedError: `nodePools[0].resourceWeights: Not found: "ephemeral-storage"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{tc.nodePool},
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_InheritedThresholds(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          *deschedulerconfig.LowNodeLoadArgs
+		expectedError string
+	}{
+		{
+			name: "overridden high threshold above inherited low threshold",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70},
+				LowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 30},
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector:   &metav1.LabelSelector{},
+						HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 50},
+					},
+				},
+			},
+		},
+		{
+			name: "overridden high threshold below inherited low threshold",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70},
+				LowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 30},
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector:   &metav1.LabelSelector{},
+						HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 50},
+						LowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 20},
+					},
+					{
+						NodeSelector:   &metav1.LabelSelector{},
+						HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 20},
+					},
+				},
+			},
+			expectedError: `nodePools[1].lowThresholds[cpu]: Invalid value: 30: low percentage must be less than or equal to highThresholds after inheriting the global lowThresholds`,
+		},
+		{
+			name: "overridden low threshold above inherited high threshold",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70},
+				LowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 30},
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector:   &metav1.LabelSelector{},
+						HighThresholds: deschedulerconfig.ResourceThresholds{"memory": 80},
+						LowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 80},
+					},
+				},
+			},
+			expectedError: `nodePools[0].lowThresholds[cpu]: Invalid value: 80: low percentage must be less than or equal to highThresholds after inheriting the global highThresholds`,
+		},
+		{
+			name: "overridden low threshold equals inherited high threshold",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70},
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector:  &metav1.LabelSelector{},
+						LowThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70},
+					},
+				},
+			},
+			expectedError: `nodePools[0].lowThresholds[cpu]: Invalid value: 70: low percentage must be less than highThresholds, otherwise there is no appropriately utilized band after inheriting the global highThresholds`,
+		},
+		{
+			name: "overridden prod high threshold below inherited prod low threshold",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				ProdHighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 60},
+				ProdLowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 40},
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector:       &metav1.LabelSelector{},
+						HighThresholds:     deschedulerconfig.ResourceThresholds{"cpu": 70},
+						LowThresholds:      deschedulerconfig.ResourceThresholds{"cpu": 30},
+						ProdHighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 35},
+					},
+				},
+			},
+			expectedError: `nodePools[0].ProdLowThresholds[cpu]: Invalid value: 40: low percentage must be less than or equal to prodHighThresholds after inheriting the global prodLowThresholds`,
+		},
+		{
+			name: "deviation thresholds are not inherited from percentage thresholds",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70},
+				LowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 30},
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector:           &metav1.LabelSelector{},
+						UseDeviationThresholds: true,
+						HighThresholds:         deschedulerconfig.ResourceThresholds{"cpu": 10},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateLowLoadUtilizationArgs(nil, tc.args)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_ResourceWeightsCoverage(t *testing.T) {
+	testCases := []struct {
+		name          string
+		nodePool      deschedulerconfig.LowNodeLoadNodePool
+		expectedError string
+	}{
+		{
+			name: "weights cover all thresholded resources",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:    &metav1.LabelSelector{},
+				HighThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 70, "memory": 80},
+				LowThresholds:   deschedulerconfig.ResourceThresholds{"cpu": 30},
+				ResourceWeights: map[corev1.ResourceName]int64{"cpu": 2, "memory": 1},
+			},
+		},
+		{
+			name: "empty weights are not checked",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:   &metav1.LabelSelector{},
+				HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70, "memory": 80},
+			},
+		},
+		{
+			name: "weight of high threshold missing",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:    &metav1.LabelSelector{},
+				HighThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 70, "memory": 80},
+				ResourceWeights: map[corev1.ResourceName]int64{"cpu": 1},
+			},
+			expectedError: `nodePools[0].resourceWeights: Not found: "memory"`,
+		},
+		{
+			name: "weight of low threshold missing",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:    &metav1.LabelSelector{},
+				HighThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 70},
+				LowThresholds:   deschedulerconfig.ResourceThresholds{"cpu": 30, "pods": 30},
+				ResourceWeights: map[corev1.ResourceName]int64{"cpu": 1},
+			},
+			expectedError: `nodePools[0].resourceWeights: Not found: "pods"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{tc.nodePool},
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_AbsoluteThresholds(t *testing.T) {
+	testCases := []struct {
+		name          string
+		nodePool      deschedulerconfig.LowNodeLoadNodePool
+		expectedError string
+	}{
+		{
+			name: "percentage cpu and absolute memory",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:           &metav1.LabelSelector{},
+				HighThresholds:         deschedulerconfig.ResourceThresholds{"cpu": 70},
+				LowThresholds:          deschedulerconfig.ResourceThresholds{"cpu": 30},
+				AbsoluteHighThresholds: map[corev1.ResourceName]resource.Quantity{"memory": resource.MustParse("64Gi")},
+				AbsoluteLowThresholds:  map[corev1.ResourceName]resource.Quantity{"memory": resource.MustParse("16Gi")},
+			},
+		},
+		{
+			name: "resource set in both percentage and absolute thresholds",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:           &metav1.LabelSelector{},
+				HighThresholds:         deschedulerconfig.ResourceThresholds{"cpu": 70},
+				AbsoluteHighThresholds: map[corev1.ResourceName]resource.Quantity{"cpu": resource.MustParse("32")},
+			},
+			expectedError: `nodePools[0].absoluteHighThresholds[cpu]: Invalid value: "32": resource can not be set in both percentage thresholds and absolute thresholds`,
+		},
+		{
+			name: "negative absolute threshold",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:          &metav1.LabelSelector{},
+				HighThresholds:        deschedulerconfig.ResourceThresholds{"cpu": 70},
+				AbsoluteLowThresholds: map[corev1.ResourceName]resource.Quantity{"memory": resource.MustParse("-1Gi")},
+			},
+			expectedError: `nodePools[0].absoluteLowThresholds[memory]: Invalid value: "-1Gi": quantity must be greater than or equal to 0`,
+		},
+		{
+			name: "absolute low equals absolute high",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:           &metav1.LabelSelector{},
+				AbsoluteHighThresholds: map[corev1.ResourceName]resource.Quantity{"memory": resource.MustParse("16Gi")},
+				AbsoluteLowThresholds:  map[corev1.ResourceName]resource.Quantity{"memory": resource.MustParse("16Gi")},
+			},
+			expectedError: `nodePools[0].absoluteLowThresholds[memory]: Invalid value: "16Gi": low quantity must be less than absoluteHighThresholds, otherwise there is no appropriately utilized band`,
+		},
+		{
+			name: "negative min absolute usage",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:     &metav1.LabelSelector{},
+				HighThresholds:   deschedulerconfig.ResourceThresholds{"cpu": 70},
+				MinAbsoluteUsage: map[corev1.ResourceName]resource.Quantity{"cpu": resource.MustParse("-1")},
+			},
+			expectedError: `nodePools[0].minAbsoluteUsage[cpu]: Invalid value: "-1": quantity must be greater than or equal to 0`,
+		},
+		{
+			name: "absolute thresholds with deviation thresholds",
+			nodePool: deschedulerconfig.LowNodeLoadNodePool{
+				NodeSelector:           &metav1.LabelSelector{},
+				UseDeviationThresholds: true,
+				AbsoluteHighThresholds: map[corev1.ResourceName]resource.Quantity{"memory": resource.MustParse("64Gi")},
+			},
+			expectedError: `nodePools[0].useDeviationThresholds: Forbidden: absolute thresholds can not be used with deviation thresholds`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{tc.nodePool},
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_AnomalyCondition(t *testing.T) {
+	testCases := []struct {
+		name             string
+		anomalyCondition *deschedulerconfig.LoadAnomalyCondition
+		expectedError    string
+	}{
+		{
+			name:             "nil anomalyCondition",
+			anomalyCondition: nil,
+		},
+		{
+			name: "valid anomalyCondition",
+			anomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+				Timeout:                  metav1.Duration{Duration: time.Minute},
+				ConsecutiveAbnormalities: 5,
+				ConsecutiveNormalities:   3,
+			},
+		},
+		{
+			name: "zero consecutiveAbnormalities",
+			anomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+				ConsecutiveAbnormalities: 0,
+				ConsecutiveNormalities:   3,
+			},
+			expectedError: "consecutiveAbnormalities must be greater than 0",
+		},
+		{
+			name: "zero consecutiveNormalities",
+			anomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+				ConsecutiveAbnormalities: 5,
+				ConsecutiveNormalities:   0,
+			},
+			expectedError: "consecutiveNormalities must be greater than 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector:     &metav1.LabelSelector{},
+						HighThresholds:   deschedulerconfig.ResourceThresholds{"cpu": 70},
+						AnomalyCondition: tc.anomalyCondition,
+					},
+				},
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_DetectorCacheTimeout(t *testing.T) {
+	anomalyCondition := &deschedulerconfig.LoadAnomalyCondition{
+		ConsecutiveAbnormalities: 5,
+		ConsecutiveNormalities:   3,
+	}
+	testCases := []struct {
+		name                 string
+		detectorCacheTimeout *metav1.Duration
+		anomalyCondition     *deschedulerconfig.LoadAnomalyCondition
+		expectedError        string
+	}{
+		{
+			name:                 "nil detectorCacheTimeout",
+			detectorCacheTimeout: nil,
+			anomalyCondition:     anomalyCondition,
+		},
+		{
+			name:                 "positive detectorCacheTimeout",
+			detectorCacheTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			anomalyCondition:     anomalyCondition,
+		},
+		{
+			name:                 "zero detectorCacheTimeout",
+			detectorCacheTimeout: &metav1.Duration{},
+			anomalyCondition:     anomalyCondition,
+			expectedError:        "detectorCacheTimeout: Invalid value: \"0s\": must be greater than 0 when anomalyCondition is set",
+		},
+		{
+			name:                 "negative detectorCacheTimeout",
+			detectorCacheTimeout: &metav1.Duration{Duration: -time.Minute},
+			anomalyCondition:     anomalyCondition,
+			expectedError:        "detectorCacheTimeout: Invalid value: \"-1m0s\": must be greater than 0 when anomalyCondition is set",
+		},
+		{
+			name:                 "zero detectorCacheTimeout without anomalyCondition",
+			detectorCacheTimeout: &metav1.Duration{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nodePools := newTestNodePools()
+			nodePools[0].AnomalyCondition = tc.anomalyCondition
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodePools:            nodePools,
+				DetectorCacheTimeout: tc.detectorCacheTimeout,
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_ConsolidationTargetUtilization(t *testing.T) {
+	testCases := []struct {
+		name                           string
+		consolidationTargetUtilization deschedulerconfig.ResourceThresholds
+		expectedError                  string
+	}{
+		{
+			name: "empty consolidationTargetUtilization",
+		},
+		{
+			name: "valid consolidationTargetUtilization",
+			consolidationTargetUtilization: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceCPU:    80,
+				corev1.ResourceMemory: 100,
+			},
+		},
+		{
+			name: "zero consolidationTargetUtilization",
+			consolidationTargetUtilization: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceCPU: 0,
+			},
+			expectedError: "consolidationTargetUtilization[cpu]: Invalid value",
+		},
+		{
+			name: "negative consolidationTargetUtilization",
+			consolidationTargetUtilization: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceMemory: -10,
+			},
+			expectedError: "consolidationTargetUtilization[memory]: Invalid value",
+		},
+		{
+			name: "consolidationTargetUtilization exceeds 100",
+			consolidationTargetUtilization: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceCPU: 120,
+			},
+			expectedError: "consolidationTargetUtilization[cpu]: Invalid value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodePools:                      newTestNodePools(),
+				ConsolidationTargetUtilization: tc.consolidationTargetUtilization,
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				assert.Contains(t, err.Error(), "percentage must be greater than 0 and less than or equal to 100")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_EmitDestinationHint(t *testing.T) {
+	testCases := []struct {
+		name                string
+		nodeFit             bool
+		emitDestinationHint bool
+		expectedError       string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:                "enabled with nodeFit",
+			nodeFit:             true,
+			emitDestinationHint: true,
+		},
+		{
+			name:                "enabled without nodeFit",
+			emitDestinationHint: true,
+			expectedError:       "emitDestinationHint: Invalid value: true: requires nodeFit to be enabled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodePools:           newTestNodePools(),
+				NodeFit:             tc.nodeFit,
+				EmitDestinationHint: tc.emitDestinationHint,
+			}
+			err := ValidateLowLoadUtilizationArgs(nil, args)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_NodePools(t *testing.T) {
+	newNodePool := func(name string, nodeSelector *metav1.LabelSelector) deschedulerconfig.LowNodeLoadNodePool {
+		return deschedulerconfig.LowNodeLoadNodePool{
+			Name:           name,
+			NodeSelector:   nodeSelector,
+			HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70},
+			LowThresholds:  deschedulerconfig.ResourceThresholds{"cpu": 30},
+		}
+	}
+	testCases := []struct {
+		name          string
+		nodePools     []deschedulerconfig.LowNodeLoadNodePool
+		expectedError string
+	}{
+		{
+			name: "unique names",
+			nodePools: []deschedulerconfig.LowNodeLoadNodePool{
+				newNodePool("pool-1", &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "1"}}),
+				newNodePool("pool-2", &metav1.LabelSelector{}),
+			},
+		},
+		{
+			name: "duplicate names",
+			nodePools: []deschedulerconfig.LowNodeLoadNodePool{
+				newNodePool("pool-1", &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "1"}}),
+				newNodePool("pool-2", &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "2"}}),
+				newNodePool("pool-1", &metav1.LabelSelector{}),
+			},
+			expectedError: `args.nodePools[2].name: Duplicate value: "pool-1"`,
+		},
+		{
+			name: "nil node selector",
+			nodePools: []deschedulerconfig.LowNodeLoadNodePool{
+				newNodePool("pool-1", &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "1"}}),
+				newNodePool("pool-2", nil),
+			},
+			expectedError: "args.nodePools[1].nodeSelector: Required value: nodeSelector must be set, use an empty selector to select all nodes explicitly",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: tc.nodePools,
+			}
+			err := ValidateLowLoadUtilizationArgs(field.NewPath("args"), args)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgs_ConvertedInheritedThresholds(t *testing.T) {
+	in := &v1alpha2.LowNodeLoadArgs{
+		HighThresholds: v1alpha2.ResourceThresholds{"cpu": 70},
+		LowThresholds:  v1alpha2.ResourceThresholds{"cpu": 30},
+		NodePools: []v1alpha2.LowNodeLoadNodePool{
+			{
+				Name:           "pool-1",
+				NodeSelector:   &metav1.LabelSelector{},
+				HighThresholds: v1alpha2.ResourceThresholds{"cpu": 20},
+			},
+		},
+	}
+	v1alpha2.SetDefaults_LowNodeLoadArgs(in)
+	args := &deschedulerconfig.LowNodeLoadArgs{}
+	assert.NoError(t, v1alpha2.Convert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in, args, nil))
+	// the node pool follows the default node pool holding the global thresholds
+	err := ValidateLowLoadUtilizationArgs(nil, args)
+	assert.ErrorContains(t, err, "nodePools[1].lowThresholds[cpu]: Invalid value: 30: low percentage must be less than or equal to highThresholds")
+}
+
+func TestValidateLowLoadUtilizationArgs_ActionableThresholds(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          *deschedulerconfig.LowNodeLoadArgs
+		expectedError bool
+	}{
+		{
+			name:          "fully empty",
+			args:          &deschedulerconfig.LowNodeLoadArgs{},
+			expectedError: true,
+		},
+		{
+			name: "node pools without high thresholds",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector:  &metav1.LabelSelector{},
+						Name:          "pool-1",
+						LowThresholds: deschedulerconfig.ResourceThresholds{"cpu": 30},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "empty global with pool high thresholds",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector: &metav1.LabelSelector{},
+						Name:         "__default_node_pool__",
+					},
+					{
+						Name:           "pool-1",
+						NodeSelector:   &metav1.LabelSelector{},
+						HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70},
+					},
+				},
+			},
+		},
+		{
+			name: "empty global with pool prod high thresholds",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector:       &metav1.LabelSelector{},
+						Name:               "pool-1",
+						ProdHighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 60},
+					},
+				},
+			},
+		},
+		{
+			name: "empty global with pool absolute high thresholds",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector:           &metav1.LabelSelector{},
+						Name:                   "pool-1",
+						AbsoluteHighThresholds: map[corev1.ResourceName]resource.Quantity{"memory": resource.MustParse("64Gi")},
+					},
+				},
+			},
+		},
+		{
+			name: "global high thresholds",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				HighThresholds: deschedulerconfig.ResourceThresholds{"cpu": 70},
+			},
+		},
+		{
+			name: "evict quarantined nodes without high thresholds",
+			args: &deschedulerconfig.LowNodeLoadArgs{
+				EvictQuarantinedNodes: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateLowLoadUtilizationArgs(field.NewPath("args"), tc.args)
+			if tc.expectedError {
+				assert.ErrorContains(t, err, "args.highThresholds: Required value: at least one of highThresholds/prodHighThresholds/absoluteHighThresholds must be set")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLowLoadUtilizationArgsWithInterval(t *testing.T) {
+	testCases := []struct {
+		name          string
+		interval      time.Duration
+		timeout       time.Duration
+		abnormalities uint32
+		expectedError bool
+	}{
+		{
+			name:          "reachable within timeout",
+			interval:      10 * time.Second,
+			timeout:       time.Minute,
+			abnormalities: 5,
+		},
+		{
+			name:          "exactly reachable within timeout",
+			interval:      10 * time.Second,
+			timeout:       time.Minute,
+			abnormalities: 6,
+		},
+		{
+			name:          "unreachable within timeout",
+			interval:      time.Minute,
+			timeout:       time.Minute,
+			abnormalities: 5,
+			expectedError: true,
+		},
+		{
+			name:          "descheduling only once",
+			interval:      0,
+			timeout:       time.Minute,
+			abnormalities: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.LowNodeLoadArgs{
+				NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+					{
+						NodeSelector: &metav1.LabelSelector{},
+						AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+							Timeout:                  metav1.Duration{Duration: tc.timeout},
+							ConsecutiveAbnormalities: tc.abnormalities,
+							ConsecutiveNormalities:   3,
+						},
+					},
+				},
+			}
+			err := ValidateLowLoadUtilizationArgsWithInterval(field.NewPath("args"), args, tc.interval)
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "args.nodePools[0].anomalyCondition.consecutiveAbnormalities")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func ValidateRemovePodsWithMissingReferencesArgs(path *field.Path, args *deschedulerconfig.RemovePodsWithMissingReferencesArgs) error {
+	var allErrs field.ErrorList
+
+	if args.MinFailingDuration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minFailingDuration"), args.MinFailingDuration, "minFailingDuration must be greater than 0"))
+	}
+
+	allErrs = append(allErrs, validateNamespaces(path.Child("namespaces"), args.Namespaces)...)
+
+	return allErrs.ToAggregate()
+}
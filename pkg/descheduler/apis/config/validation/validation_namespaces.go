@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+// validateNamespaces validates the included/excluded namespaces shared by the plugin args.
+// At most one of Include/Exclude can be set, and a namespace in both lists is reported by name
+// since the intent is ambiguous.
+func validateNamespaces(path *field.Path, namespaces *deschedulerconfig.Namespaces) field.ErrorList {
+	if namespaces == nil || len(namespaces.Include) == 0 || len(namespaces.Exclude) == 0 {
+		return nil
+	}
+	var allErrs field.ErrorList
+	excluded := sets.NewString(namespaces.Exclude...)
+	reported := sets.NewString()
+	for i, namespace := range namespaces.Include {
+		if excluded.Has(namespace) && !reported.Has(namespace) {
+			reported.Insert(namespace)
+			allErrs = append(allErrs, field.Invalid(path.Child("include").Index(i), namespace,
+				fmt.Sprintf("namespace %q is both included and excluded", namespace)))
+		}
+	}
+	if len(allErrs) == 0 {
+		allErrs = append(allErrs, field.Invalid(path, namespaces, "only one of Include/Exclude namespaces can be set"))
+	}
+	return allErrs
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateNamespaces(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces *deschedulerconfig.Namespaces
+		wantErrs   []string
+	}{
+		{
+			name: "nil namespaces",
+		},
+		{
+			name:       "only include",
+			namespaces: &deschedulerconfig.Namespaces{Include: []string{"default"}},
+		},
+		{
+			name:       "only exclude",
+			namespaces: &deschedulerconfig.Namespaces{Exclude: []string{"kube-system"}},
+		},
+		{
+			name: "both include and exclude",
+			namespaces: &deschedulerconfig.Namespaces{
+				Include: []string{"default"},
+				Exclude: []string{"kube-system"},
+			},
+			wantErrs: []string{"only one of Include/Exclude namespaces can be set"},
+		},
+		{
+			name: "namespace in both include and exclude",
+			namespaces: &deschedulerconfig.Namespaces{
+				Include: []string{"default", "kube-system"},
+				Exclude: []string{"kube-system"},
+			},
+			wantErrs: []string{"args.namespaces.include[1]: Invalid value: \"kube-system\": namespace \"kube-system\" is both included and excluded"},
+		},
+		{
+			name: "namespaces overlapping across the lists",
+			namespaces: &deschedulerconfig.Namespaces{
+				Include: []string{"team-a", "team-b", "team-a"},
+				Exclude: []string{"team-b", "team-a"},
+			},
+			wantErrs: []string{
+				"args.namespaces.include[0]: Invalid value: \"team-a\": namespace \"team-a\" is both included and excluded",
+				"args.namespaces.include[1]: Invalid value: \"team-b\": namespace \"team-b\" is both included and excluded",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateNamespaces(field.NewPath("args").Child("namespaces"), tt.namespaces)
+			assert.Len(t, errs, len(tt.wantErrs))
+			for i, err := range errs {
+				assert.Contains(t, err.Error(), tt.wantErrs[i])
+			}
+		})
+	}
+}
+
+func TestValidateRemoveFailedReadinessPodsArgs_OverlappingNamespaces(t *testing.T) {
+	err := ValidateRemoveFailedReadinessPodsArgs(field.NewPath("args"), &deschedulerconfig.RemoveFailedReadinessPodsArgs{
+		MinUnreadyDuration: metav1.Duration{Duration: 10 * time.Minute},
+		Namespaces: &deschedulerconfig.Namespaces{
+			Include: []string{"default"},
+			Exclude: []string{"default"},
+		},
+	})
+	assert.ErrorContains(t, err, `namespace "default" is both included and excluded`)
+}
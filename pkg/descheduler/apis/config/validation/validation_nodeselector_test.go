@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateRemovePodsViolatingNodeSelectorArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *deschedulerconfig.RemovePodsViolatingNodeSelectorArgs
+		wantErr bool
+	}{
+		{
+			name: "valid args",
+			args: &deschedulerconfig.RemovePodsViolatingNodeSelectorArgs{
+				NodeFit: true,
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"kube-system"},
+				},
+			},
+		},
+		{
+			name: "both include and exclude namespaces",
+			args: &deschedulerconfig.RemovePodsViolatingNodeSelectorArgs{
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRemovePodsViolatingNodeSelectorArgs(field.NewPath("args"), tt.args)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+var supportedTaintEffects = []string{
+	string(corev1.TaintEffectNoSchedule),
+	string(corev1.TaintEffectPreferNoSchedule),
+	string(corev1.TaintEffectNoExecute),
+}
+
+func ValidateRemovePodsViolatingNodeTaintEffectsArgs(path *field.Path, args *deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs) error {
+	var allErrs field.ErrorList
+
+	if len(args.TaintEffects) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("taintEffects"), "at least one taint effect must be specified"))
+	}
+	knownEffects := sets.NewString(supportedTaintEffects...)
+	seenEffects := sets.NewString()
+	for i, effect := range args.TaintEffects {
+		if !knownEffects.Has(string(effect)) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("taintEffects").Index(i), effect, supportedTaintEffects))
+			continue
+		}
+		if seenEffects.Has(string(effect)) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("taintEffects").Index(i), effect))
+		}
+		seenEffects.Insert(string(effect))
+	}
+
+	for i, excludedTaint := range args.ExcludedTaints {
+		key := strings.SplitN(excludedTaint, "=", 2)[0]
+		for _, msg := range validation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(path.Child("excludedTaints").Index(i), excludedTaint, msg))
+		}
+	}
+
+	allErrs = append(allErrs, validateNamespaces(path.Child("evictableNamespaces"), args.EvictableNamespaces)...)
+
+	return allErrs.ToAggregate()
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateRemovePodsViolatingNodeTaintEffectsArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs
+		wantErr bool
+	}{
+		{
+			name: "valid args",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				NodeFit:        true,
+				TaintEffects:   []corev1.TaintEffect{corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule},
+				ExcludedTaints: []string{"node.kubernetes.io/unschedulable", "dedicated=gpu"},
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"kube-system"},
+				},
+			},
+		},
+		{
+			name:    "missing taint effects",
+			args:    &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{},
+			wantErr: true,
+		},
+		{
+			name: "unsupported taint effect",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				TaintEffects: []corev1.TaintEffect{"NoEvict"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate taint effects",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectNoSchedule, corev1.TaintEffectNoSchedule},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid excluded taint key",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				TaintEffects:   []corev1.TaintEffect{corev1.TaintEffectNoSchedule},
+				ExcludedTaints: []string{"invalid key=value"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "both include and exclude namespaces",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectNoSchedule},
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRemovePodsViolatingNodeTaintEffectsArgs(field.NewPath("args"), tt.args)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
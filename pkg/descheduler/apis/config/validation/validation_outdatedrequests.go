@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func ValidateRemovePodsWithOutdatedRequestsArgs(path *field.Path, args *deschedulerconfig.RemovePodsWithOutdatedRequestsArgs) error {
+	var allErrs field.ErrorList
+
+	if args.DivergenceThreshold <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("divergenceThreshold"), args.DivergenceThreshold, "must be greater than 0"))
+	}
+
+	if len(args.Resources) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("resources"), "at least one resource must be specified"))
+	}
+	seenResources := sets.NewString()
+	for i, resourceName := range args.Resources {
+		if resourceName != corev1.ResourceCPU && resourceName != corev1.ResourceMemory {
+			allErrs = append(allErrs, field.NotSupported(path.Child("resources").Index(i), resourceName, []string{string(corev1.ResourceCPU), string(corev1.ResourceMemory)}))
+			continue
+		}
+		if seenResources.Has(string(resourceName)) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("resources").Index(i), resourceName))
+		}
+		seenResources.Insert(string(resourceName))
+	}
+
+	allErrs = append(allErrs, validateNamespaces(path.Child("evictableNamespaces"), args.EvictableNamespaces)...)
+
+	return allErrs.ToAggregate()
+}
@@ -19,8 +19,10 @@ package validation
 import (
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
@@ -66,10 +68,7 @@ func ValidateMigrationControllerArgs(path *field.Path, args *deschedulerconfig.M
 		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(args.LabelSelector, metav1validation.LabelSelectorValidationOptions{}, field.NewPath("labelSelector"))...)
 	}
 
-	// At most one of include/exclude can be set
-	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
-		allErrs = append(allErrs, field.Invalid(path.Child("namespaces"), args.Namespaces, "only one of Include/Exclude namespaces can be set"))
-	}
+	allErrs = append(allErrs, validateNamespaces(path.Child("namespaces"), args.Namespaces)...)
 
 	if args.MaxConcurrentReconciles < 1 {
 		allErrs = append(allErrs, field.Invalid(path.Child("maxConcurrentReconciles"), args.MaxConcurrentReconciles, "maxConcurrentReconciles should be greater than or equal to 1"))
@@ -79,8 +78,53 @@ func ValidateMigrationControllerArgs(path *field.Path, args *deschedulerconfig.M
 		allErrs = append(allErrs, field.Invalid(path.Child("defaultJobMode"), args.DefaultJobMode, fmt.Sprintf("defaultJobMode must be %s or %s", sev1alpha1.PodMigrationJobModeReservationFirst, sev1alpha1.PodMigrationJobModeEvictionDirectly)))
 	}
 
-	if args.DefaultJobTTL.Duration < 0 {
-		allErrs = append(allErrs, field.Invalid(path.Child("defaultJobTTL"), args.DefaultJobTTL, "defaultJobTTL should be positive or zero"))
+	if args.DefaultJobMode == string(sev1alpha1.PodMigrationJobModeReservationFirst) {
+		allErrs = append(allErrs, validateReservationSchedulerNames(path.Child("schedulerNames"), args.SchedulerNames)...)
+	}
+
+	if args.DefaultDeleteOptions != nil {
+		allErrs = append(allErrs, validateDeleteOptions(path.Child("defaultDeleteOptions"), args.DefaultDeleteOptions)...)
+	}
+
+	if args.DefaultJobTTL.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("defaultJobTTL"), args.DefaultJobTTL, "defaultJobTTL should be greater than 0"))
+	}
+
+	if args.NodePoolLabelKey != "" {
+		allErrs = append(allErrs, metav1validation.ValidateLabelName(args.NodePoolLabelKey, path.Child("nodePoolLabelKey"))...)
+	}
+	if args.NodePoolSelector != nil {
+		if args.NodePoolLabelKey == "" {
+			allErrs = append(allErrs, field.Required(path.Child("nodePoolLabelKey"), "nodePoolLabelKey is required when nodePoolSelector is set"))
+		}
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(args.NodePoolSelector, metav1validation.LabelSelectorValidationOptions{}, path.Child("nodePoolSelector"))...)
+	}
+
+	if args.StartupWarmup != nil && args.StartupWarmup.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("startupWarmup"), args.StartupWarmup, "startupWarmup should be positive or zero"))
+	}
+
+	if args.ArbitrationArgs != nil && args.ArbitrationArgs.DisruptionCostWeights != nil {
+		allErrs = append(allErrs, validateDisruptionCostWeights(path.Child("arbitrationArgs", "disruptionCostWeights"), args.ArbitrationArgs.DisruptionCostWeights)...)
+	}
+
+	switch args.EvictionAgePreference {
+	case "", deschedulerconfig.EvictionAgePreferenceNone, deschedulerconfig.EvictionAgePreferenceNewestFirst, deschedulerconfig.EvictionAgePreferenceOldestFirst:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("evictionAgePreference"), args.EvictionAgePreference,
+			[]string{string(deschedulerconfig.EvictionAgePreferenceNone), string(deschedulerconfig.EvictionAgePreferenceNewestFirst), string(deschedulerconfig.EvictionAgePreferenceOldestFirst)}))
+	}
+
+	seenReasons := sets.NewString()
+	for i, reason := range args.AllowedReasons {
+		if reason == "" {
+			allErrs = append(allErrs, field.Required(path.Child("allowedReasons").Index(i), "reason must not be empty"))
+			continue
+		}
+		if seenReasons.Has(reason) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("allowedReasons").Index(i), reason))
+		}
+		seenReasons.Insert(reason)
 	}
 
 	if len(allErrs) == 0 {
@@ -88,3 +132,80 @@ func ValidateMigrationControllerArgs(path *field.Path, args *deschedulerconfig.M
 	}
 	return allErrs.ToAggregate()
 }
+
+// validateReservationSchedulerNames validates the schedulers of the Reservations created in ReservationFirst mode,
+// without them the Reservations are never scheduled and the PodMigrationJobs fail until timeout.
+func validateReservationSchedulerNames(path *field.Path, schedulerNames []string) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(schedulerNames) == 0 {
+		allErrs = append(allErrs, field.Required(path, fmt.Sprintf("schedulerNames is required when defaultJobMode is %s", sev1alpha1.PodMigrationJobModeReservationFirst)))
+		return allErrs
+	}
+	for i, name := range schedulerNames {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(path.Index(i), "scheduler name must not be empty"))
+		}
+	}
+	return allErrs
+}
+
+var supportedPropagationPolicies = []string{
+	string(metav1.DeletePropagationOrphan),
+	string(metav1.DeletePropagationBackground),
+	string(metav1.DeletePropagationForeground),
+}
+
+// validateDeleteOptions validates the options used to delete the migrated pods,
+// which are otherwise rejected by the apiserver on every eviction.
+func validateDeleteOptions(path *field.Path, options *metav1.DeleteOptions) field.ErrorList {
+	var allErrs field.ErrorList
+	if options.PropagationPolicy != nil && !sets.NewString(supportedPropagationPolicies...).Has(string(*options.PropagationPolicy)) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("propagationPolicy"), *options.PropagationPolicy, supportedPropagationPolicies))
+	}
+	if options.GracePeriodSeconds != nil && *options.GracePeriodSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("gracePeriodSeconds"), *options.GracePeriodSeconds, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+// GetMigrationControllerArgsWarnings returns warnings of the MigrationControllerArgs which are valid but implausible.
+func GetMigrationControllerArgsWarnings(path *field.Path, args *deschedulerconfig.MigrationControllerArgs) []string {
+	var warnings []string
+	if !isInt32Limited(args.MaxMigratingGlobally) && !isInt32Limited(args.MaxMigratingPerNode) &&
+		!isInt32Limited(args.MaxMigratingPerNamespace) && !isIntOrPercentLimited(args.MaxMigratingPerWorkload) &&
+		len(args.ObjectLimiters) == 0 {
+		warnings = append(warnings, fmt.Sprintf("none of %s, %s, %s, %s and %s is set, pods may be migrated unboundedly, set at least one of them as a safety bound",
+			path.Child("maxMigratingGlobally"), path.Child("maxMigratingPerNode"), path.Child("maxMigratingPerNamespace"),
+			path.Child("maxMigratingPerWorkload"), path.Child("objectLimiters")))
+	}
+	return warnings
+}
+
+func isInt32Limited(limit *int32) bool {
+	return limit != nil && *limit > 0
+}
+
+func isIntOrPercentLimited(limit *intstr.IntOrString) bool {
+	if limit == nil {
+		return false
+	}
+	value, err := intstr.GetScaledValueFromIntOrPercent(limit, 100, true)
+	return err == nil && value > 0
+}
+
+func validateDisruptionCostWeights(path *field.Path, weights *deschedulerconfig.DisruptionCostWeights) field.ErrorList {
+	var allErrs field.ErrorList
+	if weights.AvailableReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("availableReplicas"), weights.AvailableReplicas, "must be greater than or equal to 0"))
+	}
+	if weights.RestartCount < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("restartCount"), weights.RestartCount, "must be greater than or equal to 0"))
+	}
+	if weights.EvictionCost < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("evictionCost"), weights.EvictionCost, "must be greater than or equal to 0"))
+	}
+	if len(allErrs) == 0 && weights.AvailableReplicas == 0 && weights.RestartCount == 0 && weights.EvictionCost == 0 {
+		allErrs = append(allErrs, field.Invalid(path, weights, "at least one of the weights must be greater than 0"))
+	}
+	return allErrs
+}
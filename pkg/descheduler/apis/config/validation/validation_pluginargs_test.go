@@ -23,8 +23,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/v1alpha2"
 )
@@ -93,6 +95,34 @@ func TestValidateMigrationControllerArgs(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid startupWarmup",
+			args: &v1alpha2.MigrationControllerArgs{
+				StartupWarmup: &metav1.Duration{Duration: time.Minute},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid startupWarmup",
+			args: &v1alpha2.MigrationControllerArgs{
+				StartupWarmup: &metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid evictionAgePreference",
+			args: &v1alpha2.MigrationControllerArgs{
+				EvictionAgePreference: v1alpha2.EvictionAgePreferenceOldestFirst,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid evictionAgePreference",
+			args: &v1alpha2.MigrationControllerArgs{
+				EvictionAgePreference: "Random",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -106,6 +136,233 @@ func TestValidateMigrationControllerArgs(t *testing.T) {
 	}
 }
 
+func TestValidateMigrationControllerArgs_DefaultJobTTL(t *testing.T) {
+	testCases := []struct {
+		name          string
+		defaultJobTTL time.Duration
+		wantErr       bool
+	}{
+		{
+			name:          "positive",
+			defaultJobTTL: 10 * time.Minute,
+			wantErr:       false,
+		},
+		{
+			name:          "zero",
+			defaultJobTTL: 0,
+			wantErr:       true,
+		},
+		{
+			name:          "negative",
+			defaultJobTTL: -time.Minute,
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			argsDefault := &v1alpha2.MigrationControllerArgs{}
+			v1alpha2.SetDefaults_MigrationControllerArgs(argsDefault)
+			args := &deschedulerconfig.MigrationControllerArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(argsDefault, args, nil))
+			args.DefaultJobTTL = metav1.Duration{Duration: tc.defaultJobTTL}
+
+			err := ValidateMigrationControllerArgs(nil, args)
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "defaultJobTTL should be greater than 0")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateMigrationControllerArgs_AllowedReasons(t *testing.T) {
+	testCases := []struct {
+		name           string
+		allowedReasons []string
+		wantErr        string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:           "valid reasons",
+			allowedReasons: []string{"manual", "LowNodeLoad"},
+		},
+		{
+			name:           "empty reason",
+			allowedReasons: []string{"manual", ""},
+			wantErr:        "allowedReasons[1]: Required value",
+		},
+		{
+			name:           "duplicated reason",
+			allowedReasons: []string{"manual", "manual"},
+			wantErr:        "allowedReasons[1]: Duplicate value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			argsDefault := &v1alpha2.MigrationControllerArgs{}
+			v1alpha2.SetDefaults_MigrationControllerArgs(argsDefault)
+			args := &deschedulerconfig.MigrationControllerArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(argsDefault, args, nil))
+			args.AllowedReasons = tc.allowedReasons
+
+			err := ValidateMigrationControllerArgs(nil, args)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMigrationControllerArgs_ReservationSchedulerNames(t *testing.T) {
+	testCases := []struct {
+		name           string
+		jobMode        sev1alpha1.PodMigrationJobMode
+		schedulerNames []string
+		wantErr        string
+	}{
+		{
+			name:           "ReservationFirst with schedulerNames",
+			jobMode:        sev1alpha1.PodMigrationJobModeReservationFirst,
+			schedulerNames: []string{"koord-scheduler"},
+		},
+		{
+			name:    "ReservationFirst without schedulerNames",
+			jobMode: sev1alpha1.PodMigrationJobModeReservationFirst,
+			wantErr: "schedulerNames: Required value: schedulerNames is required when defaultJobMode is ReservationFirst",
+		},
+		{
+			name:           "ReservationFirst with empty scheduler name",
+			jobMode:        sev1alpha1.PodMigrationJobModeReservationFirst,
+			schedulerNames: []string{"koord-scheduler", ""},
+			wantErr:        "schedulerNames[1]: Required value",
+		},
+		{
+			name:    "EvictDirectly without schedulerNames",
+			jobMode: sev1alpha1.PodMigrationJobModeEvictionDirectly,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			argsDefault := &v1alpha2.MigrationControllerArgs{}
+			v1alpha2.SetDefaults_MigrationControllerArgs(argsDefault)
+			args := &deschedulerconfig.MigrationControllerArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(argsDefault, args, nil))
+			args.DefaultJobMode = string(tc.jobMode)
+			args.SchedulerNames = tc.schedulerNames
+
+			err := ValidateMigrationControllerArgs(nil, args)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMigrationControllerArgs_DefaultDeleteOptions(t *testing.T) {
+	invalidPolicy := metav1.DeletionPropagation("Unknown")
+	backgroundPolicy := metav1.DeletePropagationBackground
+	testCases := []struct {
+		name          string
+		deleteOptions *metav1.DeleteOptions
+		wantErr       string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "valid options",
+			deleteOptions: &metav1.DeleteOptions{
+				PropagationPolicy:  &backgroundPolicy,
+				GracePeriodSeconds: pointer.Int64(0),
+			},
+		},
+		{
+			name:          "invalid propagation policy",
+			deleteOptions: &metav1.DeleteOptions{PropagationPolicy: &invalidPolicy},
+			wantErr:       "defaultDeleteOptions.propagationPolicy: Unsupported value: \"Unknown\"",
+		},
+		{
+			name:          "negative grace period",
+			deleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: pointer.Int64(-1)},
+			wantErr:       "defaultDeleteOptions.gracePeriodSeconds: Invalid value: -1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			argsDefault := &v1alpha2.MigrationControllerArgs{}
+			v1alpha2.SetDefaults_MigrationControllerArgs(argsDefault)
+			args := &deschedulerconfig.MigrationControllerArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(argsDefault, args, nil))
+			args.DefaultDeleteOptions = tc.deleteOptions
+
+			err := ValidateMigrationControllerArgs(nil, args)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMigrationControllerArgs_DisruptionCostWeights(t *testing.T) {
+	testCases := []struct {
+		name    string
+		weights *deschedulerconfig.DisruptionCostWeights
+		wantErr string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:    "valid weights",
+			weights: &deschedulerconfig.DisruptionCostWeights{AvailableReplicas: 1, RestartCount: 2, EvictionCost: 3},
+		},
+		{
+			name:    "only one weight",
+			weights: &deschedulerconfig.DisruptionCostWeights{EvictionCost: 1},
+		},
+		{
+			name:    "negative weight",
+			weights: &deschedulerconfig.DisruptionCostWeights{AvailableReplicas: 1, RestartCount: -1},
+			wantErr: "arbitrationArgs.disruptionCostWeights.restartCount: Invalid value: -1",
+		},
+		{
+			name:    "all weights are zero",
+			weights: &deschedulerconfig.DisruptionCostWeights{},
+			wantErr: "at least one of the weights must be greater than 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			argsDefault := &v1alpha2.MigrationControllerArgs{}
+			v1alpha2.SetDefaults_MigrationControllerArgs(argsDefault)
+			args := &deschedulerconfig.MigrationControllerArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(argsDefault, args, nil))
+			args.ArbitrationArgs.DisruptionCostWeights = tc.weights
+
+			err := ValidateMigrationControllerArgs(nil, args)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateMigrationControllerArgs_MaxMigratingPerNamespace(t *testing.T) {
 	testCases := []struct {
 		maxMigratingPerNamespace *int32
@@ -306,6 +563,62 @@ func TestValidateMigrationControllerArgs_MaxUnavailablePerWorkload(t *testing.T)
 	}
 }
 
+func TestValidateMigrationControllerArgs_NodePool(t *testing.T) {
+	testCases := []struct {
+		name             string
+		nodePoolLabelKey string
+		nodePoolSelector *metav1.LabelSelector
+		wantErr          string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:             "valid nodePoolLabelKey",
+			nodePoolLabelKey: "node.koordinator.sh/pool",
+		},
+		{
+			name:             "valid nodePoolLabelKey and nodePoolSelector",
+			nodePoolLabelKey: "node.koordinator.sh/pool",
+			nodePoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node.koordinator.sh/pool": "online"}},
+		},
+		{
+			name:             "invalid nodePoolLabelKey",
+			nodePoolLabelKey: "invalid key/pool/a",
+			wantErr:          "nodePoolLabelKey",
+		},
+		{
+			name:             "nodePoolSelector without nodePoolLabelKey",
+			nodePoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node.koordinator.sh/pool": "online"}},
+			wantErr:          "nodePoolLabelKey is required",
+		},
+		{
+			name:             "invalid nodePoolSelector",
+			nodePoolLabelKey: "node.koordinator.sh/pool",
+			nodePoolSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "node.koordinator.sh/pool", Operator: "Foo"}}},
+			wantErr:          "nodePoolSelector",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			argsDefault := &v1alpha2.MigrationControllerArgs{}
+			v1alpha2.SetDefaults_MigrationControllerArgs(argsDefault)
+			args := &deschedulerconfig.MigrationControllerArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(argsDefault, args, nil))
+			args.NodePoolLabelKey = tc.nodePoolLabelKey
+			args.NodePoolSelector = tc.nodePoolSelector
+
+			err := ValidateMigrationControllerArgs(nil, args)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
 // Helper functions for pointer creation
 func int32Ptr(value int32) *int32 {
 	return &value
@@ -315,3 +628,65 @@ func intstrPtr(val int) *intstr.IntOrString {
 	value := intstr.FromInt(val)
 	return &value
 }
+
+func TestGetMigrationControllerArgsWarnings_UnboundedMigration(t *testing.T) {
+	testCases := []struct {
+		name            string
+		setArgs         func(args *deschedulerconfig.MigrationControllerArgs)
+		expectedWarning bool
+	}{
+		{
+			name:            "no limiter is set",
+			setArgs:         func(args *deschedulerconfig.MigrationControllerArgs) {},
+			expectedWarning: true,
+		},
+		{
+			name: "all limiters are zero",
+			setArgs: func(args *deschedulerconfig.MigrationControllerArgs) {
+				args.MaxMigratingGlobally = pointer.Int32(0)
+				args.MaxMigratingPerNode = pointer.Int32(0)
+				args.MaxMigratingPerNamespace = pointer.Int32(0)
+				maxMigratingPerWorkload := intstr.FromString("0%")
+				args.MaxMigratingPerWorkload = &maxMigratingPerWorkload
+			},
+			expectedWarning: true,
+		},
+		{
+			name: "maxMigratingGlobally is set",
+			setArgs: func(args *deschedulerconfig.MigrationControllerArgs) {
+				args.MaxMigratingGlobally = pointer.Int32(10)
+			},
+		},
+		{
+			name: "maxMigratingPerWorkload is set",
+			setArgs: func(args *deschedulerconfig.MigrationControllerArgs) {
+				maxMigratingPerWorkload := intstr.FromString("10%")
+				args.MaxMigratingPerWorkload = &maxMigratingPerWorkload
+			},
+		},
+		{
+			name: "objectLimiters is set",
+			setArgs: func(args *deschedulerconfig.MigrationControllerArgs) {
+				args.ObjectLimiters = deschedulerconfig.ObjectLimiterMap{
+					deschedulerconfig.MigrationLimitObjectWorkload: {
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+				}
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &deschedulerconfig.MigrationControllerArgs{}
+			tc.setArgs(args)
+			warnings := GetMigrationControllerArgsWarnings(field.NewPath("args"), args)
+			if tc.expectedWarning {
+				assert.Len(t, warnings, 1)
+				assert.Contains(t, warnings[0], "args.maxMigratingGlobally")
+				assert.Contains(t, warnings[0], "args.objectLimiters")
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateRemoveFailedReadinessPodsArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *deschedulerconfig.RemoveFailedReadinessPodsArgs
+		wantErr bool
+	}{
+		{
+			name: "valid args",
+			args: &deschedulerconfig.RemoveFailedReadinessPodsArgs{
+				MinUnreadyDuration: metav1.Duration{Duration: 10 * time.Minute},
+				Namespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"kube-system"},
+				},
+			},
+		},
+		{
+			name:    "zero minUnreadyDuration",
+			args:    &deschedulerconfig.RemoveFailedReadinessPodsArgs{},
+			wantErr: true,
+		},
+		{
+			name: "negative minUnreadyDuration",
+			args: &deschedulerconfig.RemoveFailedReadinessPodsArgs{
+				MinUnreadyDuration: metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "both include and exclude namespaces",
+			args: &deschedulerconfig.RemoveFailedReadinessPodsArgs{
+				MinUnreadyDuration: metav1.Duration{Duration: 10 * time.Minute},
+				Namespaces: &deschedulerconfig.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRemoveFailedReadinessPodsArgs(field.NewPath("args"), tt.args)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func ValidateRemovePodsViolatingRequestLimitRatioArgs(path *field.Path, args *deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs) error {
+	var allErrs field.ErrorList
+
+	if len(args.MinRequestLimitRatios) == 0 && len(args.MaxRequestLimitRatios) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("minRequestLimitRatios"), "at least one of minRequestLimitRatios and maxRequestLimitRatios must be set"))
+	}
+	allErrs = append(allErrs, validateRequestLimitRatios(path.Child("minRequestLimitRatios"), args.MinRequestLimitRatios)...)
+	allErrs = append(allErrs, validateRequestLimitRatios(path.Child("maxRequestLimitRatios"), args.MaxRequestLimitRatios)...)
+	for resourceName, minRatio := range args.MinRequestLimitRatios {
+		if maxRatio, ok := args.MaxRequestLimitRatios[resourceName]; ok && minRatio > maxRatio {
+			allErrs = append(allErrs, field.Invalid(path.Child("minRequestLimitRatios").Key(string(resourceName)), minRatio, "must be less than or equal to maxRequestLimitRatios"))
+		}
+	}
+
+	allErrs = append(allErrs, validateNamespaces(path.Child("evictableNamespaces"), args.EvictableNamespaces)...)
+
+	return allErrs.ToAggregate()
+}
+
+// validateRequestLimitRatios validates the percentages of request to limit, which can never exceed 100 as
+// the request of a container must not be greater than its limit.
+func validateRequestLimitRatios(path *field.Path, ratios deschedulerconfig.ResourceThresholds) field.ErrorList {
+	var allErrs field.ErrorList
+	for resourceName, percentage := range ratios {
+		if percentage <= 0 || percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(path.Key(string(resourceName)), percentage, "percentage must be in (0, 100]"))
+		}
+	}
+	return allErrs
+}
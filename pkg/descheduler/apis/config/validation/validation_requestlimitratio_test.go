@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateRemovePodsViolatingRequestLimitRatioArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs
+		wantErr string
+	}{
+		{
+			name: "valid band",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				MinRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 50},
+				MaxRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 100, corev1.ResourceCPU: 80},
+			},
+		},
+		{
+			name:    "no band",
+			args:    &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{},
+			wantErr: "args.minRequestLimitRatios: Required value",
+		},
+		{
+			name: "zero percentage",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				MinRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceCPU: 0},
+			},
+			wantErr: "args.minRequestLimitRatios[cpu]: Invalid value",
+		},
+		{
+			name: "percentage above 100",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				MaxRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceCPU: 120},
+			},
+			wantErr: "args.maxRequestLimitRatios[cpu]: Invalid value",
+		},
+		{
+			name: "min above max",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				MinRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 80},
+				MaxRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 60},
+			},
+			wantErr: "must be less than or equal to maxRequestLimitRatios",
+		},
+		{
+			name: "namespace both included and excluded",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				MinRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 50},
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"default"},
+				},
+			},
+			wantErr: "args.evictableNamespaces",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRemovePodsViolatingRequestLimitRatioArgs(field.NewPath("args"), tt.args)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -18,10 +18,12 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
@@ -29,6 +31,7 @@ import (
 )
 
 func TestValidateDeschedulerConfiguration(t *testing.T) {
+	maxNoOfPodsToEvictTotal := uint(10)
 	tests := []struct {
 		name    string
 		args    *v1alpha2.DeschedulerConfiguration
@@ -78,6 +81,239 @@ func TestValidateDeschedulerConfiguration(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid profile namespaceSelector",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Profiles: []v1alpha2.DeschedulerProfile{
+					{
+						Name: "profile-1",
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"descheduling": "aggressive",
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid profile namespaceSelector",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Profiles: []v1alpha2.DeschedulerProfile{
+					{
+						Name: "profile-1",
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "descheduling", Operator: "Foo"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid extensionPointOrder",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Profiles: []v1alpha2.DeschedulerProfile{
+					{
+						Name:                "profile-1",
+						ExtensionPointOrder: []string{"Balance", "Deschedule"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown extension point in extensionPointOrder",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Profiles: []v1alpha2.DeschedulerProfile{
+					{
+						Name:                "profile-1",
+						ExtensionPointOrder: []string{"Balance", "Evict"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate extension point in extensionPointOrder",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Profiles: []v1alpha2.DeschedulerProfile{
+					{
+						Name:                "profile-1",
+						ExtensionPointOrder: []string{"Balance", "Balance"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid startupGracePeriod",
+			args: &v1alpha2.DeschedulerConfiguration{
+				StartupGracePeriod: metav1.Duration{Duration: time.Minute},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid startupGracePeriod",
+			args: &v1alpha2.DeschedulerConfiguration{
+				StartupGracePeriod: metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid initialObservationPeriod",
+			args: &v1alpha2.DeschedulerConfiguration{
+				InitialObservationPeriod: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid initialObservationPeriod",
+			args: &v1alpha2.DeschedulerConfiguration{
+				InitialObservationPeriod: &metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid maxCycleDuration",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxCycleDuration: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid maxCycleDuration",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxCycleDuration: &metav1.Duration{Duration: 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid safe mode",
+			args: &v1alpha2.DeschedulerConfiguration{
+				SafeModeEvictionRate: 100,
+				SafeModeCooldown:     metav1.Duration{Duration: 5 * time.Minute},
+			},
+			wantErr: false,
+		},
+		{
+			name: "safe mode with default cooldown",
+			args: &v1alpha2.DeschedulerConfiguration{
+				SafeModeEvictionRate: 100,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid safeModeEvictionRate",
+			args: &v1alpha2.DeschedulerConfiguration{
+				SafeModeEvictionRate: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid safeModeCooldown",
+			args: &v1alpha2.DeschedulerConfiguration{
+				SafeModeEvictionRate: 100,
+				SafeModeCooldown:     metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid evictionEventRate",
+			args: &v1alpha2.DeschedulerConfiguration{
+				EvictionEventRate: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid maxEvictionFractionPerCycle",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxEvictionFractionPerCycle: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.String, StrVal: "0.1"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "maxEvictionFractionPerCycle equal to 1",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxEvictionFractionPerCycle: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.Float, FloatVal: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "zero maxEvictionFractionPerCycle",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxEvictionFractionPerCycle: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.Float, FloatVal: 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "maxEvictionFractionPerCycle greater than 1",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxEvictionFractionPerCycle: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.Float, FloatVal: 1.5},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid maxNoOfPodsToEvictPerQuota",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxNoOfPodsToEvictPerQuota: map[string]uint{"team-a": 2, "team-b": 5},
+			},
+			wantErr: false,
+		},
+		{
+			name: "zero maxNoOfPodsToEvictPerQuota",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxNoOfPodsToEvictPerQuota: map[string]uint{"team-a": 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid quota name in maxNoOfPodsToEvictPerQuota",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxNoOfPodsToEvictPerQuota: map[string]uint{"Team_A": 2},
+			},
+			wantErr: true,
+		},
+		{
+			name: "evictSystemCriticalPriorityClassPods with maxNoOfPodsToEvictTotal",
+			args: &v1alpha2.DeschedulerConfiguration{
+				EvictSystemCriticalPriorityClassPods: true,
+				MaxNoOfPodsToEvictTotal:              &maxNoOfPodsToEvictTotal,
+			},
+			wantErr: false,
+		},
+		{
+			name: "evictSystemCriticalPriorityClassPods with maxEvictionFractionPerCycle",
+			args: &v1alpha2.DeschedulerConfiguration{
+				EvictSystemCriticalPriorityClassPods: true,
+				MaxEvictionFractionPerCycle:          &deschedulerconfig.Float64OrString{Type: deschedulerconfig.Float, FloatVal: 0.1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "evictSystemCriticalPriorityClassPods without total limits",
+			args: &v1alpha2.DeschedulerConfiguration{
+				EvictSystemCriticalPriorityClassPods: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid maxConcurrentProfiles",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxConcurrentProfiles: pointer.Int32(4),
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid maxConcurrentProfiles",
+			args: &v1alpha2.DeschedulerConfiguration{
+				MaxConcurrentProfiles: pointer.Int32(0),
+			},
+			wantErr: true,
+		},
 		{
 			name: "duplicate plugin config",
 			args: &v1alpha2.DeschedulerConfiguration{
@@ -103,6 +339,21 @@ func TestValidateDeschedulerConfiguration(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "plugin enabled in both deschedule and balance",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Profiles: []v1alpha2.DeschedulerProfile{
+					{
+						Name: "test",
+						Plugins: &v1alpha2.Plugins{
+							Deschedule: v1alpha2.PluginSet{Enabled: []v1alpha2.Plugin{{Name: "LowNodeLoad"}}},
+							Balance:    v1alpha2.PluginSet{Enabled: []v1alpha2.Plugin{{Name: "LowNodeLoad"}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -115,3 +366,146 @@ func TestValidateDeschedulerConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePluginConfigArgsKind(t *testing.T) {
+	tests := []struct {
+		name         string
+		pluginConfig []deschedulerconfig.PluginConfig
+		wantErr      string
+	}{
+		{
+			name: "matched args kind",
+			pluginConfig: []deschedulerconfig.PluginConfig{
+				{
+					Name: "LowNodeLoad",
+					Args: &deschedulerconfig.LowNodeLoadArgs{},
+				},
+			},
+		},
+		{
+			name: "mismatched args kind",
+			pluginConfig: []deschedulerconfig.PluginConfig{
+				{
+					Name: "LowNodeLoad",
+					Args: &deschedulerconfig.MigrationControllerArgs{},
+				},
+			},
+			wantErr: `plugin "LowNodeLoad" expects args of kind "LowNodeLoadArgs", got "MigrationControllerArgs"`,
+		},
+		{
+			name: "mismatched args kind for validated plugin",
+			pluginConfig: []deschedulerconfig.PluginConfig{
+				{
+					Name: "MigrationController",
+					Args: &deschedulerconfig.DrainScaleDownCandidatesArgs{},
+				},
+			},
+			wantErr: `plugin "MigrationController" expects args of kind "MigrationControllerArgs", got "DrainScaleDownCandidatesArgs"`,
+		},
+		{
+			name: "configuration object as args",
+			pluginConfig: []deschedulerconfig.PluginConfig{
+				{
+					Name: "LowNodeLoad",
+					Args: &deschedulerconfig.DeschedulerConfiguration{},
+				},
+			},
+			wantErr: `plugin "LowNodeLoad" expects args of kind "LowNodeLoadArgs", got "DeschedulerConfiguration"`,
+		},
+		{
+			name: "out-of-tree plugin is skipped",
+			pluginConfig: []deschedulerconfig.PluginConfig{
+				{
+					Name: "Foo",
+					Args: &deschedulerconfig.LowNodeLoadArgs{},
+				},
+			},
+		},
+		{
+			name: "undecoded args are skipped",
+			pluginConfig: []deschedulerconfig.PluginConfig{
+				{
+					Name: "LowNodeLoad",
+					Args: &runtime.Unknown{},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &deschedulerconfig.DeschedulerProfile{
+				Name:         "test",
+				PluginConfig: tt.pluginConfig,
+			}
+			errs := validatePluginConfig(field.NewPath("profiles").Index(0), profile)
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			assert.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidatePluginExtensionPoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		plugins *deschedulerconfig.Plugins
+		wantErr []string
+	}{
+		{
+			name:    "nil plugins",
+			plugins: nil,
+		},
+		{
+			name: "plugins in separate extension points",
+			plugins: &deschedulerconfig.Plugins{
+				Deschedule: deschedulerconfig.PluginSet{Enabled: []deschedulerconfig.Plugin{{Name: "RemovePodsHavingTooManyRestarts"}}},
+				Balance:    deschedulerconfig.PluginSet{Enabled: []deschedulerconfig.Plugin{{Name: "LowNodeLoad"}}},
+			},
+		},
+		{
+			name: "plugin in evict and filter alongside balance",
+			plugins: &deschedulerconfig.Plugins{
+				Balance: deschedulerconfig.PluginSet{Enabled: []deschedulerconfig.Plugin{{Name: "MigrationController"}}},
+				Evict:   deschedulerconfig.PluginSet{Enabled: []deschedulerconfig.Plugin{{Name: "MigrationController"}}},
+				Filter:  deschedulerconfig.PluginSet{Enabled: []deschedulerconfig.Plugin{{Name: "MigrationController"}}},
+			},
+		},
+		{
+			name: "plugin in both deschedule and balance",
+			plugins: &deschedulerconfig.Plugins{
+				Deschedule: deschedulerconfig.PluginSet{Enabled: []deschedulerconfig.Plugin{{Name: "Foo"}, {Name: "LowNodeLoad"}}},
+				Balance:    deschedulerconfig.PluginSet{Enabled: []deschedulerconfig.Plugin{{Name: "Bar"}, {Name: "LowNodeLoad"}}},
+			},
+			wantErr: []string{
+				`profiles[0].plugins.balance.enabled[1]: Invalid value: "LowNodeLoad": plugin "LowNodeLoad" is enabled in both deschedule and balance`,
+			},
+		},
+		{
+			name: "multiple plugins in both deschedule and balance",
+			plugins: &deschedulerconfig.Plugins{
+				Deschedule: deschedulerconfig.PluginSet{Enabled: []deschedulerconfig.Plugin{{Name: "Foo"}, {Name: "Bar"}}},
+				Balance:    deschedulerconfig.PluginSet{Enabled: []deschedulerconfig.Plugin{{Name: "Bar"}, {Name: "Foo"}}},
+			},
+			wantErr: []string{
+				`plugin "Bar" is enabled in both deschedule and balance`,
+				`plugin "Foo" is enabled in both deschedule and balance`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &deschedulerconfig.DeschedulerProfile{
+				Name:    "test",
+				Plugins: tt.plugins,
+			}
+			errs := validatePluginExtensionPoints(field.NewPath("profiles").Index(0), profile)
+			assert.Len(t, errs, len(tt.wantErr))
+			for i := range errs {
+				assert.Contains(t, errs[i].Error(), tt.wantErr[i])
+			}
+		})
+	}
+}
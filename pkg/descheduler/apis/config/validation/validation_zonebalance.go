@@ -0,0 +1,42 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func ValidateBalanceAcrossZonesArgs(path *field.Path, args *deschedulerconfig.BalanceAcrossZonesArgs) error {
+	var allErrs field.ErrorList
+
+	if args.ZoneLabelKey == "" {
+		allErrs = append(allErrs, field.Required(path.Child("zoneLabelKey"), "zoneLabelKey must be specified"))
+	} else {
+		allErrs = append(allErrs, metav1validation.ValidateLabelName(args.ZoneLabelKey, path.Child("zoneLabelKey"))...)
+	}
+
+	if args.MaxSkew < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxSkew"), args.MaxSkew, "must be greater than or equal to 1"))
+	}
+
+	allErrs = append(allErrs, validateNamespaces(path.Child("evictableNamespaces"), args.EvictableNamespaces)...)
+
+	return allErrs.ToAggregate()
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateBalanceAcrossZonesArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *deschedulerconfig.BalanceAcrossZonesArgs
+		wantErr bool
+	}{
+		{
+			name: "valid args",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				ZoneLabelKey: "topology.kubernetes.io/zone",
+				MaxSkew:      1,
+			},
+		},
+		{
+			name: "missing zoneLabelKey",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				MaxSkew: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid zoneLabelKey",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				ZoneLabelKey: "invalid zone/key",
+				MaxSkew:      1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero maxSkew",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				ZoneLabelKey: "topology.kubernetes.io/zone",
+			},
+			wantErr: true,
+		},
+		{
+			name: "both include and exclude namespaces",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				ZoneLabelKey: "topology.kubernetes.io/zone",
+				MaxSkew:      1,
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBalanceAcrossZonesArgs(field.NewPath("args"), tt.args)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
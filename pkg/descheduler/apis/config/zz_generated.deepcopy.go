@@ -23,9 +23,11 @@ package config
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
+	apiv1 "k8s.io/component-base/tracing/api/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -36,6 +38,11 @@ func (in *ArbitrationArgs) DeepCopyInto(out *ArbitrationArgs) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.DisruptionCostWeights != nil {
+		in, out := &in.DisruptionCostWeights, &out.DisruptionCostWeights
+		*out = new(DisruptionCostWeights)
+		**out = **in
+	}
 	return
 }
 
@@ -49,6 +56,36 @@ func (in *ArbitrationArgs) DeepCopy() *ArbitrationArgs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BalanceAcrossZonesArgs) DeepCopyInto(out *BalanceAcrossZonesArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalanceAcrossZonesArgs.
+func (in *BalanceAcrossZonesArgs) DeepCopy() *BalanceAcrossZonesArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(BalanceAcrossZonesArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BalanceAcrossZonesArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeschedulerConfiguration) DeepCopyInto(out *DeschedulerConfiguration) {
 	*out = *in
@@ -79,11 +116,40 @@ func (in *DeschedulerConfiguration) DeepCopyInto(out *DeschedulerConfiguration)
 		*out = new(uint)
 		**out = **in
 	}
+	if in.MaxNoOfPodsToEvictPerQuota != nil {
+		in, out := &in.MaxNoOfPodsToEvictPerQuota, &out.MaxNoOfPodsToEvictPerQuota
+		*out = make(map[string]uint, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.MaxNoOfPodsToEvictTotal != nil {
 		in, out := &in.MaxNoOfPodsToEvictTotal, &out.MaxNoOfPodsToEvictTotal
 		*out = new(uint)
 		**out = **in
 	}
+	if in.MaxEvictionFractionPerCycle != nil {
+		in, out := &in.MaxEvictionFractionPerCycle, &out.MaxEvictionFractionPerCycle
+		*out = new(Float64OrString)
+		**out = **in
+	}
+	out.StartupGracePeriod = in.StartupGracePeriod
+	if in.InitialObservationPeriod != nil {
+		in, out := &in.InitialObservationPeriod, &out.InitialObservationPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	out.SafeModeCooldown = in.SafeModeCooldown
+	if in.MaxCycleDuration != nil {
+		in, out := &in.MaxCycleDuration, &out.MaxCycleDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(apiv1.TracingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -125,6 +191,16 @@ func (in *DeschedulerProfile) DeepCopyInto(out *DeschedulerProfile) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtensionPointOrder != nil {
+		in, out := &in.ExtensionPointOrder, &out.ExtensionPointOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -154,6 +230,62 @@ func (in *Float64OrString) DeepCopy() *Float64OrString {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionCostWeights) DeepCopyInto(out *DisruptionCostWeights) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionCostWeights.
+func (in *DisruptionCostWeights) DeepCopy() *DisruptionCostWeights {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionCostWeights)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainScaleDownCandidatesArgs) DeepCopyInto(out *DrainScaleDownCandidatesArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.MarkerKeys != nil {
+		in, out := &in.MarkerKeys, &out.MarkerKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaleHeartbeatThreshold != nil {
+		in, out := &in.StaleHeartbeatThreshold, &out.StaleHeartbeatThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainScaleDownCandidatesArgs.
+func (in *DrainScaleDownCandidatesArgs) DeepCopy() *DrainScaleDownCandidatesArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainScaleDownCandidatesArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DrainScaleDownCandidatesArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadAnomalyCondition) DeepCopyInto(out *LoadAnomalyCondition) {
 	*out = *in
@@ -175,11 +307,32 @@ func (in *LoadAnomalyCondition) DeepCopy() *LoadAnomalyCondition {
 func (in *LowNodeLoadArgs) DeepCopyInto(out *LowNodeLoadArgs) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	if in.ConsolidationTargetUtilization != nil {
+		in, out := &in.ConsolidationTargetUtilization, &out.ConsolidationTargetUtilization
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CriticalThresholds != nil {
+		in, out := &in.CriticalThresholds, &out.CriticalThresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.NodeMetricExpirationSeconds != nil {
 		in, out := &in.NodeMetricExpirationSeconds, &out.NodeMetricExpirationSeconds
 		*out = new(int64)
 		**out = **in
 	}
+	if in.NodeMetricExpirationByResource != nil {
+		in, out := &in.NodeMetricExpirationByResource, &out.NodeMetricExpirationByResource
+		*out = make(map[corev1.ResourceName]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.EvictableNamespaces != nil {
 		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
 		*out = new(Namespaces)
@@ -197,6 +350,21 @@ func (in *LowNodeLoadArgs) DeepCopyInto(out *LowNodeLoadArgs) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OwnerKindEvictionPriority != nil {
+		in, out := &in.OwnerKindEvictionPriority, &out.OwnerKindEvictionPriority
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CandidateOrderSeed != nil {
+		in, out := &in.CandidateOrderSeed, &out.CandidateOrderSeed
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EvictionAggressiveness != nil {
+		in, out := &in.EvictionAggressiveness, &out.EvictionAggressiveness
+		*out = new(Float64OrString)
+		**out = **in
+	}
 	if in.HighThresholds != nil {
 		in, out := &in.HighThresholds, &out.HighThresholds
 		*out = make(ResourceThresholds, len(*in))
@@ -211,6 +379,27 @@ func (in *LowNodeLoadArgs) DeepCopyInto(out *LowNodeLoadArgs) {
 			(*out)[key] = val
 		}
 	}
+	if in.AbsoluteHighThresholds != nil {
+		in, out := &in.AbsoluteHighThresholds, &out.AbsoluteHighThresholds
+		*out = make(map[corev1.ResourceName]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.AbsoluteLowThresholds != nil {
+		in, out := &in.AbsoluteLowThresholds, &out.AbsoluteLowThresholds
+		*out = make(map[corev1.ResourceName]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MinAbsoluteUsage != nil {
+		in, out := &in.MinAbsoluteUsage, &out.MinAbsoluteUsage
+		*out = make(map[corev1.ResourceName]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	if in.ProdHighThresholds != nil {
 		in, out := &in.ProdHighThresholds, &out.ProdHighThresholds
 		*out = make(ResourceThresholds, len(*in))
@@ -292,6 +481,27 @@ func (in *LowNodeLoadNodePool) DeepCopyInto(out *LowNodeLoadNodePool) {
 			(*out)[key] = val
 		}
 	}
+	if in.AbsoluteHighThresholds != nil {
+		in, out := &in.AbsoluteHighThresholds, &out.AbsoluteHighThresholds
+		*out = make(map[corev1.ResourceName]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.AbsoluteLowThresholds != nil {
+		in, out := &in.AbsoluteLowThresholds, &out.AbsoluteLowThresholds
+		*out = make(map[corev1.ResourceName]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MinAbsoluteUsage != nil {
+		in, out := &in.MinAbsoluteUsage, &out.MinAbsoluteUsage
+		*out = make(map[corev1.ResourceName]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	if in.ProdHighThresholds != nil {
 		in, out := &in.ProdHighThresholds, &out.ProdHighThresholds
 		*out = make(ResourceThresholds, len(*in))
@@ -429,6 +639,21 @@ func (in *MigrationControllerArgs) DeepCopyInto(out *MigrationControllerArgs) {
 		*out = new(ArbitrationArgs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodePoolSelector != nil {
+		in, out := &in.NodePoolSelector, &out.NodePoolSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupWarmup != nil {
+		in, out := &in.StartupWarmup, &out.StartupWarmup
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AllowedReasons != nil {
+		in, out := &in.AllowedReasons, &out.AllowedReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -622,6 +847,288 @@ func (in *PriorityThreshold) DeepCopy() *PriorityThreshold {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RebalanceForQuotaFairnessArgs) DeepCopyInto(out *RebalanceForQuotaFairnessArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]corev1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalanceForQuotaFairnessArgs.
+func (in *RebalanceForQuotaFairnessArgs) DeepCopy() *RebalanceForQuotaFairnessArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(RebalanceForQuotaFairnessArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RebalanceForQuotaFairnessArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoveFailedReadinessPodsArgs) DeepCopyInto(out *RemoveFailedReadinessPodsArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.MinUnreadyDuration = in.MinUnreadyDuration
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoveFailedReadinessPodsArgs.
+func (in *RemoveFailedReadinessPodsArgs) DeepCopy() *RemoveFailedReadinessPodsArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoveFailedReadinessPodsArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoveFailedReadinessPodsArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoveIdleGPUPodsArgs) DeepCopyInto(out *RemoveIdleGPUPodsArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.IdleDuration = in.IdleDuration
+	if in.NodeMetricExpirationSeconds != nil {
+		in, out := &in.NodeMetricExpirationSeconds, &out.NodeMetricExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoveIdleGPUPodsArgs.
+func (in *RemoveIdleGPUPodsArgs) DeepCopy() *RemoveIdleGPUPodsArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoveIdleGPUPodsArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoveIdleGPUPodsArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovePodsViolatingNodeSelectorArgs) DeepCopyInto(out *RemovePodsViolatingNodeSelectorArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovePodsViolatingNodeSelectorArgs.
+func (in *RemovePodsViolatingNodeSelectorArgs) DeepCopy() *RemovePodsViolatingNodeSelectorArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovePodsViolatingNodeSelectorArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemovePodsViolatingNodeSelectorArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovePodsViolatingNodeTaintEffectsArgs) DeepCopyInto(out *RemovePodsViolatingNodeTaintEffectsArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.TaintEffects != nil {
+		in, out := &in.TaintEffects, &out.TaintEffects
+		*out = make([]corev1.TaintEffect, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedTaints != nil {
+		in, out := &in.ExcludedTaints, &out.ExcludedTaints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovePodsViolatingNodeTaintEffectsArgs.
+func (in *RemovePodsViolatingNodeTaintEffectsArgs) DeepCopy() *RemovePodsViolatingNodeTaintEffectsArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovePodsViolatingNodeTaintEffectsArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemovePodsViolatingNodeTaintEffectsArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovePodsViolatingRequestLimitRatioArgs) DeepCopyInto(out *RemovePodsViolatingRequestLimitRatioArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.MinRequestLimitRatios != nil {
+		in, out := &in.MinRequestLimitRatios, &out.MinRequestLimitRatios
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxRequestLimitRatios != nil {
+		in, out := &in.MaxRequestLimitRatios, &out.MaxRequestLimitRatios
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovePodsViolatingRequestLimitRatioArgs.
+func (in *RemovePodsViolatingRequestLimitRatioArgs) DeepCopy() *RemovePodsViolatingRequestLimitRatioArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovePodsViolatingRequestLimitRatioArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemovePodsViolatingRequestLimitRatioArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovePodsWithMissingReferencesArgs) DeepCopyInto(out *RemovePodsWithMissingReferencesArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.MinFailingDuration = in.MinFailingDuration
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovePodsWithMissingReferencesArgs.
+func (in *RemovePodsWithMissingReferencesArgs) DeepCopy() *RemovePodsWithMissingReferencesArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovePodsWithMissingReferencesArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemovePodsWithMissingReferencesArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovePodsWithOutdatedRequestsArgs) DeepCopyInto(out *RemovePodsWithOutdatedRequestsArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]corev1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovePodsWithOutdatedRequestsArgs.
+func (in *RemovePodsWithOutdatedRequestsArgs) DeepCopy() *RemovePodsWithOutdatedRequestsArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovePodsWithOutdatedRequestsArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemovePodsWithOutdatedRequestsArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in ResourceThresholds) DeepCopyInto(out *ResourceThresholds) {
 	{
@@ -33,7 +33,6 @@ import (
 	"github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
-	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/sorter"
 )
 
 const (
@@ -77,19 +76,23 @@ func New(args *config.MigrationControllerArgs, options Options) (Arbitrator, err
 		return nil, err
 	}
 
+	sorts := []SortFn{
+		SortJobsByCreationTime(),
+		SortJobsByPod(newPodSorter(args.EvictionAgePreference).Sort),
+	}
+	if weights := args.ArbitrationArgs.DisruptionCostWeights; weights != nil {
+		sorts = append(sorts, SortJobsByDisruptionCost(weights, f.getAvailableReplicas))
+	}
+	sorts = append(sorts, SortJobsByController(), SortJobsByMigratingNum(options.Client))
+
 	arbitrator := &arbitratorImpl{
 		waitingCollection: map[types.UID]*v1alpha1.PodMigrationJob{},
 		interval:          args.ArbitrationArgs.Interval.Duration,
-		sorts: []SortFn{
-			SortJobsByCreationTime(),
-			SortJobsByPod(sorter.PodSorter().Sort),
-			SortJobsByController(),
-			SortJobsByMigratingNum(options.Client),
-		},
-		filter:        f,
-		client:        options.Client,
-		eventRecorder: options.EventRecorder,
-		mu:            sync.Mutex{},
+		sorts:             sorts,
+		filter:            f,
+		client:            options.Client,
+		eventRecorder:     options.EventRecorder,
+		mu:                sync.Mutex{},
 	}
 
 	err = options.Manager.Add(arbitrator)
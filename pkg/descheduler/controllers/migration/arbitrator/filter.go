@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
@@ -33,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8sdeschedulerapi "sigs.k8s.io/descheduler/pkg/api"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
@@ -42,6 +44,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes/defaultevictor"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
 	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
 	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
 	pkgutil "github.com/koordinator-sh/koordinator/pkg/util"
@@ -126,6 +129,7 @@ func (f *filter) initFilters(args *deschedulerconfig.MigrationControllerArgs, ha
 		util.FilterPodWithMaxEvictionCost,
 		filterPlugin.Filter,
 		f.filterExpectedReplicas,
+		f.filterNodePool,
 	)
 	podFilter, err := podutil.NewOptions().
 		WithFilter(wrapFilterFuncs).
@@ -145,6 +149,10 @@ func (f *filter) initFilters(args *deschedulerconfig.MigrationControllerArgs, ha
 		return evictionsutil.HaveEvictAnnotation(pod) || retryablePodFilters(pod)
 	}
 	f.nonRetryablePodFilter = func(pod *corev1.Pod) bool {
+		// the pods exempted from migration never pass, even if annotated as evictable
+		if !f.filterMigrationDisabled(pod) {
+			return false
+		}
 		// any annotated as evictable pod pass non-retryable filter
 		return evictionsutil.HaveEvictAnnotation(pod) || podFilter(pod)
 	}
@@ -225,6 +233,45 @@ func (f *filter) existingPodMigrationJob(pod *corev1.Pod, expectedPhaseContexts
 	return existing
 }
 
+// filterMigrationDisabled rejects the pods exempted from migration by the annotation.
+// The pods with an invalid annotation are rejected as well, since they are likely intended to be exempted.
+func (f *filter) filterMigrationDisabled(pod *corev1.Pod) bool {
+	disabled, err := extension.IsMigrationDisabled(pod.Annotations)
+	if err != nil {
+		klog.ErrorS(err, "Failed to parse the migration disabled annotation, skip the pod", "pod", klog.KObj(pod))
+		disabled = true
+	}
+	if disabled {
+		klog.V(4).InfoS("Pod is exempted from migration", "pod", klog.KObj(pod))
+		metrics.PodsSkippedForMigrationDisabled.WithLabelValues(pod.Namespace).Inc()
+	}
+	return !disabled
+}
+
+// filterNodePool checks if the node where the pod is located belongs to the node pools selected by NodePoolSelector.
+func (f *filter) filterNodePool(pod *corev1.Pod) bool {
+	if f.args.NodePoolLabelKey == "" || f.args.NodePoolSelector == nil || pod.Spec.NodeName == "" {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(f.args.NodePoolSelector)
+	if err != nil {
+		klog.Errorf("failed to parse nodePoolSelector %v, err: %v", f.args.NodePoolSelector, err)
+		return false
+	}
+
+	node := &corev1.Node{}
+	if err := f.client.Get(context.TODO(), types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+		klog.Errorf("failed to get node %s of pod %s, err: %v", pod.Spec.NodeName, klog.KObj(pod), err)
+		return false
+	}
+	if _, ok := node.Labels[f.args.NodePoolLabelKey]; !ok || !selector.Matches(labels.Set(node.Labels)) {
+		klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod),
+			"checks", "nodePool", "node", pod.Spec.NodeName, "nodePoolLabelKey", f.args.NodePoolLabelKey)
+		return false
+	}
+	return true
+}
+
 func (f *filter) filterMaxMigratingGlobally(pod *corev1.Pod) bool {
 	if f.args.MaxMigratingGlobally == nil || *f.args.MaxMigratingGlobally <= 0 {
 		return true
@@ -449,6 +496,20 @@ func (f *filter) getUnavailablePods(pods []*corev1.Pod) map[types.NamespacedName
 	return unavailablePods
 }
 
+// getAvailableReplicas returns the number of the available Pods of the workload which the Pod belongs to.
+func (f *filter) getAvailableReplicas(pod *corev1.Pod) int {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil {
+		return 0
+	}
+	pods, _, err := f.controllerFinder.GetPodsForRef(ownerRef, pod.Namespace, nil, false)
+	if err != nil {
+		klog.V(4).InfoS("Failed to get the Pods of the workload", "pod", klog.KObj(pod), "err", err)
+		return 0
+	}
+	return len(pods) - len(f.getUnavailablePods(pods))
+}
+
 func mergeUnavailableAndMigratingPods(unavailablePods, migratingPods map[types.NamespacedName]struct{}) {
 	for k, v := range migratingPods {
 		unavailablePods[k] = v
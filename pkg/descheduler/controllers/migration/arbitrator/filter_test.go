@@ -34,8 +34,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	"github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	evictionsutil "github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
 )
 
 func TestFilterExistingMigrationJob(t *testing.T) {
@@ -362,6 +364,123 @@ func TestFilterMaxMigratingPerNode(t *testing.T) {
 	}
 }
 
+func TestFilterNodePool(t *testing.T) {
+	tests := []struct {
+		name             string
+		nodeLabels       map[string]string
+		nodePoolLabelKey string
+		nodePoolSelector *metav1.LabelSelector
+		want             bool
+	}{
+		{
+			name: "nodePoolLabelKey not set",
+			want: true,
+		},
+		{
+			name:             "nodePoolSelector not set",
+			nodePoolLabelKey: "node-pool",
+			want:             true,
+		},
+		{
+			name:             "node in selected pool",
+			nodeLabels:       map[string]string{"node-pool": "pool-a"},
+			nodePoolLabelKey: "node-pool",
+			nodePoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node-pool": "pool-a"}},
+			want:             true,
+		},
+		{
+			name:             "node in unselected pool",
+			nodeLabels:       map[string]string{"node-pool": "pool-b"},
+			nodePoolLabelKey: "node-pool",
+			nodePoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node-pool": "pool-a"}},
+			want:             false,
+		},
+		{
+			name:             "node not in any pool",
+			nodePoolLabelKey: "node-pool",
+			nodePoolSelector: &metav1.LabelSelector{},
+			want:             false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = v1alpha1.AddToScheme(scheme)
+			_ = clientgoscheme.AddToScheme(scheme)
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-node",
+					Labels: tt.nodeLabels,
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
+			a := filter{client: fakeClient, args: &config.MigrationControllerArgs{
+				NodePoolLabelKey: tt.nodePoolLabelKey,
+				NodePoolSelector: tt.nodePoolSelector,
+			}, arbitratedPodMigrationJobs: map[types.UID]bool{}}
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-pod",
+					UID:       uuid.NewUUID(),
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "test-node",
+				},
+			}
+			assert.Equal(t, tt.want, a.filterNodePool(pod))
+		})
+	}
+}
+
+func TestFilterMigrationDisabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name: "no annotation",
+			want: true,
+		},
+		{
+			name:        "migration disabled",
+			annotations: map[string]string{extension.AnnotationMigrationDisabled: "true"},
+			want:        false,
+		},
+		{
+			name:        "migration disabled with evict annotation",
+			annotations: map[string]string{extension.AnnotationMigrationDisabled: "true", evictionsutil.EvictPodAnnotationKey: ""},
+			want:        false,
+		},
+		{
+			name:        "migration not disabled",
+			annotations: map[string]string{extension.AnnotationMigrationDisabled: "false"},
+			want:        true,
+		},
+		{
+			name:        "invalid annotation",
+			annotations: map[string]string{extension.AnnotationMigrationDisabled: "yes"},
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &filter{args: &config.MigrationControllerArgs{}, arbitratedPodMigrationJobs: map[types.UID]bool{}}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test-pod",
+					UID:         uuid.NewUUID(),
+					Annotations: tt.annotations,
+				},
+			}
+			assert.Equal(t, tt.want, f.filterMigrationDisabled(pod))
+		})
+	}
+}
+
 func TestFilterMaxMigratingPerNamespace(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -28,8 +28,11 @@ import (
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	"github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/sorter"
 	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
 )
 
@@ -67,6 +70,17 @@ func SortJobsByPod(sorter func(pods []*corev1.Pod)) SortFn {
 	}
 }
 
+// newPodSorter returns the PodSorter breaking the ties of the Pods by age following the preference.
+func newPodSorter(preference config.EvictionAgePreference) *sorter.MultiSorter {
+	switch preference {
+	case config.EvictionAgePreferenceNewestFirst:
+		return sorter.PodSorter(sorter.PodCreationTimestamp)
+	case config.EvictionAgePreferenceOldestFirst:
+		return sorter.PodSorter(sorter.Reverse(sorter.PodCreationTimestamp))
+	}
+	return sorter.PodSorter()
+}
+
 // SortJobsByCreationTime returns a SortFn that stably sorts PodMigrationJobs by create time.
 func SortJobsByCreationTime() SortFn {
 	return func(jobs []*v1alpha1.PodMigrationJob, podOfJob map[*v1alpha1.PodMigrationJob]*corev1.Pod) []*v1alpha1.PodMigrationJob {
@@ -129,6 +143,48 @@ func SortJobsByController() SortFn {
 	}
 }
 
+// SortJobsByDisruptionCost returns a SortFn that stably sorts PodMigrationJobs by the disruption cost of their Pods.
+// The jobs of the Pods cheaper to disrupt, i.e. with higher arbitration scores, are placed in front.
+func SortJobsByDisruptionCost(weights *config.DisruptionCostWeights, availableReplicas func(pod *corev1.Pod) int) SortFn {
+	return func(jobs []*v1alpha1.PodMigrationJob, podOfJob map[*v1alpha1.PodMigrationJob]*corev1.Pod) []*v1alpha1.PodMigrationJob {
+		scoreOfJob := map[*v1alpha1.PodMigrationJob]int64{}
+		availableReplicasOfOwners := map[types.UID]int{}
+		for _, job := range jobs {
+			pod := podOfJob[job]
+			if pod == nil {
+				// same as SortJobsByPod, the jobs without Pods are placed in front to fail fast
+				scoreOfJob[job] = math.MaxInt64
+				continue
+			}
+			var replicas int
+			if owner := metav1.GetControllerOf(pod); owner != nil {
+				num, ok := availableReplicasOfOwners[owner.UID]
+				if !ok {
+					num = availableReplicas(pod)
+					availableReplicasOfOwners[owner.UID] = num
+				}
+				replicas = num
+			}
+			scoreOfJob[job] = getDisruptionScore(pod, replicas, weights)
+		}
+
+		sort.SliceStable(jobs, func(i, j int) bool {
+			return scoreOfJob[jobs[i]] > scoreOfJob[jobs[j]]
+		})
+		return jobs
+	}
+}
+
+// getDisruptionScore returns the arbitration score of the Pod, a higher score means cheaper to disrupt.
+func getDisruptionScore(pod *corev1.Pod, availableReplicas int, weights *config.DisruptionCostWeights) int64 {
+	var restartCount int64
+	for _, status := range pod.Status.ContainerStatuses {
+		restartCount += int64(status.RestartCount)
+	}
+	evictionCost, _ := extension.GetEvictionCost(pod.Annotations)
+	return weights.AvailableReplicas*int64(availableReplicas) + weights.RestartCount*restartCount - weights.EvictionCost*int64(evictionCost)
+}
+
 func getMigratingJobNum(c client.Client, ownerUID types.UID) int {
 	opts := &client.ListOptions{FieldSelector: fields.OneTermEqualSelector(fieldindex.IndexPodByOwnerRefUID, string(ownerUID))}
 	podList := &corev1.PodList{}
@@ -41,6 +41,7 @@ import (
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	"github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
 )
 
@@ -102,6 +103,56 @@ func TestSortJobsByPod(t *testing.T) {
 	}
 }
 
+func TestSortJobsByPodWithEvictionAgePreference(t *testing.T) {
+	testCases := []struct {
+		name         string
+		preference   config.EvictionAgePreference
+		expectedJobs []string
+	}{
+		{
+			name:         "no preference",
+			preference:   config.EvictionAgePreferenceNone,
+			expectedJobs: []string{"job-new", "job-mid", "job-old", "job-high-priority"},
+		},
+		{
+			name:         "newest first",
+			preference:   config.EvictionAgePreferenceNewestFirst,
+			expectedJobs: []string{"job-new", "job-mid", "job-old", "job-high-priority"},
+		},
+		{
+			name:         "oldest first",
+			preference:   config.EvictionAgePreferenceOldestFirst,
+			expectedJobs: []string{"job-old", "job-mid", "job-new", "job-high-priority"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			now := time.Now()
+			pods := []*corev1.Pod{
+				makePod("pod-high-priority", 100, extension.QoSNone, corev1.PodQOSBestEffort, now.Add(-3*time.Hour)),
+				makePod("pod-mid", 0, extension.QoSNone, corev1.PodQOSBestEffort, now.Add(-time.Hour)),
+				makePod("pod-old", 0, extension.QoSNone, corev1.PodQOSBestEffort, now.Add(-2*time.Hour)),
+				makePod("pod-new", 0, extension.QoSNone, corev1.PodQOSBestEffort, now),
+			}
+			jobs := make([]*v1alpha1.PodMigrationJob, 0, len(pods))
+			podOfJob := map[*v1alpha1.PodMigrationJob]*corev1.Pod{}
+			for _, pod := range pods {
+				job := makePodMigrationJob(strings.Replace(pod.Name, "pod-", "job-", 1), now, pod)
+				jobs = append(jobs, job)
+				podOfJob[job] = pod
+			}
+
+			SortJobsByPod(newPodSorter(testCase.preference).Sort)(jobs, podOfJob)
+			jobsOrder := make([]string, 0, len(jobs))
+			for _, v := range jobs {
+				jobsOrder = append(jobsOrder, v.Name)
+			}
+			assert.Equal(t, testCase.expectedJobs, jobsOrder)
+		})
+	}
+}
+
 func TestSortJobsByCreationTime(t *testing.T) {
 	fakeJobs := []*v1alpha1.PodMigrationJob{
 		{
@@ -154,6 +205,94 @@ func TestSortJobsByCreationTime(t *testing.T) {
 	}
 }
 
+func TestSortJobsByDisruptionCost(t *testing.T) {
+	newPod := func(name string, ownerUID types.UID, restartCount int32, evictionCost string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: string(ownerUID), UID: ownerUID, Controller: pointer.Bool(true)},
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "main", RestartCount: restartCount}},
+			},
+		}
+		if evictionCost != "" {
+			pod.Annotations = map[string]string{extension.AnnotationEvictionCost: evictionCost}
+		}
+		return pod
+	}
+	// the pods of rs-wide have 10 available replicas, the pods of rs-narrow have 2
+	pods := map[string]*corev1.Pod{
+		"wide":    newPod("wide", "rs-wide", 0, ""),
+		"narrow":  newPod("narrow", "rs-narrow", 0, ""),
+		"restart": newPod("restart", "rs-narrow", 5, ""),
+		"costly":  newPod("costly", "rs-wide", 0, "100"),
+	}
+	availableReplicasOfOwners := map[types.UID]int{"rs-wide": 10, "rs-narrow": 2}
+
+	testCases := []struct {
+		name         string
+		weights      *config.DisruptionCostWeights
+		expectedJobs []string
+	}{
+		{
+			name:         "all the inputs weighted equally",
+			weights:      &config.DisruptionCostWeights{AvailableReplicas: 1, RestartCount: 1, EvictionCost: 1},
+			expectedJobs: []string{"job-without-pod", "job-wide", "job-restart", "job-narrow", "job-costly"},
+		},
+		{
+			name:         "only available replicas",
+			weights:      &config.DisruptionCostWeights{AvailableReplicas: 1},
+			expectedJobs: []string{"job-without-pod", "job-wide", "job-costly", "job-narrow", "job-restart"},
+		},
+		{
+			name:         "only restart count",
+			weights:      &config.DisruptionCostWeights{RestartCount: 1},
+			expectedJobs: []string{"job-without-pod", "job-restart", "job-wide", "job-narrow", "job-costly"},
+		},
+		{
+			name:         "only eviction cost",
+			weights:      &config.DisruptionCostWeights{EvictionCost: 1},
+			expectedJobs: []string{"job-without-pod", "job-wide", "job-narrow", "job-restart", "job-costly"},
+		},
+		{
+			name:         "restart count outweighs available replicas",
+			weights:      &config.DisruptionCostWeights{AvailableReplicas: 1, RestartCount: 10},
+			expectedJobs: []string{"job-without-pod", "job-restart", "job-wide", "job-costly", "job-narrow"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			podOfJob := map[*v1alpha1.PodMigrationJob]*corev1.Pod{}
+			var jobs []*v1alpha1.PodMigrationJob
+			for _, name := range []string{"wide", "narrow", "restart", "costly", "without-pod"} {
+				job := &v1alpha1.PodMigrationJob{ObjectMeta: metav1.ObjectMeta{Name: "job-" + name}}
+				jobs = append(jobs, job)
+				if pod := pods[name]; pod != nil {
+					podOfJob[job] = pod
+				}
+			}
+			calls := 0
+			fn := SortJobsByDisruptionCost(tc.weights, func(pod *corev1.Pod) int {
+				calls++
+				return availableReplicasOfOwners[metav1.GetControllerOf(pod).UID]
+			})
+			jobs = fn(jobs, podOfJob)
+
+			var names []string
+			for _, job := range jobs {
+				names = append(names, job.Name)
+			}
+			assert.Equal(t, tc.expectedJobs, names)
+			// the available replicas are resolved once per workload
+			assert.Equal(t, 2, calls)
+		})
+	}
+}
+
 func TestSortJobsByMigratingNum(t *testing.T) {
 	testCases := []struct {
 		name                            string
@@ -31,7 +31,9 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/klog/v2"
 	k8spodutil "k8s.io/kubernetes/pkg/api/v1/pod"
@@ -75,12 +77,14 @@ var _ framework.FilterPlugin = &Reconciler{}
 type Reconciler struct {
 	client.Client
 	args                   *deschedulerconfig.MigrationControllerArgs
+	allowedReasons         sets.String
 	eventRecorder          events.EventRecorder
 	reservationInterpreter reservation.Interpreter
 	evictorInterpreter     evictor.Interpreter
 	controllerFinder       controllerfinder.Interface
 	assumedCache           *assumedCache
 	clock                  clock.Clock
+	startTime              time.Time
 
 	arbitrator arbitrator.Arbitrator
 
@@ -100,6 +104,9 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 	if err := validation.ValidateMigrationControllerArgs(nil, controllerArgs); err != nil {
 		return nil, err
 	}
+	for _, warning := range validation.GetMigrationControllerArgsWarnings(field.NewPath("args"), controllerArgs) {
+		klog.Warningf("MigrationControllerArgs: %s", warning)
+	}
 
 	r, err := newReconciler(controllerArgs, handle)
 	if err != nil {
@@ -164,6 +171,10 @@ func newReconciler(args *deschedulerconfig.MigrationControllerArgs, handle frame
 		assumedCache:           newAssumedCache(),
 		clock:                  clock.RealClock{},
 	}
+	if len(args.AllowedReasons) > 0 {
+		r.allowedReasons = sets.NewString(args.AllowedReasons...)
+	}
+	r.startTime = r.clock.Now()
 	r.initObjectLimiters()
 	if err := manager.Add(r); err != nil {
 		return nil, err
@@ -286,6 +297,11 @@ func (r *Reconciler) doMigrate(ctx context.Context, job *sev1alpha1.PodMigration
 		return reconcile.Result{}, nil
 	}
 
+	if !r.isReasonAllowed(job) {
+		klog.V(4).Infof("MigrationJob %s is skipped since its reason is not allowed", job.Name)
+		return reconcile.Result{}, nil
+	}
+
 	if job.Status.Phase != "" &&
 		job.Status.Phase != sev1alpha1.PodMigrationJobPending &&
 		job.Status.Phase != sev1alpha1.PodMigrationJobRunning {
@@ -817,6 +833,11 @@ func (r *Reconciler) evictPod(ctx context.Context, job *sev1alpha1.PodMigrationJ
 		return false, reconcile.Result{}, err
 	}
 
+	if remaining := r.startupWarmupRemaining(); remaining > 0 {
+		klog.V(4).Infof("MigrationJob %s defers evicting Pod %q until the startup warmup elapses, remaining %v", job.Name, podNamespacedName, remaining)
+		return false, reconcile.Result{RequeueAfter: remaining}, nil
+	}
+
 	if job.Spec.DeleteOptions == nil {
 		job.Spec.DeleteOptions = r.args.DefaultDeleteOptions
 	}
@@ -841,6 +862,23 @@ func (r *Reconciler) evictPod(ctx context.Context, job *sev1alpha1.PodMigrationJ
 	return false, reconcile.Result{RequeueAfter: defaultRequeueAfter}, err
 }
 
+// startupWarmupRemaining returns the remaining duration of the startup warmup, during which no Pod is evicted.
+// isReasonAllowed checks if the eviction reason or trigger of the job is in the AllowedReasons.
+func (r *Reconciler) isReasonAllowed(job *sev1alpha1.PodMigrationJob) bool {
+	if r.allowedReasons == nil {
+		return true
+	}
+	trigger, reason := evictor.GetEvictionTriggerAndReason(job.Annotations)
+	return r.allowedReasons.Has(reason) || (trigger != "" && r.allowedReasons.Has(trigger))
+}
+
+func (r *Reconciler) startupWarmupRemaining() time.Duration {
+	if r.args.StartupWarmup == nil || r.args.StartupWarmup.Duration <= 0 {
+		return 0
+	}
+	return r.args.StartupWarmup.Duration - r.clock.Since(r.startTime)
+}
+
 func (r *Reconciler) prepareJobWithReservationScheduleSuccess(ctx context.Context, job *sev1alpha1.PodMigrationJob, reservationObj reservation.Object) error {
 	scheduledNodeName := reservationObj.GetScheduledNodeName()
 	if scheduledNodeName == "" || job.Status.NodeName != "" {
@@ -957,6 +995,17 @@ func (r *Reconciler) createReservation(ctx context.Context, job *sev1alpha1.PodM
 	}
 
 	reservationOptions := reservation.CreateOrUpdateReservationOptions(job, pod)
+	if r.args.NodePoolLabelKey != "" && pod.Spec.NodeName != "" {
+		node := &corev1.Node{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+			klog.Errorf("Failed to get node %s of Pod %s for MigrationJob %s, err: %v", pod.Spec.NodeName, klog.KObj(pod), job.Name, err)
+			return err
+		}
+		reservation.AppendNodePoolAffinity(node, r.args.NodePoolLabelKey, reservationOptions)
+	}
+	if nodeName := job.Annotations[AnnotationDestinationNodeHint]; nodeName != "" {
+		reservation.AppendPreferredNodeAffinity(nodeName, reservationOptions)
+	}
 	job.Spec.ReservationOptions = reservationOptions
 
 	reservationObj, err := r.reservationInterpreter.CreateReservation(ctx, job)
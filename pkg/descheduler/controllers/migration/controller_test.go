@@ -31,9 +31,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	corev1helper "k8s.io/component-helpers/scheduling/corev1"
 	"k8s.io/utils/clock"
 	fakceclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
@@ -45,6 +47,7 @@ import (
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/v1alpha2"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/evictor"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/reservation"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
 	evictionsutil "github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
@@ -59,6 +62,15 @@ func (f fakeEvictionInterpreter) Evict(ctx context.Context, job *sev1alpha1.PodM
 	return f.err
 }
 
+type countingEvictionInterpreter struct {
+	evicted *int
+}
+
+func (f countingEvictionInterpreter) Evict(ctx context.Context, job *sev1alpha1.PodMigrationJob, pod *corev1.Pod) error {
+	*f.evicted++
+	return nil
+}
+
 type fakeReservationInterpreter struct {
 	createErr   error
 	getErr      error
@@ -662,6 +674,77 @@ func TestCreateReservation(t *testing.T) {
 	assert.Equal(t, expectReservationRef, job.Spec.ReservationOptions.ReservationRef)
 }
 
+func TestCreateReservationWithNodePool(t *testing.T) {
+	reconciler := newTestReconciler()
+	reconciler.args.NodePoolLabelKey = "node-pool"
+
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: "default",
+				Name:      "test-pod",
+			},
+		},
+	}
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), job))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+		},
+		Spec: corev1.PodSpec{
+			SchedulerName: "koord-scheduler",
+			NodeName:      "test-node",
+		},
+	}
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), pod))
+	reconciler.reservationInterpreter = fakeReservationInterpreter{
+		reservation: &sev1alpha1.Reservation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-reservation",
+				UID:  uuid.NewUUID(),
+			},
+		},
+	}
+
+	// the node of the pod is missing
+	assert.NotNil(t, reconciler.createReservation(context.TODO(), job))
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-node",
+			Labels: map[string]string{"node-pool": "pool-a"},
+		},
+	}
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), node))
+	job.Spec.ReservationOptions = nil
+	assert.Nil(t, reconciler.createReservation(context.TODO(), job))
+
+	nodeSelector := job.Spec.ReservationOptions.Template.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	assert.Len(t, nodeSelector.NodeSelectorTerms, 1)
+	assert.Equal(t, []corev1.NodeSelectorRequirement{
+		{
+			Key:      "node-pool",
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{"pool-a"},
+		},
+	}, nodeSelector.NodeSelectorTerms[0].MatchExpressions)
+	// nodes in other pools can not match the reservation
+	otherNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-other-node",
+			Labels: map[string]string{"node-pool": "pool-b"},
+		},
+	}
+	matched, err := corev1helper.MatchNodeSelectorTerms(otherNode, nodeSelector)
+	assert.NoError(t, err)
+	assert.False(t, matched)
+}
+
 func TestWaitForPendingPodScheduled(t *testing.T) {
 	reconciler := newTestReconciler()
 
@@ -965,6 +1048,146 @@ func TestMigrateWithSamePodName(t *testing.T) {
 	assert.Equal(t, "Complete", job.Status.Status)
 }
 
+func TestEvictPodDuringStartupWarmup(t *testing.T) {
+	reconciler := newTestReconciler()
+	fakeClock := fakceclock.NewFakeClock(time.Now())
+	reconciler.clock = fakeClock
+	reconciler.startTime = fakeClock.Now()
+	reconciler.args.StartupWarmup = &metav1.Duration{Duration: time.Minute}
+	evicted := 0
+	reconciler.evictorInterpreter = countingEvictionInterpreter{evicted: &evicted}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+		},
+	}
+	assert.NoError(t, reconciler.Create(context.TODO(), pod))
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			CreationTimestamp: metav1.Time{Time: fakeClock.Now()},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+			},
+		},
+	}
+	assert.NoError(t, reconciler.Create(context.TODO(), job))
+
+	fakeClock.Step(20 * time.Second)
+	complete, result, err := reconciler.evictPod(context.TODO(), job)
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, reconcile.Result{RequeueAfter: 40 * time.Second}, result)
+	assert.Equal(t, 0, evicted)
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionEviction)
+	assert.Nil(t, cond)
+
+	fakeClock.Step(40 * time.Second)
+	complete, result, err = reconciler.evictPod(context.TODO(), job)
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, reconcile.Result{RequeueAfter: defaultRequeueAfter}, result)
+	assert.Equal(t, 1, evicted)
+}
+
+func TestIsReasonAllowed(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowedReasons []string
+		annotations    map[string]string
+		want           bool
+	}{
+		{
+			name: "no allowlist",
+			annotations: map[string]string{
+				evictor.AnnotationEvictReason: "auto",
+			},
+			want: true,
+		},
+		{
+			name:           "allowed reason",
+			allowedReasons: []string{"manual"},
+			annotations: map[string]string{
+				evictor.AnnotationEvictReason: "manual",
+			},
+			want: true,
+		},
+		{
+			name:           "allowed trigger",
+			allowedReasons: []string{"LowNodeLoad"},
+			annotations: map[string]string{
+				evictor.AnnotationEvictReason:  "node is overutilized",
+				evictor.AnnotationEvictTrigger: "LowNodeLoad",
+			},
+			want: true,
+		},
+		{
+			name:           "disallowed reason",
+			allowedReasons: []string{"manual"},
+			annotations: map[string]string{
+				evictor.AnnotationEvictReason:  "auto",
+				evictor.AnnotationEvictTrigger: "LowNodeLoad",
+			},
+			want: false,
+		},
+		{
+			name:           "reason not set",
+			allowedReasons: []string{"manual"},
+			want:           false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := newTestReconciler()
+			if len(tt.allowedReasons) > 0 {
+				reconciler.allowedReasons = sets.NewString(tt.allowedReasons...)
+			}
+			job := &sev1alpha1.PodMigrationJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: tt.annotations,
+				},
+			}
+			assert.Equal(t, tt.want, reconciler.isReasonAllowed(job))
+		})
+	}
+}
+
+func TestMigrateWithDisallowedReason(t *testing.T) {
+	reconciler := newTestReconciler()
+	reconciler.allowedReasons = sets.NewString("manual")
+
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+			Annotations: map[string]string{
+				evictor.AnnotationEvictReason: "auto",
+			},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: "default",
+				Name:      "test-pod",
+			},
+		},
+	}
+	assert.NoError(t, reconciler.Create(context.TODO(), job))
+
+	result, err := reconciler.doMigrate(context.TODO(), job)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+
+	got := &sev1alpha1.PodMigrationJob{}
+	assert.NoError(t, reconciler.Get(context.TODO(), types.NamespacedName{Name: job.Name}, got))
+	assert.Equal(t, sev1alpha1.PodMigrationJobPhase(""), got.Status.Phase)
+}
+
 func TestMigrateWhenEvictingWithSucceededReservation(t *testing.T) {
 	reconciler := newTestReconciler()
 	reconciler.evictorInterpreter = fakeEvictionInterpreter{}
@@ -1433,6 +1656,36 @@ func TestEvict(t *testing.T) {
 	assert.Equal(t, expectPodRef, jobList.Items[0].Spec.PodRef)
 }
 
+func TestEvictWithDestinationHint(t *testing.T) {
+	reconciler := newTestReconciler()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-pod",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Controller: pointer.Bool(true),
+					Kind:       "Deployment",
+					Name:       "test",
+				},
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:      "test-node-1",
+			SchedulerName: "koord-scheduler",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+
+	assert.True(t, reconciler.Evict(context.TODO(), pod, framework.EvictOptions{DestinationHint: "test-node-2"}))
+	var jobList sev1alpha1.PodMigrationJobList
+	assert.NoError(t, reconciler.Client.List(context.TODO(), &jobList))
+	assert.Equal(t, 1, len(jobList.Items))
+	assert.Equal(t, "test-node-2", jobList.Items[0].Annotations[AnnotationDestinationNodeHint])
+}
+
 func TestAbortJobIfReserveOnSameNode(t *testing.T) {
 	reconciler := newTestReconciler()
 	pod := &corev1.Pod{
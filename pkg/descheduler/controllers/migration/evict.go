@@ -33,6 +33,9 @@ import (
 
 const (
 	AnnotationJobCreatedBy = "koordinator.sh/job-created-by"
+	// AnnotationDestinationNodeHint is the node the descheduler expects the pod to be rescheduled to,
+	// the Reservation of the job prefers the node but is not bound to it.
+	AnnotationDestinationNodeHint = "koordinator.sh/destination-node-hint"
 )
 
 // Evict evicts a pod
@@ -85,6 +88,9 @@ func CreatePodMigrationJob(ctx context.Context, pod *corev1.Pod, evictOptions fr
 			Phase: sev1alpha1.PodMigrationJobPending,
 		},
 	}
+	if evictOptions.DestinationHint != "" {
+		job.Annotations[AnnotationDestinationNodeHint] = evictOptions.DestinationHint
+	}
 
 	jobCtx := FromContext(ctx)
 	if err := jobCtx.ApplyTo(job); err != nil {
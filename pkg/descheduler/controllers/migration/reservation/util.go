@@ -144,6 +144,80 @@ func appendSkipNodeAffinity(pod *corev1.Pod, reservationOptions *sev1alpha1.PodM
 	}
 }
 
+// AppendNodePoolAffinity restricts the reservation to the nodes in the same node pool as the given node.
+// If the node does not belong to any node pool, the reservation can only be scheduled to nodes without the node pool label.
+func AppendNodePoolAffinity(node *corev1.Node, nodePoolLabelKey string, reservationOptions *sev1alpha1.PodMigrateReservationOptions) {
+	if node == nil || nodePoolLabelKey == "" {
+		return
+	}
+
+	affinity := reservationOptions.Template.Spec.Template.Spec.Affinity
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+		reservationOptions.Template.Spec.Template.Spec.Affinity = affinity
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+
+	nodePoolRequirement := corev1.NodeSelectorRequirement{
+		Key:      nodePoolLabelKey,
+		Operator: corev1.NodeSelectorOpDoesNotExist,
+	}
+	if nodePool, ok := node.Labels[nodePoolLabelKey]; ok {
+		nodePoolRequirement.Operator = corev1.NodeSelectorOpIn
+		nodePoolRequirement.Values = []string{nodePool}
+	}
+
+	nodeSelector := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	for i := range nodeSelector.NodeSelectorTerms {
+		term := &nodeSelector.NodeSelectorTerms[i]
+		term.MatchExpressions = append(term.MatchExpressions, nodePoolRequirement)
+	}
+	if len(nodeSelector.NodeSelectorTerms) == 0 {
+		nodeSelector.NodeSelectorTerms = []corev1.NodeSelectorTerm{
+			{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					nodePoolRequirement,
+				},
+			},
+		}
+	}
+}
+
+// AppendPreferredNodeAffinity makes the reservation prefer the given node. The preference is soft so that
+// the reservation can still be scheduled to other nodes if the node is not suitable anymore.
+func AppendPreferredNodeAffinity(nodeName string, reservationOptions *sev1alpha1.PodMigrateReservationOptions) {
+	if nodeName == "" {
+		return
+	}
+
+	affinity := reservationOptions.Template.Spec.Template.Spec.Affinity
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+		reservationOptions.Template.Spec.Template.Spec.Affinity = affinity
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.PreferredSchedulingTerm{
+			Weight: 100,
+			Preference: corev1.NodeSelectorTerm{
+				MatchFields: []corev1.NodeSelectorRequirement{
+					{
+						Key:      "metadata.name",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{nodeName},
+					},
+				},
+			},
+		})
+}
+
 func GenerateReserveResourceOwners(pod *corev1.Pod) []sev1alpha1.ReservationOwner {
 	if pod.Status.Phase == corev1.PodPending {
 		_, condition := podutil.GetPodCondition(&pod.Status, corev1.PodScheduled)
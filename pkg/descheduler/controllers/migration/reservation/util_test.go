@@ -375,3 +375,235 @@ func TestCreateOrUpdateReservationOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendNodePoolAffinity(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     *corev1.Node
+		affinity *corev1.Affinity
+		want     *corev1.Affinity
+	}{
+		{
+			name: "node in pool",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-node",
+					Labels: map[string]string{"node-pool": "pool-a"},
+				},
+			},
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{
+										Key:      "node-pool",
+										Operator: corev1.NodeSelectorOpIn,
+										Values:   []string{"pool-a"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "node not in any pool",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node",
+				},
+			},
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{
+										Key:      "node-pool",
+										Operator: corev1.NodeSelectorOpDoesNotExist,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "node in pool with existing node affinity",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-node",
+					Labels: map[string]string{"node-pool": "pool-a"},
+				},
+			},
+			affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{
+										Key:      "test",
+										Operator: corev1.NodeSelectorOpIn,
+										Values:   []string{"xxxx"},
+									},
+								},
+							},
+							{
+								MatchFields: []corev1.NodeSelectorRequirement{
+									{
+										Key:      "metadata.name",
+										Operator: corev1.NodeSelectorOpNotIn,
+										Values:   []string{"test-node"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{
+										Key:      "test",
+										Operator: corev1.NodeSelectorOpIn,
+										Values:   []string{"xxxx"},
+									},
+									{
+										Key:      "node-pool",
+										Operator: corev1.NodeSelectorOpIn,
+										Values:   []string{"pool-a"},
+									},
+								},
+							},
+							{
+								MatchFields: []corev1.NodeSelectorRequirement{
+									{
+										Key:      "metadata.name",
+										Operator: corev1.NodeSelectorOpNotIn,
+										Values:   []string{"test-node"},
+									},
+								},
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{
+										Key:      "node-pool",
+										Operator: corev1.NodeSelectorOpIn,
+										Values:   []string{"pool-a"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationOptions := &sev1alpha1.PodMigrateReservationOptions{
+				Template: &sev1alpha1.ReservationTemplateSpec{
+					Spec: sev1alpha1.ReservationSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Affinity: tt.affinity,
+							},
+						},
+					},
+				},
+			}
+			AppendNodePoolAffinity(tt.node, "node-pool", reservationOptions)
+			assert.Equal(t, tt.want, reservationOptions.Template.Spec.Template.Spec.Affinity)
+		})
+	}
+}
+
+func TestAppendPreferredNodeAffinity(t *testing.T) {
+	preferNode := corev1.PreferredSchedulingTerm{
+		Weight: 100,
+		Preference: corev1.NodeSelectorTerm{
+			MatchFields: []corev1.NodeSelectorRequirement{
+				{
+					Key:      "metadata.name",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"test-node"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		nodeName string
+		affinity *corev1.Affinity
+		want     *corev1.Affinity
+	}{
+		{
+			name: "no hint",
+		},
+		{
+			name:     "prefer the hinted node",
+			nodeName: "test-node",
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{preferNode},
+				},
+			},
+		},
+		{
+			name:     "keep the required affinity",
+			nodeName: "test-node",
+			affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{Key: "node-pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"pool-a"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{Key: "node-pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"pool-a"}},
+								},
+							},
+						},
+					},
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{preferNode},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservationOptions := &sev1alpha1.PodMigrateReservationOptions{
+				Template: &sev1alpha1.ReservationTemplateSpec{
+					Spec: sev1alpha1.ReservationSpec{
+						Template: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Affinity: tt.affinity,
+							},
+						},
+					},
+				},
+			}
+			AppendPreferredNodeAffinity(tt.nodeName, reservationOptions)
+			assert.Equal(t, tt.want, reservationOptions.Template.Spec.Template.Spec.Affinity)
+		})
+	}
+}
@@ -17,16 +17,19 @@ limitations under the License.
 package util
 
 import (
+	"fmt"
 	"math"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/reservation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
 )
 
 func GetCondition(status *sev1alpha1.PodMigrationJobStatus, conditionType sev1alpha1.PodMigrationJobConditionType) (int, *sev1alpha1.PodMigrationJobCondition) {
@@ -78,6 +81,78 @@ func IsMigratePendingPod(reservationObj reservation.Object) bool {
 	return pending
 }
 
+// IsPodMigrationJobActive returns true if the PodMigrationJob is pending or running,
+// a job without phase is pending as it has not been reconciled yet.
+func IsPodMigrationJobActive(job *sev1alpha1.PodMigrationJob) bool {
+	switch job.Status.Phase {
+	case "", sev1alpha1.PodMigrationJobPending, sev1alpha1.PodMigrationJobRunning:
+		return true
+	}
+	return false
+}
+
+// IsPodMigrationJobOfPod returns true if the PodMigrationJob migrates the pod,
+// the job is matched by the pod UID, or by the namespaced name if the UID is not specified.
+func IsPodMigrationJobOfPod(job *sev1alpha1.PodMigrationJob, pod *corev1.Pod) bool {
+	podRef := job.Spec.PodRef
+	if podRef == nil {
+		return false
+	}
+	if podRef.UID != "" && pod.UID != "" {
+		return podRef.UID == pod.UID
+	}
+	return podRef.Namespace == pod.Namespace && podRef.Name == pod.Name
+}
+
+// PodMigrationJobIndexers returns the indexers to look up the PodMigrationJobs of a pod,
+// they must be added to the PodMigrationJob informer before HasActivePodMigrationJob is called.
+func PodMigrationJobIndexers() cache.Indexers {
+	return cache.Indexers{
+		fieldindex.IndexJobByPodUID: func(obj interface{}) ([]string, error) {
+			job, ok := obj.(*sev1alpha1.PodMigrationJob)
+			if !ok || job.Spec.PodRef == nil || job.Spec.PodRef.UID == "" {
+				return []string{}, nil
+			}
+			return []string{string(job.Spec.PodRef.UID)}, nil
+		},
+		fieldindex.IndexJobPodNamespacedName: func(obj interface{}) ([]string, error) {
+			job, ok := obj.(*sev1alpha1.PodMigrationJob)
+			if !ok || job.Spec.PodRef == nil {
+				return []string{}, nil
+			}
+			return []string{fmt.Sprintf("%s/%s", job.Spec.PodRef.Namespace, job.Spec.PodRef.Name)}, nil
+		},
+	}
+}
+
+// HasActivePodMigrationJob checks if the pod is being migrated by an active PodMigrationJob.
+// The descheduling plugins evicting pods directly should skip such pods, otherwise the pods may be evicted twice.
+// The indexer must have the PodMigrationJobIndexers.
+func HasActivePodMigrationJob(indexer cache.Indexer, pod *corev1.Pod) bool {
+	var jobs []interface{}
+	if pod.UID != "" {
+		objs, err := indexer.ByIndex(fieldindex.IndexJobByPodUID, string(pod.UID))
+		if err != nil {
+			klog.ErrorS(err, "Failed to get PodMigrationJobs by pod UID", "pod", klog.KObj(pod))
+			return false
+		}
+		jobs = append(jobs, objs...)
+	}
+	objs, err := indexer.ByIndex(fieldindex.IndexJobPodNamespacedName, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+	if err != nil {
+		klog.ErrorS(err, "Failed to get PodMigrationJobs by pod namespaced name", "pod", klog.KObj(pod))
+		return false
+	}
+	jobs = append(jobs, objs...)
+	for _, obj := range jobs {
+		job, ok := obj.(*sev1alpha1.PodMigrationJob)
+		if ok && IsPodMigrationJobActive(job) && IsPodMigrationJobOfPod(job, pod) {
+			return true
+		}
+	}
+	return false
+}
+
 func GetMaxUnavailable(replicas int, intOrPercent *intstr.IntOrString) (int, error) {
 	var maxUnavailable int
 	var err error
@@ -26,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
@@ -200,6 +201,75 @@ func TestIsMigratePendingPod(t *testing.T) {
 	assert.True(t, IsMigratePendingPod(reservationObj))
 }
 
+func TestHasActivePodMigrationJob(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test", UID: "test-uid"},
+	}
+	tests := []struct {
+		name string
+		job  *sev1alpha1.PodMigrationJob
+		want bool
+	}{
+		{
+			name: "no job",
+		},
+		{
+			name: "running job of the pod",
+			job: &sev1alpha1.PodMigrationJob{
+				Spec:   sev1alpha1.PodMigrationJobSpec{PodRef: &corev1.ObjectReference{Namespace: "default", Name: "test", UID: "test-uid"}},
+				Status: sev1alpha1.PodMigrationJobStatus{Phase: sev1alpha1.PodMigrationJobRunning},
+			},
+			want: true,
+		},
+		{
+			name: "running job of the pod matched by UID only",
+			job: &sev1alpha1.PodMigrationJob{
+				Spec:   sev1alpha1.PodMigrationJobSpec{PodRef: &corev1.ObjectReference{UID: "test-uid"}},
+				Status: sev1alpha1.PodMigrationJobStatus{Phase: sev1alpha1.PodMigrationJobRunning},
+			},
+			want: true,
+		},
+		{
+			name: "running job of another pod",
+			job: &sev1alpha1.PodMigrationJob{
+				Spec:   sev1alpha1.PodMigrationJobSpec{PodRef: &corev1.ObjectReference{Namespace: "default", Name: "other", UID: "other-uid"}},
+				Status: sev1alpha1.PodMigrationJobStatus{Phase: sev1alpha1.PodMigrationJobRunning},
+			},
+		},
+		{
+			name: "unreconciled job of the pod matched by name",
+			job: &sev1alpha1.PodMigrationJob{
+				Spec: sev1alpha1.PodMigrationJobSpec{PodRef: &corev1.ObjectReference{Namespace: "default", Name: "test"}},
+			},
+			want: true,
+		},
+		{
+			name: "succeeded job of the pod",
+			job: &sev1alpha1.PodMigrationJob{
+				Spec:   sev1alpha1.PodMigrationJobSpec{PodRef: &corev1.ObjectReference{Namespace: "default", Name: "test", UID: "test-uid"}},
+				Status: sev1alpha1.PodMigrationJobStatus{Phase: sev1alpha1.PodMigrationJobSucceeded},
+			},
+		},
+		{
+			name: "pending job of a recreated pod with the same name",
+			job: &sev1alpha1.PodMigrationJob{
+				Spec:   sev1alpha1.PodMigrationJobSpec{PodRef: &corev1.ObjectReference{Namespace: "default", Name: "test", UID: "old-uid"}},
+				Status: sev1alpha1.PodMigrationJobStatus{Phase: sev1alpha1.PodMigrationJobPending},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, PodMigrationJobIndexers())
+			if tt.job != nil {
+				tt.job.Name = "test-job"
+				assert.NoError(t, indexer.Add(tt.job))
+			}
+			assert.Equal(t, tt.want, HasActivePodMigrationJob(indexer, pod))
+		})
+	}
+}
+
 func TestFilterPodWithMaxEvictionCost(t *testing.T) {
 	tests := []struct {
 		name string
@@ -20,8 +20,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -32,7 +38,9 @@ import (
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/scheme"
@@ -48,6 +56,8 @@ import (
 type Descheduler struct {
 	// Profiles are the descheduling profiles.
 	Profiles profile.Map
+	// profileNames are the names of the Profiles in the config order, in which the profiles are launched.
+	profileNames []string
 
 	// Close this to shut down the scheduler.
 	StopEverything <-chan struct{}
@@ -55,9 +65,29 @@ type Descheduler struct {
 	clientSet    clientset.Interface
 	nodeInformer corev1informers.NodeInformer
 
-	deschedulingInterval time.Duration
-	nodeSelector         string
-	evictionLimiter      frameworkruntime.EvictionLimiter
+	deschedulingInterval  time.Duration
+	startupGracePeriod    time.Duration
+	observationPeriod     time.Duration
+	maxConcurrentProfiles int
+	maxCycleDuration      time.Duration
+	nodeSelector          string
+	evictionLimiter       frameworkruntime.EvictionLimiter
+	clock                 clock.Clock
+	tracer                trace.Tracer
+
+	// extensionPointOrders are the ExtensionPointOrder of the profiles indexed by the profile name.
+	extensionPointOrders map[string][]string
+
+	// carriedOverNodes are the nodes left unprocessed by the last truncated cycle,
+	// which are processed first in the next cycle.
+	carriedOverNodes sets.String
+
+	// observeUntil is the end of the initial observation period, before which the cycles run in dry-run mode.
+	observeUntil time.Time
+
+	// paused is set by Pause and cleared by Resume. The descheduling cycles are skipped while it is set.
+	// It is kept in memory only, so it is lost once the process restarts, e.g. after losing the leadership.
+	paused atomic.Bool
 }
 
 type deschedulerOptions struct {
@@ -69,9 +99,19 @@ type deschedulerOptions struct {
 	profiles               []deschedulerconfig.DeschedulerProfile
 	applyDefaultProfile    bool
 	dryRun                 bool
+	disabled               bool
+	evictOrphanPods        bool
+	countTerminatingPods   bool
+	evictCriticalPods      bool
 	deschedulingInterval   time.Duration
+	startupGracePeriod     time.Duration
+	observationPeriod      time.Duration
+	maxConcurrentProfiles  int
+	maxCycleDuration       time.Duration
 	nodeSelector           *metav1.LabelSelector
 	evictionLimiter        frameworkruntime.EvictionLimiter
+	evictionEventRate      int32
+	tracerProvider         trace.TracerProvider
 }
 
 // Option configures a Scheduler
@@ -106,6 +146,35 @@ func WithDryRun(dryRun bool) Option {
 	}
 }
 
+// WithDisabled disables all profiles, which only collect the candidates in dry-run mode.
+// It takes precedence over the profile's Disabled.
+func WithDisabled(disabled bool) Option {
+	return func(options *deschedulerOptions) {
+		options.disabled = disabled
+	}
+}
+
+// WithEvictOrphanPods allows the pods without any ownerReferences to be evicted.
+func WithEvictOrphanPods(evictOrphanPods bool) Option {
+	return func(options *deschedulerOptions) {
+		options.evictOrphanPods = evictOrphanPods
+	}
+}
+
+// WithEvictSystemCriticalPriorityClassPods allows the pods with the system critical priority classes to be evicted.
+func WithEvictSystemCriticalPriorityClassPods(evictCriticalPods bool) Option {
+	return func(options *deschedulerOptions) {
+		options.evictCriticalPods = evictCriticalPods
+	}
+}
+
+// WithCountTerminatingPods allows the terminating pods to be evicted and counted against the eviction limits.
+func WithCountTerminatingPods(countTerminatingPods bool) Option {
+	return func(options *deschedulerOptions) {
+		options.countTerminatingPods = countTerminatingPods
+	}
+}
+
 func WithNodeSelector(nodeSelector *metav1.LabelSelector) Option {
 	return func(options *deschedulerOptions) {
 		options.nodeSelector = nodeSelector
@@ -118,6 +187,37 @@ func WithDeschedulingInterval(interval time.Duration) Option {
 	}
 }
 
+// WithStartupGracePeriod sets the duration to wait for fresh metrics before the first descheduling
+// after the descheduler started, e.g. a new leader elected.
+func WithStartupGracePeriod(gracePeriod time.Duration) Option {
+	return func(options *deschedulerOptions) {
+		options.startupGracePeriod = gracePeriod
+	}
+}
+
+// WithInitialObservationPeriod sets the duration after the startup grace period during which the descheduling
+// cycles run in dry-run mode, to avoid evicting pods based on the decisions made on cold caches.
+func WithInitialObservationPeriod(observationPeriod time.Duration) Option {
+	return func(options *deschedulerOptions) {
+		options.observationPeriod = observationPeriod
+	}
+}
+
+// WithMaxConcurrentProfiles sets the maximum number of profiles running at the same time.
+func WithMaxConcurrentProfiles(maxConcurrentProfiles int) Option {
+	return func(options *deschedulerOptions) {
+		options.maxConcurrentProfiles = maxConcurrentProfiles
+	}
+}
+
+// WithMaxCycleDuration sets the maximum duration of a descheduling cycle. Once it is exceeded, the cycle
+// stops after finishing the current node, and the unprocessed nodes are carried over to the next cycle.
+func WithMaxCycleDuration(maxCycleDuration time.Duration) Option {
+	return func(options *deschedulerOptions) {
+		options.maxCycleDuration = maxCycleDuration
+	}
+}
+
 // WithFrameworkOutOfTreeRegistry sets the registry for out-of-tree plugins. Those plugins
 // will be appended to the default registry.
 func WithFrameworkOutOfTreeRegistry(registry frameworkruntime.Registry) Option {
@@ -150,8 +250,25 @@ func WithEvictionLimiter(limiter frameworkruntime.EvictionLimiter) Option {
 	}
 }
 
+// WithEvictionEventRate sets the maximum number of Events per minute emitted on the evicted pods.
+// Zero disables the events.
+func WithEvictionEventRate(rate int32) Option {
+	return func(o *deschedulerOptions) {
+		o.evictionEventRate = rate
+	}
+}
+
+// WithTracerProvider sets the TracerProvider used to trace descheduling cycles.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(options *deschedulerOptions) {
+		options.tracerProvider = tp
+	}
+}
+
 var defaultDeschedulerOptions = deschedulerOptions{
-	applyDefaultProfile: true,
+	applyDefaultProfile:   true,
+	maxConcurrentProfiles: 1,
+	tracerProvider:        trace.NewNoopTracerProvider(),
 }
 
 func New(client clientset.Interface,
@@ -181,6 +298,16 @@ func New(client clientset.Interface,
 		options.profiles = cfg.Profiles
 	}
 
+	if options.disabled {
+		klog.Infof("Descheduler is disabled, all profiles run in dry-run mode")
+		profiles := make([]deschedulerconfig.DeschedulerProfile, 0, len(options.profiles))
+		for _, p := range options.profiles {
+			p.Disabled = true
+			profiles = append(profiles, p)
+		}
+		options.profiles = profiles
+	}
+
 	var nodeSelector string
 	if options.nodeSelector != nil {
 		selector, err := metav1.LabelSelectorAsSelector(options.nodeSelector)
@@ -208,15 +335,24 @@ func New(client clientset.Interface,
 
 	metrics.Register()
 
+	var evictionEventLimiter flowcontrol.RateLimiter
+	if options.evictionEventRate > 0 {
+		evictionEventLimiter = flowcontrol.NewTokenBucketRateLimiter(float32(options.evictionEventRate)/60, int(options.evictionEventRate))
+	}
+
 	profiles, err := profile.NewMap(
 		options.profiles,
 		registry,
 		recorderFactory,
 		frameworkruntime.WithDryRun(options.dryRun),
+		frameworkruntime.WithEvictOrphanPods(options.evictOrphanPods),
+		frameworkruntime.WithCountTerminatingPods(options.countTerminatingPods),
+		frameworkruntime.WithEvictSystemCriticalPriorityClassPods(options.evictCriticalPods),
 		frameworkruntime.WithClientSet(client),
 		frameworkruntime.WithKubeConfig(options.kubeConfig),
 		frameworkruntime.WithSharedInformerFactory(informerFactory),
 		frameworkruntime.WithEvictionLimiter(options.evictionLimiter),
+		frameworkruntime.WithEvictionEventLimiter(evictionEventLimiter),
 		frameworkruntime.WithGetPodsAssignedToNodeFunc(podAssignedToNodeAdaptor(options.podAssignedToNodeFn)),
 		frameworkruntime.WithCaptureProfile(frameworkruntime.CaptureProfile(options.frameworkCapturer)),
 	)
@@ -228,14 +364,29 @@ func New(client clientset.Interface,
 		return nil, errors.New("at least one profile is required")
 	}
 
+	profileNames := make([]string, 0, len(options.profiles))
+	extensionPointOrders := make(map[string][]string, len(options.profiles))
+	for _, p := range options.profiles {
+		profileNames = append(profileNames, p.Name)
+		extensionPointOrders[p.Name] = p.ExtensionPointOrder
+	}
+
 	descheduler := &Descheduler{
-		Profiles:             profiles,
-		StopEverything:       stopEverything,
-		clientSet:            client,
-		nodeInformer:         nodeInformer,
-		deschedulingInterval: options.deschedulingInterval,
-		nodeSelector:         nodeSelector,
-		evictionLimiter:      options.evictionLimiter,
+		Profiles:              profiles,
+		profileNames:          profileNames,
+		StopEverything:        stopEverything,
+		clientSet:             client,
+		nodeInformer:          nodeInformer,
+		deschedulingInterval:  options.deschedulingInterval,
+		startupGracePeriod:    options.startupGracePeriod,
+		observationPeriod:     options.observationPeriod,
+		maxConcurrentProfiles: options.maxConcurrentProfiles,
+		maxCycleDuration:      options.maxCycleDuration,
+		nodeSelector:          nodeSelector,
+		evictionLimiter:       options.evictionLimiter,
+		clock:                 clock.RealClock{},
+		tracer:                options.tracerProvider.Tracer(frameworkruntime.TracerName),
+		extensionPointOrders:  extensionPointOrders,
 	}
 	return descheduler, nil
 }
@@ -244,6 +395,23 @@ func (d *Descheduler) Start(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	d.checkNodeSelector(ctx, true)
+
+	// A freshly started descheduler (e.g. after a leader failover) waits for the grace period
+	// to collect fresh metrics, otherwise it may evict pods based on stale data.
+	if d.startupGracePeriod > 0 {
+		klog.Infof("Descheduler waits %v for startup grace period before descheduling", d.startupGracePeriod)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-d.clock.After(d.startupGracePeriod):
+		}
+	}
+	if d.observationPeriod > 0 {
+		klog.Infof("Descheduler runs in dry-run mode for initial observation period %v", d.observationPeriod)
+		d.observeUntil = d.clock.Now().Add(d.observationPeriod)
+	}
+
 	wait.NonSlidingUntil(func() {
 		if err := d.deschedulerOnce(ctx); err != nil {
 			klog.Errorf("Error descheduling pods: %v", err)
@@ -258,43 +426,249 @@ func (d *Descheduler) Start(ctx context.Context) error {
 	return nil
 }
 
-func (d *Descheduler) deschedulerOnce(ctx context.Context) error {
+// Pause pauses descheduling, the following descheduling cycles are skipped until Resume is called.
+// The running cycle is not interrupted.
+func (d *Descheduler) Pause() {
+	if !d.paused.Swap(true) {
+		klog.InfoS("Descheduling is paused")
+	}
+	metrics.DeschedulingPaused.Set(1)
+}
+
+// Resume resumes the paused descheduling from the next descheduling cycle.
+func (d *Descheduler) Resume() {
+	if d.paused.Swap(false) {
+		klog.InfoS("Descheduling is resumed")
+	}
+	metrics.DeschedulingPaused.Set(0)
+}
+
+// Paused returns whether descheduling is paused.
+func (d *Descheduler) Paused() bool {
+	return d.paused.Load()
+}
+
+func (d *Descheduler) deschedulerOnce(ctx context.Context) (err error) {
+	if d.Paused() {
+		klog.InfoS("Descheduling is paused, skip the descheduling cycle")
+		return nil
+	}
+
+	if d.observing() {
+		klog.InfoS("Descheduler is in the initial observation period, run the descheduling cycle in dry-run mode", "observeUntil", d.observeUntil)
+		ctx = framework.WithDryRun(ctx)
+	}
+
+	ctx, span := d.tracer.Start(ctx, "DeschedulingCycle")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	d.checkNodeSelector(ctx, false)
 	nodes, err := nodeutil.ReadyNodes(ctx, d.clientSet, d.nodeInformer, d.nodeSelector)
 	if err != nil {
 		return fmt.Errorf("unable to get ready nodes: %v", err)
 	}
+	span.SetAttributes(attribute.Int("nodes", len(nodes)))
 
 	if len(nodes) <= 1 {
 		return fmt.Errorf("the cluster size is 0 or 1 meaning eviction causes service disruption or degradation")
 	}
 
 	d.evictionLimiter.Reset()
+	defer func() {
+		span.SetAttributes(attribute.Int64("evicted", int64(d.evictionLimiter.TotalEvicted())))
+	}()
+
+	if d.maxCycleDuration > 0 {
+		deadline := framework.NewCycleDeadline(d.clock, d.maxCycleDuration)
+		ctx = framework.WithCycleDeadline(ctx, deadline)
+		nodes = prioritizeNodes(nodes, d.carriedOverNodes)
+		defer d.completeCycle(deadline, len(nodes))
+	}
 
-	for _, p := range d.Profiles {
-		processedNodes := sets.NewString()
-		selectedNodes, filterErr := filterNodes(p.NodeSelector(), nodes, processedNodes)
-		if filterErr != nil {
-			return filterErr
+	// the i-th extension points of all the profiles run before the (i+1)-th ones of any profile,
+	// so that all the Deschedule plugins still run before all the Balance plugins by default.
+	for i := range deschedulerconfig.DefaultExtensionPointOrder {
+		if i > 0 && framework.ExceedCycleDeadline(ctx, nodes) {
+			return nil
 		}
-		status := p.RunDeschedulePlugins(ctx, selectedNodes)
-		if status != nil && status.Err != nil {
-			return status.Err
+		err = d.runProfiles(ctx, nodes, func(ctx context.Context, name string, p framework.Handle, nodes []*corev1.Node) *framework.Status {
+			switch d.extensionPointOrder(name)[i] {
+			case deschedulerconfig.ExtensionPointDeschedule:
+				return p.RunDeschedulePlugins(ctx, nodes)
+			case deschedulerconfig.ExtensionPointBalance:
+				return p.RunBalancePlugins(ctx, nodes)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	for _, p := range d.Profiles {
-		processedNodes := sets.NewString()
-		selectedNodes, filterErr := filterNodes(p.NodeSelector(), nodes, processedNodes)
-		if filterErr != nil {
-			return filterErr
+// extensionPointOrder returns all the extension points of the profile in the running order.
+// The extension points omitted in the ExtensionPointOrder of the profile run after the specified ones in the default order.
+func (d *Descheduler) extensionPointOrder(profileName string) []string {
+	order := make([]string, 0, len(deschedulerconfig.DefaultExtensionPointOrder))
+	seen := sets.NewString()
+	for _, extensionPoint := range append(append([]string{}, d.extensionPointOrders[profileName]...), deschedulerconfig.DefaultExtensionPointOrder...) {
+		if !seen.Has(extensionPoint) {
+			seen.Insert(extensionPoint)
+			order = append(order, extensionPoint)
 		}
-		status := p.RunBalancePlugins(ctx, selectedNodes)
-		if status != nil && status.Err != nil {
-			return status.Err
+	}
+	return order
+}
+
+// checkNodeSelector warns if the node selector matches no node, e.g. because of a mistyped label, which silently
+// disables all the descheduling. It is not a failure since the matching nodes may join the cluster later.
+// The nodes are listed from the API server only if listFromAPIServer is set, i.e. at startup when the informer
+// may not be synced yet, the periodic checks rely on the lister to not add an uncached List to every cycle.
+func (d *Descheduler) checkNodeSelector(ctx context.Context, listFromAPIServer bool) {
+	if d.nodeSelector == "" {
+		metrics.NodeSelectorMatchesNoNode.Set(0)
+		return
+	}
+	selector, err := labels.Parse(d.nodeSelector)
+	if err != nil {
+		klog.ErrorS(err, "Failed to parse the node selector", "nodeSelector", d.nodeSelector)
+		return
+	}
+	nodes, err := d.nodeInformer.Lister().List(selector)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list the nodes matching the node selector", "nodeSelector", d.nodeSelector)
+		return
+	}
+	matched := len(nodes)
+	if matched == 0 && listFromAPIServer {
+		nodeList, err := d.clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: d.nodeSelector})
+		if err != nil {
+			klog.ErrorS(err, "Failed to list the nodes matching the node selector", "nodeSelector", d.nodeSelector)
+			return
 		}
+		matched = len(nodeList.Items)
 	}
+	if matched == 0 {
+		klog.Warningf("The node selector %q of the descheduler matches no node, nothing is going to be descheduled, please check the nodeSelector", d.nodeSelector)
+		metrics.NodeSelectorMatchesNoNode.Set(1)
+		return
+	}
+	metrics.NodeSelectorMatchesNoNode.Set(0)
+}
 
-	return nil
+// observing reports whether the descheduler is in the initial observation period, and updates the metric.
+func (d *Descheduler) observing() bool {
+	if d.observeUntil.IsZero() || !d.clock.Now().Before(d.observeUntil) {
+		metrics.InitialObservation.Set(0)
+		return false
+	}
+	metrics.InitialObservation.Set(1)
+	return true
+}
+
+// runProfiles runs the profiles in the config order with at most maxConcurrentProfiles profiles at the same time,
+// the rest are queued.
+// It stops launching the queued profiles and returns the first error once any profile fails.
+func (d *Descheduler) runProfiles(ctx context.Context, nodes []*corev1.Node,
+	run func(ctx context.Context, name string, p framework.Handle, nodes []*corev1.Node) *framework.Status) error {
+	maxConcurrentProfiles := d.maxConcurrentProfiles
+	if maxConcurrentProfiles < 1 {
+		maxConcurrentProfiles = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		failed   int32
+	)
+	sem := make(chan struct{}, maxConcurrentProfiles)
+	for _, name := range d.orderedProfileNames() {
+		p := d.Profiles[name]
+		sem <- struct{}{}
+		if atomic.LoadInt32(&failed) != 0 {
+			<-sem
+			break
+		}
+		if framework.ExceedCycleDeadline(ctx, nodes) {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(name string, p framework.Handle) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			var err error
+			selectedNodes, filterErr := filterNodes(p.NodeSelector(), nodes, sets.NewString())
+			if filterErr != nil {
+				err = filterErr
+			} else if status := run(ctx, name, p, selectedNodes); status != nil && status.Err != nil {
+				err = status.Err
+			}
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					atomic.StoreInt32(&failed, 1)
+				})
+			}
+		}(name, p)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// orderedProfileNames returns the names of the profiles in the config order. The profiles are sorted by name
+// if the order is unknown, so that they are launched in the same order in every cycle anyway.
+func (d *Descheduler) orderedProfileNames() []string {
+	if len(d.profileNames) > 0 {
+		return d.profileNames
+	}
+	names := make([]string, 0, len(d.Profiles))
+	for name := range d.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// completeCycle records the truncation of the cycle, and carries the unprocessed nodes over to the next cycle.
+func (d *Descheduler) completeCycle(deadline *framework.CycleDeadline, totalNodes int) {
+	if !deadline.Truncated() {
+		d.carriedOverNodes = nil
+		return
+	}
+	d.carriedOverNodes = deadline.UnprocessedNodes()
+	metrics.TruncatedCycles.Inc()
+	klog.Infof("Descheduling cycle was truncated as it exceeded the max duration %v, %d of %d nodes are carried over to the next cycle",
+		d.maxCycleDuration, d.carriedOverNodes.Len(), totalNodes)
+}
+
+// prioritizeNodes moves the carried over nodes to the front, keeping the relative order of the others.
+func prioritizeNodes(nodes []*corev1.Node, carriedOverNodes sets.String) []*corev1.Node {
+	if carriedOverNodes.Len() == 0 {
+		return nodes
+	}
+	r := make([]*corev1.Node, 0, len(nodes))
+	for _, v := range nodes {
+		if carriedOverNodes.Has(v.Name) {
+			r = append(r, v)
+		}
+	}
+	for _, v := range nodes {
+		if !carriedOverNodes.Has(v.Name) {
+			r = append(r, v)
+		}
+	}
+	return r
 }
 
 func podAssignedToNodeAdaptor(fn PodAssignedToNodeFn) framework.GetPodsAssignedToNodeFunc {
@@ -0,0 +1,644 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/component-base/metrics/testutil"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/profile"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+type fakeProfileHandle struct {
+	framework.Handle
+	descheduleCount int32
+	balanceCount    int32
+	dryRunCount     int32
+}
+
+func (f *fakeProfileHandle) RunDeschedulePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	atomic.AddInt32(&f.descheduleCount, 1)
+	if framework.IsDryRun(ctx) {
+		atomic.AddInt32(&f.dryRunCount, 1)
+	}
+	return nil
+}
+
+func (f *fakeProfileHandle) RunBalancePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	atomic.AddInt32(&f.balanceCount, 1)
+	return nil
+}
+
+func (f *fakeProfileHandle) NodeSelector() *metav1.LabelSelector {
+	return nil
+}
+
+func TestDeschedulerStartupGracePeriod(t *testing.T) {
+	nodes := []runtime.Object{
+		test.BuildTestNode("test-node-1", 4000, 3000, 10, nil),
+		test.BuildTestNode("test-node-2", 4000, 3000, 10, nil),
+	}
+	fakeClient := fake.NewSimpleClientset(nodes...)
+	informerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	nodeInformer.Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informerFactory.Start(ctx.Done())
+	informerFactory.WaitForCacheSync(ctx.Done())
+
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	handle := &fakeProfileHandle{}
+	d := &Descheduler{
+		Profiles:           profile.Map{"test": handle},
+		StopEverything:     ctx.Done(),
+		clientSet:          fakeClient,
+		nodeInformer:       nodeInformer,
+		startupGracePeriod: time.Minute,
+		evictionLimiter:    evictions.NewEvictionLimiter(nil, nil, nil),
+		clock:              fakeClock,
+		tracer:             trace.NewNoopTracerProvider().Tracer(frameworkruntime.TracerName),
+	}
+
+	// simulate the descheduler becoming the leader
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, d.Start(ctx))
+	}()
+
+	assert.Eventually(t, fakeClock.HasWaiters, 5*time.Second, 10*time.Millisecond)
+	fakeClock.Step(30 * time.Second)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&handle.descheduleCount))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&handle.balanceCount))
+
+	fakeClock.Step(30 * time.Second)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("descheduler does not run after startup grace period")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handle.descheduleCount))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handle.balanceCount))
+}
+
+func TestDeschedulerInitialObservationPeriod(t *testing.T) {
+	nodes := []runtime.Object{
+		test.BuildTestNode("test-node-1", 4000, 3000, 10, nil),
+		test.BuildTestNode("test-node-2", 4000, 3000, 10, nil),
+	}
+	fakeClient := fake.NewSimpleClientset(nodes...)
+	informerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	nodeInformer.Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informerFactory.Start(ctx.Done())
+	informerFactory.WaitForCacheSync(ctx.Done())
+
+	metrics.Register()
+	observing := func() float64 {
+		value, err := testutil.GetGaugeMetricValue(metrics.InitialObservation)
+		assert.NoError(t, err)
+		return value
+	}
+
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	handle := &fakeProfileHandle{}
+	d := &Descheduler{
+		Profiles:          profile.Map{"test": handle},
+		StopEverything:    ctx.Done(),
+		clientSet:         fakeClient,
+		nodeInformer:      nodeInformer,
+		observationPeriod: time.Minute,
+		evictionLimiter:   evictions.NewEvictionLimiter(nil, nil, nil),
+		clock:             fakeClock,
+		tracer:            trace.NewNoopTracerProvider().Tracer(frameworkruntime.TracerName),
+	}
+
+	// the cycles run without waiting, but in dry-run mode
+	assert.NoError(t, d.Start(ctx))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handle.descheduleCount))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handle.dryRunCount))
+	assert.Equal(t, float64(1), observing())
+
+	fakeClock.Step(30 * time.Second)
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&handle.descheduleCount))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&handle.dryRunCount))
+
+	// the cycles evict pods once the observation period ends
+	fakeClock.Step(30 * time.Second)
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&handle.descheduleCount))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&handle.dryRunCount))
+	assert.Equal(t, float64(0), observing())
+}
+
+type blockingProfileHandle struct {
+	framework.Handle
+	running    *int32
+	maxRunning *int32
+	release    <-chan struct{}
+	ran        int32
+}
+
+func (f *blockingProfileHandle) run() *framework.Status {
+	running := atomic.AddInt32(f.running, 1)
+	for {
+		maxRunning := atomic.LoadInt32(f.maxRunning)
+		if running <= maxRunning || atomic.CompareAndSwapInt32(f.maxRunning, maxRunning, running) {
+			break
+		}
+	}
+	<-f.release
+	atomic.AddInt32(f.running, -1)
+	atomic.AddInt32(&f.ran, 1)
+	return nil
+}
+
+func (f *blockingProfileHandle) RunDeschedulePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	return f.run()
+}
+
+func (f *blockingProfileHandle) RunBalancePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	return f.run()
+}
+
+func (f *blockingProfileHandle) NodeSelector() *metav1.LabelSelector {
+	return nil
+}
+
+func TestDeschedulerMaxConcurrentProfiles(t *testing.T) {
+	tests := []struct {
+		name                  string
+		maxConcurrentProfiles int
+		profiles              int
+		wantMaxRunning        int32
+	}{
+		{
+			name:                  "serialized execution",
+			maxConcurrentProfiles: 1,
+			profiles:              4,
+			wantMaxRunning:        1,
+		},
+		{
+			name:                  "bounded parallel execution",
+			maxConcurrentProfiles: 2,
+			profiles:              5,
+			wantMaxRunning:        2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var running, maxRunning int32
+			release := make(chan struct{})
+			handles := make([]*blockingProfileHandle, 0, tt.profiles)
+			profiles := profile.Map{}
+			for i := 0; i < tt.profiles; i++ {
+				handle := &blockingProfileHandle{running: &running, maxRunning: &maxRunning, release: release}
+				handles = append(handles, handle)
+				profiles[fmt.Sprintf("profile-%d", i)] = handle
+			}
+			d := &Descheduler{
+				Profiles:              profiles,
+				maxConcurrentProfiles: tt.maxConcurrentProfiles,
+			}
+
+			done := make(chan error)
+			go func() {
+				done <- d.runProfiles(context.TODO(), nil, func(ctx context.Context, name string, p framework.Handle, nodes []*corev1.Node) *framework.Status {
+					return p.RunBalancePlugins(ctx, nodes)
+				})
+			}()
+
+			assert.Eventually(t, func() bool {
+				return atomic.LoadInt32(&running) == tt.wantMaxRunning
+			}, 5*time.Second, 10*time.Millisecond)
+			// the rest profiles are queued
+			time.Sleep(100 * time.Millisecond)
+			assert.Equal(t, tt.wantMaxRunning, atomic.LoadInt32(&running))
+
+			close(release)
+			select {
+			case err := <-done:
+				assert.NoError(t, err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("profiles do not finish")
+			}
+			assert.Equal(t, tt.wantMaxRunning, atomic.LoadInt32(&maxRunning))
+			for _, handle := range handles {
+				assert.Equal(t, int32(1), atomic.LoadInt32(&handle.ran))
+			}
+		})
+	}
+}
+
+func TestDeschedulerProfilesRunInConfigOrder(t *testing.T) {
+	pod := test.BuildTestPod("test-pod", 100, 0, "test-node", nil)
+	limiter := evictions.NewEvictionLimiter(nil, nil, nil)
+	d := &Descheduler{
+		Profiles: profile.Map{
+			"alpha": &fakeProfileHandle{},
+			"zeta":  &fakeProfileHandle{},
+		},
+		// zeta is listed first in the config although it is sorted after alpha
+		profileNames:          []string{"zeta", "alpha"},
+		maxConcurrentProfiles: 1,
+	}
+	for i := 0; i < 20; i++ {
+		limiter.Reset()
+		var evictedBy []string
+		err := d.runProfiles(context.TODO(), nil, func(ctx context.Context, name string, p framework.Handle, nodes []*corev1.Node) *framework.Status {
+			// both the profiles target the same pod
+			if _, claimed := limiter.Claim(pod, name); claimed {
+				evictedBy = append(evictedBy, name)
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"zeta"}, evictedBy, "cycle %d", i)
+	}
+}
+
+// slowProfileHandle takes a minute to process each node, and stops once the cycle exceeds its max duration.
+type slowProfileHandle struct {
+	framework.Handle
+	clock          *clocktesting.FakeClock
+	processedNodes []string
+	balanceCount   int32
+}
+
+func (f *slowProfileHandle) RunDeschedulePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	for i, node := range nodes {
+		if framework.ExceedCycleDeadline(ctx, nodes[i:]) {
+			break
+		}
+		f.processedNodes = append(f.processedNodes, node.Name)
+		f.clock.Step(time.Minute)
+	}
+	return nil
+}
+
+func (f *slowProfileHandle) RunBalancePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	atomic.AddInt32(&f.balanceCount, 1)
+	return nil
+}
+
+func (f *slowProfileHandle) NodeSelector() *metav1.LabelSelector {
+	return nil
+}
+
+func TestDeschedulerMaxCycleDuration(t *testing.T) {
+	var nodes []runtime.Object
+	for i := 1; i <= 5; i++ {
+		nodes = append(nodes, test.BuildTestNode(fmt.Sprintf("test-node-%d", i), 4000, 3000, 10, nil))
+	}
+	fakeClient := fake.NewSimpleClientset(nodes...)
+	informerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	nodeInformer.Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informerFactory.Start(ctx.Done())
+	informerFactory.WaitForCacheSync(ctx.Done())
+
+	metrics.Register()
+	truncatedCycles := func() float64 {
+		value, err := testutil.GetCounterMetricValue(metrics.TruncatedCycles)
+		assert.NoError(t, err)
+		return value
+	}
+	before := truncatedCycles()
+
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	handle := &slowProfileHandle{clock: fakeClock}
+	d := &Descheduler{
+		Profiles:         profile.Map{"test": handle},
+		StopEverything:   ctx.Done(),
+		clientSet:        fakeClient,
+		nodeInformer:     nodeInformer,
+		maxCycleDuration: 150 * time.Second,
+		evictionLimiter:  evictions.NewEvictionLimiter(nil, nil, nil),
+		clock:            fakeClock,
+		tracer:           trace.NewNoopTracerProvider().Tracer(frameworkruntime.TracerName),
+	}
+
+	// the cycle is truncated after 3 nodes, and the balance plugins are skipped
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.Len(t, handle.processedNodes, 3)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&handle.balanceCount))
+	assert.Equal(t, before+1, truncatedCycles())
+	firstProcessed := sets.NewString(handle.processedNodes...)
+	assert.Equal(t, 2, d.carriedOverNodes.Len())
+	assert.False(t, firstProcessed.HasAny(d.carriedOverNodes.UnsortedList()...))
+
+	// the unprocessed nodes are processed first in the next cycle
+	carriedOverNodes := d.carriedOverNodes
+	handle.processedNodes = nil
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.Len(t, handle.processedNodes, 3)
+	assert.Equal(t, carriedOverNodes, sets.NewString(handle.processedNodes[:2]...))
+	assert.Equal(t, before+2, truncatedCycles())
+
+	// the cycle is not truncated if it finishes in time
+	d.maxCycleDuration = 10 * time.Minute
+	handle.processedNodes = nil
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.Len(t, handle.processedNodes, 5)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handle.balanceCount))
+	assert.Equal(t, 0, d.carriedOverNodes.Len())
+	assert.Equal(t, before+2, truncatedCycles())
+}
+
+func TestDeschedulerPauseAndResume(t *testing.T) {
+	var nodes []runtime.Object
+	for i := 1; i <= 2; i++ {
+		nodes = append(nodes, test.BuildTestNode(fmt.Sprintf("test-node-%d", i), 4000, 3000, 10, nil))
+	}
+	fakeClient := fake.NewSimpleClientset(nodes...)
+	informerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	nodeInformer.Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informerFactory.Start(ctx.Done())
+	informerFactory.WaitForCacheSync(ctx.Done())
+
+	metrics.Register()
+	paused := func() float64 {
+		value, err := testutil.GetGaugeMetricValue(metrics.DeschedulingPaused)
+		assert.NoError(t, err)
+		return value
+	}
+
+	handle := &fakeProfileHandle{}
+	d := &Descheduler{
+		Profiles:        profile.Map{"test": handle},
+		StopEverything:  ctx.Done(),
+		clientSet:       fakeClient,
+		nodeInformer:    nodeInformer,
+		evictionLimiter: evictions.NewEvictionLimiter(nil, nil, nil),
+		tracer:          trace.NewNoopTracerProvider().Tracer(frameworkruntime.TracerName),
+	}
+
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handle.descheduleCount))
+
+	// the cycles are skipped while paused
+	d.Pause()
+	assert.True(t, d.Paused())
+	assert.Equal(t, float64(1), paused())
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handle.descheduleCount))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handle.balanceCount))
+
+	// pausing twice is harmless
+	d.Pause()
+	assert.True(t, d.Paused())
+
+	d.Resume()
+	assert.False(t, d.Paused())
+	assert.Equal(t, float64(0), paused())
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&handle.descheduleCount))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&handle.balanceCount))
+}
+
+func TestDeschedulerNodeSelectorMatchesNoNode(t *testing.T) {
+	var nodes []runtime.Object
+	for i := 1; i <= 2; i++ {
+		nodes = append(nodes, test.BuildTestNode(fmt.Sprintf("test-node-%d", i), 4000, 3000, 10, func(node *corev1.Node) {
+			node.Labels = map[string]string{"pool": "batch"}
+		}))
+	}
+	fakeClient := fake.NewSimpleClientset(nodes...)
+	informerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	nodeInformer.Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informerFactory.Start(ctx.Done())
+	informerFactory.WaitForCacheSync(ctx.Done())
+
+	metrics.Register()
+	matchesNoNode := func() float64 {
+		value, err := testutil.GetGaugeMetricValue(metrics.NodeSelectorMatchesNoNode)
+		assert.NoError(t, err)
+		return value
+	}
+
+	handle := &fakeProfileHandle{}
+	d := &Descheduler{
+		Profiles:        profile.Map{"test": handle},
+		StopEverything:  ctx.Done(),
+		clientSet:       fakeClient,
+		nodeInformer:    nodeInformer,
+		nodeSelector:    "pool=btach",
+		evictionLimiter: evictions.NewEvictionLimiter(nil, nil, nil),
+		tracer:          trace.NewNoopTracerProvider().Tracer(frameworkruntime.TracerName),
+	}
+
+	// the mistyped node selector is reported on every cycle
+	fakeClient.ClearActions()
+	assert.Error(t, d.deschedulerOnce(ctx))
+	assert.Equal(t, float64(1), matchesNoNode())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&handle.descheduleCount))
+	// the periodic check does not list the nodes from the API server, only ReadyNodes does
+	nodeLists := 0
+	for _, action := range fakeClient.Actions() {
+		if action.Matches("list", "nodes") {
+			nodeLists++
+		}
+	}
+	assert.Equal(t, 1, nodeLists)
+
+	d.nodeSelector = "pool=batch"
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.Equal(t, float64(0), matchesNoNode())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handle.descheduleCount))
+}
+
+type orderRecordingProfileHandle struct {
+	framework.Handle
+	name    string
+	lock    *sync.Mutex
+	records *[]string
+}
+
+func (f *orderRecordingProfileHandle) record(extensionPoint string) *framework.Status {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	*f.records = append(*f.records, f.name+"/"+extensionPoint)
+	return nil
+}
+
+func (f *orderRecordingProfileHandle) RunDeschedulePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	return f.record(deschedulerconfig.ExtensionPointDeschedule)
+}
+
+func (f *orderRecordingProfileHandle) RunBalancePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	return f.record(deschedulerconfig.ExtensionPointBalance)
+}
+
+func (f *orderRecordingProfileHandle) NodeSelector() *metav1.LabelSelector {
+	return nil
+}
+
+func TestDeschedulerExtensionPointOrder(t *testing.T) {
+	var nodes []runtime.Object
+	for i := 1; i <= 2; i++ {
+		nodes = append(nodes, test.BuildTestNode(fmt.Sprintf("test-node-%d", i), 4000, 3000, 10, nil))
+	}
+	fakeClient := fake.NewSimpleClientset(nodes...)
+	informerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	nodeInformer.Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informerFactory.Start(ctx.Done())
+	informerFactory.WaitForCacheSync(ctx.Done())
+
+	var lock sync.Mutex
+	var records []string
+	d := &Descheduler{
+		Profiles: profile.Map{
+			"default":   &orderRecordingProfileHandle{name: "default", lock: &lock, records: &records},
+			"reordered": &orderRecordingProfileHandle{name: "reordered", lock: &lock, records: &records},
+		},
+		StopEverything:  ctx.Done(),
+		clientSet:       fakeClient,
+		nodeInformer:    nodeInformer,
+		evictionLimiter: evictions.NewEvictionLimiter(nil, nil, nil),
+		tracer:          trace.NewNoopTracerProvider().Tracer(frameworkruntime.TracerName),
+		extensionPointOrders: map[string][]string{
+			"reordered": {deschedulerconfig.ExtensionPointBalance},
+		},
+	}
+	assert.Equal(t, []string{"Deschedule", "Balance"}, d.extensionPointOrder("default"))
+	assert.Equal(t, []string{"Balance", "Deschedule"}, d.extensionPointOrder("reordered"))
+
+	assert.NoError(t, d.deschedulerOnce(ctx))
+	assert.Len(t, records, 4)
+	// the first extension points of all the profiles run before the second ones
+	assert.ElementsMatch(t, []string{"default/Deschedule", "reordered/Balance"}, records[:2])
+	assert.ElementsMatch(t, []string{"default/Balance", "reordered/Deschedule"}, records[2:])
+}
+
+type fakeEvictPlugin struct{}
+
+func (pl *fakeEvictPlugin) Name() string {
+	return "fake-evict-plugin"
+}
+
+func (pl *fakeEvictPlugin) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	return true
+}
+
+func TestDeschedulerDisabled(t *testing.T) {
+	newProfile := func(name string, disabled bool) deschedulerconfig.DeschedulerProfile {
+		return deschedulerconfig.DeschedulerProfile{
+			Name: name,
+			Plugins: &deschedulerconfig.Plugins{
+				Evict: deschedulerconfig.PluginSet{
+					Enabled: []deschedulerconfig.Plugin{{Name: "fake-evict-plugin"}},
+				},
+			},
+			Disabled: disabled,
+		}
+	}
+	tests := []struct {
+		name         string
+		disabled     bool
+		wantDisabled map[string]bool
+	}{
+		{
+			name:     "per-profile disabled",
+			disabled: false,
+			wantDisabled: map[string]bool{
+				"enabled-profile":  false,
+				"disabled-profile": true,
+			},
+		},
+		{
+			name:     "global disabled overrides per-profile",
+			disabled: true,
+			wantDisabled: map[string]bool{
+				"enabled-profile":  true,
+				"disabled-profile": true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset()
+			informerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			profiles := []deschedulerconfig.DeschedulerProfile{
+				newProfile("enabled-profile", false),
+				newProfile("disabled-profile", true),
+			}
+			gotDisabled := map[string]bool{}
+			_, err := New(fakeClient, informerFactory, nil,
+				func(string) events.EventRecorder { return events.NewFakeRecorder(1024) },
+				nil,
+				WithProfiles(profiles...),
+				WithDisabled(tt.disabled),
+				WithFrameworkOutOfTreeRegistry(frameworkruntime.Registry{
+					"fake-evict-plugin": func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+						return &fakeEvictPlugin{}, nil
+					},
+				}),
+				WithBuildFrameworkCapturer(func(p deschedulerconfig.DeschedulerProfile) {
+					gotDisabled[p.Name] = p.Disabled
+				}),
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDisabled, gotDisabled)
+			// the configured profiles should not be modified
+			assert.False(t, profiles[0].Disabled)
+		})
+	}
+}
@@ -21,17 +21,34 @@ import (
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
 )
 
 type EvictionLimiter struct {
 	maxPodsToEvictPerNode      *uint
 	maxPodsToEvictPerNamespace *uint
 	maxPodsToEvictTotal        *uint
+	maxPodsToEvictPerQuota     map[string]uint
+	maxEvictionFraction        float64
+	countRunningPods           func() (int, error)
+	maxPodsToEvictByFraction   *uint
 	lock                       sync.RWMutex
 	totalCount                 uint
 	nodePodCount               nodePodEvictedCount
 	namespacePodCount          namespacePodEvictCount
+	quotaPodCount              quotaPodEvictCount
+	// claimedPods records the profile evicting the pod in the descheduling cycle,
+	// so that a pod selected by multiple profiles is evicted and counted once.
+	claimedPods map[types.NamespacedName]*podClaim
+	safeMode    *SafeMode
+}
+
+type podClaim struct {
+	profileName string
+	evicted     bool
 }
 
 func NewEvictionLimiter(
@@ -45,16 +62,93 @@ func NewEvictionLimiter(
 		maxPodsToEvictTotal:        maxPodsToEvictTotal,
 		nodePodCount:               make(nodePodEvictedCount),
 		namespacePodCount:          make(namespacePodEvictCount),
+		quotaPodCount:              make(quotaPodEvictCount),
+		claimedPods:                make(map[types.NamespacedName]*podClaim),
 	}
 }
 
+// SetSafeMode sets the SafeMode checked before every eviction. The SafeMode is not reset
+// with the limiter at the beginning of each descheduling cycle.
+func (pe *EvictionLimiter) SetSafeMode(safeMode *SafeMode) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+
+	pe.safeMode = safeMode
+}
+
+// SetMaxPodsToEvictPerQuota restricts the pods to be evicted per ElasticQuota, keyed by the quota name.
+// The quota of a pod is resolved by its quota name label, and the pods of the quotas not limited are skipped.
+func (pe *EvictionLimiter) SetMaxPodsToEvictPerQuota(maxPodsToEvictPerQuota map[string]uint) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+
+	pe.maxPodsToEvictPerQuota = maxPodsToEvictPerQuota
+}
+
+// SetMaxEvictionFraction restricts the pods to be evicted total in a descheduling cycle to the fraction
+// of the running pods counted by countRunningPods. The running pods are counted on every Reset.
+func (pe *EvictionLimiter) SetMaxEvictionFraction(fraction float64, countRunningPods func() (int, error)) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+
+	pe.maxEvictionFraction = fraction
+	pe.countRunningPods = countRunningPods
+}
+
 func (pe *EvictionLimiter) Reset() {
+	pe.lock.RLock()
+	fraction, countRunningPods := pe.maxEvictionFraction, pe.countRunningPods
+	pe.lock.RUnlock()
+
+	var maxPodsToEvictByFraction *uint
+	countFailed := false
+	if fraction > 0 && countRunningPods != nil {
+		if count, err := countRunningPods(); err != nil {
+			klog.ErrorS(err, "Failed to count running pods, keep the last limit of evicted pods by fraction")
+			countFailed = true
+		} else {
+			limit := uint(fraction * float64(count))
+			maxPodsToEvictByFraction = &limit
+		}
+	}
+
 	pe.lock.Lock()
 	defer pe.lock.Unlock()
 
+	if !countFailed {
+		pe.maxPodsToEvictByFraction = maxPodsToEvictByFraction
+	}
 	pe.totalCount = 0
 	pe.nodePodCount = make(nodePodEvictedCount)
 	pe.namespacePodCount = make(namespacePodEvictCount)
+	pe.quotaPodCount = make(quotaPodEvictCount)
+	pe.claimedPods = make(map[types.NamespacedName]*podClaim)
+}
+
+// Claim claims the pod to be evicted by the profile in the descheduling cycle. It returns the profile
+// which has claimed the pod and false if the pod has been claimed by another profile already.
+func (pe *EvictionLimiter) Claim(pod *corev1.Pod, profileName string) (string, bool) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+
+	key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	if claim, ok := pe.claimedPods[key]; ok {
+		return claim.profileName, claim.profileName == profileName
+	}
+	pe.claimedPods[key] = &podClaim{profileName: profileName}
+	return profileName, true
+}
+
+// Release gives up the claim of the profile on the pod which failed to be evicted,
+// so that the pod can be evicted by the other profiles.
+func (pe *EvictionLimiter) Release(pod *corev1.Pod, profileName string) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+
+	key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	if claim, ok := pe.claimedPods[key]; ok && claim.profileName == profileName && !claim.evicted {
+		delete(pe.claimedPods, key)
+	}
 }
 
 // NodeEvicted gives a number of pods evicted for node
@@ -72,6 +166,14 @@ func (pe *EvictionLimiter) NamespaceEvicted(namespace string) uint {
 	return pe.namespacePodCount[namespace]
 }
 
+// QuotaEvicted gives a number of pods evicted for the ElasticQuota
+func (pe *EvictionLimiter) QuotaEvicted(quotaName string) uint {
+	pe.lock.RLock()
+	defer pe.lock.RUnlock()
+
+	return pe.quotaPodCount[quotaName]
+}
+
 // TotalEvicted gives a number of pods evicted through all nodes
 func (pe *EvictionLimiter) TotalEvicted() uint {
 	pe.lock.RLock()
@@ -86,7 +188,8 @@ func (pe *EvictionLimiter) NodeLimitExceeded(node *corev1.Node) bool {
 	defer pe.lock.RUnlock()
 
 	if pe.maxPodsToEvictPerNode != nil {
-		return pe.nodePodCount[node.Name] == *pe.maxPodsToEvictPerNode
+		// the pods evicted ignoring the node limit may exceed it
+		return pe.nodePodCount[node.Name] >= *pe.maxPodsToEvictPerNode
 	}
 	return false
 }
@@ -101,12 +204,82 @@ func (pe *EvictionLimiter) NamespaceLimitExceeded(namespace string) bool {
 	return false
 }
 
+// QuotaLimitExceeded checks if the number of evictions for the ElasticQuota was exceeded
+func (pe *EvictionLimiter) QuotaLimitExceeded(quotaName string) bool {
+	pe.lock.RLock()
+	defer pe.lock.RUnlock()
+
+	if limit, ok := pe.maxPodsToEvictPerQuota[quotaName]; ok {
+		return pe.quotaPodCount[quotaName] == limit
+	}
+	return false
+}
+
 func (pe *EvictionLimiter) AllowEvict(pod *corev1.Pod) bool {
+	return pe.allowEvict(pod, false)
+}
+
+// AllowEvictIgnoringNodeLimit checks the eviction limits except the limit of evicted pods per node.
+func (pe *EvictionLimiter) AllowEvictIgnoringNodeLimit(pod *corev1.Pod) bool {
+	return pe.allowEvict(pod, true)
+}
+
+func (pe *EvictionLimiter) allowEvict(pod *corev1.Pod, ignoreNodeLimit bool) bool {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+
+	return pe.allowEvictLocked(pod, ignoreNodeLimit)
+}
+
+// Reserve checks the eviction limits and counts the pod against them while holding the lock, so that
+// the profiles evicting pods concurrently cannot exceed the limits. The reservation must be either
+// confirmed by Confirm once the pod is evicted, or given back by Unreserve if the eviction fails.
+func (pe *EvictionLimiter) Reserve(pod *corev1.Pod, ignoreNodeLimit bool) bool {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+
+	if !pe.allowEvictLocked(pod, ignoreNodeLimit) {
+		return false
+	}
+	pe.count(pod)
+	return true
+}
+
+// Unreserve gives back the counts reserved for the pod which failed to be evicted.
+func (pe *EvictionLimiter) Unreserve(pod *corev1.Pod) {
 	pe.lock.Lock()
 	defer pe.lock.Unlock()
 
+	if nodeName := pod.Spec.NodeName; nodeName != "" && pe.nodePodCount[nodeName] > 0 {
+		pe.nodePodCount[nodeName]--
+	}
+	if pe.namespacePodCount[pod.Namespace] > 0 {
+		pe.namespacePodCount[pod.Namespace]--
+	}
+	if quotaName := extension.GetQuotaName(pod); quotaName != "" && pe.quotaPodCount[quotaName] > 0 {
+		pe.quotaPodCount[quotaName]--
+	}
+	if pe.totalCount > 0 {
+		pe.totalCount--
+	}
+}
+
+// Confirm completes the eviction of the pod reserved by Reserve.
+func (pe *EvictionLimiter) Confirm(pod *corev1.Pod) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+
+	pe.confirm(pod)
+}
+
+func (pe *EvictionLimiter) allowEvictLocked(pod *corev1.Pod, ignoreNodeLimit bool) bool {
+	if pe.safeMode != nil && !pe.safeMode.Allow() {
+		klog.ErrorS(fmt.Errorf("descheduler is in the safe mode"), "Error evicting pod", "pod", klog.KObj(pod))
+		return false
+	}
+
 	nodeName := pod.Spec.NodeName
-	if nodeName != "" {
+	if nodeName != "" && !ignoreNodeLimit {
 		if pe.maxPodsToEvictPerNode != nil && pe.nodePodCount[pod.Spec.NodeName]+1 > *pe.maxPodsToEvictPerNode {
 			klog.ErrorS(fmt.Errorf("maximum number of evicted pods per node reached"), "Error evicting pod", "limit", *pe.maxPodsToEvictPerNode, "node", nodeName)
 			return false
@@ -118,21 +291,55 @@ func (pe *EvictionLimiter) AllowEvict(pod *corev1.Pod) bool {
 		return false
 	}
 
-	if pe.maxPodsToEvictTotal != nil && pe.totalCount+1 > *pe.maxPodsToEvictTotal {
-		klog.ErrorS(fmt.Errorf("maximum number of evicted pods total reached"), "Error evicting pod", "limit", *pe.maxPodsToEvictTotal)
+	if quotaName := extension.GetQuotaName(pod); quotaName != "" {
+		if limit, ok := pe.maxPodsToEvictPerQuota[quotaName]; ok && pe.quotaPodCount[quotaName]+1 > limit {
+			klog.ErrorS(fmt.Errorf("maximum number of evicted pods per quota reached"), "Error evicting pod", "limit", limit, "quota", quotaName)
+			return false
+		}
+	}
+
+	if maxPodsToEvictTotal := pe.effectiveMaxPodsToEvictTotal(); maxPodsToEvictTotal != nil && pe.totalCount+1 > *maxPodsToEvictTotal {
+		klog.ErrorS(fmt.Errorf("maximum number of evicted pods total reached"), "Error evicting pod", "limit", *maxPodsToEvictTotal)
 		return false
 	}
 	return true
 }
 
+// effectiveMaxPodsToEvictTotal returns the smaller one of the absolute and the fractional limits of evicted pods total.
+func (pe *EvictionLimiter) effectiveMaxPodsToEvictTotal() *uint {
+	if pe.maxPodsToEvictByFraction == nil {
+		return pe.maxPodsToEvictTotal
+	}
+	if pe.maxPodsToEvictTotal == nil || *pe.maxPodsToEvictByFraction < *pe.maxPodsToEvictTotal {
+		return pe.maxPodsToEvictByFraction
+	}
+	return pe.maxPodsToEvictTotal
+}
+
 func (pe *EvictionLimiter) Done(pod *corev1.Pod) {
 	pe.lock.Lock()
 	defer pe.lock.Unlock()
 
+	pe.count(pod)
+	pe.confirm(pod)
+}
+
+func (pe *EvictionLimiter) count(pod *corev1.Pod) {
 	if pod.Spec.NodeName != "" {
 		pe.nodePodCount[pod.Spec.NodeName]++
 	}
 	pe.namespacePodCount[pod.Namespace]++
+	if quotaName := extension.GetQuotaName(pod); quotaName != "" {
+		pe.quotaPodCount[quotaName]++
+	}
 	pe.totalCount++
-	return
+}
+
+func (pe *EvictionLimiter) confirm(pod *corev1.Pod) {
+	if claim, ok := pe.claimedPods[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]; ok {
+		claim.evicted = true
+	}
+	if pe.safeMode != nil {
+		pe.safeMode.Record()
+	}
 }
@@ -17,11 +17,17 @@ limitations under the License.
 package evictions
 
 import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
 )
 
 func makeTestPod(namespace, name, nodeName string) *corev1.Pod {
@@ -240,6 +246,28 @@ func TestEvictionLimiter_NodeLimitExceeded(t *testing.T) {
 	}
 }
 
+func TestEvictionLimiter_AllowEvictIgnoringNodeLimit(t *testing.T) {
+	limiter := NewEvictionLimiter(uintPtr(1), nil, uintPtr(3))
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+
+	pod1 := makeTestPod("default", "pod-1", "node-1")
+	assert.True(t, limiter.AllowEvict(pod1))
+	limiter.Done(pod1)
+	assert.True(t, limiter.NodeLimitExceeded(node))
+
+	pod2 := makeTestPod("default", "pod-2", "node-1")
+	assert.False(t, limiter.AllowEvict(pod2))
+	assert.True(t, limiter.AllowEvictIgnoringNodeLimit(pod2))
+	limiter.Done(pod2)
+	assert.True(t, limiter.NodeLimitExceeded(node))
+
+	// the total limit is still respected
+	pod3 := makeTestPod("default", "pod-3", "node-1")
+	assert.True(t, limiter.AllowEvictIgnoringNodeLimit(pod3))
+	limiter.Done(pod3)
+	assert.False(t, limiter.AllowEvictIgnoringNodeLimit(makeTestPod("default", "pod-4", "node-1")))
+}
+
 func TestEvictionLimiter_NamespaceLimitExceeded(t *testing.T) {
 	type testCase struct {
 		name             string
@@ -306,6 +334,50 @@ func TestEvictionLimiter_NamespaceLimitExceeded(t *testing.T) {
 	}
 }
 
+func TestEvictionLimiter_MaxPodsToEvictPerQuota(t *testing.T) {
+	makeQuotaPod := func(name, quotaName string) *corev1.Pod {
+		pod := makeTestPod("default", name, "node-1")
+		if quotaName != "" {
+			pod.Labels = map[string]string{extension.LabelQuotaName: quotaName}
+		}
+		return pod
+	}
+
+	limiter := NewEvictionLimiter(nil, nil, nil)
+	limiter.SetMaxPodsToEvictPerQuota(map[string]uint{"team-a": 1, "team-b": 2})
+
+	evictSeq := []*corev1.Pod{
+		makeQuotaPod("a-1", "team-a"),
+		makeQuotaPod("a-2", "team-a"),
+		makeQuotaPod("b-1", "team-b"),
+		makeQuotaPod("b-2", "team-b"),
+		makeQuotaPod("b-3", "team-b"),
+		makeQuotaPod("c-1", "team-c"),
+		makeQuotaPod("c-2", "team-c"),
+		makeQuotaPod("no-quota", ""),
+	}
+	expectAllow := []bool{true, false, true, true, false, true, true, true}
+	for i, pod := range evictSeq {
+		allowed := limiter.AllowEvict(pod)
+		assert.Equal(t, expectAllow[i], allowed, "pod %s", pod.Name)
+		if allowed {
+			limiter.Done(pod)
+		}
+	}
+
+	assert.Equal(t, uint(1), limiter.QuotaEvicted("team-a"))
+	assert.Equal(t, uint(2), limiter.QuotaEvicted("team-b"))
+	assert.Equal(t, uint(2), limiter.QuotaEvicted("team-c"))
+	assert.True(t, limiter.QuotaLimitExceeded("team-a"))
+	assert.True(t, limiter.QuotaLimitExceeded("team-b"))
+	assert.False(t, limiter.QuotaLimitExceeded("team-c"))
+	assert.Equal(t, uint(6), limiter.TotalEvicted())
+
+	limiter.Reset()
+	assert.False(t, limiter.QuotaLimitExceeded("team-a"))
+	assert.True(t, limiter.AllowEvict(makeQuotaPod("a-2", "team-a")))
+}
+
 func TestEvictionLimiter_Reset(t *testing.T) {
 	limit := uint(1)
 	limiter := NewEvictionLimiter(&limit, &limit, &limit)
@@ -325,3 +397,174 @@ func TestEvictionLimiter_Reset(t *testing.T) {
 	assert.False(t, limiter.NamespaceLimitExceeded("default"))
 	assert.Equal(t, uint(0), limiter.TotalEvicted())
 }
+
+func TestEvictionLimiter_MaxEvictionFraction(t *testing.T) {
+	tests := []struct {
+		name           string
+		totalLimit     *uint
+		fraction       float64
+		runningPods    int
+		countErr       error
+		expectedAllows int
+	}{
+		{
+			name:           "fractional limit is tighter",
+			totalLimit:     uintPtr(5),
+			fraction:       0.2,
+			runningPods:    10,
+			expectedAllows: 2,
+		},
+		{
+			name:           "absolute limit is tighter",
+			totalLimit:     uintPtr(1),
+			fraction:       0.5,
+			runningPods:    10,
+			expectedAllows: 1,
+		},
+		{
+			name:           "only fractional limit",
+			fraction:       0.3,
+			runningPods:    10,
+			expectedAllows: 3,
+		},
+		{
+			name:           "failed to count running pods",
+			totalLimit:     uintPtr(4),
+			fraction:       0.1,
+			countErr:       errors.New("failed"),
+			expectedAllows: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewEvictionLimiter(nil, nil, tt.totalLimit)
+			limiter.SetMaxEvictionFraction(tt.fraction, func() (int, error) {
+				return tt.runningPods, tt.countErr
+			})
+			limiter.Reset()
+
+			allows := 0
+			for i := 0; i < 10; i++ {
+				pod := makeTestPod("default", fmt.Sprintf("pod-%d", i), fmt.Sprintf("node-%d", i))
+				if limiter.AllowEvict(pod) {
+					limiter.Done(pod)
+					allows++
+				}
+			}
+			assert.Equal(t, tt.expectedAllows, allows)
+		})
+	}
+}
+
+func TestEvictionLimiter_MaxEvictionFractionRecountedOnReset(t *testing.T) {
+	runningPods := 10
+	limiter := NewEvictionLimiter(nil, nil, nil)
+	limiter.SetMaxEvictionFraction(0.1, func() (int, error) {
+		return runningPods, nil
+	})
+
+	limiter.Reset()
+	assert.True(t, limiter.AllowEvict(makeTestPod("default", "pod-1", "node-1")))
+	limiter.Done(makeTestPod("default", "pod-1", "node-1"))
+	assert.False(t, limiter.AllowEvict(makeTestPod("default", "pod-2", "node-2")))
+
+	runningPods = 5
+	limiter.Reset()
+	assert.False(t, limiter.AllowEvict(makeTestPod("default", "pod-2", "node-2")))
+}
+
+func TestEvictionLimiter_Claim(t *testing.T) {
+	limiter := NewEvictionLimiter(nil, nil, nil)
+	pod := makeTestPod("default", "pod-1", "node-1")
+
+	claimedBy, ok := limiter.Claim(pod, "profile-a")
+	assert.True(t, ok)
+	assert.Equal(t, "profile-a", claimedBy)
+
+	// the profile claiming the pod can evict it again, e.g. on retries
+	claimedBy, ok = limiter.Claim(pod, "profile-a")
+	assert.True(t, ok)
+	assert.Equal(t, "profile-a", claimedBy)
+
+	claimedBy, ok = limiter.Claim(pod, "profile-b")
+	assert.False(t, ok)
+	assert.Equal(t, "profile-a", claimedBy)
+
+	// the claim is given up if the pod failed to be evicted
+	limiter.Release(pod, "profile-b")
+	_, ok = limiter.Claim(pod, "profile-b")
+	assert.False(t, ok)
+	limiter.Release(pod, "profile-a")
+	claimedBy, ok = limiter.Claim(pod, "profile-b")
+	assert.True(t, ok)
+	assert.Equal(t, "profile-b", claimedBy)
+
+	// the claim of an evicted pod is kept until the limiter is reset
+	limiter.Done(pod)
+	limiter.Release(pod, "profile-b")
+	_, ok = limiter.Claim(pod, "profile-a")
+	assert.False(t, ok)
+	assert.Equal(t, uint(1), limiter.TotalEvicted())
+
+	limiter.Reset()
+	claimedBy, ok = limiter.Claim(pod, "profile-a")
+	assert.True(t, ok)
+	assert.Equal(t, "profile-a", claimedBy)
+}
+
+func TestEvictionLimiter_Reserve(t *testing.T) {
+	limiter := NewEvictionLimiter(uintPtr(1), nil, uintPtr(2))
+	pod1 := makeTestPod("default", "pod-1", "node-1")
+	pod2 := makeTestPod("default", "pod-2", "node-1")
+
+	assert.True(t, limiter.Reserve(pod1, false))
+	assert.Equal(t, uint(1), limiter.NodeEvicted("node-1"))
+	// the reserved pod counts against the limits before it is evicted
+	assert.False(t, limiter.Reserve(pod2, false))
+
+	// the counts are given back if the eviction fails
+	limiter.Unreserve(pod1)
+	assert.Equal(t, uint(0), limiter.NodeEvicted("node-1"))
+	assert.Equal(t, uint(0), limiter.TotalEvicted())
+	assert.True(t, limiter.Reserve(pod2, false))
+	limiter.Confirm(pod2)
+	assert.Equal(t, uint(1), limiter.TotalEvicted())
+
+	assert.True(t, limiter.Reserve(pod1, true))
+	assert.False(t, limiter.Reserve(makeTestPod("default", "pod-3", "node-2"), false))
+	assert.Equal(t, uint(2), limiter.TotalEvicted())
+}
+
+func TestEvictionLimiter_ReserveConcurrently(t *testing.T) {
+	limiter := NewEvictionLimiter(uintPtr(2), uintPtr(3), uintPtr(5))
+	limiter.SetMaxPodsToEvictPerQuota(map[string]uint{"quota-a": 1})
+
+	var wg sync.WaitGroup
+	var reserved int32
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pod := makeTestPod(fmt.Sprintf("ns-%d", i%4), fmt.Sprintf("pod-%d", i), fmt.Sprintf("node-%d", i%3))
+			if i%5 == 0 {
+				pod.Labels = map[string]string{extension.LabelQuotaName: "quota-a"}
+			}
+			if limiter.Reserve(pod, false) {
+				atomic.AddInt32(&reserved, 1)
+				limiter.Confirm(pod)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint(5), limiter.TotalEvicted())
+	assert.Equal(t, int32(5), atomic.LoadInt32(&reserved))
+	for i := 0; i < 3; i++ {
+		assert.LessOrEqual(t, limiter.NodeEvicted(fmt.Sprintf("node-%d", i)), uint(2))
+	}
+	for i := 0; i < 4; i++ {
+		assert.LessOrEqual(t, limiter.NamespaceEvicted(fmt.Sprintf("ns-%d", i)), uint(3))
+	}
+	assert.LessOrEqual(t, limiter.QuotaEvicted("quota-a"), uint(1))
+}
@@ -47,6 +47,7 @@ const (
 
 type nodePodEvictedCount map[string]uint
 type namespacePodEvictCount map[string]uint
+type quotaPodEvictCount map[string]uint
 
 type PodEvictor struct {
 	client                     clientset.Interface
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
+)
+
+const safeModeWindow = time.Minute
+
+// SafeMode halts all the evictions for a cooldown period once the number of evictions
+// within the last minute across all the profiles reaches the configured rate.
+type SafeMode struct {
+	lock          sync.Mutex
+	clock         clock.Clock
+	rate          int
+	cooldown      time.Duration
+	evictionTimes []time.Time
+	activeUntil   time.Time
+}
+
+func NewSafeMode(rate int32, cooldown time.Duration) *SafeMode {
+	return newSafeModeWithClock(rate, cooldown, clock.RealClock{})
+}
+
+func newSafeModeWithClock(rate int32, cooldown time.Duration, clock clock.Clock) *SafeMode {
+	return &SafeMode{
+		clock:    clock,
+		rate:     int(rate),
+		cooldown: cooldown,
+	}
+}
+
+// Allow returns false if the safe mode is active or the eviction rate has reached the limit.
+func (s *SafeMode) Allow() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.clock.Now()
+	if !s.activeUntil.IsZero() {
+		if now.Before(s.activeUntil) {
+			return false
+		}
+		klog.InfoS("Descheduler left the safe mode after cooldown", "cooldown", s.cooldown)
+		s.recoverLocked()
+	}
+
+	s.pruneLocked(now)
+	if len(s.evictionTimes) >= s.rate {
+		s.activeUntil = now.Add(s.cooldown)
+		s.evictionTimes = nil
+		metrics.SafeModeActive.Set(1)
+		metrics.SafeModeTriggered.Inc()
+		klog.ErrorS(fmt.Errorf("eviction rate exceeded the safe mode threshold"), "Descheduler entered the safe mode, all evictions are halted",
+			"rate", s.rate, "window", safeModeWindow, "cooldown", s.cooldown, "until", s.activeUntil)
+		return false
+	}
+	return true
+}
+
+// Record records an eviction.
+func (s *SafeMode) Record() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.clock.Now()
+	s.pruneLocked(now)
+	s.evictionTimes = append(s.evictionTimes, now)
+}
+
+// Active returns whether the safe mode is active.
+func (s *SafeMode) Active() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return !s.activeUntil.IsZero() && s.clock.Now().Before(s.activeUntil)
+}
+
+// Reset leaves the safe mode immediately and clears the recorded evictions.
+func (s *SafeMode) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.activeUntil.IsZero() {
+		klog.InfoS("Descheduler left the safe mode by manual reset")
+	}
+	s.recoverLocked()
+}
+
+func (s *SafeMode) recoverLocked() {
+	s.activeUntil = time.Time{}
+	s.evictionTimes = nil
+	metrics.SafeModeActive.Set(0)
+}
+
+func (s *SafeMode) pruneLocked(now time.Time) {
+	i := 0
+	for ; i < len(s.evictionTimes); i++ {
+		if now.Sub(s.evictionTimes[i]) < safeModeWindow {
+			break
+		}
+	}
+	s.evictionTimes = s.evictionTimes[i:]
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestSafeMode(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	safeMode := newSafeModeWithClock(3, 10*time.Minute, fakeClock)
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, safeMode.Allow())
+		safeMode.Record()
+	}
+	assert.False(t, safeMode.Active())
+
+	// the 4th eviction within one minute triggers the safe mode
+	assert.False(t, safeMode.Allow())
+	assert.True(t, safeMode.Active())
+
+	fakeClock.Step(5 * time.Minute)
+	assert.False(t, safeMode.Allow())
+	assert.True(t, safeMode.Active())
+
+	// recover after cooldown
+	fakeClock.Step(5 * time.Minute)
+	assert.False(t, safeMode.Active())
+	assert.True(t, safeMode.Allow())
+}
+
+func TestSafeModeSlidingWindow(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	safeMode := newSafeModeWithClock(2, 10*time.Minute, fakeClock)
+
+	assert.True(t, safeMode.Allow())
+	safeMode.Record()
+	fakeClock.Step(40 * time.Second)
+	assert.True(t, safeMode.Allow())
+	safeMode.Record()
+
+	// the first eviction is out of the window
+	fakeClock.Step(30 * time.Second)
+	assert.True(t, safeMode.Allow())
+	safeMode.Record()
+	assert.False(t, safeMode.Allow())
+	assert.True(t, safeMode.Active())
+}
+
+func TestSafeModeReset(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	safeMode := newSafeModeWithClock(1, 10*time.Minute, fakeClock)
+
+	assert.True(t, safeMode.Allow())
+	safeMode.Record()
+	assert.False(t, safeMode.Allow())
+	assert.True(t, safeMode.Active())
+
+	safeMode.Reset()
+	assert.False(t, safeMode.Active())
+	assert.True(t, safeMode.Allow())
+}
+
+func TestEvictionLimiterWithSafeMode(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	limiter := NewEvictionLimiter(nil, nil, nil)
+	limiter.SetSafeMode(newSafeModeWithClock(2, time.Minute, fakeClock))
+
+	for i, name := range []string{"pod-1", "pod-2"} {
+		pod := makeTestPod("default", name, "node-1")
+		assert.True(t, limiter.AllowEvict(pod), "eviction %d", i)
+		limiter.Done(pod)
+	}
+	// resetting the limiter in a new descheduling cycle keeps the safe mode
+	limiter.Reset()
+	assert.False(t, limiter.AllowEvict(makeTestPod("default", "pod-3", "node-2")))
+
+	fakeClock.Step(time.Minute)
+	assert.True(t, limiter.AllowEvict(makeTestPod("default", "pod-3", "node-2")))
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Koordinator Authors.
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+)
+
+type cycleDeadlineKey struct{}
+
+// CycleDeadline limits the runtime of a descheduling cycle. The plugins check it before processing each node,
+// and stop gracefully once it is exceeded, recording the nodes left unprocessed for the next cycle.
+type CycleDeadline struct {
+	clock    clock.Clock
+	deadline time.Time
+
+	lock             sync.Mutex
+	truncated        bool
+	unprocessedNodes sets.String
+}
+
+// NewCycleDeadline returns a CycleDeadline expiring after maxDuration from now.
+func NewCycleDeadline(clock clock.Clock, maxDuration time.Duration) *CycleDeadline {
+	return &CycleDeadline{
+		clock:            clock,
+		deadline:         clock.Now().Add(maxDuration),
+		unprocessedNodes: sets.NewString(),
+	}
+}
+
+// WithCycleDeadline returns a copy of ctx carrying the CycleDeadline.
+func WithCycleDeadline(ctx context.Context, deadline *CycleDeadline) context.Context {
+	return context.WithValue(ctx, cycleDeadlineKey{}, deadline)
+}
+
+// CycleDeadlineFrom returns the CycleDeadline carried by ctx, or nil if the cycle is not limited.
+func CycleDeadlineFrom(ctx context.Context) *CycleDeadline {
+	deadline, _ := ctx.Value(cycleDeadlineKey{}).(*CycleDeadline)
+	return deadline
+}
+
+// ExceedCycleDeadline reports whether the descheduling cycle carried by ctx has exceeded its maximum duration.
+// If so, the unprocessedNodes are recorded to be processed first in the next cycle.
+func ExceedCycleDeadline(ctx context.Context, unprocessedNodes []*corev1.Node) bool {
+	deadline := CycleDeadlineFrom(ctx)
+	if deadline == nil || !deadline.Exceeded() {
+		return false
+	}
+	names := make([]string, 0, len(unprocessedNodes))
+	for _, node := range unprocessedNodes {
+		names = append(names, node.Name)
+	}
+	deadline.Truncate(names...)
+	return true
+}
+
+// Exceeded reports whether the deadline has passed.
+func (c *CycleDeadline) Exceeded() bool {
+	return !c.clock.Now().Before(c.deadline)
+}
+
+// Truncate marks the cycle as truncated with the unprocessed nodes.
+func (c *CycleDeadline) Truncate(unprocessedNodes ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.truncated = true
+	c.unprocessedNodes.Insert(unprocessedNodes...)
+}
+
+// Truncated reports whether any plugin stopped early because the deadline was exceeded.
+func (c *CycleDeadline) Truncated() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.truncated
+}
+
+// UnprocessedNodes returns the names of the nodes left unprocessed in the truncated cycle.
+func (c *CycleDeadline) UnprocessedNodes() sets.String {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return sets.NewString(c.unprocessedNodes.UnsortedList()...)
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpuidle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	slolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
+)
+
+const (
+	RemoveIdleGPUPodsName = "RemoveIdleGPUPods"
+)
+
+var _ framework.DeschedulePlugin = &RemoveIdleGPUPods{}
+
+// gpuResourceNames are the resources indicating the pod holds GPUs.
+var gpuResourceNames = []corev1.ResourceName{
+	extension.ResourceNvidiaGPU,
+	extension.ResourceGPU,
+	extension.ResourceGPUShared,
+	extension.ResourceGPUCore,
+	extension.ResourceGPUMemory,
+	extension.ResourceGPUMemoryRatio,
+}
+
+// RemoveIdleGPUPods evicts the pods holding GPUs whose GPU utilization reported in the NodeMetric
+// keeps below the threshold for the idle duration, so that the expensive GPUs can be reclaimed.
+// The idle time of a pod is tracked in memory, it starts over once the descheduler restarts.
+type RemoveIdleGPUPods struct {
+	handle           framework.Handle
+	podFilter        framework.FilterFunc
+	args             *deschedulerconfig.RemoveIdleGPUPodsArgs
+	nodeMetricLister slolisters.NodeMetricLister
+	clock            clock.Clock
+	// idleSince records the time since when the pods have been observed idle.
+	idleSince map[types.UID]time.Time
+}
+
+// NewRemoveIdleGPUPods builds plugin from its arguments while passing a handle
+func NewRemoveIdleGPUPods(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	idleGPUArgs, ok := args.(*deschedulerconfig.RemoveIdleGPUPodsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemoveIdleGPUPodsArgs, got %T", args)
+	}
+	if err := validation.ValidateRemoveIdleGPUPodsArgs(nil, idleGPUArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if idleGPUArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(idleGPUArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(idleGPUArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, isNotExempted)).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	koordClientSet, ok := handle.(koordclientset.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		koordClientSet, err = koordclientset.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+	nodeMetricInformer := koordSharedInformerFactory.Slo().V1alpha1().NodeMetrics()
+	nodeMetricInformer.Informer()
+	koordSharedInformerFactory.Start(context.TODO().Done())
+	koordSharedInformerFactory.WaitForCacheSync(context.TODO().Done())
+
+	return &RemoveIdleGPUPods{
+		handle:           handle,
+		podFilter:        podFilter,
+		args:             idleGPUArgs,
+		nodeMetricLister: nodeMetricInformer.Lister(),
+		clock:            clock.RealClock{},
+		idleSince:        map[types.UID]time.Time{},
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *RemoveIdleGPUPods) Name() string {
+	return RemoveIdleGPUPodsName
+}
+
+// Deschedule extension point implementation for the plugin
+func (pl *RemoveIdleGPUPods) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("RemoveIdleGPUPods is paused and will do nothing.")
+		return nil
+	}
+
+	now := pl.clock.Now()
+	observedPods := sets.New[types.UID]()
+	truncated := false
+	for i, node := range nodes {
+		if framework.ExceedCycleDeadline(ctx, nodes[i:]) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d nodes left unprocessed", RemoveIdleGPUPodsName, len(nodes)-i)
+			truncated = true
+			break
+		}
+		podMetrics := pl.getPodMetrics(node, now)
+		if podMetrics == nil {
+			continue
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			if !holdsGPU(pod) {
+				continue
+			}
+			podMetric := podMetrics[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]
+			if !isGPUIdle(podMetric, pl.args.UtilizationThreshold) {
+				continue
+			}
+			observedPods.Insert(pod.UID)
+			idleSince, ok := pl.idleSince[pod.UID]
+			if !ok {
+				pl.idleSince[pod.UID] = now
+				continue
+			}
+			if idleDuration := now.Sub(idleSince); idleDuration < pl.args.IdleDuration.Duration {
+				klog.V(5).InfoS("Pod is idle on GPUs but not long enough", "pod", klog.KObj(pod), "node", klog.KObj(node), "idleDuration", idleDuration)
+				continue
+			}
+			if !pl.handle.Evictor().PreEvictionFilter(pod) {
+				klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			evictionOptions := framework.EvictOptions{
+				PluginName: RemoveIdleGPUPodsName,
+				Reason:     fmt.Sprintf("GPU utilization is below %d%% for more than %v", pl.args.UtilizationThreshold, pl.args.IdleDuration.Duration),
+			}
+			if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		}
+	}
+
+	// The pods not observed idle start over, unless their nodes are left unprocessed by the truncated cycle.
+	if !truncated {
+		for uid := range pl.idleSince {
+			if !observedPods.Has(uid) {
+				delete(pl.idleSince, uid)
+			}
+		}
+	}
+	return nil
+}
+
+// getPodMetrics returns the pod metrics in the NodeMetric of the node, or nil if the NodeMetric is missing or expired.
+func (pl *RemoveIdleGPUPods) getPodMetrics(node *corev1.Node, now time.Time) map[types.NamespacedName]*slov1alpha1.PodMetricInfo {
+	nodeMetric, err := pl.nodeMetricLister.Get(node.Name)
+	if err != nil {
+		klog.V(4).InfoS("Failed to get NodeMetric", "node", klog.KObj(node), "err", err)
+		return nil
+	}
+	updateTime := nodeMetric.Status.UpdateTime
+	if updateTime == nil || pl.args.NodeMetricExpirationSeconds != nil &&
+		now.Sub(updateTime.Time) >= time.Duration(*pl.args.NodeMetricExpirationSeconds)*time.Second {
+		klog.V(4).InfoS("NodeMetric has expired", "node", klog.KObj(node), "updateTime", updateTime)
+		return nil
+	}
+	podMetrics := make(map[types.NamespacedName]*slov1alpha1.PodMetricInfo, len(nodeMetric.Status.PodsMetric))
+	for _, podMetric := range nodeMetric.Status.PodsMetric {
+		if podMetric != nil {
+			podMetrics[types.NamespacedName{Namespace: podMetric.Namespace, Name: podMetric.Name}] = podMetric
+		}
+	}
+	return podMetrics
+}
+
+func isNotExempted(pod *corev1.Pod) bool {
+	return pod.Annotations[extension.AnnotationNoEvict] != "true"
+}
+
+func holdsGPU(pod *corev1.Pod) bool {
+	for _, resourceName := range gpuResourceNames {
+		if utils.GetResourceRequest(pod, resourceName) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isGPUIdle returns true if the pod metric reports the utilization of at least one GPU,
+// and the utilization of every GPU is below the threshold.
+func isGPUIdle(podMetric *slov1alpha1.PodMetricInfo, utilizationThreshold int32) bool {
+	if podMetric == nil {
+		return false
+	}
+	reported := false
+	for _, device := range podMetric.PodUsage.Devices {
+		if device.Type != schedulingv1alpha1.GPU {
+			continue
+		}
+		coreUsage, ok := device.Resources[extension.ResourceGPUCore]
+		if !ok {
+			continue
+		}
+		if coreUsage.Value() >= int64(utilizationThreshold) {
+			return false
+		}
+		reported = true
+	}
+	return reported
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpuidle
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+type fakeFrameworkHandle struct {
+	framework.Handle
+	koordinatorclientset.Interface
+}
+
+func TestNewRemoveIdleGPUPodsWithInvalidArgs(t *testing.T) {
+	_, err := NewRemoveIdleGPUPods(&deschedulerconfig.RemoveIdleGPUPodsArgs{
+		UtilizationThreshold: 101,
+		IdleDuration:         metav1.Duration{Duration: time.Minute},
+	}, nil)
+	assert.Error(t, err)
+	_, err = NewRemoveIdleGPUPods(&deschedulerconfig.RemoveIdleGPUPodsArgs{
+		UtilizationThreshold: 10,
+	}, nil)
+	assert.Error(t, err)
+	_, err = NewRemoveIdleGPUPods(&deschedulerconfig.LowNodeLoadArgs{}, nil)
+	assert.Error(t, err)
+}
+
+func TestIsGPUIdle(t *testing.T) {
+	gpuDevice := func(utilization int64) schedulingv1alpha1.DeviceInfo {
+		return schedulingv1alpha1.DeviceInfo{
+			Type: schedulingv1alpha1.GPU,
+			Resources: corev1.ResourceList{
+				extension.ResourceGPUCore: *resource.NewQuantity(utilization, resource.DecimalSI),
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		podMetric *slov1alpha1.PodMetricInfo
+		want      bool
+	}{
+		{
+			name: "missing pod metric",
+		},
+		{
+			name:      "no GPU reported",
+			podMetric: &slov1alpha1.PodMetricInfo{},
+		},
+		{
+			name: "all GPUs idle",
+			podMetric: &slov1alpha1.PodMetricInfo{
+				PodUsage: slov1alpha1.ResourceMap{Devices: []schedulingv1alpha1.DeviceInfo{gpuDevice(0), gpuDevice(9)}},
+			},
+			want: true,
+		},
+		{
+			name: "one GPU busy",
+			podMetric: &slov1alpha1.PodMetricInfo{
+				PodUsage: slov1alpha1.ResourceMap{Devices: []schedulingv1alpha1.DeviceInfo{gpuDevice(0), gpuDevice(10)}},
+			},
+		},
+		{
+			name: "ignore the other devices",
+			podMetric: &slov1alpha1.PodMetricInfo{
+				PodUsage: slov1alpha1.ResourceMap{Devices: []schedulingv1alpha1.DeviceInfo{
+					gpuDevice(1),
+					{Type: schedulingv1alpha1.RDMA},
+				}},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isGPUIdle(tt.podMetric, 10))
+		})
+	}
+}
+
+func TestRemoveIdleGPUPods(t *testing.T) {
+	withGPU := func(annotations map[string]string) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Annotations = annotations
+			pod.Spec.Containers[0].Resources.Requests[extension.ResourceNvidiaGPU] = resource.MustParse("1")
+		}
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("idle-gpu", 100, 0, "node-1", withGPU(nil)),
+		test.BuildTestPod("busy-gpu", 100, 0, "node-1", withGPU(nil)),
+		test.BuildTestPod("idle-gpu-no-evict", 100, 0, "node-1", withGPU(map[string]string{extension.AnnotationNoEvict: "true"})),
+		test.BuildTestPod("no-gpu", 100, 0, "node-1", test.SetRSOwnerRef),
+		test.BuildTestPod("idle-gpu-2", 100, 0, "node-2", withGPU(nil)),
+	}
+	nodes := []*corev1.Node{
+		test.BuildTestNode("node-1", 4000, 3000, 10, nil),
+		test.BuildTestNode("node-2", 4000, 3000, 10, nil),
+	}
+	utilizations := map[string]int64{
+		"idle-gpu":          2,
+		"busy-gpu":          80,
+		"idle-gpu-no-evict": 0,
+		"no-gpu":            0,
+		"idle-gpu-2":        1,
+	}
+
+	tests := []struct {
+		name             string
+		args             *deschedulerconfig.RemoveIdleGPUPodsArgs
+		elapsed          time.Duration
+		metricAge        time.Duration
+		maxEvictionTotal *uint
+		expectedEvicted  []string
+		expectedCount    int
+	}{
+		{
+			name:            "evict pods idle for longer than the idle duration",
+			elapsed:         11 * time.Minute,
+			expectedEvicted: []string{"idle-gpu", "idle-gpu-2"},
+			expectedCount:   2,
+		},
+		{
+			name:    "not evict pods idle for shorter than the idle duration",
+			elapsed: 5 * time.Minute,
+		},
+		{
+			name:             "respect the eviction limits",
+			elapsed:          11 * time.Minute,
+			maxEvictionTotal: func() *uint { v := uint(1); return &v }(),
+			expectedCount:    1,
+		},
+		{
+			name:      "skip the expired NodeMetrics",
+			elapsed:   11 * time.Minute,
+			metricAge: 2 * time.Hour,
+		},
+		{
+			name: "respect the evictable namespaces",
+			args: &deschedulerconfig.RemoveIdleGPUPodsArgs{
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"default"},
+				},
+			},
+			elapsed: 11 * time.Minute,
+		},
+		{
+			name: "paused",
+			args: &deschedulerconfig.RemoveIdleGPUPodsArgs{
+				Paused: true,
+			},
+			elapsed: 11 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			args := &deschedulerconfig.RemoveIdleGPUPodsArgs{}
+			if tt.args != nil {
+				args = tt.args.DeepCopy()
+			}
+			args.UtilizationThreshold = 10
+			args.IdleDuration = metav1.Duration{Duration: 10 * time.Minute}
+			expirationSeconds := int64(time.Hour / time.Second)
+			args.NodeMetricExpirationSeconds = &expirationSeconds
+
+			fakeClock := clocktesting.NewFakeClock(time.Now())
+			var koordObjs []runtime.Object
+			for _, node := range nodes {
+				nodeMetric := &slov1alpha1.NodeMetric{
+					ObjectMeta: metav1.ObjectMeta{Name: node.Name},
+				}
+				for _, pod := range pods {
+					if pod.Spec.NodeName != node.Name {
+						continue
+					}
+					nodeMetric.Status.PodsMetric = append(nodeMetric.Status.PodsMetric, &slov1alpha1.PodMetricInfo{
+						Namespace: pod.Namespace,
+						Name:      pod.Name,
+						PodUsage: slov1alpha1.ResourceMap{
+							Devices: []schedulingv1alpha1.DeviceInfo{
+								{
+									Type: schedulingv1alpha1.GPU,
+									Resources: corev1.ResourceList{
+										extension.ResourceGPUCore: *resource.NewQuantity(utilizations[pod.Name], resource.DecimalSI),
+									},
+								},
+							},
+						},
+					})
+				}
+				koordObjs = append(koordObjs, nodeMetric)
+			}
+			koordClientSet := koordfake.NewSimpleClientset(koordObjs...)
+			setMetricUpdateTime := func(updateTime time.Time) {
+				for _, node := range nodes {
+					nodeMetric, err := koordClientSet.SloV1alpha1().NodeMetrics().Get(ctx, node.Name, metav1.GetOptions{})
+					assert.NoError(t, err)
+					nodeMetric.Status.UpdateTime = &metav1.Time{Time: updateTime}
+					_, err = koordClientSet.SloV1alpha1().NodeMetrics().UpdateStatus(ctx, nodeMetric, metav1.UpdateOptions{})
+					assert.NoError(t, err)
+				}
+			}
+			setMetricUpdateTime(fakeClock.Now())
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			var plugin *RemoveIdleGPUPods
+
+			fh, err := frameworktesting.NewPluginTestEnv(ctx, objs, tt.maxEvictionTotal,
+				func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+					reg.Register(RemoveIdleGPUPodsName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+						pl, err := NewRemoveIdleGPUPods(args, &fakeFrameworkHandle{
+							Handle:    handle,
+							Interface: koordClientSet,
+						})
+						if err != nil {
+							return nil, err
+						}
+						plugin = pl.(*RemoveIdleGPUPods)
+						plugin.clock = fakeClock
+						return pl, nil
+					})
+					profile.Plugins.Deschedule.Enabled = append(profile.Plugins.Deschedule.Enabled, deschedulerconfig.Plugin{Name: RemoveIdleGPUPodsName})
+					profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+						Name: RemoveIdleGPUPodsName,
+						Args: args,
+					})
+				},
+			)
+			assert.NoError(t, err)
+
+			// the first cycle only observes the idle pods
+			fh.RunDeschedulePlugins(ctx, nodes)
+			assert.Empty(t, fh.EvictedPods())
+
+			fakeClock.Step(tt.elapsed)
+			if tt.metricAge > 0 {
+				setMetricUpdateTime(fakeClock.Now().Add(-tt.metricAge))
+			} else {
+				setMetricUpdateTime(fakeClock.Now())
+			}
+			assert.Eventually(t, func() bool {
+				nodeMetric, err := plugin.nodeMetricLister.Get(nodes[0].Name)
+				return err == nil && nodeMetric.Status.UpdateTime.Time.Equal(fakeClock.Now().Add(-tt.metricAge))
+			}, 5*time.Second, 10*time.Millisecond)
+			fh.RunDeschedulePlugins(ctx, nodes)
+			evictedPods := fh.EvictedPods()
+
+			assert.Len(t, evictedPods, tt.expectedCount)
+			if tt.expectedEvicted != nil {
+				sort.Strings(evictedPods)
+				assert.Equal(t, tt.expectedEvicted, evictedPods)
+			}
+		})
+	}
+}
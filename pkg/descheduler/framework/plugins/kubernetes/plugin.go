@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removeduplicates"
@@ -190,6 +191,11 @@ func (a *PluginAdaptor) Deschedule(ctx context.Context, nodes []*corev1.Node) *f
 		}
 	}
 
+	// the upstream plugins do not check the cycle deadline, so they are only skipped once it is exceeded
+	if framework.ExceedCycleDeadline(ctx, nodes) {
+		klog.Infof("%s is skipped as the descheduling cycle exceeded its max duration", a.descriptor.Name)
+		return &framework.Status{}
+	}
 	status := deschedulePlugin.Deschedule(ctx, nodes)
 	if status != nil {
 		return &framework.Status{
@@ -207,6 +213,11 @@ func (a *PluginAdaptor) Balance(ctx context.Context, nodes []*corev1.Node) *fram
 		}
 	}
 
+	// the upstream plugins do not check the cycle deadline, so they are only skipped once it is exceeded
+	if framework.ExceedCycleDeadline(ctx, nodes) {
+		klog.Infof("%s is skipped as the descheduling cycle exceeded its max duration", a.descriptor.Name)
+		return &framework.Status{}
+	}
 	status := balancePlugin.Balance(ctx, nodes)
 	if status != nil {
 		return &framework.Status{
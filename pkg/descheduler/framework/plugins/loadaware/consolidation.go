@@ -0,0 +1,183 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+)
+
+const consolidationEvictionReason = "node is underutilized and drained for consolidation"
+
+// consolidateNodes drains the underutilized nodes from the least utilized one, so that the cluster autoscaler can
+// scale them down. A node is drained only if all its evictable pods fit on the remaining nodes without exceeding
+// the consolidation target utilization, otherwise it is kept as a destination of the following nodes.
+// The usages of the destination nodes are updated with the drained pods, and the drained nodes are returned.
+func (pl *LowNodeLoad) consolidateNodes(ctx context.Context, nodePoolName string, candidateNodes []NodeInfo,
+	nodeUsages map[string]*NodeUsage, resourceWeights map[corev1.ResourceName]int64) sets.String {
+	drainedNodes := sets.NewString()
+	if len(candidateNodes) == 0 {
+		return drainedNodes
+	}
+
+	candidates := make([]NodeInfo, len(candidateNodes))
+	copy(candidates, candidateNodes)
+	sortNodesByUsage(candidates, resourceWeights, true, false)
+
+	pendingNodes := sets.NewString()
+	for _, v := range candidates {
+		pendingNodes.Insert(v.node.Name)
+	}
+	targetThresholds := newConsolidationThresholds(nodeUsages, pl.args.ConsolidationTargetUtilization)
+
+	for i, candidate := range candidates {
+		if framework.ExceedCycleDeadline(ctx, nodesOf(candidates[i:])) {
+			klog.Infof("%s stops consolidating as the descheduling cycle exceeded its max duration, %d candidate nodes left unprocessed in nodePool %s",
+				LowNodeLoadName, len(candidates)-i, nodePoolName)
+			break
+		}
+		pendingNodes.Delete(candidate.node.Name)
+		var destinationNodes []NodeInfo
+		for name, nodeUsage := range nodeUsages {
+			if name == candidate.node.Name || pendingNodes.Has(name) || drainedNodes.Has(name) {
+				continue
+			}
+			destinationNodes = append(destinationNodes, NodeInfo{NodeUsage: nodeUsage})
+		}
+		if len(destinationNodes) == 0 {
+			break
+		}
+		// fill up the most utilized nodes first to leave the others to be consolidated
+		sortNodesByUsage(destinationNodes, resourceWeights, false, false)
+		targetNodes := make([]*corev1.Node, 0, len(destinationNodes))
+		for _, v := range destinationNodes {
+			targetNodes = append(targetNodes, v.node)
+		}
+
+		_, removablePods := classifyPods(candidate.allPods, pl.podFilter)
+		if len(removablePods) == 0 {
+			continue
+		}
+		destinationUsages, ok := pl.fitPodsOnNodes(candidate, removablePods, targetNodes, nodeUsages, targetThresholds)
+		if !ok {
+			klog.V(4).InfoS("Node cannot be drained for consolidation, its pods do not fit on the remaining nodes",
+				"node", klog.KObj(candidate.node), "nodePool", nodePoolName)
+			continue
+		}
+
+		klog.V(4).InfoS("Draining node for consolidation", "node", klog.KObj(candidate.node), "pods", len(removablePods), "nodePool", nodePoolName)
+		if !pl.evictPodsForConsolidation(ctx, nodePoolName, candidate, removablePods) {
+			break
+		}
+		for name, usage := range destinationUsages {
+			nodeUsages[name].usage = usage
+		}
+		drainedNodes.Insert(candidate.node.Name)
+	}
+	return drainedNodes
+}
+
+// fitPodsOnNodes checks whether all the pods fit on the target nodes under the thresholds,
+// and returns the usages of the target nodes with the pods placed if so.
+func (pl *LowNodeLoad) fitPodsOnNodes(nodeInfo NodeInfo, pods []*corev1.Pod, targetNodes []*corev1.Node,
+	nodeUsages map[string]*NodeUsage, thresholds map[string]NodeThresholds) (map[string]map[corev1.ResourceName]*resource.Quantity, bool) {
+	clonedUsages := make(map[string]*NodeUsage, len(targetNodes))
+	for _, node := range targetNodes {
+		nodeUsage := *nodeUsages[node.Name]
+		nodeUsage.usage = copyUsage(nodeUsage.usage)
+		clonedUsages[node.Name] = &nodeUsage
+	}
+	for _, pod := range pods {
+		podMetric := nodeInfo.podMetrics[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]
+		if podMetric == nil {
+			klog.V(4).InfoS("Failed to find PodMetric", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node))
+			return nil, false
+		}
+		if !podFitsAnyNodeWithThreshold(pl.handle.GetPodsAssignedToNodeFunc(), pod, targetNodes, clonedUsages, thresholds, false, podMetric) {
+			return nil, false
+		}
+	}
+	result := make(map[string]map[corev1.ResourceName]*resource.Quantity, len(clonedUsages))
+	for name, nodeUsage := range clonedUsages {
+		result[name] = nodeUsage.usage
+	}
+	return result, true
+}
+
+// evictPodsForConsolidation evicts the pods of the drained node, and returns false if any eviction failed,
+// e.g. the eviction limits are reached, so that the following nodes are not drained partially.
+func (pl *LowNodeLoad) evictPodsForConsolidation(ctx context.Context, nodePoolName string, nodeInfo NodeInfo, pods []*corev1.Pod) bool {
+	for _, pod := range pods {
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName)
+			continue
+		}
+		evictionOptions := framework.EvictOptions{
+			Reason: consolidationEvictionReason,
+		}
+		if !pl.evictor.Evict(ctx, pod, evictionOptions) {
+			klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName)
+			return false
+		}
+		klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName)
+	}
+	return true
+}
+
+func newConsolidationThresholds(nodeUsages map[string]*NodeUsage, targetUtilization deschedulerconfig.ResourceThresholds) map[string]NodeThresholds {
+	nodeThresholds := make(map[string]NodeThresholds, len(nodeUsages))
+	for name, nodeUsage := range nodeUsages {
+		thresholds := make(map[corev1.ResourceName]*resource.Quantity, len(targetUtilization))
+		for resourceName, percentage := range targetUtilization {
+			thresholds[resourceName] = resourceThreshold(nodeUsage.node.Status.Allocatable, resourceName, percentage)
+		}
+		nodeThresholds[name] = NodeThresholds{highResourceThreshold: thresholds}
+	}
+	return nodeThresholds
+}
+
+func copyUsage(usage map[corev1.ResourceName]*resource.Quantity) map[corev1.ResourceName]*resource.Quantity {
+	result := make(map[corev1.ResourceName]*resource.Quantity, len(usage))
+	for resourceName, quantity := range usage {
+		if quantity != nil {
+			result[resourceName] = quantity.Copy()
+		}
+	}
+	return result
+}
+
+// filterOutNodes returns the nodes not in the given set.
+func filterOutNodes(nodes []NodeInfo, excluded sets.String) []NodeInfo {
+	if excluded.Len() == 0 {
+		return nodes
+	}
+	result := make([]NodeInfo, 0, len(nodes))
+	for _, v := range nodes {
+		if !excluded.Has(v.node.Name) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
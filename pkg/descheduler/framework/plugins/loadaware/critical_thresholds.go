@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+)
+
+// criticalNodeEvictor evicts the pods on the nodes above the critical thresholds beyond the limit of evicted pods per node,
+// since the node may fail if the safety-critical resources are exhausted.
+type criticalNodeEvictor struct {
+	framework.Evictor
+	criticalNodes sets.String
+}
+
+func newCriticalNodeEvictor(evictor framework.Evictor, criticalNodes sets.String) framework.Evictor {
+	if criticalNodes.Len() == 0 {
+		return evictor
+	}
+	return &criticalNodeEvictor{
+		Evictor:       evictor,
+		criticalNodes: criticalNodes,
+	}
+}
+
+func (e *criticalNodeEvictor) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	if e.criticalNodes.Has(pod.Spec.NodeName) {
+		evictOptions.IgnoreNodeLimit = true
+	}
+	return e.Evictor.Evict(ctx, pod, evictOptions)
+}
+
+// getCriticalNodes returns the nodes whose usage of any resource exceeds its critical threshold.
+// The resources without usage, i.e. not thresholded in the node pool, are ignored.
+func getCriticalNodes(nodeUsages map[string]*NodeUsage, criticalThresholds deschedulerconfig.ResourceThresholds) sets.String {
+	criticalNodes := sets.NewString()
+	if len(criticalThresholds) == 0 {
+		return criticalNodes
+	}
+	for name, nodeUsage := range nodeUsages {
+		for resourceName, percentage := range criticalThresholds {
+			usage := nodeUsage.usage[resourceName]
+			if usage == nil {
+				continue
+			}
+			threshold := resourceThreshold(nodeUsage.node.Status.Allocatable, resourceName, percentage)
+			if usage.Cmp(*threshold) > 0 {
+				klog.V(4).InfoS("Node exceeds the critical threshold, the limit of evicted pods per node is ignored",
+					"node", klog.KObj(nodeUsage.node), "resource", resourceName, "usage", usage.String(), "criticalThreshold", threshold.String())
+				criticalNodes.Insert(name)
+				break
+			}
+		}
+	}
+	return criticalNodes
+}
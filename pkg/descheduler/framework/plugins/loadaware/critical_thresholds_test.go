@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+type recordingEvictor struct {
+	framework.Evictor
+	ignoreNodeLimit map[string]bool
+}
+
+func (e *recordingEvictor) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	e.ignoreNodeLimit[pod.Name] = evictOptions.IgnoreNodeLimit
+	return true
+}
+
+func TestGetCriticalNodes(t *testing.T) {
+	nodeUsages := map[string]*NodeUsage{
+		"n1": {
+			node: test.BuildTestNode("n1", 4000, 3000, 10, nil),
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:    resource.NewMilliQuantity(3900, resource.DecimalSI),
+				corev1.ResourceMemory: resource.NewQuantity(1000, resource.BinarySI),
+			},
+		},
+		"n2": {
+			node: test.BuildTestNode("n2", 4000, 3000, 10, nil),
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:    resource.NewMilliQuantity(3000, resource.DecimalSI),
+				corev1.ResourceMemory: resource.NewQuantity(1000, resource.BinarySI),
+			},
+		},
+		"n3": {
+			node: test.BuildTestNode("n3", 4000, 3000, 10, nil),
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU: resource.NewMilliQuantity(1000, resource.DecimalSI),
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		criticalThresholds deschedulerconfig.ResourceThresholds
+		want               sets.String
+	}{
+		{
+			name: "no critical thresholds",
+			want: sets.NewString(),
+		},
+		{
+			name:               "node above the cpu critical threshold",
+			criticalThresholds: deschedulerconfig.ResourceThresholds{corev1.ResourceCPU: 95},
+			want:               sets.NewString("n1"),
+		},
+		{
+			name:               "node at the critical threshold is not critical",
+			criticalThresholds: deschedulerconfig.ResourceThresholds{corev1.ResourceCPU: 75},
+			want:               sets.NewString("n1"),
+		},
+		{
+			name:               "resource without usage is ignored",
+			criticalThresholds: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 10},
+			want:               sets.NewString("n1", "n2"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getCriticalNodes(nodeUsages, tt.criticalThresholds))
+		})
+	}
+}
+
+func TestCriticalNodeEvictor(t *testing.T) {
+	recorder := &recordingEvictor{ignoreNodeLimit: map[string]bool{}}
+	assert.Same(t, recorder, newCriticalNodeEvictor(recorder, sets.NewString()).(*recordingEvictor))
+
+	evictor := newCriticalNodeEvictor(recorder, sets.NewString("n1"))
+	evictor.Evict(context.TODO(), test.BuildTestPod("p1", 100, 0, "n1", nil), framework.EvictOptions{})
+	evictor.Evict(context.TODO(), test.BuildTestPod("p2", 100, 0, "n2", nil), framework.EvictOptions{})
+	assert.Equal(t, map[string]bool{"p1": true, "p2": false}, recorder.ignoreNodeLimit)
+}
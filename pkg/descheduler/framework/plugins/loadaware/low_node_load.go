@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	gocache "github.com/patrickmn/go-cache"
 	corev1 "k8s.io/api/core/v1"
@@ -29,8 +30,11 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
 	koordslolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
@@ -44,6 +48,12 @@ import (
 
 const (
 	LowNodeLoadName = "LowNodeLoad"
+
+	// defaultDetectorCacheTimeout is used if the DetectorCacheTimeout is not set.
+	defaultDetectorCacheTimeout = 5 * time.Minute
+
+	// informerSyncTimeout bounds the wait for the caches of the informers started by the plugin.
+	informerSyncTimeout = time.Minute
 )
 
 var _ framework.BalancePlugin = &LowNodeLoad{}
@@ -52,11 +62,14 @@ var _ framework.BalancePlugin = &LowNodeLoad{}
 // Note that the plugin refers to the actual usage of the node.
 type LowNodeLoad struct {
 	handle               framework.Handle
+	evictor              framework.Evictor
 	podFilter            framework.FilterFunc
 	nodeMetricLister     koordslolisters.NodeMetricLister
 	args                 *deschedulerconfig.LowNodeLoadArgs
+	migrationJobIndexer  cache.Indexer
 	nodeAnomalyDetectors *gocache.Cache
 	prodAnomalyDetectors *gocache.Cache
+	overutilization      *overutilizationTracker
 }
 
 // NewLowNodeLoad builds plugin from its arguments while passing a handle
@@ -68,8 +81,11 @@ func NewLowNodeLoad(args runtime.Object, handle framework.Handle) (framework.Plu
 	if err := validation.ValidateLowLoadUtilizationArgs(nil, loadLoadUtilizationArgs); err != nil {
 		return nil, err
 	}
+	for _, warning := range validation.GetLowLoadUtilizationArgsWarnings(field.NewPath("args"), loadLoadUtilizationArgs) {
+		klog.Warningf("LowNodeLoadArgs: %s", warning)
+	}
 
-	podSelectorFn, err := filterPods(loadLoadUtilizationArgs.PodSelectors)
+	podSelectorFn, err := filterPods(loadLoadUtilizationArgs.PodSelectors, loadLoadUtilizationArgs.PodSelectorMatchMode)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod selector filter: %v", err)
 	}
@@ -104,19 +120,47 @@ func NewLowNodeLoad(args runtime.Object, handle framework.Handle) (framework.Plu
 	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
 	nodeMetricInformer := koordSharedInformerFactory.Slo().V1alpha1().NodeMetrics()
 	nodeMetricInformer.Informer()
-	koordSharedInformerFactory.Start(context.TODO().Done())
-	koordSharedInformerFactory.WaitForCacheSync(context.TODO().Done())
+	migrationJobInformer, err := newPodMigrationJobInformer(koordClientSet, koordSharedInformerFactory)
+	if err != nil {
+		return nil, err
+	}
+	// The informers run as long as the plugin, they are only stopped if the NodeMetric cache fails to sync.
+	stopCh := make(chan struct{})
+	koordSharedInformerFactory.Start(stopCh)
+	syncCtx, cancel := context.WithTimeout(context.Background(), informerSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), nodeMetricInformer.Informer().HasSynced) {
+		close(stopCh)
+		return nil, fmt.Errorf("failed to wait for the NodeMetric cache to sync")
+	}
+	evictor := handle.Evictor()
+	var migrationJobIndexer cache.Indexer
+	if migrationJobInformer != nil {
+		if cache.WaitForCacheSync(syncCtx.Done(), migrationJobInformer.HasSynced) {
+			migrationJobIndexer = migrationJobInformer.GetIndexer()
+			evictor = newMigrationAwareEvictor(evictor, LowNodeLoadName, migrationJobIndexer)
+		} else {
+			klog.Warningf("LowNodeLoad failed to wait for the PodMigrationJob cache to sync, the pods being migrated are not skipped")
+		}
+	}
 
-	nodeAnomalyDetectors := gocache.New(loadLoadUtilizationArgs.DetectorCacheTimeout.Duration, loadLoadUtilizationArgs.DetectorCacheTimeout.Duration)
-	prodAnomalyDetectors := gocache.New(loadLoadUtilizationArgs.DetectorCacheTimeout.Duration, loadLoadUtilizationArgs.DetectorCacheTimeout.Duration)
+	detectorCacheTimeout := defaultDetectorCacheTimeout
+	if loadLoadUtilizationArgs.DetectorCacheTimeout != nil {
+		detectorCacheTimeout = loadLoadUtilizationArgs.DetectorCacheTimeout.Duration
+	}
+	nodeAnomalyDetectors := gocache.New(detectorCacheTimeout, detectorCacheTimeout)
+	prodAnomalyDetectors := gocache.New(detectorCacheTimeout, detectorCacheTimeout)
 
 	return &LowNodeLoad{
 		handle:               handle,
+		evictor:              evictor,
 		nodeMetricLister:     nodeMetricInformer.Lister(),
 		args:                 loadLoadUtilizationArgs,
+		migrationJobIndexer:  migrationJobIndexer,
 		podFilter:            podFilter,
 		nodeAnomalyDetectors: nodeAnomalyDetectors,
 		prodAnomalyDetectors: prodAnomalyDetectors,
+		overutilization:      newOverutilizationTracker(),
 	}, nil
 }
 
@@ -140,20 +184,92 @@ func (pl *LowNodeLoad) Balance(ctx context.Context, nodes []*corev1.Node) *frame
 		return nil
 	}
 
+	if pl.args.EvictQuarantinedNodes {
+		nodes = pl.drainQuarantinedNodes(ctx, nodes)
+	}
+
+	var snapshot *Snapshot
+	if snapshots.isEnabled() {
+		snapshot = &Snapshot{Timestamp: metav1.Now()}
+	}
 	processedNodes := sets.NewString()
 	for _, nodePool := range pl.args.NodePools {
+		if unprocessedNodes := filterOutProcessedNodes(nodes, processedNodes); framework.ExceedCycleDeadline(ctx, unprocessedNodes) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d nodes left unprocessed", LowNodeLoadName, len(unprocessedNodes))
+			break
+		}
 		klog.V(4).InfoS("try to process nodePool", "nodePool", nodePool.Name)
-		status := pl.processOneNodePool(ctx, &nodePool, nodes, processedNodes)
+		status := pl.processOneNodePool(ctx, &nodePool, nodes, processedNodes, snapshot)
 		if status != nil && status.Err != nil {
 			klog.ErrorS(status.Err, "Failed to processOneNodePool", "nodePool", nodePool.Name)
 		} else {
 			klog.V(4).InfoS("Successfully processed nodePool", "nodePool", nodePool.Name)
 		}
 	}
+	if snapshot != nil {
+		snapshots.store(pl, snapshot)
+	}
+	pl.overutilization.prune(nodes)
 	return nil
 }
 
-func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *deschedulerconfig.LowNodeLoadNodePool, nodes []*corev1.Node, processedNodes sets.String) *framework.Status {
+// drainQuarantinedNodes evicts all the evictable pods on the quarantined nodes without checking the utilization,
+// the pods still go through the same filters as the balanced pods, and returns the remaining nodes to be balanced.
+func (pl *LowNodeLoad) drainQuarantinedNodes(ctx context.Context, nodes []*corev1.Node) []*corev1.Node {
+	remainingNodes := make([]*corev1.Node, 0, len(nodes))
+	for i, node := range nodes {
+		if framework.ExceedCycleDeadline(ctx, nodes[i:]) {
+			klog.Infof("%s stops draining the quarantined nodes as the descheduling cycle exceeded its max duration, %d nodes left unprocessed", LowNodeLoadName, len(nodes)-i)
+			return append(remainingNodes, nodes[i:]...)
+		}
+		if node.Labels[extension.LabelNodeQuarantine] != "true" {
+			remainingNodes = append(remainingNodes, node)
+			continue
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on quarantined node", "node", klog.KObj(node))
+			continue
+		}
+		klog.V(4).InfoS("Draining quarantined node", "node", klog.KObj(node), "pods", len(pods))
+		for _, pod := range pods {
+			if !pl.podFilter(pod) {
+				klog.V(4).InfoS("Pod aborted eviction because it was filtered by filters", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			if !pl.evictor.PreEvictionFilter(pod) {
+				klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			if pl.args.DryRun {
+				klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			evictionOptions := framework.EvictOptions{
+				Reason: "node is quarantined",
+			}
+			if !pl.evictor.Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		}
+	}
+	return remainingNodes
+}
+
+// filterOutProcessedNodes returns the nodes not processed by the previous node pools.
+func filterOutProcessedNodes(nodes []*corev1.Node, processedNodes sets.String) []*corev1.Node {
+	r := make([]*corev1.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if !processedNodes.Has(node.Name) {
+			r = append(r, node)
+		}
+	}
+	return r
+}
+
+func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *deschedulerconfig.LowNodeLoadNodePool, nodes []*corev1.Node, processedNodes sets.String, snapshot *Snapshot) *framework.Status {
 	nodes, err := filterNodes(nodePool.NodeSelector, nodes, processedNodes)
 	if err != nil {
 		return &framework.Status{Err: err}
@@ -164,46 +280,114 @@ func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *desched
 		return nil
 	}
 
-	lowThresholds, highThresholds, prodLowThresholds, prodHighThresholds := newThresholds(nodePool.UseDeviationThresholds, nodePool.LowThresholds, nodePool.HighThresholds, nodePool.ProdLowThresholds, nodePool.ProdHighThresholds)
-	resourceNames := getResourceNames(lowThresholds)
-	nodeUsages := getNodeUsage(nodes, resourceNames, pl.nodeMetricLister, pl.handle.GetPodsAssignedToNodeFunc(), pl.args.NodeMetricExpirationSeconds)
-	nodeThresholds := getNodeThresholds(nodeUsages, lowThresholds, highThresholds, prodLowThresholds, prodHighThresholds, resourceNames, nodePool.UseDeviationThresholds)
-	lowNodes, sourceNodes, prodLowNodes, prodHighNodes, bothLowNodes := classifyNodes(nodeUsages, nodeThresholds, lowThresholdFilter, highThresholdFilter, prodLowThresholdFilter, prodHighThresholdFilter)
+	if len(nodes) < int(pl.args.MinNodesInScope) {
+		klog.InfoS("Number of nodes in scope is less than MinNodesInScope, skip processing LowNodeLoad",
+			"nodesInScope", len(nodes), "minNodesInScope", pl.args.MinNodesInScope, "nodePool", nodePool.Name)
+		return nil
+	}
+
+	u := pl.classifyNodePool(nodePool, nodes)
+	pl.overutilization.observeNodeUsages(u.nodeUsages, u.nodeThresholds, time.Now())
+	if snapshot != nil {
+		snapshot.recordNodePool(nodePool.Name, u.nodeUsages, pl.podFilter, u.lowNodes, u.sourceNodes, u.prodLowNodes, u.prodHighNodes, u.bothLowNodes)
+	}
+
+	logUtilizationCriteria(nodePool.Name, "Criteria for nodes under low thresholds and above high thresholds", u.lowThresholds, u.highThresholds,
+		u.prodLowThresholds, u.prodHighThresholds, len(u.lowNodes), len(u.sourceNodes), len(u.prodLowNodes), len(u.prodHighNodes), len(u.bothLowNodes), len(nodes))
 
-	logUtilizationCriteria(nodePool.Name, "Criteria for nodes under low thresholds and above high thresholds", lowThresholds, highThresholds,
-		prodLowThresholds, prodHighThresholds, len(lowNodes), len(sourceNodes), len(prodLowNodes), len(prodHighNodes), len(bothLowNodes), len(nodes))
+	if len(pl.args.ConsolidationTargetUtilization) > 0 {
+		drainedNodes := pl.consolidateNodes(ctx, nodePool.Name, append(append([]NodeInfo{}, u.lowNodes...), u.bothLowNodes...), u.nodeUsages, nodePool.ResourceWeights)
+		// the drained nodes are going to be scaled down, they must not be the destinations of the pods evicted below
+		u.lowNodes = filterOutNodes(u.lowNodes, drainedNodes)
+		u.bothLowNodes = filterOutNodes(u.bothLowNodes, drainedNodes)
+		processedNodes.Insert(drainedNodes.UnsortedList()...)
+	}
 
-	if len(sourceNodes) == 0 && len(prodHighNodes) == 0 {
+	if len(u.sourceNodes) == 0 && len(u.prodHighNodes) == 0 {
 		klog.V(4).InfoS("All nodes are under target utilization, nothing to do here", "nodePool", nodePool.Name)
 		return nil
 	}
 
-	abnormalNodes := filterRealAbnormalNodes(sourceNodes, pl.nodeAnomalyDetectors, nodePool.AnomalyCondition)
-	abnormalProdNodes := filterRealAbnormalNodes(prodHighNodes, pl.prodAnomalyDetectors, nodePool.AnomalyCondition)
+	abnormalNodes := filterRealAbnormalNodes(u.sourceNodes, pl.nodeAnomalyDetectors, nodePool.AnomalyCondition)
+	abnormalProdNodes := filterRealAbnormalNodes(u.prodHighNodes, pl.prodAnomalyDetectors, nodePool.AnomalyCondition)
 	if len(abnormalNodes) == 0 && len(abnormalProdNodes) == 0 {
 		klog.V(4).InfoS("None of the nodes were detected as anomalous, nothing to do here", "nodePool", nodePool.Name)
 		return nil
 	}
 
-	if len(lowNodes) == 0 && len(prodLowNodes) == 0 && len(bothLowNodes) == 0 {
-		klog.V(4).InfoS("No nodes are underutilized, nothing to do here, you might tune your thresholds further", "nodePool", nodePool.Name)
+	resetNodesAsNormal(u.lowNodes, pl.nodeAnomalyDetectors)
+	resetNodesAsNormal(u.prodLowNodes, pl.prodAnomalyDetectors)
+	resetNodesAsNormal(u.bothLowNodes, pl.nodeAnomalyDetectors)
+
+	if reason := pl.checkUnderutilizedNodes(nodePool.Name, u, len(nodes)); reason != "" {
+		klog.V(4).InfoS("Nothing to do here", "reason", reason, "nodePool", nodePool.Name)
 		return nil
 	}
 
-	resetNodesAsNormal(lowNodes, pl.nodeAnomalyDetectors)
-	resetNodesAsNormal(prodLowNodes, pl.prodAnomalyDetectors)
-	resetNodesAsNormal(bothLowNodes, pl.nodeAnomalyDetectors)
+	pl.evictFromSourceNodes(ctx, nodePool, u, abnormalNodes, abnormalProdNodes, pl.evictor, pl.args.DryRun, true)
+	tryMarkNodesAsNormal(abnormalNodes, pl.nodeAnomalyDetectors)
+	tryMarkNodesAsNormal(abnormalProdNodes, pl.prodAnomalyDetectors)
+	for _, v := range u.sourceNodes {
+		processedNodes.Insert(v.node.Name)
+	}
+	return nil
+}
+
+// nodePoolUtilization is the usages and thresholds of the nodes in a node pool, and the nodes classified by them.
+type nodePoolUtilization struct {
+	lowThresholds, highThresholds, prodLowThresholds, prodHighThresholds ResourceThresholds
 
-	allLowNodes := len(lowNodes) + len(prodLowNodes) + len(bothLowNodes)
+	resourceNames  []corev1.ResourceName
+	nodeUsages     map[string]*NodeUsage
+	nodeThresholds map[string]NodeThresholds
+
+	lowNodes, sourceNodes, prodLowNodes, prodHighNodes, bothLowNodes []NodeInfo
+}
+
+// classifyNodePool computes the usages and the thresholds of the nodes in the node pool, and classifies the nodes.
+// It is shared by the descheduling cycles and WouldEvict, so that both see the same classification.
+func (pl *LowNodeLoad) classifyNodePool(nodePool *deschedulerconfig.LowNodeLoadNodePool, nodes []*corev1.Node) *nodePoolUtilization {
+	u := &nodePoolUtilization{}
+	u.lowThresholds, u.highThresholds, u.prodLowThresholds, u.prodHighThresholds = newThresholds(nodePool.UseDeviationThresholds, nodePool.LowThresholds, nodePool.HighThresholds, nodePool.ProdLowThresholds, nodePool.ProdHighThresholds)
+	addAbsoluteThresholdResources(u.lowThresholds, u.highThresholds, u.prodLowThresholds, u.prodHighThresholds, nodePool.AbsoluteLowThresholds, nodePool.AbsoluteHighThresholds)
+	u.resourceNames = getResourceNames(u.lowThresholds)
+	u.nodeUsages = getNodeUsage(nodes, u.resourceNames, pl.nodeMetricLister, pl.handle.GetPodsAssignedToNodeFunc(), pl.args.NodeMetricExpirationSeconds, pl.args.NodeMetricExpirationByResource)
+	u.nodeThresholds = getNodeThresholds(u.nodeUsages, u.lowThresholds, u.highThresholds, u.prodLowThresholds, u.prodHighThresholds, u.resourceNames, nodePool.UseDeviationThresholds)
+	applyAbsoluteThresholds(u.nodeUsages, u.nodeThresholds, nodePool.AbsoluteLowThresholds, nodePool.AbsoluteHighThresholds)
+	applyMinAbsoluteUsage(u.nodeThresholds, nodePool.MinAbsoluteUsage)
+	if pl.args.ThrottledNodeConditionType != "" {
+		markThrottledNodes(u.nodeUsages, u.nodeThresholds, pl.args.ThrottledNodeConditionType)
+	}
+	u.lowNodes, u.sourceNodes, u.prodLowNodes, u.prodHighNodes, u.bothLowNodes = classifyNodes(u.nodeUsages, u.nodeThresholds, lowThresholdFilter, highThresholdFilter, prodLowThresholdFilter, prodHighThresholdFilter)
+	return u
+}
+
+// checkUnderutilizedNodes returns why the pods cannot be moved to the underutilized nodes of the node pool,
+// or an empty string if they can.
+func (pl *LowNodeLoad) checkUnderutilizedNodes(nodePoolName string, u *nodePoolUtilization, totalNodes int) string {
+	allLowNodes := len(u.lowNodes) + len(u.prodLowNodes) + len(u.bothLowNodes)
+	if allLowNodes == 0 {
+		return fmt.Sprintf("no node is underutilized in node pool %s, you might tune your thresholds further", nodePoolName)
+	}
 	if allLowNodes <= int(pl.args.NumberOfNodes) {
-		klog.V(4).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here",
-			"underutilizedNodes", allLowNodes, "numberOfNodes", pl.args.NumberOfNodes, "nodePool", nodePool.Name)
-		return nil
+		return fmt.Sprintf("%d nodes are underutilized in node pool %s, not more than numberOfNodes %d", allLowNodes, nodePoolName, pl.args.NumberOfNodes)
+	}
+	if allLowNodes == totalNodes {
+		return fmt.Sprintf("all nodes are underutilized in node pool %s", nodePoolName)
 	}
+	return ""
+}
 
-	if allLowNodes == len(nodes) {
-		klog.V(4).InfoS("All nodes are underutilized, nothing to do here", "nodePool", nodePool.Name)
-		return nil
+// evictFromSourceNodes evicts the pods from the source nodes with the evictor until the nodes are relieved,
+// the anomaly detectors of the relieved nodes are reset if resetDetectors is set.
+func (pl *LowNodeLoad) evictFromSourceNodes(ctx context.Context, nodePool *deschedulerconfig.LowNodeLoadNodePool, u *nodePoolUtilization,
+	sourceNodes, prodSourceNodes []NodeInfo, evictor framework.Evictor, dryRun, resetDetectors bool) {
+	var nodeReliefThresholds, prodReliefThresholds map[string]map[corev1.ResourceName]*resource.Quantity
+	if pl.args.EvictionAggressiveness != nil {
+		if aggressiveness := pl.args.EvictionAggressiveness.FloatValue(); aggressiveness < 1 {
+			nodeReliefThresholds = newReliefThresholds(sourceNodes, false, aggressiveness)
+			prodReliefThresholds = newReliefThresholds(prodSourceNodes, true, aggressiveness)
+		}
 	}
 
 	continueEvictionCond := func(nodeInfo NodeInfo, totalAvailableUsages map[corev1.ResourceName]*resource.Quantity, prod bool) bool {
@@ -216,14 +400,27 @@ func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *desched
 			thresholds = nodeInfo.thresholds.highResourceThreshold
 		}
 		if _, overutilized := isNodeOverutilized(usage, thresholds); !overutilized {
-			if prod {
-				resetNodesAsNormal([]NodeInfo{nodeInfo}, pl.prodAnomalyDetectors)
-			} else {
-				resetNodesAsNormal([]NodeInfo{nodeInfo}, pl.nodeAnomalyDetectors)
+			if resetDetectors {
+				if prod {
+					resetNodesAsNormal([]NodeInfo{nodeInfo}, pl.prodAnomalyDetectors)
+				} else {
+					resetNodesAsNormal([]NodeInfo{nodeInfo}, pl.nodeAnomalyDetectors)
+				}
 			}
 			return false
 		}
-		for _, resourceName := range resourceNames {
+		reliefThresholds := nodeReliefThresholds
+		if prod {
+			reliefThresholds = prodReliefThresholds
+		}
+		if relief, ok := reliefThresholds[nodeInfo.node.Name]; ok {
+			if _, overutilized := isNodeOverutilized(usage, relief); !overutilized {
+				klog.V(4).InfoS("The overutilization relieved by EvictionAggressiveness in this cycle, stop evicting",
+					"node", klog.KObj(nodeInfo.node), "prod", prod, "nodePool", nodePool.Name)
+				return false
+			}
+		}
+		for _, resourceName := range u.resourceNames {
 			if quantity, ok := totalAvailableUsages[resourceName]; ok {
 				if quantity.CmpInt64(0) < 1 {
 					klog.V(4).InfoS("available usage is too low.", "resourceName", resourceName, "prod", prod)
@@ -234,35 +431,35 @@ func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *desched
 		return true
 	}
 
-	sortNodesByUsage(abnormalNodes, nodePool.ResourceWeights, false, false)
-	sortNodesByUsage(abnormalProdNodes, nodePool.ResourceWeights, false, true)
+	if pl.args.CandidateOrderSeed != nil {
+		shuffleCandidates(sourceNodes, *pl.args.CandidateOrderSeed)
+		shuffleCandidates(prodSourceNodes, *pl.args.CandidateOrderSeed)
+	}
+	sortSourceNodes(sourceNodes, nodePool.ResourceWeights, pl.args.NodeProcessingOrder, false)
+	sortSourceNodes(prodSourceNodes, nodePool.ResourceWeights, pl.args.NodeProcessingOrder, true)
 
 	evictPodsFromSourceNodes(
 		ctx,
 		nodePool.Name,
-		abnormalNodes,
-		lowNodes,
-		abnormalProdNodes,
-		prodLowNodes,
-		bothLowNodes,
-		nodeUsages,
-		nodeThresholds,
-		pl.args.DryRun,
+		sourceNodes,
+		u.lowNodes,
+		prodSourceNodes,
+		u.prodLowNodes,
+		u.bothLowNodes,
+		u.nodeUsages,
+		u.nodeThresholds,
+		dryRun,
 		pl.args.NodeFit,
+		pl.args.EmitDestinationHint,
 		nodePool.ResourceWeights,
-		pl.handle.Evictor(),
+		pl.args.OwnerKindEvictionPriority,
+		newCriticalNodeEvictor(evictor, getCriticalNodes(u.nodeUsages, pl.args.CriticalThresholds)),
 		pl.podFilter,
 		pl.handle.GetPodsAssignedToNodeFunc(),
-		resourceNames,
+		u.resourceNames,
 		continueEvictionCond,
-		overUtilizedEvictionReason(highThresholds, prodHighThresholds),
+		overUtilizedEvictionReason(u.highThresholds, u.prodHighThresholds),
 	)
-	tryMarkNodesAsNormal(abnormalNodes, pl.nodeAnomalyDetectors)
-	tryMarkNodesAsNormal(abnormalProdNodes, pl.prodAnomalyDetectors)
-	for _, v := range sourceNodes {
-		processedNodes.Insert(v.node.Name)
-	}
-	return nil
 }
 
 func resetNodesAsNormal(lowNodes []NodeInfo, nodeAnomalyDetectors *gocache.Cache) {
@@ -357,11 +554,30 @@ func newThresholds(useDeviationThresholds bool, low, high, lowProd, highProd des
 	return thresholds, highThresholds, prodThreshold, highProdThreshold
 }
 
+// addAbsoluteThresholdResources makes the resources with absolute thresholds also be classified.
+// Their percentage thresholds are filled as unlimited and overridden by applyAbsoluteThresholds later.
+func addAbsoluteThresholdResources(low, high, prodLow, prodHigh deschedulerconfig.ResourceThresholds, absoluteLow, absoluteHigh map[corev1.ResourceName]resource.Quantity) {
+	for _, absolute := range []map[corev1.ResourceName]resource.Quantity{absoluteLow, absoluteHigh} {
+		for resourceName := range absolute {
+			if _, ok := low[resourceName]; ok {
+				continue
+			}
+			low[resourceName] = MaxResourcePercentage
+			high[resourceName] = MaxResourcePercentage
+			prodLow[resourceName] = MaxResourcePercentage
+			prodHigh[resourceName] = MaxResourcePercentage
+		}
+	}
+}
+
 func lowThresholdFilter(usage *NodeUsage, threshold NodeThresholds) bool {
 	if nodeutil.IsNodeUnschedulable(usage.node) {
 		klog.V(4).InfoS("Node is unschedulable, thus not considered as underutilized", "node", klog.KObj(usage.node))
 		return false
 	}
+	if usage.throttled {
+		return false
+	}
 	return isNodeUnderutilized(usage.usage, threshold.lowResourceThreshold)
 }
 
@@ -370,6 +586,9 @@ func prodLowThresholdFilter(usage *NodeUsage, threshold NodeThresholds) bool {
 		klog.V(4).InfoS("Node is unschedulable, thus not considered as underutilized", "node", klog.KObj(usage.node))
 		return false
 	}
+	if usage.throttled {
+		return false
+	}
 	return isNodeUnderutilized(usage.prodUsage, threshold.prodLowResourceThreshold)
 }
 
@@ -383,6 +602,35 @@ func prodHighThresholdFilter(usage *NodeUsage, threshold NodeThresholds) bool {
 	return overutilized
 }
 
+// newReliefThresholds returns the usage to reach in this cycle for every overutilized node, which only relieves
+// the aggressiveness fraction of the usage above the high thresholds. The resources not overutilized keep their thresholds.
+func newReliefThresholds(nodes []NodeInfo, prod bool, aggressiveness float64) map[string]map[corev1.ResourceName]*resource.Quantity {
+	reliefThresholds := make(map[string]map[corev1.ResourceName]*resource.Quantity, len(nodes))
+	for _, nodeInfo := range nodes {
+		usage, thresholds := nodeInfo.NodeUsage.usage, nodeInfo.thresholds.highResourceThreshold
+		if prod {
+			usage, thresholds = nodeInfo.NodeUsage.prodUsage, nodeInfo.thresholds.prodHighResourceThreshold
+		}
+		relief := make(map[corev1.ResourceName]*resource.Quantity, len(thresholds))
+		for resourceName, threshold := range thresholds {
+			used := usage[resourceName]
+			if used == nil || used.Cmp(*threshold) <= 0 {
+				relief[resourceName] = threshold
+				continue
+			}
+			if resourceName == corev1.ResourceCPU {
+				excess := used.MilliValue() - threshold.MilliValue()
+				relief[resourceName] = resource.NewMilliQuantity(used.MilliValue()-int64(float64(excess)*aggressiveness), threshold.Format)
+			} else {
+				excess := used.Value() - threshold.Value()
+				relief[resourceName] = resource.NewQuantity(used.Value()-int64(float64(excess)*aggressiveness), threshold.Format)
+			}
+		}
+		reliefThresholds[nodeInfo.node.Name] = relief
+	}
+	return reliefThresholds
+}
+
 func filterNodes(nodeSelector *metav1.LabelSelector, nodes []*corev1.Node, processedNodes sets.String) ([]*corev1.Node, error) {
 	if nodeSelector == nil {
 		return nodes, nil
@@ -403,7 +651,7 @@ func filterNodes(nodeSelector *metav1.LabelSelector, nodes []*corev1.Node, proce
 	return r, nil
 }
 
-func filterPods(podSelectors []deschedulerconfig.LowNodeLoadPodSelector) (framework.FilterFunc, error) {
+func filterPods(podSelectors []deschedulerconfig.LowNodeLoadPodSelector, matchMode deschedulerconfig.PodSelectorMatchMode) (framework.FilterFunc, error) {
 	var selectors []labels.Selector
 	for _, v := range podSelectors {
 		if v.Selector != nil {
@@ -419,6 +667,14 @@ func filterPods(podSelectors []deschedulerconfig.LowNodeLoadPodSelector) (framew
 		if len(selectors) == 0 {
 			return true
 		}
+		if matchMode == deschedulerconfig.PodSelectorMatchModeAll {
+			for _, v := range selectors {
+				if !v.Matches(labels.Set(pod.Labels)) {
+					return false
+				}
+			}
+			return true
+		}
 		for _, v := range selectors {
 			if v.Matches(labels.Set(pod.Labels)) {
 				return true
@@ -18,13 +18,15 @@ package loadaware
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	gocache "github.com/patrickmn/go-cache"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
-	policy "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,7 +36,9 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	coretesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/events"
+	clocktesting "k8s.io/utils/clock/testing"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
@@ -55,37 +59,6 @@ type fakeFrameworkHandle struct {
 	koordinatorclientset.Interface
 }
 
-func setupFakeDiscoveryWithPolicyResource(fake *coretesting.Fake) {
-	fake.AddReactor("get", "group", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
-		fake.Resources = []*metav1.APIResourceList{
-			{
-				GroupVersion: policy.SchemeGroupVersion.String(),
-				APIResources: []metav1.APIResource{
-					{
-						Name: util.EvictionSubResourceName,
-						Kind: util.EvictionKind,
-					},
-				},
-			},
-		}
-		return true, nil, nil
-	})
-	fake.AddReactor("get", "resource", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
-		fake.Resources = []*metav1.APIResourceList{
-			{
-				GroupVersion: "v1",
-				APIResources: []metav1.APIResource{
-					{
-						Name: util.EvictionSubResourceName,
-						Kind: util.EvictionKind,
-					},
-				},
-			},
-		}
-		return true, nil, nil
-	})
-}
-
 func setupNodeMetrics(koordClientSet koordinatorclientset.Interface, nodes []*corev1.Node, pods []*corev1.Pod, podMetrics map[types.NamespacedName]*slov1alpha1.ResourceMap) {
 	nodeMetrics := map[string]*slov1alpha1.NodeMetric{}
 	if len(pods) > 0 {
@@ -1373,7 +1346,7 @@ func TestLowNodeLoad(t *testing.T) {
 				objs = append(objs, pod)
 			}
 			fakeClient := fake.NewSimpleClientset(objs...)
-			setupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+			frameworktesting.SetupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
 
 			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
 			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
@@ -1423,6 +1396,7 @@ func TestLowNodeLoad(t *testing.T) {
 								NodeFit: true,
 								NodePools: []deschedulerconfig.LowNodeLoadNodePool{
 									{
+										NodeSelector:           &metav1.LabelSelector{},
 										LowThresholds:          tt.thresholds,
 										HighThresholds:         tt.targetThresholds,
 										ProdLowThresholds:      tt.prodLowThresholds,
@@ -1430,6 +1404,7 @@ func TestLowNodeLoad(t *testing.T) {
 										UseDeviationThresholds: tt.useDeviationThresholds,
 										AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
 											ConsecutiveAbnormalities: 1,
+											ConsecutiveNormalities:   1,
 										},
 									},
 								},
@@ -1540,7 +1515,7 @@ func TestMaxEvictionTotal(t *testing.T) {
 				objs = append(objs, pod)
 			}
 			fakeClient := fake.NewSimpleClientset(objs...)
-			setupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+			frameworktesting.SetupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
 
 			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
 			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
@@ -1590,11 +1565,13 @@ func TestMaxEvictionTotal(t *testing.T) {
 								NodeFit: true,
 								NodePools: []deschedulerconfig.LowNodeLoadNodePool{
 									{
+										NodeSelector:           &metav1.LabelSelector{},
 										LowThresholds:          tt.thresholds,
 										HighThresholds:         tt.targetThresholds,
 										UseDeviationThresholds: tt.useDeviationThresholds,
 										AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
 											ConsecutiveAbnormalities: 1,
+											ConsecutiveNormalities:   1,
 										},
 									},
 								},
@@ -1623,191 +1600,1234 @@ func TestMaxEvictionTotal(t *testing.T) {
 	}
 }
 
-func TestOverUtilizedEvictionReason(t *testing.T) {
-	tests := []struct {
+func TestNodeProcessingOrderWithMaxEvictionTotal(t *testing.T) {
+	buildPods := func(nodeName string, count int) []*corev1.Pod {
+		var pods []*corev1.Pod
+		for i := 0; i < count; i++ {
+			pods = append(pods, test.BuildTestPod(fmt.Sprintf("%s-p%d", nodeName, i), 400, 0, nodeName, test.SetRSOwnerRef))
+		}
+		return pods
+	}
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+		test.BuildTestNode("n3", 4000, 3000, 10, nil),
+		test.BuildTestNode("n4", 4000, 3000, 10, nil),
+	}
+	var pods []*corev1.Pod
+	pods = append(pods, buildPods("n1", 8)...) // 80% cpu
+	pods = append(pods, buildPods("n2", 9)...) // 90% cpu, the most overutilized
+	pods = append(pods, buildPods("n3", 6)...) // 60% cpu, the least overutilized
+	pods = append(pods, test.BuildTestPod("n4-p0", 400, 0, "n4", test.SetDSOwnerRef))
+
+	testCases := []struct {
 		name             string
-		targetThresholds ResourceThresholds
-		prodThresholds   ResourceThresholds
-		prod             bool
-		node             *corev1.Node
-		usage            map[corev1.ResourceName]*resource.Quantity
-		prodUsage        map[corev1.ResourceName]*resource.Quantity
-		want             string
+		order            deschedulerconfig.NodeProcessingOrder
+		expectedEvictedN string
 	}{
 		{
-			name: "cpu overutilized",
-			targetThresholds: deschedulerconfig.ResourceThresholds{
-				corev1.ResourceCPU:    50,
-				corev1.ResourceMemory: 50,
-			},
-			node: &corev1.Node{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "test-node",
-				},
-				Status: corev1.NodeStatus{
-					Allocatable: corev1.ResourceList{
-						corev1.ResourceCPU:    resource.MustParse("96"),
-						corev1.ResourceMemory: resource.MustParse("512Gi"),
-					},
-				},
-			},
-			usage: map[corev1.ResourceName]*resource.Quantity{
-				corev1.ResourceCPU:    resource.NewMilliQuantity(64*1000, resource.DecimalSI),
-				corev1.ResourceMemory: resource.NewQuantity(32*1024*1024*1024, resource.BinarySI),
-			},
-			want: "node is overutilized, node cpu usage(66.67%)>threshold(50.00%)",
-		},
-		{
-			name: "both cpu and memory overutilized",
-			targetThresholds: deschedulerconfig.ResourceThresholds{
-				corev1.ResourceCPU:    50,
-				corev1.ResourceMemory: 50,
-			},
-			node: &corev1.Node{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "test-node",
-				},
-				Status: corev1.NodeStatus{
-					Allocatable: corev1.ResourceList{
-						corev1.ResourceCPU:    resource.MustParse("96"),
-						corev1.ResourceMemory: resource.MustParse("512Gi"),
-					},
-				},
-			},
-			usage: map[corev1.ResourceName]*resource.Quantity{
-				corev1.ResourceCPU:    resource.NewMilliQuantity(64*1000, resource.DecimalSI),
-				corev1.ResourceMemory: resource.NewQuantity(400*1024*1024*1024, resource.BinarySI),
-			},
-			want: "node is overutilized, node cpu usage(66.67%)>threshold(50.00%), node memory usage(78.12%)>threshold(50.00%)",
+			name:             "most overutilized first",
+			order:            deschedulerconfig.NodeProcessingOrderMostOverutilizedFirst,
+			expectedEvictedN: "n2",
 		},
 		{
-			name: "prod cpu overutilized",
-			targetThresholds: deschedulerconfig.ResourceThresholds{
-				corev1.ResourceCPU:    50,
-				corev1.ResourceMemory: 50,
-			},
-			prodThresholds: deschedulerconfig.ResourceThresholds{
-				corev1.ResourceCPU:    40,
-				corev1.ResourceMemory: 40,
-			},
-			prod: true,
-			node: &corev1.Node{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "test-node",
-				},
-				Status: corev1.NodeStatus{
-					Allocatable: corev1.ResourceList{
-						corev1.ResourceCPU:    resource.MustParse("96"),
-						corev1.ResourceMemory: resource.MustParse("512Gi"),
-					},
-				},
-			},
-			usage: map[corev1.ResourceName]*resource.Quantity{
-				corev1.ResourceCPU:    resource.NewMilliQuantity(46*1000, resource.DecimalSI),
-				corev1.ResourceMemory: resource.NewQuantity(250*1024*1024*1024, resource.BinarySI),
-			},
-			prodUsage: map[corev1.ResourceName]*resource.Quantity{
-				corev1.ResourceCPU:    resource.NewMilliQuantity(45*1000, resource.DecimalSI),
-				corev1.ResourceMemory: resource.NewQuantity(200*1024*1024*1024, resource.BinarySI),
-			},
-			want: "node is overutilized, prod cpu usage(46.88%)>threshold(40.00%)",
+			name:             "least overutilized first",
+			order:            deschedulerconfig.NodeProcessingOrderLeastFirst,
+			expectedEvictedN: "n3",
 		},
 		{
-			name: "prod cpu && memory overutilized",
-			targetThresholds: deschedulerconfig.ResourceThresholds{
-				corev1.ResourceCPU:    50,
-				corev1.ResourceMemory: 50,
-			},
-			prodThresholds: deschedulerconfig.ResourceThresholds{
-				corev1.ResourceCPU:    40,
-				corev1.ResourceMemory: 40,
-			},
-			prod: true,
-			node: &corev1.Node{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "test-node",
-				},
-				Status: corev1.NodeStatus{
-					Allocatable: corev1.ResourceList{
-						corev1.ResourceCPU:    resource.MustParse("96"),
-						corev1.ResourceMemory: resource.MustParse("512Gi"),
-					},
-				},
-			},
-			usage: map[corev1.ResourceName]*resource.Quantity{
-				corev1.ResourceCPU:    resource.NewMilliQuantity(46*1000, resource.DecimalSI),
-				corev1.ResourceMemory: resource.NewQuantity(250*1024*1024*1024, resource.BinarySI),
-			},
-			prodUsage: map[corev1.ResourceName]*resource.Quantity{
-				corev1.ResourceCPU:    resource.NewMilliQuantity(45*1000, resource.DecimalSI),
-				corev1.ResourceMemory: resource.NewQuantity(250*1024*1024*1024, resource.BinarySI),
-			},
-			want: "node is overutilized, prod cpu usage(46.88%)>threshold(40.00%), prod memory usage(48.83%)>threshold(40.00%)",
+			name:             "by name",
+			order:            deschedulerconfig.NodeProcessingOrderByName,
+			expectedEvictedN: "n1",
 		},
 	}
-
-	for _, tt := range tests {
+	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			nodeUsage := &NodeUsage{
-				node:      tt.node,
-				usage:     tt.usage,
-				prodUsage: tt.prodUsage,
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
 			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			frameworktesting.SetupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+			var evictedPods []string
+			fakeClient.PrependReactor("create", "pods", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+				if action.GetSubresource() == "eviction" {
+					obj := action.(coretesting.CreateAction).GetObject()
+					evictedPods = append(evictedPods, obj.(metav1.Object).GetName())
+				}
+				return false, nil, nil
+			})
 
-			resourceNames := getResourceNames(tt.targetThresholds)
-			nodeThresholds := getNodeThresholds(map[string]*NodeUsage{"test-node": nodeUsage}, nil, tt.targetThresholds,
-				nil, tt.prodThresholds, resourceNames, false)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+			podInformer := sharedInformerFactory.Core().V1().Pods()
 
-			evictionReasonGenerator := overUtilizedEvictionReason(tt.targetThresholds, tt.prodThresholds)
-			got := evictionReasonGenerator(NodeInfo{
-				NodeUsage:  nodeUsage,
-				thresholds: nodeThresholds["test-node"],
-			}, tt.prod)
-			assert.Equal(t, tt.want, got)
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			eventRecorder := &events.FakeRecorder{}
+			maxEvictionTotal := uint(1)
+			evictionLimiter := evictions.NewEvictionLimiter(nil, nil, &maxEvictionTotal)
+
+			koordClientSet := koordfake.NewSimpleClientset()
+			setupNodeMetrics(koordClientSet, nodes, pods, nil)
+
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(defaultevictor.PluginName, defaultevictor.New)
+						profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: defaultevictor.PluginName,
+							Args: &defaultevictor.DefaultEvictorArgs{},
+						})
+					},
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(LowNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+							return NewLowNodeLoad(args, &fakeFrameworkHandle{
+								Handle:    handle,
+								Interface: koordClientSet,
+							})
+						})
+						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: LowNodeLoadName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: LowNodeLoadName,
+							Args: &deschedulerconfig.LowNodeLoadArgs{
+								NodeFit:             true,
+								NodeProcessingOrder: tt.order,
+								NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+									{
+										NodeSelector:    &metav1.LabelSelector{},
+										LowThresholds:   ResourceThresholds{corev1.ResourceCPU: 30},
+										HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 50},
+										ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+										AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+											ConsecutiveAbnormalities: 1,
+											ConsecutiveNormalities:   1,
+										},
+									},
+								},
+								DetectorCacheTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+							},
+						})
+					},
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithEvictionLimiter(evictionLimiter),
+				frameworkruntime.WithEventRecorder(eventRecorder),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			fh.RunBalancePlugins(ctx, nodes)
+
+			assert.Equal(t, uint(1), evictionLimiter.TotalEvicted())
+			assert.Len(t, evictedPods, 1)
+			assert.True(t, strings.HasPrefix(evictedPods[0], tt.expectedEvictedN+"-"), "unexpected evicted pod %v", evictedPods)
 		})
 	}
 }
 
-func Test_filterNodes(t *testing.T) {
-	tests := []struct {
-		name         string
-		nodeSelector *metav1.LabelSelector
-		nodes        []*corev1.Node
-		want         []*corev1.Node
-		wantErr      bool
+func TestLowNodeLoadMinNodesInScope(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+	}
+	var pods []*corev1.Pod
+	for i := 0; i < 9; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n1-p%d", i), 400, 0, "n1", test.SetRSOwnerRef))
+	}
+	pods = append(pods, test.BuildTestPod("n2-p0", 400, 0, "n2", test.SetDSOwnerRef))
+
+	testCases := []struct {
+		name            string
+		minNodesInScope int32
+		expectedEvicted bool
 	}{
 		{
-			name: "empty selector",
-			nodes: []*corev1.Node{
-				test.BuildTestNode("test-node-1", 4000, 3000, 9, nil),
-				test.BuildTestNode("test-node-2", 4000, 3000, 10, nil),
-			},
-			want: []*corev1.Node{
-				test.BuildTestNode("test-node-1", 4000, 3000, 9, nil),
-				test.BuildTestNode("test-node-2", 4000, 3000, 10, nil),
-			},
-			wantErr: false,
+			name:            "nodes in scope below the minimum",
+			minNodesInScope: 3,
+			expectedEvicted: false,
 		},
 		{
-			name: "matched selector",
-			nodeSelector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"test": "true",
-				},
-			},
-			nodes: []*corev1.Node{
-				test.BuildTestNode("test-node-1", 4000, 3000, 9, nil),
-				test.BuildTestNode("test-node-2", 4000, 3000, 10, func(node *corev1.Node) {
-					if node.Labels == nil {
-						node.Labels = map[string]string{}
-					}
-					node.Labels["test"] = "true"
-				}),
-			},
-			want: []*corev1.Node{
-				test.BuildTestNode("test-node-2", 4000, 3000, 10, func(node *corev1.Node) {
-					if node.Labels == nil {
-						node.Labels = map[string]string{}
+			name:            "nodes in scope equal to the minimum",
+			minNodesInScope: 2,
+			expectedEvicted: true,
+		},
+		{
+			name:            "no minimum",
+			minNodesInScope: 0,
+			expectedEvicted: true,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			frameworktesting.SetupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			eventRecorder := &events.FakeRecorder{}
+			maxEvictionTotal := uint(1)
+			evictionLimiter := evictions.NewEvictionLimiter(nil, nil, &maxEvictionTotal)
+
+			koordClientSet := koordfake.NewSimpleClientset()
+			setupNodeMetrics(koordClientSet, nodes, pods, nil)
+
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(defaultevictor.PluginName, defaultevictor.New)
+						profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: defaultevictor.PluginName,
+							Args: &defaultevictor.DefaultEvictorArgs{},
+						})
+					},
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(LowNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+							return NewLowNodeLoad(args, &fakeFrameworkHandle{
+								Handle:    handle,
+								Interface: koordClientSet,
+							})
+						})
+						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: LowNodeLoadName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: LowNodeLoadName,
+							Args: &deschedulerconfig.LowNodeLoadArgs{
+								NodeFit:         true,
+								MinNodesInScope: tt.minNodesInScope,
+								NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+									{
+										NodeSelector:    &metav1.LabelSelector{},
+										LowThresholds:   ResourceThresholds{corev1.ResourceCPU: 30},
+										HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 50},
+										ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+										AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+											ConsecutiveAbnormalities: 1,
+											ConsecutiveNormalities:   1,
+										},
+									},
+								},
+								DetectorCacheTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+							},
+						})
+					},
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithEvictionLimiter(evictionLimiter),
+				frameworkruntime.WithEventRecorder(eventRecorder),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			fh.RunBalancePlugins(ctx, nodes)
+
+			if tt.expectedEvicted {
+				assert.Equal(t, uint(1), evictionLimiter.TotalEvicted())
+			} else {
+				assert.Equal(t, uint(0), evictionLimiter.TotalEvicted())
+			}
+		})
+	}
+}
+
+func TestLowNodeLoadEvictionAggressiveness(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+		test.BuildTestNode("n3", 4000, 3000, 10, nil),
+	}
+	var initialPods []*corev1.Pod
+	for i := 0; i < 9; i++ {
+		initialPods = append(initialPods, test.BuildTestPod(fmt.Sprintf("n1-p%d", i), 400, 0, "n1", test.SetRSOwnerRef))
+	}
+	initialPods = append(initialPods, test.BuildTestPod("n2-p0", 400, 0, "n2", test.SetDSOwnerRef))
+	initialPods = append(initialPods, test.BuildTestPod("n3-p0", 400, 0, "n3", test.SetDSOwnerRef))
+
+	testCases := []struct {
+		name                   string
+		evictionAggressiveness *deschedulerconfig.Float64OrString
+		expectedEvictedByCycle []int
+	}{
+		{
+			name:                   "relieve all the excess in one cycle by default",
+			expectedEvictedByCycle: []int{4},
+		},
+		{
+			name:                   "relieve all the excess in one cycle",
+			evictionAggressiveness: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.Float, FloatVal: 1},
+			expectedEvictedByCycle: []int{4},
+		},
+		{
+			name:                   "relieve half of the excess per cycle",
+			evictionAggressiveness: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.Float, FloatVal: 0.5},
+			expectedEvictedByCycle: []int{2, 1, 1},
+		},
+		{
+			name:                   "relieve a quarter of the excess per cycle",
+			evictionAggressiveness: &deschedulerconfig.Float64OrString{Type: deschedulerconfig.String, StrVal: "0.25"},
+			expectedEvictedByCycle: []int{1, 1, 1, 1},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			pods := initialPods
+			var evictedByCycle []int
+			// run the cycles until the node converges under the high thresholds, evicted pods are removed before the next cycle
+			for cycle := 0; cycle < 10; cycle++ {
+				evictedPods := runLowNodeLoadCycle(t, nodes, pods, func(args *deschedulerconfig.LowNodeLoadArgs) {
+					args.EvictionAggressiveness = tt.evictionAggressiveness
+				})
+				if len(evictedPods) == 0 {
+					break
+				}
+				evictedByCycle = append(evictedByCycle, len(evictedPods))
+				var remainingPods []*corev1.Pod
+				for _, pod := range pods {
+					if !evictedPods.Has(pod.Name) {
+						remainingPods = append(remainingPods, pod)
+					}
+				}
+				pods = remainingPods
+			}
+			assert.Equal(t, tt.expectedEvictedByCycle, evictedByCycle)
+		})
+	}
+}
+
+func TestLowNodeLoadThrottledNodes(t *testing.T) {
+	setThrottled := func(status corev1.ConditionStatus) func(node *corev1.Node) {
+		return func(node *corev1.Node) {
+			node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{
+				Type:   "ThermalThrottle",
+				Status: status,
+			})
+		}
+	}
+	// n1 is appropriately utilized with 40% cpu usage, n2 and n3 are underutilized with 10% cpu usage
+	var pods []*corev1.Pod
+	for i := 0; i < 4; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n1-p%d", i), 400, 0, "n1", test.SetRSOwnerRef))
+	}
+	pods = append(pods, test.BuildTestPod("n2-p0", 400, 0, "n2", test.SetDSOwnerRef))
+	pods = append(pods, test.BuildTestPod("n3-p0", 400, 0, "n3", test.SetDSOwnerRef))
+
+	testCases := []struct {
+		name               string
+		conditionType      corev1.NodeConditionType
+		nodes              []*corev1.Node
+		expectedEvictCount int
+	}{
+		{
+			name: "throttled node condition is not configured",
+			nodes: []*corev1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, setThrottled(corev1.ConditionTrue)),
+				test.BuildTestNode("n2", 4000, 3000, 10, nil),
+				test.BuildTestNode("n3", 4000, 3000, 10, nil),
+			},
+			expectedEvictCount: 0,
+		},
+		{
+			name:          "relieve the throttled node to the low thresholds",
+			conditionType: "ThermalThrottle",
+			nodes: []*corev1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, setThrottled(corev1.ConditionTrue)),
+				test.BuildTestNode("n2", 4000, 3000, 10, nil),
+				test.BuildTestNode("n3", 4000, 3000, 10, nil),
+			},
+			expectedEvictCount: 1,
+		},
+		{
+			name:          "the node is not throttled",
+			conditionType: "ThermalThrottle",
+			nodes: []*corev1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, setThrottled(corev1.ConditionFalse)),
+				test.BuildTestNode("n2", 4000, 3000, 10, nil),
+				test.BuildTestNode("n3", 4000, 3000, 10, nil),
+			},
+			expectedEvictCount: 0,
+		},
+		{
+			name:          "the throttled nodes are not underutilized",
+			conditionType: "ThermalThrottle",
+			nodes: []*corev1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, setThrottled(corev1.ConditionTrue)),
+				test.BuildTestNode("n2", 4000, 3000, 10, setThrottled(corev1.ConditionTrue)),
+				test.BuildTestNode("n3", 4000, 3000, 10, setThrottled(corev1.ConditionTrue)),
+			},
+			expectedEvictCount: 0,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			evictedPods := runLowNodeLoadCycle(t, tt.nodes, pods, func(args *deschedulerconfig.LowNodeLoadArgs) {
+				args.ThrottledNodeConditionType = tt.conditionType
+			})
+			assert.Equal(t, tt.expectedEvictCount, evictedPods.Len())
+			for _, name := range evictedPods.List() {
+				assert.True(t, strings.HasPrefix(name, "n1-"), "unexpected evicted pod %s", name)
+			}
+		})
+	}
+}
+
+func runLowNodeLoadCycle(t *testing.T, nodes []*corev1.Node, pods []*corev1.Pod, setArgs func(args *deschedulerconfig.LowNodeLoadArgs), opts ...frameworkruntime.Option) sets.String {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var objs []runtime.Object
+	for _, node := range nodes {
+		objs = append(objs, node)
+	}
+	for _, pod := range pods {
+		objs = append(objs, pod)
+	}
+	fakeClient := fake.NewSimpleClientset(objs...)
+	frameworktesting.SetupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+	evictedPods := sets.NewString()
+	fakeClient.PrependReactor("create", "pods", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		if action.GetSubresource() == "eviction" {
+			obj := action.(coretesting.CreateAction).GetObject()
+			evictedPods.Insert(obj.(metav1.Object).GetName())
+		}
+		return false, nil, nil
+	})
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+	podInformer := sharedInformerFactory.Core().V1().Pods()
+
+	getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+	if err != nil {
+		t.Errorf("Build get pods assigned to node function error: %v", err)
+	}
+
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	koordClientSet := koordfake.NewSimpleClientset()
+	setupNodeMetrics(koordClientSet, nodes, pods, nil)
+
+	fh, err := frameworktesting.NewFramework(
+		[]frameworktesting.RegisterPluginFunc{
+			func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+				reg.Register(defaultevictor.PluginName, defaultevictor.New)
+				profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+				profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+				profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+					Name: defaultevictor.PluginName,
+					Args: &defaultevictor.DefaultEvictorArgs{},
+				})
+			},
+			func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+				reg.Register(LowNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+					return NewLowNodeLoad(args, &fakeFrameworkHandle{
+						Handle:    handle,
+						Interface: koordClientSet,
+					})
+				})
+				args := &deschedulerconfig.LowNodeLoadArgs{
+					NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+						{
+							NodeSelector:    &metav1.LabelSelector{},
+							LowThresholds:   ResourceThresholds{corev1.ResourceCPU: 30},
+							HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 50},
+							ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+							AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+								ConsecutiveAbnormalities: 1,
+								ConsecutiveNormalities:   1,
+							},
+						},
+					},
+					DetectorCacheTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+				}
+				setArgs(args)
+				profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: LowNodeLoadName})
+				profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+					Name: LowNodeLoadName,
+					Args: args,
+				})
+			},
+		},
+		"test",
+		append([]frameworkruntime.Option{
+			frameworkruntime.WithClientSet(fakeClient),
+			frameworkruntime.WithEvictionLimiter(evictions.NewEvictionLimiter(nil, nil, nil)),
+			frameworkruntime.WithEventRecorder(&events.FakeRecorder{}),
+			frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+			frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+		}, opts...)...,
+	)
+	assert.NoError(t, err)
+
+	fh.RunBalancePlugins(ctx, nodes)
+	return evictedPods
+}
+
+func TestLowNodeLoadSystemCriticalPriorityClassPods(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+		test.BuildTestNode("n3", 4000, 3000, 10, nil),
+	}
+	var pods []*corev1.Pod
+	for i := 0; i < 9; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n1-p%d", i), 400, 0, "n1", func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			if i%2 == 0 {
+				pod.Spec.PriorityClassName = "system-cluster-critical"
+			} else {
+				pod.Spec.PriorityClassName = "system-node-critical"
+			}
+		}))
+	}
+	pods = append(pods, test.BuildTestPod("n2-p0", 400, 0, "n2", test.SetDSOwnerRef))
+	pods = append(pods, test.BuildTestPod("n3-p0", 400, 0, "n3", test.SetDSOwnerRef))
+
+	testCases := []struct {
+		name              string
+		evictCriticalPods bool
+		expectedEvicted   int
+	}{
+		{
+			name:            "critical pods are retained on the overloaded node",
+			expectedEvicted: 0,
+		},
+		{
+			name:              "critical pods are evicted if allowed",
+			evictCriticalPods: true,
+			expectedEvicted:   4,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			evictedPods := runLowNodeLoadCycle(t, nodes, pods, func(args *deschedulerconfig.LowNodeLoadArgs) {},
+				frameworkruntime.WithEvictSystemCriticalPriorityClassPods(tt.evictCriticalPods))
+			assert.Equal(t, tt.expectedEvicted, evictedPods.Len())
+		})
+	}
+}
+
+func TestLowNodeLoadConsolidation(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+		test.BuildTestNode("n3", 4000, 3000, 10, nil),
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("n1-p0", 200, 0, "n1", test.SetRSOwnerRef),
+		test.BuildTestPod("n1-ds", 100, 0, "n1", test.SetDSOwnerRef),
+	}
+	for i := 0; i < 4; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n2-p%d", i), 400, 0, "n2", test.SetRSOwnerRef))
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n3-p%d", i), 400, 0, "n3", test.SetRSOwnerRef))
+	}
+
+	testCases := []struct {
+		name              string
+		targetUtilization ResourceThresholds
+		expectedEvicted   []string
+	}{
+		{
+			name:            "consolidation disabled",
+			expectedEvicted: []string{},
+		},
+		{
+			name:              "underutilized node is drained onto the remaining nodes",
+			targetUtilization: ResourceThresholds{corev1.ResourceCPU: 80},
+			expectedEvicted:   []string{"n1-p0"},
+		},
+		{
+			name:              "underutilized node is kept if its pods exceed the target utilization of the remaining nodes",
+			targetUtilization: ResourceThresholds{corev1.ResourceCPU: 40},
+			expectedEvicted:   []string{},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			evictedPods := runLowNodeLoadCycle(t, nodes, pods, func(args *deschedulerconfig.LowNodeLoadArgs) {
+				args.ConsolidationTargetUtilization = tt.targetUtilization
+			})
+			assert.ElementsMatch(t, tt.expectedEvicted, evictedPods.List())
+		})
+	}
+}
+
+func TestLowNodeLoadEvictQuarantinedNodes(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, func(node *corev1.Node) {
+			node.Labels = map[string]string{extension.LabelNodeQuarantine: "true"}
+		}),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("n1-rs-1", 400, 0, "n1", test.SetRSOwnerRef),
+		test.BuildTestPod("n1-rs-2", 400, 0, "n1", test.SetRSOwnerRef),
+		test.BuildTestPod("n1-ds", 400, 0, "n1", test.SetDSOwnerRef),
+		test.BuildTestPod("n1-protected", 400, 0, "n1", func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Namespace = "kube-system"
+		}),
+		test.BuildTestPod("n2-rs", 400, 0, "n2", test.SetRSOwnerRef),
+	}
+
+	testCases := []struct {
+		name                  string
+		evictQuarantinedNodes bool
+		expectedEvicted       []string
+	}{
+		{
+			name:                  "quarantine draining disabled",
+			evictQuarantinedNodes: false,
+		},
+		{
+			name:                  "drain quarantined node but keep protected pods",
+			evictQuarantinedNodes: true,
+			expectedEvicted:       []string{"n1-rs-1", "n1-rs-2"},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			frameworktesting.SetupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+			var evictedPods []string
+			fakeClient.PrependReactor("create", "pods", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+				if action.GetSubresource() == "eviction" {
+					obj := action.(coretesting.CreateAction).GetObject()
+					evictedPods = append(evictedPods, obj.(metav1.Object).GetName())
+				}
+				return false, nil, nil
+			})
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			eventRecorder := &events.FakeRecorder{}
+			evictionLimiter := evictions.NewEvictionLimiter(nil, nil, nil)
+
+			koordClientSet := koordfake.NewSimpleClientset()
+			setupNodeMetrics(koordClientSet, nodes, pods, nil)
+
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(defaultevictor.PluginName, defaultevictor.New)
+						profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: defaultevictor.PluginName,
+							Args: &defaultevictor.DefaultEvictorArgs{},
+						})
+					},
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(LowNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+							return NewLowNodeLoad(args, &fakeFrameworkHandle{
+								Handle:    handle,
+								Interface: koordClientSet,
+							})
+						})
+						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: LowNodeLoadName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: LowNodeLoadName,
+							Args: &deschedulerconfig.LowNodeLoadArgs{
+								NodeFit:               true,
+								EvictQuarantinedNodes: tt.evictQuarantinedNodes,
+								EvictableNamespaces: &deschedulerconfig.Namespaces{
+									Exclude: []string{"kube-system"},
+								},
+								NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+									{
+										NodeSelector:    &metav1.LabelSelector{},
+										LowThresholds:   ResourceThresholds{corev1.ResourceCPU: 30},
+										HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 50},
+										ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+										AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+											ConsecutiveAbnormalities: 1,
+											ConsecutiveNormalities:   1,
+										},
+									},
+								},
+								DetectorCacheTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+							},
+						})
+					},
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithEvictionLimiter(evictionLimiter),
+				frameworkruntime.WithEventRecorder(eventRecorder),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			fh.RunBalancePlugins(ctx, nodes)
+
+			sort.Strings(evictedPods)
+			assert.Equal(t, tt.expectedEvicted, evictedPods)
+		})
+	}
+}
+
+func TestLowNodeLoadTruncatedByCycleDeadline(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+		test.BuildTestNode("n3", 4000, 3000, 10, nil),
+		test.BuildTestNode("n4", 4000, 3000, 10, nil),
+	}
+	var pods []*corev1.Pod
+	// n1 is the most overutilized source node and is processed first, n2 is overutilized too
+	for i := 0; i < 5; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n1-rs-%d", i), 600, 0, "n1", test.SetRSOwnerRef))
+	}
+	for i := 0; i < 4; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n2-rs-%d", i), 600, 0, "n2", test.SetRSOwnerRef))
+	}
+	pods = append(pods,
+		test.BuildTestPod("n3-rs", 100, 0, "n3", test.SetRSOwnerRef),
+		test.BuildTestPod("n4-rs", 100, 0, "n4", test.SetRSOwnerRef),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	deadline := framework.NewCycleDeadline(fakeClock, time.Minute)
+	ctx = framework.WithCycleDeadline(ctx, deadline)
+
+	var objs []runtime.Object
+	for _, node := range nodes {
+		objs = append(objs, node)
+	}
+	for _, pod := range pods {
+		objs = append(objs, pod)
+	}
+	fakeClient := fake.NewSimpleClientset(objs...)
+	frameworktesting.SetupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+	var evictedPods []string
+	fakeClient.PrependReactor("create", "pods", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		if action.GetSubresource() == "eviction" {
+			obj := action.(coretesting.CreateAction).GetObject()
+			evictedPods = append(evictedPods, obj.(metav1.Object).GetName())
+			// every eviction takes longer than the max cycle duration
+			fakeClock.Step(2 * time.Minute)
+		}
+		return false, nil, nil
+	})
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+	podInformer := sharedInformerFactory.Core().V1().Pods()
+	getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+	assert.NoError(t, err)
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	koordClientSet := koordfake.NewSimpleClientset()
+	setupNodeMetrics(koordClientSet, nodes, pods, nil)
+
+	fh, err := frameworktesting.NewFramework(
+		[]frameworktesting.RegisterPluginFunc{
+			func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+				reg.Register(defaultevictor.PluginName, defaultevictor.New)
+				profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+				profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+				profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+					Name: defaultevictor.PluginName,
+					Args: &defaultevictor.DefaultEvictorArgs{},
+				})
+			},
+			func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+				reg.Register(LowNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+					return NewLowNodeLoad(args, &fakeFrameworkHandle{
+						Handle:    handle,
+						Interface: koordClientSet,
+					})
+				})
+				profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: LowNodeLoadName})
+				profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+					Name: LowNodeLoadName,
+					Args: &deschedulerconfig.LowNodeLoadArgs{
+						NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+							{
+								NodeSelector:    &metav1.LabelSelector{},
+								LowThresholds:   ResourceThresholds{corev1.ResourceCPU: 30},
+								HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 50},
+								ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+								AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+									ConsecutiveAbnormalities: 1,
+									ConsecutiveNormalities:   1,
+								},
+							},
+						},
+						DetectorCacheTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				})
+			},
+		},
+		"test",
+		frameworkruntime.WithClientSet(fakeClient),
+		frameworkruntime.WithEvictionLimiter(evictions.NewEvictionLimiter(nil, nil, nil)),
+		frameworkruntime.WithEventRecorder(&events.FakeRecorder{}),
+		frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+		frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+	)
+	assert.NoError(t, err)
+
+	fh.RunBalancePlugins(ctx, nodes)
+
+	// the deadline is exceeded while n1 is being processed, n2 is left for the next cycle
+	assert.NotEmpty(t, evictedPods)
+	for _, name := range evictedPods {
+		assert.True(t, strings.HasPrefix(name, "n1-"), "unexpected evicted pod %s", name)
+	}
+	assert.True(t, deadline.Truncated())
+	assert.True(t, deadline.UnprocessedNodes().Has("n2"))
+	assert.False(t, deadline.UnprocessedNodes().Has("n1"))
+}
+
+type preEvictionFilterEvictor struct {
+	rejectedPods sets.String
+	evictedPods  []string
+}
+
+func (e *preEvictionFilterEvictor) Filter(pod *corev1.Pod) bool {
+	return true
+}
+
+func (e *preEvictionFilterEvictor) PreEvictionFilter(pod *corev1.Pod) bool {
+	return !e.rejectedPods.Has(pod.Name)
+}
+
+func (e *preEvictionFilterEvictor) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	e.evictedPods = append(e.evictedPods, pod.Name)
+	return true
+}
+
+type podsAssignedToNodeHandle struct {
+	framework.Handle
+	getPodsAssignedToNode framework.GetPodsAssignedToNodeFunc
+}
+
+func (h *podsAssignedToNodeHandle) GetPodsAssignedToNodeFunc() framework.GetPodsAssignedToNodeFunc {
+	return h.getPodsAssignedToNode
+}
+
+func TestLowNodeLoadDrainQuarantinedNodesWithFilters(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, func(node *corev1.Node) {
+			node.Labels = map[string]string{extension.LabelNodeQuarantine: "true"}
+		}),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("n1-rs", 400, 0, "n1", test.SetRSOwnerRef),
+		test.BuildTestPod("n1-filtered", 400, 0, "n1", test.SetRSOwnerRef),
+		test.BuildTestPod("n1-pre-eviction-filtered", 400, 0, "n1", test.SetRSOwnerRef),
+		test.BuildTestPod("n2-rs", 400, 0, "n2", test.SetRSOwnerRef),
+	}
+
+	evictor := &preEvictionFilterEvictor{rejectedPods: sets.NewString("n1-pre-eviction-filtered")}
+	pl := &LowNodeLoad{
+		handle: &podsAssignedToNodeHandle{
+			getPodsAssignedToNode: func(nodeName string, filter framework.FilterFunc) ([]*corev1.Pod, error) {
+				var result []*corev1.Pod
+				for _, pod := range pods {
+					if pod.Spec.NodeName == nodeName && filter(pod) {
+						result = append(result, pod)
+					}
+				}
+				return result, nil
+			},
+		},
+		evictor: evictor,
+		podFilter: func(pod *corev1.Pod) bool {
+			return pod.Name != "n1-filtered"
+		},
+		args: &deschedulerconfig.LowNodeLoadArgs{EvictQuarantinedNodes: true},
+	}
+
+	remainingNodes := pl.drainQuarantinedNodes(context.TODO(), nodes)
+	assert.Equal(t, []*corev1.Node{nodes[1]}, remainingNodes)
+	assert.Equal(t, []string{"n1-rs"}, evictor.evictedPods)
+}
+
+func TestLowNodeLoadOwnerKindEvictionPriority(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+	}
+	var pods []*corev1.Pod
+	for i := 0; i < 4; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n1-deployment-%d", i), 800, 0, "n1", func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Labels = map[string]string{"pod-template-hash": "abc"}
+		}))
+	}
+	for i := 0; i < 2; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n1-job-%d", i), 400, 0, "n1", func(pod *corev1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "Job", APIVersion: "batch/v1", Name: "job-1"}}
+		}))
+	}
+	pods = append(pods, test.BuildTestPod("n2-ds", 100, 0, "n2", test.SetDSOwnerRef))
+
+	testCases := []struct {
+		name                      string
+		ownerKindEvictionPriority []string
+		expectedEvictedPrefix     string
+	}{
+		{
+			name:                  "evict the pod with the highest usage by default",
+			expectedEvictedPrefix: "n1-deployment-",
+		},
+		{
+			name:                      "evict jobs before deployments",
+			ownerKindEvictionPriority: []string{"Job", "Deployment"},
+			expectedEvictedPrefix:     "n1-job-",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			frameworktesting.SetupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+			var evictedPods []string
+			fakeClient.PrependReactor("create", "pods", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+				if action.GetSubresource() == "eviction" {
+					obj := action.(coretesting.CreateAction).GetObject()
+					evictedPods = append(evictedPods, obj.(metav1.Object).GetName())
+				}
+				return false, nil, nil
+			})
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			eventRecorder := &events.FakeRecorder{}
+			maxEvictionTotal := uint(1)
+			evictionLimiter := evictions.NewEvictionLimiter(nil, nil, &maxEvictionTotal)
+
+			koordClientSet := koordfake.NewSimpleClientset()
+			setupNodeMetrics(koordClientSet, nodes, pods, nil)
+
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(defaultevictor.PluginName, defaultevictor.New)
+						profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: defaultevictor.PluginName,
+							Args: &defaultevictor.DefaultEvictorArgs{},
+						})
+					},
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(LowNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+							return NewLowNodeLoad(args, &fakeFrameworkHandle{
+								Handle:    handle,
+								Interface: koordClientSet,
+							})
+						})
+						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: LowNodeLoadName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: LowNodeLoadName,
+							Args: &deschedulerconfig.LowNodeLoadArgs{
+								NodeFit:                   true,
+								OwnerKindEvictionPriority: tt.ownerKindEvictionPriority,
+								NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+									{
+										NodeSelector:    &metav1.LabelSelector{},
+										LowThresholds:   ResourceThresholds{corev1.ResourceCPU: 30},
+										HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 50},
+										ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+										AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+											ConsecutiveAbnormalities: 1,
+											ConsecutiveNormalities:   1,
+										},
+									},
+								},
+								DetectorCacheTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+							},
+						})
+					},
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithEvictionLimiter(evictionLimiter),
+				frameworkruntime.WithEventRecorder(eventRecorder),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			fh.RunBalancePlugins(ctx, nodes)
+
+			assert.Len(t, evictedPods, 1)
+			assert.True(t, strings.HasPrefix(evictedPods[0], tt.expectedEvictedPrefix), "unexpected evicted pod %v", evictedPods)
+		})
+	}
+}
+
+func TestOverUtilizedEvictionReason(t *testing.T) {
+	tests := []struct {
+		name             string
+		targetThresholds ResourceThresholds
+		prodThresholds   ResourceThresholds
+		prod             bool
+		node             *corev1.Node
+		usage            map[corev1.ResourceName]*resource.Quantity
+		prodUsage        map[corev1.ResourceName]*resource.Quantity
+		want             string
+	}{
+		{
+			name: "cpu overutilized",
+			targetThresholds: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceCPU:    50,
+				corev1.ResourceMemory: 50,
+			},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node",
+				},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("96"),
+						corev1.ResourceMemory: resource.MustParse("512Gi"),
+					},
+				},
+			},
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:    resource.NewMilliQuantity(64*1000, resource.DecimalSI),
+				corev1.ResourceMemory: resource.NewQuantity(32*1024*1024*1024, resource.BinarySI),
+			},
+			want: "node is overutilized, node cpu usage(66.67%)>threshold(50.00%)",
+		},
+		{
+			name: "both cpu and memory overutilized",
+			targetThresholds: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceCPU:    50,
+				corev1.ResourceMemory: 50,
+			},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node",
+				},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("96"),
+						corev1.ResourceMemory: resource.MustParse("512Gi"),
+					},
+				},
+			},
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:    resource.NewMilliQuantity(64*1000, resource.DecimalSI),
+				corev1.ResourceMemory: resource.NewQuantity(400*1024*1024*1024, resource.BinarySI),
+			},
+			want: "node is overutilized, node cpu usage(66.67%)>threshold(50.00%), node memory usage(78.12%)>threshold(50.00%)",
+		},
+		{
+			name: "prod cpu overutilized",
+			targetThresholds: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceCPU:    50,
+				corev1.ResourceMemory: 50,
+			},
+			prodThresholds: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceCPU:    40,
+				corev1.ResourceMemory: 40,
+			},
+			prod: true,
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node",
+				},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("96"),
+						corev1.ResourceMemory: resource.MustParse("512Gi"),
+					},
+				},
+			},
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:    resource.NewMilliQuantity(46*1000, resource.DecimalSI),
+				corev1.ResourceMemory: resource.NewQuantity(250*1024*1024*1024, resource.BinarySI),
+			},
+			prodUsage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:    resource.NewMilliQuantity(45*1000, resource.DecimalSI),
+				corev1.ResourceMemory: resource.NewQuantity(200*1024*1024*1024, resource.BinarySI),
+			},
+			want: "node is overutilized, prod cpu usage(46.88%)>threshold(40.00%)",
+		},
+		{
+			name: "prod cpu && memory overutilized",
+			targetThresholds: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceCPU:    50,
+				corev1.ResourceMemory: 50,
+			},
+			prodThresholds: deschedulerconfig.ResourceThresholds{
+				corev1.ResourceCPU:    40,
+				corev1.ResourceMemory: 40,
+			},
+			prod: true,
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node",
+				},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("96"),
+						corev1.ResourceMemory: resource.MustParse("512Gi"),
+					},
+				},
+			},
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:    resource.NewMilliQuantity(46*1000, resource.DecimalSI),
+				corev1.ResourceMemory: resource.NewQuantity(250*1024*1024*1024, resource.BinarySI),
+			},
+			prodUsage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:    resource.NewMilliQuantity(45*1000, resource.DecimalSI),
+				corev1.ResourceMemory: resource.NewQuantity(250*1024*1024*1024, resource.BinarySI),
+			},
+			want: "node is overutilized, prod cpu usage(46.88%)>threshold(40.00%), prod memory usage(48.83%)>threshold(40.00%)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeUsage := &NodeUsage{
+				node:      tt.node,
+				usage:     tt.usage,
+				prodUsage: tt.prodUsage,
+			}
+
+			resourceNames := getResourceNames(tt.targetThresholds)
+			nodeThresholds := getNodeThresholds(map[string]*NodeUsage{"test-node": nodeUsage}, nil, tt.targetThresholds,
+				nil, tt.prodThresholds, resourceNames, false)
+
+			evictionReasonGenerator := overUtilizedEvictionReason(tt.targetThresholds, tt.prodThresholds)
+			got := evictionReasonGenerator(NodeInfo{
+				NodeUsage:  nodeUsage,
+				thresholds: nodeThresholds["test-node"],
+			}, tt.prod)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_filterNodes(t *testing.T) {
+	tests := []struct {
+		name         string
+		nodeSelector *metav1.LabelSelector
+		nodes        []*corev1.Node
+		want         []*corev1.Node
+		wantErr      bool
+	}{
+		{
+			name: "empty selector",
+			nodes: []*corev1.Node{
+				test.BuildTestNode("test-node-1", 4000, 3000, 9, nil),
+				test.BuildTestNode("test-node-2", 4000, 3000, 10, nil),
+			},
+			want: []*corev1.Node{
+				test.BuildTestNode("test-node-1", 4000, 3000, 9, nil),
+				test.BuildTestNode("test-node-2", 4000, 3000, 10, nil),
+			},
+			wantErr: false,
+		},
+		{
+			name: "matched selector",
+			nodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"test": "true",
+				},
+			},
+			nodes: []*corev1.Node{
+				test.BuildTestNode("test-node-1", 4000, 3000, 9, nil),
+				test.BuildTestNode("test-node-2", 4000, 3000, 10, func(node *corev1.Node) {
+					if node.Labels == nil {
+						node.Labels = map[string]string{}
+					}
+					node.Labels["test"] = "true"
+				}),
+			},
+			want: []*corev1.Node{
+				test.BuildTestNode("test-node-2", 4000, 3000, 10, func(node *corev1.Node) {
+					if node.Labels == nil {
+						node.Labels = map[string]string{}
 					}
 					node.Labels["test"] = "true"
 				}),
@@ -1857,6 +2877,76 @@ func Test_filterNodes(t *testing.T) {
 	}
 }
 
+func Test_filterPods(t *testing.T) {
+	podSelectors := []deschedulerconfig.LowNodeLoadPodSelector{
+		{
+			Name: "app",
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "test"},
+			},
+		},
+		{
+			Name: "tier",
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"tier": "batch"},
+			},
+		},
+	}
+	withLabels := func(labels map[string]string) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			pod.Labels = labels
+		}
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("pod-both", 100, 100, "test-node", withLabels(map[string]string{"app": "test", "tier": "batch"})),
+		test.BuildTestPod("pod-app", 100, 100, "test-node", withLabels(map[string]string{"app": "test"})),
+		test.BuildTestPod("pod-tier", 100, 100, "test-node", withLabels(map[string]string{"tier": "batch"})),
+		test.BuildTestPod("pod-none", 100, 100, "test-node", nil),
+	}
+	tests := []struct {
+		name         string
+		podSelectors []deschedulerconfig.LowNodeLoadPodSelector
+		matchMode    deschedulerconfig.PodSelectorMatchMode
+		want         []string
+	}{
+		{
+			name:      "empty selectors",
+			matchMode: deschedulerconfig.PodSelectorMatchModeAll,
+			want:      []string{"pod-both", "pod-app", "pod-tier", "pod-none"},
+		},
+		{
+			name:         "default match mode is union",
+			podSelectors: podSelectors,
+			want:         []string{"pod-both", "pod-app", "pod-tier"},
+		},
+		{
+			name:         "match any selectors",
+			podSelectors: podSelectors,
+			matchMode:    deschedulerconfig.PodSelectorMatchModeAny,
+			want:         []string{"pod-both", "pod-app", "pod-tier"},
+		},
+		{
+			name:         "match all selectors",
+			podSelectors: podSelectors,
+			matchMode:    deschedulerconfig.PodSelectorMatchModeAll,
+			want:         []string{"pod-both"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := filterPods(tt.podSelectors, tt.matchMode)
+			assert.NoError(t, err)
+			var got []string
+			for _, pod := range pods {
+				if filter(pod) {
+					got = append(got, pod.Name)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_resetNodesAsNormal(t *testing.T) {
 	node := NodeInfo{
 		NodeUsage: &NodeUsage{
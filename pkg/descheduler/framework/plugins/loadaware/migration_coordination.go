@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	migrationutil "github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
+)
+
+// migrationAwareEvictor skips the pods which are being migrated by an active PodMigrationJob,
+// e.g. created by the migration controller for another descheduling plugin, to avoid evicting the pods twice.
+type migrationAwareEvictor struct {
+	framework.Evictor
+	pluginName          string
+	migrationJobIndexer cache.Indexer
+}
+
+// newMigrationAwareEvictor wraps the evictor, the indexer must have the PodMigrationJobIndexers.
+func newMigrationAwareEvictor(evictor framework.Evictor, pluginName string, migrationJobIndexer cache.Indexer) framework.Evictor {
+	return &migrationAwareEvictor{
+		Evictor:             evictor,
+		pluginName:          pluginName,
+		migrationJobIndexer: migrationJobIndexer,
+	}
+}
+
+func (e *migrationAwareEvictor) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	if migrationutil.HasActivePodMigrationJob(e.migrationJobIndexer, pod) {
+		klog.V(4).InfoS("Pod skipped eviction because it is being migrated by an active PodMigrationJob", "pod", klog.KObj(pod), "plugin", e.pluginName)
+		metrics.PodsSkippedForExistingMigration.WithLabelValues(e.pluginName).Inc()
+		return false
+	}
+	return e.Evictor.Evict(ctx, pod, evictOptions)
+}
+
+// newPodMigrationJobInformer returns the PodMigrationJob informer with the PodMigrationJobIndexers,
+// or nil if the PodMigrationJob resource is not served, e.g. the CRD is not installed in the cluster.
+func newPodMigrationJobInformer(koordClientSet koordclientset.Interface, factory koordinformers.SharedInformerFactory) (cache.SharedIndexInformer, error) {
+	resourceList, err := koordClientSet.Discovery().ServerResourcesForGroupVersion(sev1alpha1.SchemeGroupVersion.String())
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to discover the PodMigrationJob resource, the pods being migrated are not skipped")
+		}
+		return nil, nil
+	}
+	served := false
+	for _, resource := range resourceList.APIResources {
+		if resource.Name == "podmigrationjobs" {
+			served = true
+			break
+		}
+	}
+	if !served {
+		return nil, nil
+	}
+	informer := factory.Scheduling().V1alpha1().PodMigrationJobs().Informer()
+	if err := informer.AddIndexers(migrationutil.PodMigrationJobIndexers()); err != nil {
+		return nil, err
+	}
+	return informer, nil
+}
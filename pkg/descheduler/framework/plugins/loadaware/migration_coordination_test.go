@@ -0,0 +1,194 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes/defaultevictor"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestLowNodeLoadSkipPodsWithActiveMigrationJob(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, func(node *corev1.Node) {
+			node.Labels = map[string]string{extension.LabelNodeQuarantine: "true"}
+		}),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("n1-migrating", 400, 0, "n1", test.SetRSOwnerRef),
+		test.BuildTestPod("n1-migrated", 400, 0, "n1", test.SetRSOwnerRef),
+		test.BuildTestPod("n1-rs", 400, 0, "n1", test.SetRSOwnerRef),
+	}
+	jobs := []*sev1alpha1.PodMigrationJob{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "running-job"},
+			Spec: sev1alpha1.PodMigrationJobSpec{
+				PodRef: &corev1.ObjectReference{Namespace: pods[0].Namespace, Name: pods[0].Name},
+			},
+			Status: sev1alpha1.PodMigrationJobStatus{Phase: sev1alpha1.PodMigrationJobRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "succeeded-job"},
+			Spec: sev1alpha1.PodMigrationJobSpec{
+				PodRef: &corev1.ObjectReference{Namespace: pods[1].Namespace, Name: pods[1].Name},
+			},
+			Status: sev1alpha1.PodMigrationJobStatus{Phase: sev1alpha1.PodMigrationJobSucceeded},
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		podMigrationJobServed bool
+		expectedEvicted       []string
+	}{
+		{
+			name:                  "skip the pods with active PodMigrationJobs",
+			podMigrationJobServed: true,
+			expectedEvicted:       []string{"n1-migrated", "n1-rs"},
+		},
+		{
+			name:            "PodMigrationJob resource not served",
+			expectedEvicted: []string{"n1-migrated", "n1-migrating", "n1-rs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			setupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+			var evictedPods []string
+			fakeClient.PrependReactor("create", "pods", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+				if action.GetSubresource() == "eviction" {
+					obj := action.(coretesting.CreateAction).GetObject()
+					evictedPods = append(evictedPods, obj.(metav1.Object).GetName())
+				}
+				return false, nil, nil
+			})
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			assert.NoError(t, err)
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			koordClientSet := koordfake.NewSimpleClientset()
+			if tt.podMigrationJobServed {
+				koordClientSet.Fake.Resources = []*metav1.APIResourceList{
+					{
+						GroupVersion: sev1alpha1.SchemeGroupVersion.String(),
+						APIResources: []metav1.APIResource{{Name: "podmigrationjobs", Kind: "PodMigrationJob"}},
+					},
+				}
+			}
+			setupNodeMetrics(koordClientSet, nodes, pods, nil)
+			for _, job := range jobs {
+				_, err := koordClientSet.SchedulingV1alpha1().PodMigrationJobs().Create(ctx, job, metav1.CreateOptions{})
+				assert.NoError(t, err)
+			}
+
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(defaultevictor.PluginName, defaultevictor.New)
+						profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: defaultevictor.PluginName,
+							Args: &defaultevictor.DefaultEvictorArgs{},
+						})
+					},
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(LowNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+							return NewLowNodeLoad(args, &fakeFrameworkHandle{
+								Handle:    handle,
+								Interface: koordClientSet,
+							})
+						})
+						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: LowNodeLoadName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: LowNodeLoadName,
+							Args: &deschedulerconfig.LowNodeLoadArgs{
+								NodeFit:               true,
+								EvictQuarantinedNodes: true,
+								NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+									{
+										NodeSelector:    &metav1.LabelSelector{},
+										LowThresholds:   ResourceThresholds{corev1.ResourceCPU: 30},
+										HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 50},
+										ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+										AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+											ConsecutiveAbnormalities: 1,
+											ConsecutiveNormalities:   1,
+										},
+									},
+								},
+								DetectorCacheTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+							},
+						})
+					},
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithEvictionLimiter(evictions.NewEvictionLimiter(nil, nil, nil)),
+				frameworkruntime.WithEventRecorder(&events.FakeRecorder{}),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			fh.RunBalancePlugins(ctx, nodes)
+
+			sort.Strings(evictedPods)
+			assert.Equal(t, tt.expectedEvicted, evictedPods)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
+)
+
+// overutilizationTracker tracks since when the nodes have been continuously overutilized by resource,
+// and exposes the durations as the NodeOverutilizedSeconds metric. It tells the persistently hot nodes
+// from the momentarily spiking ones.
+type overutilizationTracker struct {
+	lock  sync.Mutex
+	since map[string]map[corev1.ResourceName]time.Time
+}
+
+func newOverutilizationTracker() *overutilizationTracker {
+	return &overutilizationTracker{since: map[string]map[corev1.ResourceName]time.Time{}}
+}
+
+// observe records the overutilized resources of the node classified in the cycle at now.
+// The duration of a resource is reset once the node is no longer overutilized by it.
+func (t *overutilizationTracker) observe(nodeName string, overutilizedResources corev1.ResourceList, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	since := t.since[nodeName]
+	for resourceName := range since {
+		if _, ok := overutilizedResources[resourceName]; !ok {
+			delete(since, resourceName)
+			metrics.NodeOverutilizedSeconds.Delete(map[string]string{"node": nodeName, "resource": string(resourceName)})
+		}
+	}
+	for resourceName := range overutilizedResources {
+		if since == nil {
+			since = map[corev1.ResourceName]time.Time{}
+			t.since[nodeName] = since
+		}
+		start, ok := since[resourceName]
+		if !ok {
+			start = now
+			since[resourceName] = now
+		}
+		metrics.NodeOverutilizedSeconds.WithLabelValues(nodeName, string(resourceName)).Set(now.Sub(start).Seconds())
+	}
+	if len(since) == 0 {
+		delete(t.since, nodeName)
+	}
+}
+
+// prune forgets the nodes which are not balanced any more, e.g. the deleted nodes.
+func (t *overutilizationTracker) prune(nodes []*corev1.Node) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	existing := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		existing[node.Name] = struct{}{}
+	}
+	for nodeName, since := range t.since {
+		if _, ok := existing[nodeName]; ok {
+			continue
+		}
+		for resourceName := range since {
+			metrics.NodeOverutilizedSeconds.Delete(map[string]string{"node": nodeName, "resource": string(resourceName)})
+		}
+		delete(t.since, nodeName)
+	}
+}
+
+// observeNodeUsages records the overutilized resources of all the classified nodes against their high thresholds.
+func (t *overutilizationTracker) observeNodeUsages(nodeUsages map[string]*NodeUsage, nodeThresholds map[string]NodeThresholds, now time.Time) {
+	for nodeName, nodeUsage := range nodeUsages {
+		overutilizedResources, _ := isNodeOverutilized(nodeUsage.usage, nodeThresholds[nodeName].highResourceThreshold)
+		t.observe(nodeName, overutilizedResources, now)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/component-base/metrics/testutil"
+
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
+)
+
+func TestOverutilizationTracker(t *testing.T) {
+	metrics.Register()
+
+	overutilizedSeconds := func(nodeName string, resourceName corev1.ResourceName) float64 {
+		value, err := testutil.GetGaugeMetricValue(metrics.NodeOverutilizedSeconds.WithLabelValues(nodeName, string(resourceName)))
+		assert.NoError(t, err)
+		return value
+	}
+	cpuOverutilized := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}
+
+	tracker := newOverutilizationTracker()
+	start := time.Now()
+
+	// the duration accumulates while the node is continuously overutilized
+	tracker.observe("n1", cpuOverutilized, start)
+	assert.Equal(t, float64(0), overutilizedSeconds("n1", corev1.ResourceCPU))
+	tracker.observe("n1", cpuOverutilized, start.Add(time.Minute))
+	assert.Equal(t, float64(60), overutilizedSeconds("n1", corev1.ResourceCPU))
+	tracker.observe("n1", cpuOverutilized, start.Add(3*time.Minute))
+	assert.Equal(t, float64(180), overutilizedSeconds("n1", corev1.ResourceCPU))
+
+	// the duration is reset once the node returns under the high thresholds
+	tracker.observe("n1", nil, start.Add(4*time.Minute))
+	assert.Empty(t, tracker.since)
+	tracker.observe("n1", cpuOverutilized, start.Add(5*time.Minute))
+	assert.Equal(t, float64(0), overutilizedSeconds("n1", corev1.ResourceCPU))
+	tracker.observe("n1", cpuOverutilized, start.Add(6*time.Minute))
+	assert.Equal(t, float64(60), overutilizedSeconds("n1", corev1.ResourceCPU))
+
+	// the resources are tracked independently
+	tracker.observe("n1", corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}, start.Add(7*time.Minute))
+	assert.Equal(t, map[corev1.ResourceName]time.Time{corev1.ResourceMemory: start.Add(7 * time.Minute)}, tracker.since["n1"])
+
+	// the nodes not balanced any more are forgotten
+	tracker.observe("n2", cpuOverutilized, start)
+	tracker.prune([]*corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "n2"}}})
+	assert.NotContains(t, tracker.since, "n1")
+	assert.Contains(t, tracker.since, "n2")
+}
+
+func TestOverutilizationTrackerObserveNodeUsages(t *testing.T) {
+	metrics.Register()
+
+	tracker := newOverutilizationTracker()
+	nodeUsages := map[string]*NodeUsage{
+		"hot": {usage: map[corev1.ResourceName]*resource.Quantity{
+			corev1.ResourceCPU:    resource.NewMilliQuantity(3000, resource.DecimalSI),
+			corev1.ResourceMemory: resource.NewQuantity(1<<30, resource.BinarySI),
+		}},
+		"cold": {usage: map[corev1.ResourceName]*resource.Quantity{
+			corev1.ResourceCPU:    resource.NewMilliQuantity(1000, resource.DecimalSI),
+			corev1.ResourceMemory: resource.NewQuantity(1<<30, resource.BinarySI),
+		}},
+	}
+	highThresholds := map[corev1.ResourceName]*resource.Quantity{
+		corev1.ResourceCPU:    resource.NewMilliQuantity(2000, resource.DecimalSI),
+		corev1.ResourceMemory: resource.NewQuantity(2<<30, resource.BinarySI),
+	}
+	nodeThresholds := map[string]NodeThresholds{
+		"hot":  {highResourceThreshold: highThresholds},
+		"cold": {highResourceThreshold: highThresholds},
+	}
+	now := time.Now()
+	tracker.observeNodeUsages(nodeUsages, nodeThresholds, now)
+	assert.Equal(t, map[string]map[corev1.ResourceName]time.Time{
+		"hot": {corev1.ResourceCPU: now},
+	}, tracker.since)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+)
+
+const (
+	NodeClassificationLow      = "Low"
+	NodeClassificationHigh     = "High"
+	NodeClassificationProdLow  = "ProdLow"
+	NodeClassificationProdHigh = "ProdHigh"
+	NodeClassificationBothLow  = "BothLow"
+)
+
+// Snapshot is the view of the node utilization of a LowNodeLoad plugin in one descheduling cycle.
+type Snapshot struct {
+	Timestamp metav1.Time    `json:"timestamp"`
+	Nodes     []NodeSnapshot `json:"nodes"`
+}
+
+// NodeSnapshot is the utilization, classifications and candidate pods of a node in a Snapshot.
+type NodeSnapshot struct {
+	Name            string                                    `json:"name"`
+	NodePool        string                                    `json:"nodePool"`
+	Usage           map[corev1.ResourceName]resource.Quantity `json:"usage,omitempty"`
+	ProdUsage       map[corev1.ResourceName]resource.Quantity `json:"prodUsage,omitempty"`
+	Classifications []string                                  `json:"classifications,omitempty"`
+	CandidatePods   []string                                  `json:"candidatePods,omitempty"`
+}
+
+// snapshotStore keeps the latest Snapshot of every LowNodeLoad plugin.
+type snapshotStore struct {
+	lock      sync.RWMutex
+	enabled   bool
+	snapshots map[*LowNodeLoad]*Snapshot
+}
+
+var snapshots = &snapshotStore{snapshots: map[*LowNodeLoad]*Snapshot{}}
+
+// EnableSnapshot enables the LowNodeLoad plugins to record a Snapshot in every descheduling cycle.
+func EnableSnapshot() {
+	snapshots.lock.Lock()
+	defer snapshots.lock.Unlock()
+	snapshots.enabled = true
+}
+
+func (s *snapshotStore) isEnabled() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.enabled
+}
+
+// store replaces the Snapshot of the plugin as a whole, so that readers never see a partial cycle.
+func (s *snapshotStore) store(pl *LowNodeLoad, snapshot *Snapshot) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.snapshots[pl] = snapshot
+}
+
+func (s *snapshotStore) list() []*Snapshot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	result := make([]*Snapshot, 0, len(s.snapshots))
+	for _, snapshot := range s.snapshots {
+		result = append(result, snapshot)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(&result[j].Timestamp)
+	})
+	return result
+}
+
+// plugins returns the plugins which have recorded a Snapshot, in the order of their latest Snapshots.
+func (s *snapshotStore) plugins() []*LowNodeLoad {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	result := make([]*LowNodeLoad, 0, len(s.snapshots))
+	for pl := range s.snapshots {
+		result = append(result, pl)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return s.snapshots[result[i]].Timestamp.Before(&s.snapshots[result[j]].Timestamp)
+	})
+	return result
+}
+
+// SnapshotHandler serves the latest Snapshots of all the LowNodeLoad plugins as a JSON document.
+func SnapshotHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(snapshots.list()); err != nil {
+			klog.ErrorS(err, "Failed to encode LowNodeLoad snapshots")
+		}
+	})
+}
+
+// recordNodePool adds the nodes of the nodePool into the snapshot with their classifications.
+// The candidate pods are only resolved on the source nodes.
+func (s *Snapshot) recordNodePool(nodePool string, nodeUsages map[string]*NodeUsage, podFilter framework.FilterFunc,
+	lowNodes, highNodes, prodLowNodes, prodHighNodes, bothLowNodes []NodeInfo) {
+	classifications := map[string][]string{}
+	for classification, nodes := range map[string][]NodeInfo{
+		NodeClassificationLow:      lowNodes,
+		NodeClassificationHigh:     highNodes,
+		NodeClassificationProdLow:  prodLowNodes,
+		NodeClassificationProdHigh: prodHighNodes,
+		NodeClassificationBothLow:  bothLowNodes,
+	} {
+		for _, v := range nodes {
+			classifications[v.node.Name] = append(classifications[v.node.Name], classification)
+		}
+	}
+
+	for name, nodeUsage := range nodeUsages {
+		nodeSnapshot := NodeSnapshot{
+			Name:      name,
+			NodePool:  nodePool,
+			Usage:     copyQuantities(nodeUsage.usage),
+			ProdUsage: copyQuantities(nodeUsage.prodUsage),
+		}
+		sort.Strings(classifications[name])
+		nodeSnapshot.Classifications = classifications[name]
+		for _, classification := range nodeSnapshot.Classifications {
+			if classification != NodeClassificationHigh && classification != NodeClassificationProdHigh {
+				continue
+			}
+			for _, pod := range nodeUsage.allPods {
+				if podFilter == nil || podFilter(pod) {
+					nodeSnapshot.CandidatePods = append(nodeSnapshot.CandidatePods, klog.KObj(pod).String())
+				}
+			}
+			break
+		}
+		s.Nodes = append(s.Nodes, nodeSnapshot)
+	}
+	sort.Slice(s.Nodes, func(i, j int) bool {
+		return s.Nodes[i].Name < s.Nodes[j].Name
+	})
+}
+
+func copyQuantities(quantities map[corev1.ResourceName]*resource.Quantity) map[corev1.ResourceName]resource.Quantity {
+	if len(quantities) == 0 {
+		return nil
+	}
+	result := make(map[corev1.ResourceName]resource.Quantity, len(quantities))
+	for resourceName, quantity := range quantities {
+		if quantity != nil {
+			result[resourceName] = quantity.DeepCopy()
+		}
+	}
+	return result
+}
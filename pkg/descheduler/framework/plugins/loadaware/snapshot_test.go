@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes/defaultevictor"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestLowNodeLoadSnapshot(t *testing.T) {
+	EnableSnapshot()
+	defer func() {
+		snapshots = &snapshotStore{snapshots: map[*LowNodeLoad]*Snapshot{}}
+	}()
+
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+	}
+	var pods []*corev1.Pod
+	for i := 0; i < 3; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n1-p%d", i), 1000, 0, "n1", test.SetRSOwnerRef))
+	}
+	pods = append(pods, test.BuildTestPod("n1-ds", 400, 0, "n1", test.SetDSOwnerRef))
+	pods = append(pods, test.BuildTestPod("n2-p0", 400, 0, "n2", test.SetRSOwnerRef))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var objs []runtime.Object
+	for _, node := range nodes {
+		objs = append(objs, node)
+	}
+	for _, pod := range pods {
+		objs = append(objs, pod)
+	}
+	fakeClient := fake.NewSimpleClientset(objs...)
+	setupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+	podInformer := sharedInformerFactory.Core().V1().Pods()
+
+	getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+	if err != nil {
+		t.Errorf("Build get pods assigned to node function error: %v", err)
+	}
+
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	koordClientSet := koordfake.NewSimpleClientset()
+	setupNodeMetrics(koordClientSet, nodes, pods, nil)
+
+	fh, err := frameworktesting.NewFramework(
+		[]frameworktesting.RegisterPluginFunc{
+			func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+				reg.Register(defaultevictor.PluginName, defaultevictor.New)
+				profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+				profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+				profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+					Name: defaultevictor.PluginName,
+					Args: &defaultevictor.DefaultEvictorArgs{},
+				})
+			},
+			func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+				reg.Register(LowNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+					return NewLowNodeLoad(args, &fakeFrameworkHandle{
+						Handle:    handle,
+						Interface: koordClientSet,
+					})
+				})
+				profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: LowNodeLoadName})
+				profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+					Name: LowNodeLoadName,
+					Args: &deschedulerconfig.LowNodeLoadArgs{
+						DryRun: true,
+						NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+							{
+								NodeSelector:    &metav1.LabelSelector{},
+								Name:            "test-pool",
+								LowThresholds:   ResourceThresholds{corev1.ResourceCPU: 30},
+								HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 50},
+								ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+								AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+									ConsecutiveAbnormalities: 1,
+									ConsecutiveNormalities:   1,
+								},
+							},
+						},
+						DetectorCacheTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				})
+			},
+		},
+		"test",
+		frameworkruntime.WithClientSet(fakeClient),
+		frameworkruntime.WithEvictionLimiter(evictions.NewEvictionLimiter(nil, nil, nil)),
+		frameworkruntime.WithEventRecorder(&events.FakeRecorder{}),
+		frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+		frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+	)
+	assert.NoError(t, err)
+
+	fh.RunBalancePlugins(ctx, nodes)
+
+	recorder := httptest.NewRecorder()
+	SnapshotHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/snapshot", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []Snapshot
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	assert.Len(t, got, 1)
+	assert.Len(t, got[0].Nodes, 2)
+
+	n1, n2 := got[0].Nodes[0], got[0].Nodes[1]
+	assert.Equal(t, "n1", n1.Name)
+	assert.Equal(t, "test-pool", n1.NodePool)
+	assert.Equal(t, []string{NodeClassificationHigh}, n1.Classifications)
+	cpuUsage := n1.Usage[corev1.ResourceCPU]
+	assert.Equal(t, int64(3400), cpuUsage.MilliValue())
+	assert.ElementsMatch(t, []string{"default/n1-p0", "default/n1-p1", "default/n1-p2"}, n1.CandidatePods)
+
+	assert.Equal(t, "n2", n2.Name)
+	assert.Equal(t, []string{NodeClassificationBothLow}, n2.Classifications)
+	assert.Empty(t, n2.CandidatePods)
+}
@@ -19,6 +19,7 @@ package loadaware
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"sort"
 	"time"
 
@@ -49,6 +50,8 @@ type NodeUsage struct {
 	usage      map[corev1.ResourceName]*resource.Quantity
 	prodUsage  map[corev1.ResourceName]*resource.Quantity
 	podMetrics map[types.NamespacedName]*slov1alpha1.ResourceMap
+	// throttled indicates the node reports the throttled condition, it is never considered underutilized.
+	throttled bool
 }
 
 type NodeThresholds struct {
@@ -132,6 +135,80 @@ func getNodeThresholds(
 	return nodeThresholdsMap
 }
 
+// applyAbsoluteThresholds overrides the node thresholds of the resources with absolute thresholds,
+// the absolute quantities are capped by the node allocatable.
+func applyAbsoluteThresholds(nodeUsages map[string]*NodeUsage, nodeThresholds map[string]NodeThresholds, absoluteLow, absoluteHigh map[corev1.ResourceName]resource.Quantity) {
+	if len(absoluteLow) == 0 && len(absoluteHigh) == 0 {
+		return
+	}
+	for nodeName, thresholds := range nodeThresholds {
+		nodeUsage, ok := nodeUsages[nodeName]
+		if !ok {
+			continue
+		}
+		allocatable := nodeUsage.node.Status.Allocatable
+		for resourceName, quantity := range absoluteLow {
+			thresholds.lowResourceThreshold[resourceName] = absoluteResourceThreshold(allocatable, resourceName, quantity)
+		}
+		for resourceName, quantity := range absoluteHigh {
+			thresholds.highResourceThreshold[resourceName] = absoluteResourceThreshold(allocatable, resourceName, quantity)
+		}
+	}
+}
+
+// applyMinAbsoluteUsage raises the high thresholds of the nodes to the usage floor,
+// so that a node is not considered overutilized for a resource unless its usage exceeds the floor.
+func applyMinAbsoluteUsage(nodeThresholds map[string]NodeThresholds, minAbsoluteUsage map[corev1.ResourceName]resource.Quantity) {
+	for _, thresholds := range nodeThresholds {
+		for resourceName, floor := range minAbsoluteUsage {
+			for _, highThreshold := range []map[corev1.ResourceName]*resource.Quantity{thresholds.highResourceThreshold, thresholds.prodHighResourceThreshold} {
+				if threshold, ok := highThreshold[resourceName]; ok && threshold.Cmp(floor) < 0 {
+					highThreshold[resourceName] = floor.Copy()
+				}
+			}
+		}
+	}
+}
+
+// markThrottledNodes marks the nodes with the throttled condition in status True, and lowers their high thresholds
+// to the low thresholds, so that the throttled nodes are relieved once their usage exceeds the low thresholds.
+func markThrottledNodes(nodeUsages map[string]*NodeUsage, nodeThresholds map[string]NodeThresholds, conditionType corev1.NodeConditionType) {
+	for nodeName, nodeUsage := range nodeUsages {
+		if !isNodeThrottled(nodeUsage.node, conditionType) {
+			continue
+		}
+		nodeUsage.throttled = true
+		thresholds := nodeThresholds[nodeName]
+		highResourceThreshold := make(map[corev1.ResourceName]*resource.Quantity, len(thresholds.highResourceThreshold))
+		for resourceName, threshold := range thresholds.highResourceThreshold {
+			if lowThreshold, ok := thresholds.lowResourceThreshold[resourceName]; ok && lowThreshold.Cmp(*threshold) < 0 {
+				threshold = lowThreshold.Copy()
+			}
+			highResourceThreshold[resourceName] = threshold
+		}
+		thresholds.highResourceThreshold = highResourceThreshold
+		nodeThresholds[nodeName] = thresholds
+		klog.V(4).InfoS("Node is throttled, thus relieved once the usage exceeds the low thresholds", "node", klog.KObj(nodeUsage.node), "condition", conditionType)
+	}
+}
+
+func isNodeThrottled(node *corev1.Node, conditionType corev1.NodeConditionType) bool {
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == conditionType {
+			return node.Status.Conditions[i].Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func absoluteResourceThreshold(nodeCapacity corev1.ResourceList, resourceName corev1.ResourceName, threshold resource.Quantity) *resource.Quantity {
+	resourceCapacityQuantity := nodeCapacity[resourceName]
+	if threshold.Cmp(resourceCapacityQuantity) > 0 {
+		return resourceCapacityQuantity.Copy()
+	}
+	return threshold.Copy()
+}
+
 func resourceThreshold(nodeCapacity corev1.ResourceList, resourceName corev1.ResourceName, threshold Percentage) *resource.Quantity {
 	resourceCapacityFraction := func(resourceNodeCapacity int64) int64 {
 		// A threshold is in percentages but in <0;100> interval.
@@ -147,7 +224,7 @@ func resourceThreshold(nodeCapacity corev1.ResourceList, resourceName corev1.Res
 	return resource.NewQuantity(resourceCapacityFraction(resourceCapacityQuantity.Value()), resourceCapacityQuantity.Format)
 }
 
-func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nodeMetricLister slolisters.NodeMetricLister, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, nodeMetricExpirationSeconds *int64) map[string]*NodeUsage {
+func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nodeMetricLister slolisters.NodeMetricLister, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, nodeMetricExpirationSeconds *int64, nodeMetricExpirationByResource map[corev1.ResourceName]int64) map[string]*NodeUsage {
 	nodeUsages := map[string]*NodeUsage{}
 	for _, v := range nodes {
 		pods, err := podutil.ListPodsOnANode(v.Name, getPodsAssignedToNode, nil)
@@ -170,10 +247,13 @@ func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nod
 			klog.ErrorS(err, "Failed to get NodeMetric", "node", klog.KObj(v))
 			continue
 		}
-		// We should check if NodeMetric is expired.
-		if nodeMetric.Status.NodeMetric == nil || nodeMetricExpirationSeconds != nil &&
-			isNodeMetricExpired(nodeMetric.Status.UpdateTime, *nodeMetricExpirationSeconds) {
-			klog.ErrorS(err, "NodeMetric has expired", "node", klog.KObj(v), "effective period", time.Duration(*nodeMetricExpirationSeconds)*time.Second)
+		if nodeMetric.Status.NodeMetric == nil {
+			klog.ErrorS(err, "NodeMetric has not been reported", "node", klog.KObj(v))
+			continue
+		}
+		// We should check if NodeMetric is expired for any of the resources.
+		if expiredResource, expirationSeconds, expired := getNodeMetricExpiredResource(nodeMetric.Status.UpdateTime, resourceNames, nodeMetricExpirationSeconds, nodeMetricExpirationByResource); expired {
+			klog.ErrorS(err, "NodeMetric has expired", "node", klog.KObj(v), "resource", expiredResource, "effective period", time.Duration(expirationSeconds)*time.Second)
 			continue
 		}
 
@@ -313,8 +393,9 @@ func evictPodsFromSourceNodes(
 	nodeUsages map[string]*NodeUsage,
 	nodeThresholds map[string]NodeThresholds,
 	dryRun bool,
-	nodeFit bool,
+	nodeFit, emitDestinationHint bool,
 	resourceWeights map[corev1.ResourceName]int64,
+	ownerKindOrder []string,
 	podEvictor framework.Evictor,
 	podFilter framework.FilterFunc,
 	nodeIndexer podutil.GetPodsAssignedToNodeFunc,
@@ -350,7 +431,7 @@ func evictPodsFromSourceNodes(
 
 	targetNodes = append(targetNodes, bothTotalNodes...)
 	balancePods(ctx, nodePoolName, sourceNodes, targetNodes, nodeUsages, nodeThresholds,
-		nodeTotalAvailableUsages, dryRun, nodeFit, false, resourceWeights, podEvictor,
+		nodeTotalAvailableUsages, dryRun, nodeFit, emitDestinationHint, false, resourceWeights, ownerKindOrder, podEvictor,
 		podFilter, nodeIndexer, continueEviction, evictionReasonGenerator)
 
 	// bothLowNode will be used by nodeHigh and prodHigh nodes, needs sub resources used by pods on nodeHigh.
@@ -389,7 +470,7 @@ func evictPodsFromSourceNodes(
 	}
 	klog.V(4).InfoS("Total prod usage capacity to be moved", prodKeysAndValues...)
 	balancePods(ctx, nodePoolName, prodSourceNodes, prodTargetNodes, nodeUsages, nodeThresholds,
-		prodTotalAvailableUsages, dryRun, nodeFit, true, resourceWeights, podEvictor,
+		prodTotalAvailableUsages, dryRun, nodeFit, emitDestinationHint, true, resourceWeights, ownerKindOrder, podEvictor,
 		podFilter, nodeIndexer, continueEviction, evictionReasonGenerator)
 }
 
@@ -418,14 +499,24 @@ func balancePods(ctx context.Context,
 	nodeThresholds map[string]NodeThresholds,
 	totalAvailableUsages map[corev1.ResourceName]*resource.Quantity,
 	dryRun bool,
-	nodeFit, prod bool,
+	nodeFit, emitDestinationHint, prod bool,
 	resourceWeights map[corev1.ResourceName]int64,
+	ownerKindOrder []string,
 	podEvictor framework.Evictor,
 	podFilter framework.FilterFunc,
 	nodeIndexer podutil.GetPodsAssignedToNodeFunc,
 	continueEviction continueEvictionCond,
 	evictionReasonGenerator evictionReasonGeneratorFn) {
-	for _, srcNode := range sourceNodes {
+	var destinationHints map[types.NamespacedName]string
+	if nodeFit && emitDestinationHint {
+		destinationHints = map[types.NamespacedName]string{}
+	}
+	for i, srcNode := range sourceNodes {
+		if framework.ExceedCycleDeadline(ctx, nodesOf(sourceNodes[i:])) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d source nodes left unprocessed in nodePool %s",
+				LowNodeLoadName, len(sourceNodes)-i, nodePoolName)
+			return
+		}
 		var allPods []*corev1.Pod
 		if prod {
 			allPods = srcNode.prodPods
@@ -444,7 +535,14 @@ func balancePods(ctx context.Context,
 					klog.V(4).InfoS("Failed to find PodMetric", "pod", klog.KObj(pod), "node", klog.KObj(srcNode.node), "nodePool", nodePoolName)
 					return false
 				}
-				return podFitsAnyNodeWithThreshold(nodeIndexer, pod, targetNodes, nodeUsages, nodeThresholds, prod, podMetric)
+				destination := findNodeFitsPodWithThreshold(nodeIndexer, pod, targetNodes, nodeUsages, nodeThresholds, prod, podMetric)
+				if destination == nil {
+					return false
+				}
+				if destinationHints != nil {
+					destinationHints[podNamespacedName] = destination.Name
+				}
+				return true
 			}),
 		)
 		klog.V(4).InfoS("Evicting pods from node",
@@ -455,12 +553,21 @@ func balancePods(ctx context.Context,
 			klog.V(4).InfoS("No removable pods on node, try next node", "node", klog.KObj(srcNode.node), "nodePool", nodePoolName)
 			continue
 		}
-		sortPodsOnOneOverloadedNode(srcNode, removablePods, resourceWeights, prod)
+		sortPodsOnOneOverloadedNode(srcNode, removablePods, resourceWeights, ownerKindOrder, prod)
 
-		evictPods(ctx, nodePoolName, dryRun, prod, removablePods, srcNode, totalAvailableUsages, podEvictor, podFilter, continueEviction, evictionReasonGenerator)
+		evictPods(ctx, nodePoolName, dryRun, prod, removablePods, srcNode, totalAvailableUsages, destinationHints, podEvictor, podFilter, continueEviction, evictionReasonGenerator)
 	}
 }
 
+// nodesOf returns the nodes of the NodeInfos.
+func nodesOf(nodeInfos []NodeInfo) []*corev1.Node {
+	nodes := make([]*corev1.Node, 0, len(nodeInfos))
+	for _, v := range nodeInfos {
+		nodes = append(nodes, v.node)
+	}
+	return nodes
+}
+
 func targetAvailableUsage(destinationNodes []NodeInfo, resourceNames []corev1.ResourceName, prod bool) (map[corev1.ResourceName]*resource.Quantity, []*corev1.Node) {
 	var targetNodes []*corev1.Node
 	totalAvailableUsages := map[corev1.ResourceName]*resource.Quantity{}
@@ -501,6 +608,7 @@ func evictPods(
 	inputPods []*corev1.Pod,
 	nodeInfo NodeInfo,
 	totalAvailableUsages map[corev1.ResourceName]*resource.Quantity,
+	destinationHints map[types.NamespacedName]string,
 	podEvictor framework.Evictor,
 	podFilter framework.FilterFunc,
 	continueEviction continueEvictionCond,
@@ -519,7 +627,8 @@ func evictPods(
 			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName)
 		} else {
 			evictionOptions := framework.EvictOptions{
-				Reason: evictionReasonGenerator(nodeInfo, prod),
+				Reason:          evictionReasonGenerator(nodeInfo, prod),
+				DestinationHint: destinationHints[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}],
 			}
 			if !podEvictor.Evict(ctx, pod, evictionOptions) {
 				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName)
@@ -575,7 +684,7 @@ func evictPods(
 // sortNodesByUsage sorts nodes based on usage.
 func sortNodesByUsage(nodes []NodeInfo, resourceToWeightMap map[corev1.ResourceName]int64, ascending, prod bool) {
 	scorer := sorter.ResourceUsageScorer(resourceToWeightMap)
-	sort.Slice(nodes, func(i, j int) bool {
+	sort.SliceStable(nodes, func(i, j int) bool {
 		var iNodeUsage, jNodeUsage corev1.ResourceList
 		if prod {
 			iNodeUsage = usageToResourceList(nodes[i].prodUsage)
@@ -594,6 +703,48 @@ func sortNodesByUsage(nodes []NodeInfo, resourceToWeightMap map[corev1.ResourceN
 	})
 }
 
+// sortSourceNodes sorts the overutilized nodes based on the processing order.
+func sortSourceNodes(nodes []NodeInfo, resourceToWeightMap map[corev1.ResourceName]int64, order deschedulerconfig.NodeProcessingOrder, prod bool) {
+	switch order {
+	case deschedulerconfig.NodeProcessingOrderLeastFirst:
+		sortNodesByUsage(nodes, resourceToWeightMap, true, prod)
+	case deschedulerconfig.NodeProcessingOrderByName:
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].node.Name < nodes[j].node.Name
+		})
+	default:
+		sortNodesByUsage(nodes, resourceToWeightMap, false, prod)
+	}
+}
+
+// shuffleCandidates puts the candidate nodes and their pods into a pseudo-random order determined by the seed,
+// so that the order of the candidates with equal scores is reproducible after the stable sorts.
+func shuffleCandidates(nodes []NodeInfo, seed int64) {
+	r := rand.New(rand.NewSource(seed))
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].node.Name < nodes[j].node.Name
+	})
+	r.Shuffle(len(nodes), func(i, j int) {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	})
+	for _, nodeInfo := range nodes {
+		shufflePods(nodeInfo.allPods, r)
+		shufflePods(nodeInfo.prodPods, r)
+	}
+}
+
+func shufflePods(pods []*corev1.Pod, r *rand.Rand) {
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	r.Shuffle(len(pods), func(i, j int) {
+		pods[i], pods[j] = pods[j], pods[i]
+	})
+}
+
 func usageToResourceList(usage map[corev1.ResourceName]*resource.Quantity) corev1.ResourceList {
 	m := corev1.ResourceList{}
 	for k, v := range usage {
@@ -633,6 +784,24 @@ func isNodeMetricExpired(lastUpdateTime *metav1.Time, nodeMetricExpirationSecond
 			time.Since(lastUpdateTime.Time) >= time.Duration(nodeMetricExpirationSeconds)*time.Second
 }
 
+// getNodeMetricExpiredResource returns the first resource whose metric is expired. The expiration of a resource
+// is looked up in nodeMetricExpirationByResource before falling back to nodeMetricExpirationSeconds.
+func getNodeMetricExpiredResource(lastUpdateTime *metav1.Time, resourceNames []corev1.ResourceName, nodeMetricExpirationSeconds *int64, nodeMetricExpirationByResource map[corev1.ResourceName]int64) (corev1.ResourceName, int64, bool) {
+	for _, resourceName := range resourceNames {
+		expirationSeconds, ok := nodeMetricExpirationByResource[resourceName]
+		if !ok {
+			if nodeMetricExpirationSeconds == nil {
+				continue
+			}
+			expirationSeconds = *nodeMetricExpirationSeconds
+		}
+		if isNodeMetricExpired(lastUpdateTime, expirationSeconds) {
+			return resourceName, expirationSeconds, true
+		}
+	}
+	return "", 0, false
+}
+
 func getResourceNames(thresholds ResourceThresholds) []corev1.ResourceName {
 	names := make([]corev1.ResourceName, 0, len(thresholds))
 	for resourceName := range thresholds {
@@ -697,7 +866,7 @@ func calcAverageResourceUsagePercent(nodeUsages map[string]*NodeUsage) (Resource
 	}
 	return average, prodAverage
 }
-func sortPodsOnOneOverloadedNode(srcNode NodeInfo, removablePods []*corev1.Pod, resourceWeights map[corev1.ResourceName]int64, prod bool) {
+func sortPodsOnOneOverloadedNode(srcNode NodeInfo, removablePods []*corev1.Pod, resourceWeights map[corev1.ResourceName]int64, ownerKindOrder []string, prod bool) {
 	weights := make(map[corev1.ResourceName]int64)
 	// get the overused resource of this node, and the weights of appropriately using resources will be zero.
 	var overusedResources corev1.ResourceList
@@ -717,6 +886,30 @@ func sortPodsOnOneOverloadedNode(srcNode NodeInfo, removablePods []*corev1.Pod,
 		}
 		resourcesThatExceedThresholds[or] = usedCopy
 	}
+	// the kubelet evicts the pods under the disk pressure on its own regardless of the priorities,
+	// so the pods using the most ephemeral-storage are evicted first to relieve the pressure in time.
+	if _, ok := overusedResources[corev1.ResourceEphemeralStorage]; ok {
+		sorter.SortPodsByEphemeralStorageUsage(
+			ownerKindOrder,
+			resourcesThatExceedThresholds,
+			removablePods,
+			srcNode.podMetrics,
+			map[string]corev1.ResourceList{srcNode.node.Name: srcNode.node.Status.Allocatable},
+			weights,
+		)
+		return
+	}
+	if len(ownerKindOrder) > 0 {
+		sorter.SortPodsByOwnerKindAndUsage(
+			ownerKindOrder,
+			resourcesThatExceedThresholds,
+			removablePods,
+			srcNode.podMetrics,
+			map[string]corev1.ResourceList{srcNode.node.Name: srcNode.node.Status.Allocatable},
+			weights,
+		)
+		return
+	}
 	sorter.SortPodsByUsage(
 		resourcesThatExceedThresholds,
 		removablePods,
@@ -730,6 +923,13 @@ func sortPodsOnOneOverloadedNode(srcNode NodeInfo, removablePods []*corev1.Pod,
 // utilization will exceed the threshold after this pod was scheduled on it.
 func podFitsAnyNodeWithThreshold(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *corev1.Pod, nodes []*corev1.Node,
 	nodeUsages map[string]*NodeUsage, nodeThresholds map[string]NodeThresholds, prod bool, podMetric *slov1alpha1.ResourceMap) bool {
+	return findNodeFitsPodWithThreshold(nodeIndexer, pod, nodes, nodeUsages, nodeThresholds, prod, podMetric) != nil
+}
+
+// findNodeFitsPodWithThreshold returns the first of the given nodes the pod fits on without exceeding the threshold,
+// and the usage of the node is updated with the pod. It returns nil if the pod does not fit any of the nodes.
+func findNodeFitsPodWithThreshold(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *corev1.Pod, nodes []*corev1.Node,
+	nodeUsages map[string]*NodeUsage, nodeThresholds map[string]NodeThresholds, prod bool, podMetric *slov1alpha1.ResourceMap) *corev1.Node {
 	for _, node := range nodes {
 		errors := nodeutil.NodeFit(nodeIndexer, pod, node)
 		if len(errors) == 0 {
@@ -770,10 +970,10 @@ func podFitsAnyNodeWithThreshold(nodeIndexer podutil.GetPodsAssignedToNodeFunc,
 				}
 			}
 			klog.V(4).InfoS("Pod fits on node", "pod", klog.KObj(pod), "node", klog.KObj(node))
-			return true
+			return node
 		} else {
 			klog.V(4).InfoS("Pod does not fit on node", "pod", klog.KObj(pod), "node", klog.KObj(node), "errors", utilerrors.NewAggregate(errors))
 		}
 	}
-	return false
+	return nil
 }
@@ -18,8 +18,10 @@ package loadaware
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -29,8 +31,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
 
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	slolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
 )
 
@@ -130,6 +137,156 @@ func TestResourceUsagePercentages(t *testing.T) {
 	t.Logf("resourceUsagePercentage: %#v\n", resourceUsagePercentage)
 }
 
+func TestApplyAbsoluteThresholds(t *testing.T) {
+	newNodeUsage := func(name string, allocatableMemory string, cpu int64, memory string) *NodeUsage {
+		return &NodeUsage{
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    *resource.NewQuantity(32, resource.DecimalSI),
+						corev1.ResourceMemory: resource.MustParse(allocatableMemory),
+					},
+				},
+			},
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:    resource.NewQuantity(cpu, resource.DecimalSI),
+				corev1.ResourceMemory: resource.NewQuantity(resource.MustParse(memory).Value(), resource.BinarySI),
+			},
+		}
+	}
+	nodeUsages := map[string]*NodeUsage{
+		"node-low":      newNodeUsage("node-low", "128Gi", 4, "8Gi"),
+		"node-high-mem": newNodeUsage("node-high-mem", "128Gi", 10, "60Gi"),
+		"node-high-cpu": newNodeUsage("node-high-cpu", "128Gi", 28, "20Gi"),
+		"node-small":    newNodeUsage("node-small", "32Gi", 10, "20Gi"),
+	}
+	absoluteLow := map[corev1.ResourceName]resource.Quantity{corev1.ResourceMemory: resource.MustParse("16Gi")}
+	absoluteHigh := map[corev1.ResourceName]resource.Quantity{corev1.ResourceMemory: resource.MustParse("48Gi")}
+
+	lowThresholds, highThresholds, prodLowThresholds, prodHighThresholds := newThresholds(false,
+		ResourceThresholds{corev1.ResourceCPU: 30}, ResourceThresholds{corev1.ResourceCPU: 70}, nil, nil)
+	addAbsoluteThresholdResources(lowThresholds, highThresholds, prodLowThresholds, prodHighThresholds, absoluteLow, absoluteHigh)
+	resourceNames := getResourceNames(lowThresholds)
+	assert.ElementsMatch(t, []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}, resourceNames)
+	nodeThresholds := getNodeThresholds(nodeUsages, lowThresholds, highThresholds, prodLowThresholds, prodHighThresholds, resourceNames, false)
+	applyAbsoluteThresholds(nodeUsages, nodeThresholds, absoluteLow, absoluteHigh)
+
+	// the absolute high threshold is capped by the allocatable of the small node
+	smallHighMemory := nodeThresholds["node-small"].highResourceThreshold[corev1.ResourceMemory]
+	assert.Equal(t, resource.MustParse("32Gi").Value(), smallHighMemory.Value())
+
+	tests := []struct {
+		node              string
+		wantUnderutilized bool
+		wantOverutilized  bool
+	}{
+		{node: "node-low", wantUnderutilized: true},
+		{node: "node-high-mem", wantOverutilized: true},
+		{node: "node-high-cpu", wantOverutilized: true},
+		{node: "node-small"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.node, func(t *testing.T) {
+			assert.Equal(t, tt.wantUnderutilized, lowThresholdFilter(nodeUsages[tt.node], nodeThresholds[tt.node]))
+			assert.Equal(t, tt.wantOverutilized, highThresholdFilter(nodeUsages[tt.node], nodeThresholds[tt.node]))
+		})
+	}
+}
+
+func TestApplyMinAbsoluteUsage(t *testing.T) {
+	newNodeUsage := func(name string, allocatableCPU, cpu int64) *NodeUsage {
+		return &NodeUsage{
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU: *resource.NewQuantity(allocatableCPU, resource.DecimalSI),
+					},
+				},
+			},
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU: resource.NewQuantity(cpu, resource.DecimalSI),
+			},
+			prodUsage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU: resource.NewQuantity(cpu, resource.DecimalSI),
+			},
+		}
+	}
+	nodeUsages := map[string]*NodeUsage{
+		// 75% of the small node is used, but the usage is below the floor
+		"small-node": newNodeUsage("small-node", 4, 3),
+		"large-node": newNodeUsage("large-node", 64, 48),
+	}
+	resourceNames := []corev1.ResourceName{corev1.ResourceCPU}
+	nodeThresholds := getNodeThresholds(nodeUsages, ResourceThresholds{corev1.ResourceCPU: 30}, ResourceThresholds{corev1.ResourceCPU: 60},
+		ResourceThresholds{corev1.ResourceCPU: 30}, ResourceThresholds{corev1.ResourceCPU: 60}, resourceNames, false)
+	assert.True(t, highThresholdFilter(nodeUsages["small-node"], nodeThresholds["small-node"]))
+
+	applyMinAbsoluteUsage(nodeThresholds, map[corev1.ResourceName]resource.Quantity{corev1.ResourceCPU: resource.MustParse("8")})
+	assert.False(t, highThresholdFilter(nodeUsages["small-node"], nodeThresholds["small-node"]))
+	assert.False(t, prodHighThresholdFilter(nodeUsages["small-node"], nodeThresholds["small-node"]))
+	assert.True(t, highThresholdFilter(nodeUsages["large-node"], nodeThresholds["large-node"]))
+	assert.True(t, prodHighThresholdFilter(nodeUsages["large-node"], nodeThresholds["large-node"]))
+}
+
+func TestShuffleCandidatesReproducible(t *testing.T) {
+	newCandidates := func(reversed bool) []NodeInfo {
+		var nodes []NodeInfo
+		for i := 0; i < 5; i++ {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node-%d", i)},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU: *resource.NewQuantity(32, resource.DecimalSI),
+					},
+				},
+			}
+			var pods []*corev1.Pod
+			for j := 0; j < 5; j++ {
+				pods = append(pods, test.BuildTestPod(fmt.Sprintf("pod-%d-%d", i, j), 1000, 0, node.Name, nil))
+			}
+			if reversed {
+				for l, r := 0, len(pods)-1; l < r; l, r = l+1, r-1 {
+					pods[l], pods[r] = pods[r], pods[l]
+				}
+			}
+			nodes = append(nodes, NodeInfo{
+				NodeUsage: &NodeUsage{
+					node:    node,
+					allPods: pods,
+					usage: map[corev1.ResourceName]*resource.Quantity{
+						corev1.ResourceCPU: resource.NewQuantity(16, resource.DecimalSI),
+					},
+				},
+			})
+		}
+		if reversed {
+			for l, r := 0, len(nodes)-1; l < r; l, r = l+1, r-1 {
+				nodes[l], nodes[r] = nodes[r], nodes[l]
+			}
+		}
+		return nodes
+	}
+	evictionOrder := func(nodes []NodeInfo, seed int64) []string {
+		shuffleCandidates(nodes, seed)
+		sortSourceNodes(nodes, map[corev1.ResourceName]int64{corev1.ResourceCPU: 1}, deschedulerconfig.NodeProcessingOrderMostOverutilizedFirst, false)
+		var order []string
+		for _, nodeInfo := range nodes {
+			sortPodsOnOneOverloadedNode(nodeInfo, nodeInfo.allPods, nil, nil, false)
+			for _, pod := range nodeInfo.allPods {
+				order = append(order, pod.Name)
+			}
+		}
+		return order
+	}
+
+	first := evictionOrder(newCandidates(false), 42)
+	second := evictionOrder(newCandidates(true), 42)
+	assert.Len(t, first, 25)
+	assert.Equal(t, first, second)
+}
+
 func TestSortNodesByUsageDescendingOrder(t *testing.T) {
 	nodeList := []NodeInfo{testNode1, testNode2, testNode3}
 	expectedNodeList := []NodeInfo{testNode3, testNode1, testNode2}
@@ -155,6 +312,42 @@ func TestSortNodesByUsageAscendingOrder(t *testing.T) {
 
 	assert.Equal(t, expectedNodeList, nodeList)
 }
+
+func TestSortSourceNodes(t *testing.T) {
+	weightMap := map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:    1,
+		corev1.ResourceMemory: 1,
+		corev1.ResourcePods:   1,
+	}
+	tests := []struct {
+		order            deschedulerconfig.NodeProcessingOrder
+		expectedNodeList []NodeInfo
+	}{
+		{
+			order:            "",
+			expectedNodeList: []NodeInfo{testNode3, testNode1, testNode2},
+		},
+		{
+			order:            deschedulerconfig.NodeProcessingOrderMostOverutilizedFirst,
+			expectedNodeList: []NodeInfo{testNode3, testNode1, testNode2},
+		},
+		{
+			order:            deschedulerconfig.NodeProcessingOrderLeastFirst,
+			expectedNodeList: []NodeInfo{testNode2, testNode1, testNode3},
+		},
+		{
+			order:            deschedulerconfig.NodeProcessingOrderByName,
+			expectedNodeList: []NodeInfo{testNode1, testNode2, testNode3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			nodeList := []NodeInfo{testNode2, testNode3, testNode1}
+			sortSourceNodes(nodeList, weightMap, tt.order, false)
+			assert.Equal(t, tt.expectedNodeList, nodeList)
+		})
+	}
+}
 func TestSortPodsOnOneOverloadedNode(t *testing.T) {
 	nodeInfo := NodeInfo{
 		NodeUsage: &NodeUsage{
@@ -231,7 +424,61 @@ func TestSortPodsOnOneOverloadedNode(t *testing.T) {
 		corev1.ResourceCPU:    int64(1),
 		corev1.ResourceMemory: int64(1),
 	}
-	sortPodsOnOneOverloadedNode(nodeInfo, removablePods, resourceWeights, false)
+	sortPodsOnOneOverloadedNode(nodeInfo, removablePods, resourceWeights, nil, false)
+	assert.Equal(t, expectedResult, removablePods)
+}
+
+func TestSortPodsOnOneOverloadedNode_EphemeralStorage(t *testing.T) {
+	podMetric := func(cpu int64, ephemeralStorage string) *slov1alpha1.ResourceMap {
+		return &slov1alpha1.ResourceMap{
+			ResourceList: corev1.ResourceList{
+				corev1.ResourceCPU:              *resource.NewMilliQuantity(cpu, resource.DecimalSI),
+				corev1.ResourceEphemeralStorage: resource.MustParse(ephemeralStorage),
+			},
+		}
+	}
+	nodeInfo := NodeInfo{
+		NodeUsage: &NodeUsage{
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("32"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+					},
+				},
+				ObjectMeta: metav1.ObjectMeta{Name: "node0"},
+			},
+			// only make ephemeral-storage overused
+			usage: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:              resource.NewMilliQuantity(6000, resource.DecimalSI),
+				corev1.ResourceEphemeralStorage: resource.NewQuantity(90*1024*1024*1024, resource.BinarySI),
+			},
+			podMetrics: map[types.NamespacedName]*slov1alpha1.ResourceMap{
+				{Namespace: "ns", Name: "pod1"}: podMetric(3000, "10Gi"),
+				{Namespace: "ns", Name: "pod2"}: podMetric(1000, "60Gi"),
+				{Namespace: "ns", Name: "pod3"}: podMetric(2000, "20Gi"),
+			},
+		},
+		thresholds: NodeThresholds{
+			highResourceThreshold: map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU:              resource.NewMilliQuantity(20000, resource.DecimalSI),
+				corev1.ResourceEphemeralStorage: resource.NewQuantity(80*1024*1024*1024, resource.BinarySI),
+			},
+		},
+	}
+	// the pod using the most ephemeral-storage has the highest priority, it is still evicted first under the disk pressure
+	highPriority := int32(1000)
+	removablePods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns"}, Spec: corev1.PodSpec{NodeName: "node0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "ns"}, Spec: corev1.PodSpec{NodeName: "node0", Priority: &highPriority}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod3", Namespace: "ns"}, Spec: corev1.PodSpec{NodeName: "node0"}},
+	}
+	expectedResult := []*corev1.Pod{removablePods[1], removablePods[2], removablePods[0]}
+	resourceWeights := map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:              1,
+		corev1.ResourceEphemeralStorage: 1,
+	}
+	sortPodsOnOneOverloadedNode(nodeInfo, removablePods, resourceWeights, nil, false)
 	assert.Equal(t, expectedResult, removablePods)
 }
 
@@ -373,3 +620,192 @@ func sumNodeUsage(nodeUsages map[string]*NodeUsage) *NodeUsage {
 	}
 	return totalUsage
 }
+
+type hintRecordingEvictor struct {
+	destinationHints map[string]string
+}
+
+func (e *hintRecordingEvictor) Filter(pod *corev1.Pod) bool {
+	return true
+}
+
+func (e *hintRecordingEvictor) PreEvictionFilter(pod *corev1.Pod) bool {
+	return true
+}
+
+func (e *hintRecordingEvictor) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	e.destinationHints[pod.Name] = evictOptions.DestinationHint
+	return true
+}
+
+func TestBalancePodsEmitDestinationHint(t *testing.T) {
+	tests := []struct {
+		name                string
+		emitDestinationHint bool
+		expectedHints       map[string]string
+	}{
+		{
+			name:          "hint disabled",
+			expectedHints: map[string]string{"p1": ""},
+		},
+		{
+			name:                "hint emitted for the reschedulable pod",
+			emitDestinationHint: true,
+			expectedHints:       map[string]string{"p1": "n3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sourceNode := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+			unschedulableNode := test.BuildTestNode("n2", 4000, 3000, 10, func(node *corev1.Node) {
+				node.Spec.Unschedulable = true
+			})
+			destinationNode := test.BuildTestNode("n3", 4000, 3000, 10, nil)
+			pod := test.BuildTestPod("p1", 100, 0, "n1", test.SetRSOwnerRef)
+
+			fakeClient := fake.NewSimpleClientset(sourceNode, unschedulableNode, destinationNode, pod)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			assert.NoError(t, err)
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			sourceNodes := []NodeInfo{
+				{
+					NodeUsage: &NodeUsage{
+						node:    sourceNode,
+						allPods: []*corev1.Pod{pod},
+						usage: map[corev1.ResourceName]*resource.Quantity{
+							corev1.ResourceCPU: resource.NewMilliQuantity(3000, resource.DecimalSI),
+						},
+						podMetrics: map[types.NamespacedName]*slov1alpha1.ResourceMap{
+							{Namespace: pod.Namespace, Name: pod.Name}: {
+								ResourceList: corev1.ResourceList{corev1.ResourceCPU: *resource.NewMilliQuantity(100, resource.DecimalSI)},
+							},
+						},
+					},
+				},
+			}
+			nodeUsages := map[string]*NodeUsage{}
+			nodeThresholds := map[string]NodeThresholds{}
+			for _, node := range []*corev1.Node{unschedulableNode, destinationNode} {
+				nodeUsages[node.Name] = &NodeUsage{
+					node: node,
+					usage: map[corev1.ResourceName]*resource.Quantity{
+						corev1.ResourceCPU: resource.NewMilliQuantity(500, resource.DecimalSI),
+					},
+				}
+				nodeThresholds[node.Name] = NodeThresholds{
+					highResourceThreshold: map[corev1.ResourceName]*resource.Quantity{
+						corev1.ResourceCPU: resource.NewMilliQuantity(2000, resource.DecimalSI),
+					},
+				}
+			}
+			totalAvailableUsages := map[corev1.ResourceName]*resource.Quantity{
+				corev1.ResourceCPU: resource.NewMilliQuantity(3000, resource.DecimalSI),
+			}
+
+			evictor := &hintRecordingEvictor{destinationHints: map[string]string{}}
+			balancePods(ctx, "test", sourceNodes, []*corev1.Node{unschedulableNode, destinationNode}, nodeUsages, nodeThresholds,
+				totalAvailableUsages, false, true, tt.emitDestinationHint, false, map[corev1.ResourceName]int64{corev1.ResourceCPU: 1}, nil,
+				evictor, func(pod *corev1.Pod) bool { return true }, getPodsAssignedToNode,
+				func(nodeInfo NodeInfo, totalAvailableUsages map[corev1.ResourceName]*resource.Quantity, prod bool) bool {
+					return true
+				},
+				func(nodeInfo NodeInfo, prod bool) string { return "" })
+			assert.Equal(t, tt.expectedHints, evictor.destinationHints)
+		})
+	}
+}
+
+func TestGetNodeUsageWithNodeMetricExpirationByResource(t *testing.T) {
+	node := test.BuildTestNode("test-node", 4000, 8*1024*1024*1024, 10, nil)
+	nodeMetric := &slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: node.Name,
+		},
+		Status: slov1alpha1.NodeMetricStatus{
+			// cpu is fresh under 180 seconds, but memory is stale under 60 seconds
+			UpdateTime: &metav1.Time{Time: time.Now().Add(-120 * time.Second)},
+			NodeMetric: &slov1alpha1.NodeMetricInfo{
+				SystemUsage: slov1alpha1.ResourceMap{
+					ResourceList: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(nodeMetric))
+	nodeMetricLister := slolisters.NewNodeMetricLister(indexer)
+	getPodsAssignedToNode := func(string, framework.FilterFunc) ([]*corev1.Pod, error) {
+		return nil, nil
+	}
+
+	tests := []struct {
+		name                           string
+		resourceNames                  []corev1.ResourceName
+		nodeMetricExpirationSeconds    *int64
+		nodeMetricExpirationByResource map[corev1.ResourceName]int64
+		wantUsage                      bool
+	}{
+		{
+			name:                        "fresh under the global expiration",
+			resourceNames:               []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory},
+			nodeMetricExpirationSeconds: pointer.Int64(180),
+			wantUsage:                   true,
+		},
+		{
+			name:                        "stale under the global expiration",
+			resourceNames:               []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory},
+			nodeMetricExpirationSeconds: pointer.Int64(60),
+			wantUsage:                   false,
+		},
+		{
+			name:                        "cpu is fresh but memory is stale",
+			resourceNames:               []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory},
+			nodeMetricExpirationSeconds: pointer.Int64(180),
+			nodeMetricExpirationByResource: map[corev1.ResourceName]int64{
+				corev1.ResourceMemory: 60,
+			},
+			wantUsage: false,
+		},
+		{
+			name:                        "only cpu is considered and it is fresh",
+			resourceNames:               []corev1.ResourceName{corev1.ResourceCPU},
+			nodeMetricExpirationSeconds: pointer.Int64(180),
+			nodeMetricExpirationByResource: map[corev1.ResourceName]int64{
+				corev1.ResourceMemory: 60,
+			},
+			wantUsage: true,
+		},
+		{
+			name:                        "memory override extends the global expiration",
+			resourceNames:               []corev1.ResourceName{corev1.ResourceMemory},
+			nodeMetricExpirationSeconds: pointer.Int64(60),
+			nodeMetricExpirationByResource: map[corev1.ResourceName]int64{
+				corev1.ResourceMemory: 600,
+			},
+			wantUsage: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeUsages := getNodeUsage([]*corev1.Node{node}, tt.resourceNames, nodeMetricLister, getPodsAssignedToNode,
+				tt.nodeMetricExpirationSeconds, tt.nodeMetricExpirationByResource)
+			nodeUsage, ok := nodeUsages[node.Name]
+			assert.Equal(t, tt.wantUsage, ok)
+			if ok {
+				for _, resourceName := range tt.resourceNames {
+					assert.NotNil(t, nodeUsage.usage[resourceName])
+				}
+			}
+		})
+	}
+}
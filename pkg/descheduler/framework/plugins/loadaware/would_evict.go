@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	migrationutil "github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+)
+
+// WouldEvictResult is whether a pod is an eviction candidate of a LowNodeLoad plugin and why.
+type WouldEvictResult struct {
+	Plugin  string `json:"plugin"`
+	Profile string `json:"profile"`
+	Evict   bool   `json:"evict"`
+	Reason  string `json:"reason"`
+}
+
+// WouldEvict reports whether the pod is an eviction candidate of the plugin with the current utilization of its node,
+// and the reason why or why not. It resolves the node pool and classifies the nodes as a descheduling cycle does, then
+// runs the eviction of the pods on the node, which sorts the pods, finds the fitting underutilized nodes, skips the pods
+// being migrated and stops once the node is relieved, but the pods are not evicted. The anomaly detectors are neither
+// fed nor consulted, so the pods on an overutilized node are reported as candidates before the node is detected as
+// anomalous. Consolidation, PodDisruptionBudgets and the eviction limits are not reflected.
+func (pl *LowNodeLoad) WouldEvict(podNamespace, podName string) (bool, string, error) {
+	podLister := pl.handle.SharedInformerFactory().Core().V1().Pods().Lister()
+	nodeLister := pl.handle.SharedInformerFactory().Core().V1().Nodes().Lister()
+	pod, err := podLister.Pods(podNamespace).Get(podName)
+	if err != nil {
+		return false, "", err
+	}
+	if pod.Spec.NodeName == "" {
+		return false, "pod is not assigned to any node", nil
+	}
+	node, err := nodeLister.Get(pod.Spec.NodeName)
+	if err != nil {
+		return false, "", err
+	}
+
+	if pl.args.EvictQuarantinedNodes && node.Labels[extension.LabelNodeQuarantine] == "true" {
+		if !pl.podFilter(pod) {
+			return false, "pod is filtered out by the evictor or the pod selectors", nil
+		}
+		return true, fmt.Sprintf("node %s is quarantined", node.Name), nil
+	}
+
+	allNodes, err := nodeLister.List(labels.Everything())
+	if err != nil {
+		return false, "", err
+	}
+	allNodes, err = filterNodes(pl.handle.NodeSelector(), allNodes, sets.NewString())
+	if err != nil {
+		return false, "", err
+	}
+	if !containsNode(allNodes, node.Name) {
+		return false, fmt.Sprintf("node %s is not selected by the descheduler", node.Name), nil
+	}
+	nodes := make([]*corev1.Node, 0, len(allNodes))
+	for _, v := range allNodes {
+		if pl.args.EvictQuarantinedNodes && v.Labels[extension.LabelNodeQuarantine] == "true" {
+			continue
+		}
+		nodes = append(nodes, v)
+	}
+
+	for i := range pl.args.NodePools {
+		nodePool := &pl.args.NodePools[i]
+		poolNodes, err := filterNodes(nodePool.NodeSelector, nodes, sets.NewString())
+		if err != nil {
+			return false, "", err
+		}
+		if !containsNode(poolNodes, node.Name) {
+			continue
+		}
+		return pl.wouldEvictInNodePool(pod, node, poolNodes, nodePool)
+	}
+	return false, fmt.Sprintf("node %s does not belong to any node pool", node.Name), nil
+}
+
+func (pl *LowNodeLoad) wouldEvictInNodePool(pod *corev1.Pod, node *corev1.Node, nodes []*corev1.Node, nodePool *deschedulerconfig.LowNodeLoadNodePool) (bool, string, error) {
+	nodePoolName := nodePool.Name
+	if len(nodes) < int(pl.args.MinNodesInScope) {
+		return false, fmt.Sprintf("node pool %s has %d nodes, less than minNodesInScope %d", nodePoolName, len(nodes), pl.args.MinNodesInScope), nil
+	}
+
+	u := pl.classifyNodePool(nodePool, nodes)
+	if _, ok := u.nodeUsages[node.Name]; !ok {
+		return false, fmt.Sprintf("usage of node %s is unavailable", node.Name), nil
+	}
+	sourceNodes := filterNodeInfos(u.sourceNodes, node.Name)
+	prodSourceNodes := filterNodeInfos(u.prodHighNodes, node.Name)
+	if len(sourceNodes) == 0 && len(prodSourceNodes) == 0 {
+		return false, fmt.Sprintf("node %s is not above the high thresholds of node pool %s", node.Name, nodePoolName), nil
+	}
+	if reason := pl.checkUnderutilizedNodes(nodePoolName, u, len(nodes)); reason != "" {
+		return false, reason, nil
+	}
+	if !pl.podFilter(pod) {
+		return false, "pod is filtered out by the evictor or the pod selectors", nil
+	}
+
+	// evict the pods from the node of the pod as a descheduling cycle does, but record the eviction of the pod instead.
+	recorder := &wouldEvictRecorder{
+		Evictor:             pl.handle.Evictor(),
+		pod:                 pod,
+		migrationJobIndexer: pl.migrationJobIndexer,
+	}
+	pl.evictFromSourceNodes(context.TODO(), nodePool, u, sourceNodes, prodSourceNodes, recorder, false, false)
+	if recorder.reason == "" {
+		return false, fmt.Sprintf("node %s is relieved before the pod is evicted, or no underutilized node of node pool %s fits the pod", node.Name, nodePoolName), nil
+	}
+	return recorder.evict, recorder.reason, nil
+}
+
+// wouldEvictRecorder pretends to evict the pods, and records whether the pod would be evicted and why.
+type wouldEvictRecorder struct {
+	framework.Evictor
+	pod                 *corev1.Pod
+	migrationJobIndexer cache.Indexer
+	evict               bool
+	reason              string
+}
+
+func (r *wouldEvictRecorder) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	target := pod.Namespace == r.pod.Namespace && pod.Name == r.pod.Name
+	if r.migrationJobIndexer != nil && migrationutil.HasActivePodMigrationJob(r.migrationJobIndexer, pod) {
+		if target {
+			r.reason = "pod is being migrated by an active PodMigrationJob"
+		}
+		return false
+	}
+	if target {
+		r.evict, r.reason = true, evictOptions.Reason
+	}
+	return true
+}
+
+func filterNodeInfos(nodes []NodeInfo, nodeName string) []NodeInfo {
+	var filtered []NodeInfo
+	for _, v := range nodes {
+		if v.node.Name == nodeName {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+func containsNode(nodes []*corev1.Node, nodeName string) bool {
+	for _, v := range nodes {
+		if v.Name == nodeName {
+			return true
+		}
+	}
+	return false
+}
+
+// WouldEvictHandler serves whether the pod specified by the namespace and name query parameters is an eviction
+// candidate of each LowNodeLoad plugin recording the Snapshots.
+func WouldEvictHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace, name := r.URL.Query().Get("namespace"), r.URL.Query().Get("name")
+		if namespace == "" || name == "" {
+			http.Error(w, "namespace and name are required", http.StatusBadRequest)
+			return
+		}
+		plugins := snapshots.plugins()
+		results := make([]WouldEvictResult, 0, len(plugins))
+		for _, pl := range plugins {
+			evict, reason, err := pl.WouldEvict(namespace, name)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			} else if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			results = append(results, WouldEvictResult{
+				Plugin:  LowNodeLoadName,
+				Profile: pl.handle.ProfileName(),
+				Evict:   evict,
+				Reason:  reason,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(results); err != nil {
+			klog.ErrorS(err, "Failed to encode LowNodeLoad eviction candidacy", "pod", klog.KRef(namespace, name))
+		}
+	})
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes/defaultevictor"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestLowNodeLoadWouldEvict(t *testing.T) {
+	EnableSnapshot()
+	defer func() {
+		snapshots = &snapshotStore{snapshots: map[*LowNodeLoad]*Snapshot{}}
+	}()
+
+	setPoolLabel := func(node *corev1.Node) {
+		node.Labels = map[string]string{"pool": "test"}
+	}
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, setPoolLabel),
+		test.BuildTestNode("n2", 4000, 3000, 10, setPoolLabel),
+		test.BuildTestNode("n3", 4000, 3000, 10, nil),
+	}
+	var pods []*corev1.Pod
+	creationTime := time.Now()
+	for i := 0; i < 3; i++ {
+		// the creation timestamps break the ties in the eviction order
+		createdAt := metav1.NewTime(creationTime.Add(time.Duration(i) * time.Minute))
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("n1-p%d", i), 1000, 0, "n1", func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.CreationTimestamp = createdAt
+		}))
+	}
+	pods = append(pods, test.BuildTestPod("n1-ds", 400, 0, "n1", test.SetDSOwnerRef))
+	pods = append(pods, test.BuildTestPod("n2-p0", 400, 0, "n2", test.SetRSOwnerRef))
+	pods = append(pods, test.BuildTestPod("n3-p0", 3600, 0, "n3", test.SetRSOwnerRef))
+	pods = append(pods, test.BuildTestPod("pending", 400, 0, "", test.SetRSOwnerRef))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var objs []runtime.Object
+	for _, node := range nodes {
+		objs = append(objs, node)
+	}
+	for _, pod := range pods {
+		objs = append(objs, pod)
+	}
+	fakeClient := fake.NewSimpleClientset(objs...)
+	setupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+	podInformer := sharedInformerFactory.Core().V1().Pods()
+
+	getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+	if err != nil {
+		t.Errorf("Build get pods assigned to node function error: %v", err)
+	}
+
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	koordClientSet := koordfake.NewSimpleClientset()
+	setupNodeMetrics(koordClientSet, nodes, pods, nil)
+
+	fh, err := frameworktesting.NewFramework(
+		[]frameworktesting.RegisterPluginFunc{
+			func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+				reg.Register(defaultevictor.PluginName, defaultevictor.New)
+				profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+				profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+				profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+					Name: defaultevictor.PluginName,
+					Args: &defaultevictor.DefaultEvictorArgs{},
+				})
+			},
+			func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+				reg.Register(LowNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+					return NewLowNodeLoad(args, &fakeFrameworkHandle{
+						Handle:    handle,
+						Interface: koordClientSet,
+					})
+				})
+				profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: LowNodeLoadName})
+				profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+					Name: LowNodeLoadName,
+					Args: &deschedulerconfig.LowNodeLoadArgs{
+						DryRun: true,
+						NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+							{
+								NodeSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"pool": "test"},
+								},
+								Name:            "test-pool",
+								LowThresholds:   ResourceThresholds{corev1.ResourceCPU: 30},
+								HighThresholds:  ResourceThresholds{corev1.ResourceCPU: 50},
+								ResourceWeights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+								AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+									ConsecutiveAbnormalities: 1,
+									ConsecutiveNormalities:   1,
+								},
+							},
+						},
+						DetectorCacheTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				})
+			},
+		},
+		"test",
+		frameworkruntime.WithClientSet(fakeClient),
+		frameworkruntime.WithEvictionLimiter(evictions.NewEvictionLimiter(nil, nil, nil)),
+		frameworkruntime.WithEventRecorder(&events.FakeRecorder{}),
+		frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+		frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+	)
+	assert.NoError(t, err)
+
+	fh.RunBalancePlugins(ctx, nodes)
+	plugins := snapshots.plugins()
+	assert.Len(t, plugins, 1)
+
+	tests := []struct {
+		name       string
+		pod        string
+		wantEvict  bool
+		wantReason string
+	}{
+		{
+			name:       "pod filtered out by evictor",
+			pod:        "n1-ds",
+			wantReason: "pod is filtered out by the evictor or the pod selectors",
+		},
+		{
+			name:       "pod on underutilized node",
+			pod:        "n2-p0",
+			wantReason: "node n2 is not above the high thresholds of node pool test-pool",
+		},
+		{
+			name:       "pod on node out of node pools",
+			pod:        "n3-p0",
+			wantReason: "node n3 does not belong to any node pool",
+		},
+		{
+			name:       "pod not assigned",
+			pod:        "pending",
+			wantReason: "pod is not assigned to any node",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evict, reason, err := plugins[0].WouldEvict("default", tt.pod)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEvict, evict)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+
+	// n1 is relieved after two of the pods on it are evicted, the last one in the eviction order stays.
+	evicted := 0
+	for i := 0; i < 3; i++ {
+		evict, reason, err := plugins[0].WouldEvict("default", fmt.Sprintf("n1-p%d", i))
+		assert.NoError(t, err)
+		if evict {
+			evicted++
+			assert.Contains(t, reason, "node is overutilized, node cpu usage(")
+		} else {
+			assert.Equal(t, "node n1 is relieved before the pod is evicted, or no underutilized node of node pool test-pool fits the pod", reason)
+		}
+	}
+	assert.Equal(t, 2, evicted)
+
+	evict, reason, err := plugins[0].WouldEvict("default", "n1-p1")
+	assert.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	WouldEvictHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/wouldevict?namespace=default&name=n1-p1", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var got []WouldEvictResult
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	assert.Equal(t, []WouldEvictResult{{Plugin: LowNodeLoadName, Profile: "test", Evict: evict, Reason: reason}}, got)
+
+	recorder = httptest.NewRecorder()
+	WouldEvictHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/wouldevict?namespace=default&name=missing", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	WouldEvictHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/wouldevict?namespace=default", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
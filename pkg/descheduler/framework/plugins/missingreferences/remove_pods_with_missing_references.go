@@ -0,0 +1,274 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package missingreferences
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	RemovePodsWithMissingReferencesName = "RemovePodsWithMissingReferences"
+)
+
+const (
+	referenceKindConfigMap = "ConfigMap"
+	referenceKindSecret    = "Secret"
+)
+
+var _ framework.DeschedulePlugin = &RemovePodsWithMissingReferences{}
+
+// RemovePodsWithMissingReferences evicts pods which reference the ConfigMaps or Secrets not existing anymore,
+// since they can never become ready on the node, so that their controllers can reconcile them.
+// Only the pods which have been unready for longer than MinFailingDuration are considered,
+// and the optional references are ignored.
+type RemovePodsWithMissingReferences struct {
+	handle    framework.Handle
+	podFilter framework.FilterFunc
+	args      *deschedulerconfig.RemovePodsWithMissingReferencesArgs
+	clock     clock.Clock
+}
+
+// NewRemovePodsWithMissingReferences builds plugin from its arguments while passing a handle
+func NewRemovePodsWithMissingReferences(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	referencesArgs, ok := args.(*deschedulerconfig.RemovePodsWithMissingReferencesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsWithMissingReferencesArgs, got %T", args)
+	}
+	if err := validation.ValidateRemovePodsWithMissingReferencesArgs(nil, referencesArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if referencesArgs.Namespaces != nil {
+		excludedNamespaces = sets.NewString(referencesArgs.Namespaces.Exclude...)
+		includedNamespaces = sets.NewString(referencesArgs.Namespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsWithMissingReferences{
+		handle:    handle,
+		podFilter: podFilter,
+		args:      referencesArgs,
+		clock:     clock.RealClock{},
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *RemovePodsWithMissingReferences) Name() string {
+	return RemovePodsWithMissingReferencesName
+}
+
+// Deschedule extension point implementation for the plugin
+func (pl *RemovePodsWithMissingReferences) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("RemovePodsWithMissingReferences is paused and will do nothing.")
+		return nil
+	}
+
+	// the existences are cached in one round, as the pods of a workload usually reference the same objects
+	checker := &referenceChecker{handle: pl.handle, exists: map[podReference]bool{}}
+	for i, node := range nodes {
+		if framework.ExceedCycleDeadline(ctx, nodes[i:]) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d nodes left unprocessed", RemovePodsWithMissingReferencesName, len(nodes)-i)
+			break
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			since, failing := failingSince(pod)
+			if !failing || pl.clock.Since(since) < pl.args.MinFailingDuration.Duration {
+				continue
+			}
+			missingReferences := checker.missingReferences(ctx, pod)
+			if len(missingReferences) == 0 {
+				continue
+			}
+			klog.V(4).InfoS("Pod references missing objects", "pod", klog.KObj(pod), "node", klog.KObj(node), "references", missingReferences)
+			if !pl.handle.Evictor().PreEvictionFilter(pod) {
+				klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			evictionOptions := framework.EvictOptions{
+				PluginName: RemovePodsWithMissingReferencesName,
+				Reason:     fmt.Sprintf("pod references missing %s", strings.Join(missingReferences, ", ")),
+			}
+			if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		}
+	}
+	return nil
+}
+
+// failingSince returns since when the pending or running pod has been unready.
+// The pods whose Ready condition is not reported yet are considered unready since they started.
+func failingSince(pod *corev1.Pod) (time.Time, bool) {
+	if pod.Status.Phase != corev1.PodPending && pod.Status.Phase != corev1.PodRunning {
+		return time.Time{}, false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodReady {
+			continue
+		}
+		if condition.Status == corev1.ConditionTrue {
+			return time.Time{}, false
+		}
+		return condition.LastTransitionTime.Time, true
+	}
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time, true
+	}
+	return pod.CreationTimestamp.Time, true
+}
+
+type podReference struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (r podReference) String() string {
+	return fmt.Sprintf("%s %s/%s", r.kind, r.namespace, r.name)
+}
+
+type referenceChecker struct {
+	handle framework.Handle
+	exists map[podReference]bool
+}
+
+// missingReferences returns the required ConfigMaps and Secrets of the pod which do not exist.
+// The objects failed to get are considered existing to not evict pods by transient errors.
+func (c *referenceChecker) missingReferences(ctx context.Context, pod *corev1.Pod) []string {
+	var missing []string
+	for _, reference := range getPodReferences(pod) {
+		exists, ok := c.exists[reference]
+		if !ok {
+			exists = c.exist(ctx, reference)
+			c.exists[reference] = exists
+		}
+		if !exists {
+			missing = append(missing, reference.String())
+		}
+	}
+	return missing
+}
+
+func (c *referenceChecker) exist(ctx context.Context, reference podReference) bool {
+	var err error
+	switch reference.kind {
+	case referenceKindConfigMap:
+		_, err = c.handle.ClientSet().CoreV1().ConfigMaps(reference.namespace).Get(ctx, reference.name, metav1.GetOptions{})
+	case referenceKindSecret:
+		_, err = c.handle.ClientSet().CoreV1().Secrets(reference.namespace).Get(ctx, reference.name, metav1.GetOptions{})
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		klog.ErrorS(err, "Failed to get the object referenced by pods", "reference", reference.String())
+		return true
+	}
+	return !errors.IsNotFound(err)
+}
+
+// getPodReferences returns the non-optional ConfigMaps and Secrets referenced by the volumes and the containers of the pod.
+func getPodReferences(pod *corev1.Pod) []podReference {
+	var references []podReference
+	seen := map[podReference]bool{}
+	add := func(kind, name string, optional *bool) {
+		if name == "" || (optional != nil && *optional) {
+			return
+		}
+		reference := podReference{kind: kind, namespace: pod.Namespace, name: name}
+		if !seen[reference] {
+			seen[reference] = true
+			references = append(references, reference)
+		}
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.ConfigMap != nil {
+			add(referenceKindConfigMap, volume.ConfigMap.Name, volume.ConfigMap.Optional)
+		}
+		if volume.Secret != nil {
+			add(referenceKindSecret, volume.Secret.SecretName, volume.Secret.Optional)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					add(referenceKindConfigMap, source.ConfigMap.Name, source.ConfigMap.Optional)
+				}
+				if source.Secret != nil {
+					add(referenceKindSecret, source.Secret.Name, source.Secret.Optional)
+				}
+			}
+		}
+	}
+
+	containers := make([]corev1.Container, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	containers = append(containers, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				add(referenceKindConfigMap, envFrom.ConfigMapRef.Name, envFrom.ConfigMapRef.Optional)
+			}
+			if envFrom.SecretRef != nil {
+				add(referenceKindSecret, envFrom.SecretRef.Name, envFrom.SecretRef.Optional)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				add(referenceKindConfigMap, env.ValueFrom.ConfigMapKeyRef.Name, env.ValueFrom.ConfigMapKeyRef.Optional)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				add(referenceKindSecret, env.ValueFrom.SecretKeyRef.Name, env.ValueFrom.SecretKeyRef.Optional)
+			}
+		}
+	}
+	return references
+}
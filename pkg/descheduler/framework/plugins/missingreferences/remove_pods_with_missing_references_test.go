@@ -0,0 +1,235 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package missingreferences
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestNewRemovePodsWithMissingReferencesWithInvalidArgs(t *testing.T) {
+	_, err := NewRemovePodsWithMissingReferences(&deschedulerconfig.RemovePodsWithMissingReferencesArgs{}, nil)
+	assert.Error(t, err)
+	_, err = NewRemovePodsWithMissingReferences(&deschedulerconfig.LowNodeLoadArgs{}, nil)
+	assert.Error(t, err)
+}
+
+func TestRemovePodsWithMissingReferences(t *testing.T) {
+	node := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	nodes := []*corev1.Node{node}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "present-config"}}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "present-secret"}}
+
+	now := time.Now()
+	withReadiness := func(ready bool, lastTransition time.Time) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Status.Phase = corev1.PodPending
+			status := corev1.ConditionFalse
+			if ready {
+				pod.Status.Phase = corev1.PodRunning
+				status = corev1.ConditionTrue
+			}
+			pod.Status.Conditions = []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: status, LastTransitionTime: metav1.Time{Time: lastTransition}},
+			}
+		}
+	}
+	withConfigMapVolume := func(name string, optional bool) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+				Name: name,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: name},
+						Optional:             &optional,
+					},
+				},
+			})
+		}
+	}
+	withSecretEnv := func(name string) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			pod.Spec.Containers[0].EnvFrom = append(pod.Spec.Containers[0].EnvFrom, corev1.EnvFromSource{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+			})
+		}
+	}
+	buildPod := func(name string, apply ...func(pod *corev1.Pod)) *corev1.Pod {
+		return test.BuildTestPod(name, 100, 0, "n1", func(pod *corev1.Pod) {
+			for _, fn := range apply {
+				fn(pod)
+			}
+		})
+	}
+	pods := []*corev1.Pod{
+		buildPod("present-references", withReadiness(false, now.Add(-time.Hour)), withConfigMapVolume("present-config", false), withSecretEnv("present-secret")),
+		buildPod("missing-configmap", withReadiness(false, now.Add(-time.Hour)), withConfigMapVolume("missing-config", false)),
+		buildPod("missing-secret", withReadiness(false, now.Add(-time.Hour)), withConfigMapVolume("present-config", false), withSecretEnv("missing-secret")),
+		buildPod("missing-optional-configmap", withReadiness(false, now.Add(-time.Hour)), withConfigMapVolume("missing-config", true)),
+		buildPod("recently-failing", withReadiness(false, now.Add(-time.Minute)), withConfigMapVolume("missing-config", false)),
+		buildPod("ready", withReadiness(true, now.Add(-time.Hour)), withSecretEnv("missing-secret")),
+	}
+
+	tests := []struct {
+		name             string
+		args             *deschedulerconfig.RemovePodsWithMissingReferencesArgs
+		maxEvictionTotal *uint
+		expectedEvicted  []string
+		expectedCount    int
+	}{
+		{
+			name: "evict pods failing with missing references for longer than minFailingDuration",
+			args: &deschedulerconfig.RemovePodsWithMissingReferencesArgs{
+				MinFailingDuration: metav1.Duration{Duration: 10 * time.Minute},
+			},
+			expectedEvicted: []string{"missing-configmap", "missing-secret"},
+			expectedCount:   2,
+		},
+		{
+			name: "evict nothing with a longer minFailingDuration",
+			args: &deschedulerconfig.RemovePodsWithMissingReferencesArgs{
+				MinFailingDuration: metav1.Duration{Duration: 3 * time.Hour},
+			},
+		},
+		{
+			name: "respect the eviction limits",
+			args: &deschedulerconfig.RemovePodsWithMissingReferencesArgs{
+				MinFailingDuration: metav1.Duration{Duration: 10 * time.Minute},
+			},
+			maxEvictionTotal: func() *uint { v := uint(1); return &v }(),
+			expectedCount:    1,
+		},
+		{
+			name: "respect the namespaces",
+			args: &deschedulerconfig.RemovePodsWithMissingReferencesArgs{
+				MinFailingDuration: metav1.Duration{Duration: 10 * time.Minute},
+				Namespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"default"},
+				},
+			},
+		},
+		{
+			name: "paused",
+			args: &deschedulerconfig.RemovePodsWithMissingReferencesArgs{
+				Paused:             true,
+				MinFailingDuration: metav1.Duration{Duration: 10 * time.Minute},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			objs = append(objs, configMap, secret)
+			fh, err := frameworktesting.NewPluginTestEnv(ctx, objs, tt.maxEvictionTotal,
+				func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+					reg.Register(RemovePodsWithMissingReferencesName, NewRemovePodsWithMissingReferences)
+					profile.Plugins.Deschedule.Enabled = append(profile.Plugins.Deschedule.Enabled, deschedulerconfig.Plugin{Name: RemovePodsWithMissingReferencesName})
+					profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+						Name: RemovePodsWithMissingReferencesName,
+						Args: tt.args,
+					})
+				},
+			)
+			assert.NoError(t, err)
+
+			fh.RunDeschedulePlugins(ctx, nodes)
+			evictedPods := fh.EvictedPods()
+
+			assert.Len(t, evictedPods, tt.expectedCount)
+			if tt.expectedEvicted != nil {
+				sort.Strings(evictedPods)
+				assert.Equal(t, tt.expectedEvicted, evictedPods)
+			}
+		})
+	}
+}
+
+func TestGetPodReferences(t *testing.T) {
+	optional := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "projected",
+					VolumeSource: corev1.VolumeSource{
+						Projected: &corev1.ProjectedVolumeSource{
+							Sources: []corev1.VolumeProjection{
+								{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "projected-config"}}},
+								{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "projected-secret"}, Optional: &optional}},
+							},
+						},
+					},
+				},
+				{
+					Name:         "secret",
+					VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "volume-secret"}},
+				},
+			},
+			InitContainers: []corev1.Container{
+				{
+					Name: "init",
+					Env: []corev1.EnvVar{
+						{
+							Name: "KEY",
+							ValueFrom: &corev1.EnvVarSource{
+								ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "env-config"}, Key: "key"},
+							},
+						},
+					},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					EnvFrom: []corev1.EnvFromSource{
+						{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "volume-secret"}}},
+					},
+				},
+			},
+		},
+	}
+	expected := []podReference{
+		{kind: referenceKindConfigMap, namespace: "default", name: "projected-config"},
+		{kind: referenceKindSecret, namespace: "default", name: "volume-secret"},
+		{kind: referenceKindConfigMap, namespace: "default", name: "env-config"},
+	}
+	assert.Equal(t, expected, getPodReferences(pod))
+}
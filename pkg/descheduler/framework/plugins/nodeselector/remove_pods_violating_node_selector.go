@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeselector
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	RemovePodsViolatingNodeSelectorName = "RemovePodsViolatingNodeSelector"
+)
+
+var _ framework.DeschedulePlugin = &RemovePodsViolatingNodeSelector{}
+
+// RemovePodsViolatingNodeSelector evicts pods whose nodeSelector is no longer satisfied by the labels
+// of the node they are running on, e.g. after the node has been relabeled.
+type RemovePodsViolatingNodeSelector struct {
+	handle    framework.Handle
+	podFilter framework.FilterFunc
+	args      *deschedulerconfig.RemovePodsViolatingNodeSelectorArgs
+}
+
+// NewRemovePodsViolatingNodeSelector builds plugin from its arguments while passing a handle
+func NewRemovePodsViolatingNodeSelector(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	nodeSelectorArgs, ok := args.(*deschedulerconfig.RemovePodsViolatingNodeSelectorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsViolatingNodeSelectorArgs, got %T", args)
+	}
+	if err := validation.ValidateRemovePodsViolatingNodeSelectorArgs(nil, nodeSelectorArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if nodeSelectorArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(nodeSelectorArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(nodeSelectorArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsViolatingNodeSelector{
+		handle:    handle,
+		podFilter: podFilter,
+		args:      nodeSelectorArgs,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *RemovePodsViolatingNodeSelector) Name() string {
+	return RemovePodsViolatingNodeSelectorName
+}
+
+// Deschedule extension point implementation for the plugin
+func (pl *RemovePodsViolatingNodeSelector) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("RemovePodsViolatingNodeSelector is paused and will do nothing.")
+		return nil
+	}
+
+	for i, node := range nodes {
+		if framework.ExceedCycleDeadline(ctx, nodes[i:]) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d nodes left unprocessed", RemovePodsViolatingNodeSelectorName, len(nodes)-i)
+			break
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			if len(pod.Spec.NodeSelector) == 0 || labels.SelectorFromSet(pod.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+				continue
+			}
+			klog.V(4).InfoS("Pod violates the nodeSelector on its node", "pod", klog.KObj(pod), "node", klog.KObj(node))
+			if pl.args.NodeFit && !nodeutil.PodFitsAnyOtherNode(pl.handle.GetPodsAssignedToNodeFunc(), pod, nodes) {
+				klog.V(4).InfoS("Pod aborted eviction because it does not fit any other node", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			if !pl.handle.Evictor().PreEvictionFilter(pod) {
+				klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			evictionOptions := framework.EvictOptions{
+				PluginName: RemovePodsViolatingNodeSelectorName,
+				Reason:     "node labels no longer match the nodeSelector of the pod",
+			}
+			if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		}
+	}
+	return nil
+}
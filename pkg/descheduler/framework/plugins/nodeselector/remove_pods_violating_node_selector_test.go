@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeselector
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestNewRemovePodsViolatingNodeSelectorWithInvalidArgs(t *testing.T) {
+	_, err := NewRemovePodsViolatingNodeSelector(&deschedulerconfig.RemovePodsViolatingNodeSelectorArgs{
+		EvictableNamespaces: &deschedulerconfig.Namespaces{
+			Include: []string{"default"},
+			Exclude: []string{"kube-system"},
+		},
+	}, nil)
+	assert.Error(t, err)
+	_, err = NewRemovePodsViolatingNodeSelector(&deschedulerconfig.LowNodeLoadArgs{}, nil)
+	assert.Error(t, err)
+}
+
+func TestRemovePodsViolatingNodeSelector(t *testing.T) {
+	relabeledNode := test.BuildTestNode("relabeled", 4000, 3000, 10, func(node *corev1.Node) {
+		node.Labels = map[string]string{"disk": "hdd"}
+	})
+	ssdNode := test.BuildTestNode("ssd", 4000, 3000, 10, func(node *corev1.Node) {
+		node.Labels = map[string]string{"disk": "ssd"}
+	})
+	nodes := []*corev1.Node{relabeledNode, ssdNode}
+
+	withNodeSelector := func(selector map[string]string) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Spec.NodeSelector = selector
+		}
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("relabeled-mismatched", 100, 0, "relabeled", withNodeSelector(map[string]string{"disk": "ssd"})),
+		test.BuildTestPod("relabeled-matched", 100, 0, "relabeled", withNodeSelector(map[string]string{"disk": "hdd"})),
+		test.BuildTestPod("relabeled-unschedulable", 100, 0, "relabeled", withNodeSelector(map[string]string{"disk": "nvme"})),
+		test.BuildTestPod("relabeled-without-selector", 100, 0, "relabeled", test.SetRSOwnerRef),
+		test.BuildTestPod("ssd-matched", 100, 0, "ssd", withNodeSelector(map[string]string{"disk": "ssd"})),
+	}
+
+	tests := []struct {
+		name             string
+		args             *deschedulerconfig.RemovePodsViolatingNodeSelectorArgs
+		maxEvictionTotal *uint
+		expectedEvicted  []string
+		expectedCount    int
+	}{
+		{
+			name: "evict pods whose nodeSelector mismatches and which fit other nodes",
+			args: &deschedulerconfig.RemovePodsViolatingNodeSelectorArgs{
+				NodeFit: true,
+			},
+			expectedEvicted: []string{"relabeled-mismatched"},
+			expectedCount:   1,
+		},
+		{
+			name:            "evict all pods whose nodeSelector mismatches without nodeFit",
+			args:            &deschedulerconfig.RemovePodsViolatingNodeSelectorArgs{},
+			expectedEvicted: []string{"relabeled-mismatched", "relabeled-unschedulable"},
+			expectedCount:   2,
+		},
+		{
+			name:             "respect the eviction limits",
+			args:             &deschedulerconfig.RemovePodsViolatingNodeSelectorArgs{},
+			maxEvictionTotal: func() *uint { v := uint(1); return &v }(),
+			expectedCount:    1,
+		},
+		{
+			name: "respect the evictable namespaces",
+			args: &deschedulerconfig.RemovePodsViolatingNodeSelectorArgs{
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"default"},
+				},
+			},
+		},
+		{
+			name: "paused",
+			args: &deschedulerconfig.RemovePodsViolatingNodeSelectorArgs{
+				Paused: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fh, err := frameworktesting.NewPluginTestEnv(ctx, objs, tt.maxEvictionTotal,
+				func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+					reg.Register(RemovePodsViolatingNodeSelectorName, NewRemovePodsViolatingNodeSelector)
+					profile.Plugins.Deschedule.Enabled = append(profile.Plugins.Deschedule.Enabled, deschedulerconfig.Plugin{Name: RemovePodsViolatingNodeSelectorName})
+					profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+						Name: RemovePodsViolatingNodeSelectorName,
+						Args: tt.args,
+					})
+				},
+			)
+			assert.NoError(t, err)
+
+			fh.RunDeschedulePlugins(ctx, nodes)
+			evictedPods := fh.EvictedPods()
+
+			assert.Len(t, evictedPods, tt.expectedCount)
+			if tt.expectedEvicted != nil {
+				sort.Strings(evictedPods)
+				assert.Equal(t, tt.expectedEvicted, evictedPods)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetaints
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
+)
+
+const (
+	RemovePodsViolatingNodeTaintEffectsName = "RemovePodsViolatingNodeTaintEffects"
+)
+
+var _ framework.DeschedulePlugin = &RemovePodsViolatingNodeTaintEffects{}
+
+// RemovePodsViolatingNodeTaintEffects evicts pods running on nodes with taints the pods do not tolerate,
+// e.g. the taints added after the pods have been scheduled. Only the taints with the configured effects are considered.
+type RemovePodsViolatingNodeTaintEffects struct {
+	handle         framework.Handle
+	podFilter      framework.FilterFunc
+	args           *deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs
+	taintEffects   sets.String
+	excludedTaints sets.String
+}
+
+// NewRemovePodsViolatingNodeTaintEffects builds plugin from its arguments while passing a handle
+func NewRemovePodsViolatingNodeTaintEffects(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	nodeTaintsArgs, ok := args.(*deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsViolatingNodeTaintEffectsArgs, got %T", args)
+	}
+	if err := validation.ValidateRemovePodsViolatingNodeTaintEffectsArgs(nil, nodeTaintsArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if nodeTaintsArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(nodeTaintsArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(nodeTaintsArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	taintEffects := sets.NewString()
+	for _, effect := range nodeTaintsArgs.TaintEffects {
+		taintEffects.Insert(string(effect))
+	}
+
+	return &RemovePodsViolatingNodeTaintEffects{
+		handle:         handle,
+		podFilter:      podFilter,
+		args:           nodeTaintsArgs,
+		taintEffects:   taintEffects,
+		excludedTaints: sets.NewString(nodeTaintsArgs.ExcludedTaints...),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *RemovePodsViolatingNodeTaintEffects) Name() string {
+	return RemovePodsViolatingNodeTaintEffectsName
+}
+
+// Deschedule extension point implementation for the plugin
+func (pl *RemovePodsViolatingNodeTaintEffects) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("RemovePodsViolatingNodeTaintEffects is paused and will do nothing.")
+		return nil
+	}
+
+	for i, node := range nodes {
+		if framework.ExceedCycleDeadline(ctx, nodes[i:]) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d nodes left unprocessed", RemovePodsViolatingNodeTaintEffectsName, len(nodes)-i)
+			break
+		}
+		taints := pl.filterTaints(node.Spec.Taints)
+		if len(taints) == 0 {
+			continue
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			taint := untoleratedTaint(pod, taints)
+			if taint == nil {
+				continue
+			}
+			klog.V(4).InfoS("Pod does not tolerate the taint on its node", "pod", klog.KObj(pod), "node", klog.KObj(node), "taint", taint.ToString())
+			if pl.args.NodeFit && !nodeutil.PodFitsAnyOtherNode(pl.handle.GetPodsAssignedToNodeFunc(), pod, nodes) {
+				klog.V(4).InfoS("Pod aborted eviction because it does not fit any other node", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			if !pl.handle.Evictor().PreEvictionFilter(pod) {
+				klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			evictionOptions := framework.EvictOptions{
+				PluginName: RemovePodsViolatingNodeTaintEffectsName,
+				Reason:     fmt.Sprintf("pod does not tolerate the taint %s of the node", taint.ToString()),
+			}
+			if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		}
+	}
+	return nil
+}
+
+// filterTaints returns the taints with the configured effects which are not excluded.
+func (pl *RemovePodsViolatingNodeTaintEffects) filterTaints(taints []corev1.Taint) []corev1.Taint {
+	var result []corev1.Taint
+	for _, taint := range taints {
+		if !pl.taintEffects.Has(string(taint.Effect)) {
+			continue
+		}
+		if pl.excludedTaints.Has(taint.Key) || pl.excludedTaints.Has(fmt.Sprintf("%s=%s", taint.Key, taint.Value)) {
+			continue
+		}
+		result = append(result, taint)
+	}
+	return result
+}
+
+func untoleratedTaint(pod *corev1.Pod, taints []corev1.Taint) *corev1.Taint {
+	for i := range taints {
+		if !utils.TolerationsTolerateTaint(pod.Spec.Tolerations, &taints[i]) {
+			return &taints[i]
+		}
+	}
+	return nil
+}
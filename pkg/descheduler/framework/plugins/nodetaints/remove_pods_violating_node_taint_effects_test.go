@@ -0,0 +1,184 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetaints
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestNewRemovePodsViolatingNodeTaintEffectsWithInvalidArgs(t *testing.T) {
+	_, err := NewRemovePodsViolatingNodeTaintEffects(&deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+		TaintEffects: []corev1.TaintEffect{"NoEvict"},
+	}, nil)
+	assert.Error(t, err)
+	_, err = NewRemovePodsViolatingNodeTaintEffects(&deschedulerconfig.LowNodeLoadArgs{}, nil)
+	assert.Error(t, err)
+}
+
+func TestRemovePodsViolatingNodeTaintEffects(t *testing.T) {
+	noScheduleNode := test.BuildTestNode("no-schedule", 4000, 3000, 10, func(node *corev1.Node) {
+		node.Spec.Taints = []corev1.Taint{
+			{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule},
+		}
+	})
+	preferNoScheduleNode := test.BuildTestNode("prefer-no-schedule", 4000, 3000, 10, func(node *corev1.Node) {
+		node.Spec.Taints = []corev1.Taint{
+			{Key: "spot", Value: "true", Effect: corev1.TaintEffectPreferNoSchedule},
+		}
+	})
+	untaintedNode := test.BuildTestNode("untainted", 4000, 3000, 10, nil)
+	nodes := []*corev1.Node{noScheduleNode, preferNoScheduleNode, untaintedNode}
+
+	withTolerations := func(tolerations ...corev1.Toleration) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Spec.Tolerations = tolerations
+		}
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("no-schedule-intolerant", 100, 0, "no-schedule", test.SetRSOwnerRef),
+		test.BuildTestPod("no-schedule-tolerant", 100, 0, "no-schedule", withTolerations(corev1.Toleration{
+			Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "infra", Effect: corev1.TaintEffectNoSchedule,
+		})),
+		test.BuildTestPod("prefer-no-schedule-intolerant", 100, 0, "prefer-no-schedule", test.SetRSOwnerRef),
+		test.BuildTestPod("prefer-no-schedule-tolerant", 100, 0, "prefer-no-schedule", withTolerations(corev1.Toleration{
+			Key: "spot", Operator: corev1.TolerationOpExists,
+		})),
+		test.BuildTestPod("untainted", 100, 0, "untainted", test.SetRSOwnerRef),
+	}
+
+	tests := []struct {
+		name             string
+		args             *deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs
+		maxEvictionTotal *uint
+		expectedEvicted  []string
+		expectedCount    int
+	}{
+		{
+			name: "evict pods not tolerating NoSchedule taints",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				NodeFit:      true,
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectNoSchedule},
+			},
+			expectedEvicted: []string{"no-schedule-intolerant"},
+			expectedCount:   1,
+		},
+		{
+			name: "evict pods not tolerating PreferNoSchedule taints",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectPreferNoSchedule},
+			},
+			expectedEvicted: []string{"prefer-no-schedule-intolerant"},
+			expectedCount:   1,
+		},
+		{
+			name: "evict pods not tolerating NoSchedule and PreferNoSchedule taints",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule},
+			},
+			expectedEvicted: []string{"no-schedule-intolerant", "prefer-no-schedule-intolerant"},
+			expectedCount:   2,
+		},
+		{
+			name: "ignore the excluded taint keys",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				TaintEffects:   []corev1.TaintEffect{corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule},
+				ExcludedTaints: []string{"dedicated"},
+			},
+			expectedEvicted: []string{"prefer-no-schedule-intolerant"},
+			expectedCount:   1,
+		},
+		{
+			name: "ignore the excluded taint key value pairs",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				TaintEffects:   []corev1.TaintEffect{corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule},
+				ExcludedTaints: []string{"spot=true", "dedicated=other"},
+			},
+			expectedEvicted: []string{"no-schedule-intolerant"},
+			expectedCount:   1,
+		},
+		{
+			name: "respect the eviction limits",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule},
+			},
+			maxEvictionTotal: func() *uint { v := uint(1); return &v }(),
+			expectedCount:    1,
+		},
+		{
+			name: "respect the evictable namespaces",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule},
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"default"},
+				},
+			},
+		},
+		{
+			name: "paused",
+			args: &deschedulerconfig.RemovePodsViolatingNodeTaintEffectsArgs{
+				Paused:       true,
+				TaintEffects: []corev1.TaintEffect{corev1.TaintEffectNoSchedule},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fh, err := frameworktesting.NewPluginTestEnv(ctx, objs, tt.maxEvictionTotal,
+				func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+					reg.Register(RemovePodsViolatingNodeTaintEffectsName, NewRemovePodsViolatingNodeTaintEffects)
+					profile.Plugins.Deschedule.Enabled = append(profile.Plugins.Deschedule.Enabled, deschedulerconfig.Plugin{Name: RemovePodsViolatingNodeTaintEffectsName})
+					profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+						Name: RemovePodsViolatingNodeTaintEffectsName,
+						Args: tt.args,
+					})
+				},
+			)
+			assert.NoError(t, err)
+
+			fh.RunDeschedulePlugins(ctx, nodes)
+			evictedPods := fh.EvictedPods()
+
+			assert.Len(t, evictedPods, tt.expectedCount)
+			if tt.expectedEvicted != nil {
+				sort.Strings(evictedPods)
+				assert.Equal(t, tt.expectedEvicted, evictedPods)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outdatedrequests
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var verticalPodAutoscalerGVR = schema.GroupVersionResource{
+	Group:    "autoscaling.k8s.io",
+	Version:  "v1",
+	Resource: "verticalpodautoscalers",
+}
+
+// updateModeOff means the VerticalPodAutoscaler only provides recommendations,
+// the recreated pods keep their requests, so there is no point to evict them.
+const updateModeOff = "Off"
+
+// workloadKey identifies the workload targeted by a VerticalPodAutoscaler.
+type workloadKey struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+// containerRecommendations maps the container names to the target requests recommended for them.
+type containerRecommendations map[string]corev1.ResourceList
+
+// parseVerticalPodAutoscaler returns the target workload and the container recommendations of the VerticalPodAutoscaler.
+// It returns false if the VerticalPodAutoscaler won't apply its recommendations to the recreated pods.
+func parseVerticalPodAutoscaler(obj *unstructured.Unstructured) (workloadKey, containerRecommendations, bool, error) {
+	updateMode, _, err := unstructured.NestedString(obj.Object, "spec", "updatePolicy", "updateMode")
+	if err != nil {
+		return workloadKey{}, nil, false, err
+	}
+	if updateMode == updateModeOff {
+		return workloadKey{}, nil, false, nil
+	}
+
+	kind, _, err := unstructured.NestedString(obj.Object, "spec", "targetRef", "kind")
+	if err != nil {
+		return workloadKey{}, nil, false, err
+	}
+	name, _, err := unstructured.NestedString(obj.Object, "spec", "targetRef", "name")
+	if err != nil {
+		return workloadKey{}, nil, false, err
+	}
+	if kind == "" || name == "" {
+		return workloadKey{}, nil, false, nil
+	}
+
+	items, found, err := unstructured.NestedSlice(obj.Object, "status", "recommendation", "containerRecommendations")
+	if err != nil || !found {
+		return workloadKey{}, nil, false, err
+	}
+	recommendations := containerRecommendations{}
+	for _, item := range items {
+		containerRecommendation, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		containerName, _, err := unstructured.NestedString(containerRecommendation, "containerName")
+		if err != nil {
+			return workloadKey{}, nil, false, err
+		}
+		target, _, err := unstructured.NestedStringMap(containerRecommendation, "target")
+		if err != nil {
+			return workloadKey{}, nil, false, err
+		}
+		resourceList := corev1.ResourceList{}
+		for resourceName, value := range target {
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return workloadKey{}, nil, false, fmt.Errorf("invalid target %s of container %s: %v", resourceName, containerName, err)
+			}
+			resourceList[corev1.ResourceName(resourceName)] = quantity
+		}
+		recommendations[containerName] = resourceList
+	}
+
+	key := workloadKey{namespace: obj.GetNamespace(), kind: kind, name: name}
+	return key, recommendations, len(recommendations) > 0, nil
+}
+
+// getWorkloadKeys returns the keys of the workloads which may be targeted by a VerticalPodAutoscaler to control the pod.
+// The Deployment owning the ReplicaSet of the pod is derived from the pod-template-hash label.
+func getWorkloadKeys(pod *corev1.Pod) []workloadKey {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil
+	}
+	keys := []workloadKey{{namespace: pod.Namespace, kind: owner.Kind, name: owner.Name}}
+	if owner.Kind == "ReplicaSet" {
+		hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+		if hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			keys = append(keys, workloadKey{namespace: pod.Namespace, kind: "Deployment", name: strings.TrimSuffix(owner.Name, "-"+hash)})
+		}
+	}
+	return keys
+}
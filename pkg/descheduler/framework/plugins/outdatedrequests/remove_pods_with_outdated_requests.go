@@ -0,0 +1,254 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outdatedrequests
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	RemovePodsWithOutdatedRequestsName = "RemovePodsWithOutdatedRequests"
+)
+
+var _ framework.DeschedulePlugin = &RemovePodsWithOutdatedRequests{}
+
+// RemovePodsWithOutdatedRequests evicts pods whose requests diverge from the target recommended
+// by the VerticalPodAutoscaler, so that the pods are recreated with the recommended requests.
+type RemovePodsWithOutdatedRequests struct {
+	handle    framework.Handle
+	podFilter framework.FilterFunc
+	args      *deschedulerconfig.RemovePodsWithOutdatedRequestsArgs
+	vpaLister cache.GenericLister
+}
+
+// NewRemovePodsWithOutdatedRequests builds plugin from its arguments while passing a handle
+func NewRemovePodsWithOutdatedRequests(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	outdatedRequestsArgs, ok := args.(*deschedulerconfig.RemovePodsWithOutdatedRequestsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsWithOutdatedRequestsArgs, got %T", args)
+	}
+	if err := validation.ValidateRemovePodsWithOutdatedRequestsArgs(nil, outdatedRequestsArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if outdatedRequestsArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(outdatedRequestsArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(outdatedRequestsArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	dynamicClient, ok := handle.(dynamic.Interface)
+	if !ok {
+		var err error
+		dynamicClient, err = dynamic.NewForConfig(handle.KubeConfig())
+		if err != nil {
+			return nil, err
+		}
+	}
+	dynamicInformerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 0)
+	vpaInformer := dynamicInformerFactory.ForResource(verticalPodAutoscalerGVR)
+	vpaInformer.Informer()
+	dynamicInformerFactory.Start(context.TODO().Done())
+	dynamicInformerFactory.WaitForCacheSync(context.TODO().Done())
+
+	return &RemovePodsWithOutdatedRequests{
+		handle:    handle,
+		podFilter: podFilter,
+		args:      outdatedRequestsArgs,
+		vpaLister: vpaInformer.Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *RemovePodsWithOutdatedRequests) Name() string {
+	return RemovePodsWithOutdatedRequestsName
+}
+
+// Deschedule extension point implementation for the plugin
+func (pl *RemovePodsWithOutdatedRequests) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("RemovePodsWithOutdatedRequests is paused and will do nothing.")
+		return nil
+	}
+
+	recommendations, err := pl.listRecommendations()
+	if err != nil {
+		klog.ErrorS(err, "Failed to list VerticalPodAutoscaler recommendations")
+		return &framework.Status{Err: err}
+	}
+	if len(recommendations) == 0 {
+		return nil
+	}
+
+	for i, node := range nodes {
+		if framework.ExceedCycleDeadline(ctx, nodes[i:]) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d nodes left unprocessed", RemovePodsWithOutdatedRequestsName, len(nodes)-i)
+			break
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			podRecommendations := getPodRecommendations(recommendations, pod)
+			if podRecommendations == nil {
+				continue
+			}
+			reason, outdated := pl.getOutdatedReason(pod, podRecommendations)
+			if !outdated {
+				continue
+			}
+			if pl.args.NodeFit {
+				recommendedPod := pl.withRecommendedRequests(pod, podRecommendations)
+				if !nodeutil.PodFitsAnyNode(pl.handle.GetPodsAssignedToNodeFunc(), recommendedPod, nodes) {
+					klog.V(4).InfoS("Pod with recommended requests fits no node, skip evicting", "pod", klog.KObj(pod), "node", klog.KObj(node))
+					continue
+				}
+			}
+			if !pl.handle.Evictor().PreEvictionFilter(pod) {
+				klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			evictionOptions := framework.EvictOptions{
+				PluginName: RemovePodsWithOutdatedRequestsName,
+				Reason:     reason,
+			}
+			if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node), "reason", reason)
+		}
+	}
+	return nil
+}
+
+func (pl *RemovePodsWithOutdatedRequests) listRecommendations() (map[workloadKey]containerRecommendations, error) {
+	objs, err := pl.vpaLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	recommendations := map[workloadKey]containerRecommendations{}
+	for _, obj := range objs {
+		vpa, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		key, containerRecommendations, ok, err := parseVerticalPodAutoscaler(vpa)
+		if err != nil {
+			klog.ErrorS(err, "Failed to parse VerticalPodAutoscaler", "verticalPodAutoscaler", klog.KObj(vpa))
+			continue
+		}
+		if ok {
+			recommendations[key] = containerRecommendations
+		}
+	}
+	return recommendations, nil
+}
+
+func getPodRecommendations(recommendations map[workloadKey]containerRecommendations, pod *corev1.Pod) containerRecommendations {
+	for _, key := range getWorkloadKeys(pod) {
+		if podRecommendations, ok := recommendations[key]; ok {
+			return podRecommendations
+		}
+	}
+	return nil
+}
+
+// getOutdatedReason checks whether the request of any container diverges from the recommended target
+// more than the DivergenceThreshold, and returns the reason if it does.
+func (pl *RemovePodsWithOutdatedRequests) getOutdatedReason(pod *corev1.Pod, recommendations containerRecommendations) (string, bool) {
+	for _, container := range pod.Spec.Containers {
+		target, ok := recommendations[container.Name]
+		if !ok {
+			continue
+		}
+		for _, resourceName := range pl.args.Resources {
+			recommended, ok := target[resourceName]
+			if !ok || recommended.IsZero() {
+				continue
+			}
+			requested := container.Resources.Requests[resourceName]
+			divergence := requestDivergence(requested, recommended)
+			if divergence > float64(pl.args.DivergenceThreshold) {
+				return fmt.Sprintf("container %s %s request %s diverges from the recommendation %s by %.0f%%",
+					container.Name, resourceName, requested.String(), recommended.String(), divergence), true
+			}
+		}
+	}
+	return "", false
+}
+
+// requestDivergence returns the percentage by which the requested quantity differs from the recommended quantity.
+func requestDivergence(requested, recommended resource.Quantity) float64 {
+	diff := math.Abs(float64(requested.MilliValue() - recommended.MilliValue()))
+	return diff * 100 / float64(recommended.MilliValue())
+}
+
+// withRecommendedRequests returns a copy of the pod whose requests are replaced with the recommended targets,
+// which is used to check whether the recreated pod can be rescheduled.
+func (pl *RemovePodsWithOutdatedRequests) withRecommendedRequests(pod *corev1.Pod, recommendations containerRecommendations) *corev1.Pod {
+	recommendedPod := pod.DeepCopy()
+	for i := range recommendedPod.Spec.Containers {
+		container := &recommendedPod.Spec.Containers[i]
+		target, ok := recommendations[container.Name]
+		if !ok {
+			continue
+		}
+		for _, resourceName := range pl.args.Resources {
+			recommended, ok := target[resourceName]
+			if !ok {
+				continue
+			}
+			if container.Resources.Requests == nil {
+				container.Resources.Requests = corev1.ResourceList{}
+			}
+			container.Resources.Requests[resourceName] = recommended
+		}
+	}
+	return recommendedPod
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outdatedrequests
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/utils/pointer"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+type fakeFrameworkHandle struct {
+	framework.Handle
+	dynamic.Interface
+}
+
+func setDeploymentOwnerRef(deployment string) func(pod *corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		hash := "5d8f7c9b4"
+		pod.Labels = map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: hash}
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       deployment + "-" + hash,
+				Controller: pointer.Bool(true),
+			},
+		}
+		pod.Spec.Containers[0].Name = "app"
+	}
+}
+
+func buildTestVerticalPodAutoscaler(deployment, updateMode, targetCPU string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "autoscaling.k8s.io/v1",
+			"kind":       "VerticalPodAutoscaler",
+			"metadata": map[string]interface{}{
+				"namespace": "default",
+				"name":      deployment,
+			},
+			"spec": map[string]interface{}{
+				"targetRef": map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"name":       deployment,
+				},
+				"updatePolicy": map[string]interface{}{
+					"updateMode": updateMode,
+				},
+			},
+			"status": map[string]interface{}{
+				"recommendation": map[string]interface{}{
+					"containerRecommendations": []interface{}{
+						map[string]interface{}{
+							"containerName": "app",
+							"target": map[string]interface{}{
+								"cpu": targetCPU,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewRemovePodsWithOutdatedRequestsWithInvalidArgs(t *testing.T) {
+	_, err := NewRemovePodsWithOutdatedRequests(&deschedulerconfig.RemovePodsWithOutdatedRequestsArgs{}, nil)
+	assert.Error(t, err)
+	_, err = NewRemovePodsWithOutdatedRequests(&deschedulerconfig.LowNodeLoadArgs{}, nil)
+	assert.Error(t, err)
+}
+
+func TestRemovePodsWithOutdatedRequests(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("node-1", 4000, 3000, 10, nil),
+		test.BuildTestNode("node-2", 4000, 3000, 10, nil),
+	}
+	pods := []*corev1.Pod{
+		// 400% above the recommendation
+		test.BuildTestPod("web-1", 100, 0, "node-1", setDeploymentOwnerRef("web")),
+		// 10% below the recommendation
+		test.BuildTestPod("api-1", 100, 0, "node-1", setDeploymentOwnerRef("api")),
+		// the recommendation won't be applied
+		test.BuildTestPod("batch-1", 100, 0, "node-2", setDeploymentOwnerRef("batch")),
+		// the recommended requests fit no node
+		test.BuildTestPod("huge-1", 100, 0, "node-2", setDeploymentOwnerRef("huge")),
+		// no recommendation
+		test.BuildTestPod("other-1", 100, 0, "node-2", setDeploymentOwnerRef("other")),
+	}
+	vpas := []runtime.Object{
+		buildTestVerticalPodAutoscaler("web", "Auto", "500m"),
+		buildTestVerticalPodAutoscaler("api", "Auto", "110m"),
+		buildTestVerticalPodAutoscaler("batch", "Off", "500m"),
+		buildTestVerticalPodAutoscaler("huge", "Recreate", "16"),
+	}
+	defaultResources := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+	tests := []struct {
+		name             string
+		args             *deschedulerconfig.RemovePodsWithOutdatedRequestsArgs
+		maxEvictionTotal *uint
+		expectedEvicted  []string
+		expectedCount    int
+	}{
+		{
+			name: "evict pods diverged above the threshold",
+			args: &deschedulerconfig.RemovePodsWithOutdatedRequestsArgs{
+				DivergenceThreshold: 20,
+				Resources:           defaultResources,
+				NodeFit:             true,
+			},
+			expectedEvicted: []string{"web-1"},
+			expectedCount:   1,
+		},
+		{
+			name: "evict pods diverged above the threshold without nodeFit",
+			args: &deschedulerconfig.RemovePodsWithOutdatedRequestsArgs{
+				DivergenceThreshold: 20,
+				Resources:           defaultResources,
+			},
+			expectedEvicted: []string{"huge-1", "web-1"},
+			expectedCount:   2,
+		},
+		{
+			name: "evict pods diverged above a lower threshold",
+			args: &deschedulerconfig.RemovePodsWithOutdatedRequestsArgs{
+				DivergenceThreshold: 5,
+				Resources:           defaultResources,
+				NodeFit:             true,
+			},
+			expectedEvicted: []string{"api-1", "web-1"},
+			expectedCount:   2,
+		},
+		{
+			name: "no pods diverged above a higher threshold",
+			args: &deschedulerconfig.RemovePodsWithOutdatedRequestsArgs{
+				DivergenceThreshold: 500,
+				Resources:           defaultResources,
+				NodeFit:             true,
+			},
+		},
+		{
+			name: "only compare the configured resources",
+			args: &deschedulerconfig.RemovePodsWithOutdatedRequestsArgs{
+				DivergenceThreshold: 20,
+				Resources:           []corev1.ResourceName{corev1.ResourceMemory},
+				NodeFit:             true,
+			},
+		},
+		{
+			name: "respect the eviction limits",
+			args: &deschedulerconfig.RemovePodsWithOutdatedRequestsArgs{
+				DivergenceThreshold: 20,
+				Resources:           defaultResources,
+			},
+			maxEvictionTotal: func() *uint { v := uint(1); return &v }(),
+			expectedCount:    1,
+		},
+		{
+			name: "respect the evictable namespaces",
+			args: &deschedulerconfig.RemovePodsWithOutdatedRequestsArgs{
+				DivergenceThreshold: 20,
+				Resources:           defaultResources,
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"default"},
+				},
+			},
+		},
+		{
+			name: "paused",
+			args: &deschedulerconfig.RemovePodsWithOutdatedRequestsArgs{
+				Paused:              true,
+				DivergenceThreshold: 20,
+				Resources:           defaultResources,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{verticalPodAutoscalerGVR: "VerticalPodAutoscalerList"}, vpas...)
+
+			fh, err := frameworktesting.NewPluginTestEnv(ctx, objs, tt.maxEvictionTotal,
+				func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+					reg.Register(RemovePodsWithOutdatedRequestsName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+						return NewRemovePodsWithOutdatedRequests(args, &fakeFrameworkHandle{
+							Handle:    handle,
+							Interface: dynamicClient,
+						})
+					})
+					profile.Plugins.Deschedule.Enabled = append(profile.Plugins.Deschedule.Enabled, deschedulerconfig.Plugin{Name: RemovePodsWithOutdatedRequestsName})
+					profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+						Name: RemovePodsWithOutdatedRequestsName,
+						Args: tt.args,
+					})
+				},
+			)
+			assert.NoError(t, err)
+
+			fh.RunDeschedulePlugins(ctx, nodes)
+			evictedPods := fh.EvictedPods()
+
+			assert.Len(t, evictedPods, tt.expectedCount)
+			if tt.expectedEvicted != nil {
+				sort.Strings(evictedPods)
+				assert.Equal(t, tt.expectedEvicted, evictedPods)
+			}
+		})
+	}
+}
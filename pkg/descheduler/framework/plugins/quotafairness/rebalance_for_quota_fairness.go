@@ -0,0 +1,367 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotafairness
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	resourcehelper "k8s.io/kubernetes/pkg/api/v1/resource"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/clientset/versioned"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/informers/externalversions"
+	schedlisters "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/listers/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	RebalanceForQuotaFairnessName = "RebalanceForQuotaFairness"
+)
+
+var _ framework.BalancePlugin = &RebalanceForQuotaFairness{}
+
+// RebalanceForQuotaFairness evicts the pods borrowing above the Min of the quotas which are over their fair share,
+// so that the starved sibling quotas can use the capacity.
+// The fair share (runtime) and the demand (request) of the quotas are calculated by the quota manager of koord-scheduler
+// from the quota topology, and are read from the annotations of the ElasticQuotas.
+type RebalanceForQuotaFairness struct {
+	handle      framework.Handle
+	podFilter   framework.FilterFunc
+	args        *deschedulerconfig.RebalanceForQuotaFairnessArgs
+	quotaLister schedlisters.ElasticQuotaLister
+}
+
+// NewRebalanceForQuotaFairness builds plugin from its arguments while passing a handle
+func NewRebalanceForQuotaFairness(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	fairnessArgs, ok := args.(*deschedulerconfig.RebalanceForQuotaFairnessArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RebalanceForQuotaFairnessArgs, got %T", args)
+	}
+	if err := validation.ValidateRebalanceForQuotaFairnessArgs(nil, fairnessArgs); err != nil {
+		return nil, err
+	}
+
+	client, ok := handle.(versioned.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		var err error
+		client, err = versioned.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	schedSharedInformerFactory := externalversions.NewSharedInformerFactory(client, 0)
+	quotaInformer := schedSharedInformerFactory.Scheduling().V1alpha1().ElasticQuotas()
+	quotaInformer.Informer()
+	schedSharedInformerFactory.Start(context.TODO().Done())
+	schedSharedInformerFactory.WaitForCacheSync(context.TODO().Done())
+
+	return newRebalanceForQuotaFairness(fairnessArgs, handle, quotaInformer.Lister())
+}
+
+func newRebalanceForQuotaFairness(args *deschedulerconfig.RebalanceForQuotaFairnessArgs, handle framework.Handle,
+	quotaLister schedlisters.ElasticQuotaLister) (*RebalanceForQuotaFairness, error) {
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if args.Namespaces != nil {
+		excludedNamespaces = sets.NewString(args.Namespaces.Exclude...)
+		includedNamespaces = sets.NewString(args.Namespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RebalanceForQuotaFairness{
+		handle:      handle,
+		podFilter:   podFilter,
+		args:        args,
+		quotaLister: quotaLister,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *RebalanceForQuotaFairness) Name() string {
+	return RebalanceForQuotaFairnessName
+}
+
+// quotaShare is the fairness data of a quota on the considered resources.
+type quotaShare struct {
+	name string
+	// deficit is the resources the quota is entitled to but can not use, i.e. min(runtime, request) - used
+	deficit corev1.ResourceList
+	// excess is the resources the quota borrows above its fair share and Min, i.e. used - max(runtime, min)
+	excess corev1.ResourceList
+}
+
+// Balance extension point implementation for the plugin
+func (pl *RebalanceForQuotaFairness) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("RebalanceForQuotaFairness is paused and will do nothing.")
+		return nil
+	}
+
+	quotas, err := pl.quotaLister.List(labels.Everything())
+	if err != nil {
+		return &framework.Status{Err: err}
+	}
+	// the pods are attached to the leaf quotas, which compete with their siblings for the capacity of the parent
+	siblings := map[string][]*quotaShare{}
+	for _, quota := range quotas {
+		if extension.IsParentQuota(quota) || quota.Name == extension.RootQuotaName || quota.Name == extension.SystemQuotaName {
+			continue
+		}
+		share, err := pl.newQuotaShare(quota)
+		if err != nil {
+			klog.ErrorS(err, "Failed to get the fairness data of quota", "quota", klog.KObj(quota))
+			continue
+		}
+		parent := extension.GetParentQuotaName(quota)
+		siblings[parent] = append(siblings[parent], share)
+	}
+
+	var podsByQuota map[string][]*corev1.Pod
+	for parent, shares := range siblings {
+		// the pods of the quotas may run on any of the nodes
+		if framework.ExceedCycleDeadline(ctx, nodes) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration", RebalanceForQuotaFairnessName)
+			break
+		}
+		deficit := corev1.ResourceList{}
+		var starved, overShare []*quotaShare
+		for _, share := range shares {
+			if !isZero(share.deficit) {
+				starved = append(starved, share)
+				addTo(deficit, share.deficit)
+			}
+			if !isZero(share.excess) {
+				overShare = append(overShare, share)
+			}
+		}
+		if len(starved) == 0 || len(overShare) == 0 {
+			continue
+		}
+		if podsByQuota == nil {
+			podsByQuota = pl.listPodsByQuota(nodes)
+		}
+		klog.V(4).InfoS("Quotas are starved while their siblings are over the fair share", "parent", parent,
+			"starved", quotaNames(starved), "overShare", quotaNames(overShare), "deficit", deficit)
+		sort.Slice(overShare, func(i, j int) bool {
+			return overShare[i].name < overShare[j].name
+		})
+		for _, share := range overShare {
+			if isZero(deficit) {
+				break
+			}
+			pl.evictBorrowers(ctx, share, podsByQuota[share.name], deficit)
+		}
+	}
+	return nil
+}
+
+func (pl *RebalanceForQuotaFairness) newQuotaShare(quota *v1alpha1.ElasticQuota) (*quotaShare, error) {
+	fairShare, err := extension.GetRuntime(quota)
+	if err != nil {
+		return nil, err
+	}
+	request, err := extension.GetRequest(quota)
+	if err != nil {
+		return nil, err
+	}
+	share := &quotaShare{
+		name:    quota.Name,
+		deficit: corev1.ResourceList{},
+		excess:  corev1.ResourceList{},
+	}
+	for _, resourceName := range pl.args.Resources {
+		used := quota.Status.Used[resourceName]
+
+		entitled := minQuantity(fairShare[resourceName], request[resourceName])
+		if entitled.Cmp(used) > 0 {
+			entitled.Sub(used)
+			share.deficit[resourceName] = entitled
+		}
+
+		guaranteed := maxQuantity(fairShare[resourceName], quota.Spec.Min[resourceName])
+		if used.Cmp(guaranteed) > 0 {
+			excess := used.DeepCopy()
+			excess.Sub(guaranteed)
+			share.excess[resourceName] = excess
+		}
+	}
+	return share, nil
+}
+
+// listPodsByQuota lists the evictable pods on the nodes grouped by the quota labels.
+func (pl *RebalanceForQuotaFairness) listPodsByQuota(nodes []*corev1.Node) map[string][]*corev1.Pod {
+	podsByQuota := map[string][]*corev1.Pod{}
+	for _, node := range nodes {
+		pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			if quotaName := extension.GetQuotaName(pod); quotaName != "" {
+				podsByQuota[quotaName] = append(podsByQuota[quotaName], pod)
+			}
+		}
+	}
+	return podsByQuota
+}
+
+// evictBorrowers evicts the pods of the over-share quota from the lowest priority and the newest one,
+// until the deficit of the starved siblings is covered. A pod is skipped if evicting it takes the quota
+// below its fair share or Min, or it does not release any resource in deficit.
+func (pl *RebalanceForQuotaFairness) evictBorrowers(ctx context.Context, share *quotaShare, pods []*corev1.Pod, deficit corev1.ResourceList) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		pi, pj := podPriority(pods[i]), podPriority(pods[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+
+	var evicted int32
+	for _, pod := range pods {
+		if evicted >= pl.args.MaxEvictionsPerQuota || isZero(deficit) {
+			return
+		}
+		requests := resourcehelper.PodRequests(pod, resourcehelper.PodResourcesOptions{})
+		if !pl.releasesDeficit(requests, share.excess, deficit) {
+			continue
+		}
+		if !pl.handle.Evictor().PreEvictionFilter(pod) {
+			klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod), "quota", share.name)
+			continue
+		}
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "quota", share.name)
+		} else {
+			evictionOptions := framework.EvictOptions{
+				PluginName: RebalanceForQuotaFairnessName,
+				Reason:     fmt.Sprintf("quota %s borrows above its fair share while its sibling quotas are starved", share.name),
+			}
+			if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "quota", share.name)
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "quota", share.name)
+		}
+		evicted++
+		for _, resourceName := range pl.args.Resources {
+			subFrom(share.excess, resourceName, requests[resourceName])
+			subFrom(deficit, resourceName, requests[resourceName])
+		}
+	}
+}
+
+// releasesDeficit checks whether the pod releases any resource in deficit without exceeding the excess of its quota.
+func (pl *RebalanceForQuotaFairness) releasesDeficit(requests, excess, deficit corev1.ResourceList) bool {
+	releases := false
+	for _, resourceName := range pl.args.Resources {
+		request := requests[resourceName]
+		if request.IsZero() {
+			continue
+		}
+		if request.Cmp(excess[resourceName]) > 0 {
+			return false
+		}
+		if q := deficit[resourceName]; !q.IsZero() {
+			releases = true
+		}
+	}
+	return releases
+}
+
+func podPriority(pod *corev1.Pod) int32 {
+	if pod.Spec.Priority != nil {
+		return *pod.Spec.Priority
+	}
+	return 0
+}
+
+func quotaNames(shares []*quotaShare) []string {
+	names := make([]string, 0, len(shares))
+	for _, share := range shares {
+		names = append(names, share.name)
+	}
+	return names
+}
+
+func minQuantity(a, b resource.Quantity) resource.Quantity {
+	if a.Cmp(b) < 0 {
+		return a.DeepCopy()
+	}
+	return b.DeepCopy()
+}
+
+func maxQuantity(a, b resource.Quantity) resource.Quantity {
+	if a.Cmp(b) > 0 {
+		return a.DeepCopy()
+	}
+	return b.DeepCopy()
+}
+
+func isZero(list corev1.ResourceList) bool {
+	for _, q := range list {
+		if q.Sign() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func addTo(list, delta corev1.ResourceList) {
+	for resourceName, q := range delta {
+		sum := list[resourceName]
+		sum.Add(q)
+		list[resourceName] = sum
+	}
+}
+
+// subFrom subtracts the quantity from the list, the result is not less than zero.
+func subFrom(list corev1.ResourceList, resourceName corev1.ResourceName, q resource.Quantity) {
+	remaining, ok := list[resourceName]
+	if !ok {
+		return
+	}
+	remaining.Sub(q)
+	if remaining.Sign() < 0 {
+		remaining = resource.Quantity{}
+	}
+	list[resourceName] = remaining
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotafairness
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	schedfake "github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/clientset/versioned/fake"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/generated/informers/externalversions"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestNewRebalanceForQuotaFairnessWithInvalidArgs(t *testing.T) {
+	_, err := NewRebalanceForQuotaFairness(&deschedulerconfig.RebalanceForQuotaFairnessArgs{}, nil)
+	assert.Error(t, err)
+	_, err = NewRebalanceForQuotaFairness(&deschedulerconfig.LowNodeLoadArgs{}, nil)
+	assert.Error(t, err)
+}
+
+func newTestQuota(name, parent string, min, used, runtime, request int64) *v1alpha1.ElasticQuota {
+	cpu := func(value int64) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: *resource.NewQuantity(value, resource.DecimalSI)}
+	}
+	runtimeData, _ := json.Marshal(cpu(runtime))
+	requestData, _ := json.Marshal(cpu(request))
+	return &v1alpha1.ElasticQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				extension.LabelQuotaParent: parent,
+			},
+			Annotations: map[string]string{
+				extension.AnnotationRuntime: string(runtimeData),
+				extension.AnnotationRequest: string(requestData),
+			},
+		},
+		Spec: v1alpha1.ElasticQuotaSpec{
+			Min: cpu(min),
+			Max: cpu(100),
+		},
+		Status: v1alpha1.ElasticQuotaStatus{
+			Used: cpu(used),
+		},
+	}
+}
+
+func TestRebalanceForQuotaFairness(t *testing.T) {
+	node := test.BuildTestNode("n1", 16000, 32000, 20, nil)
+	nodes := []*corev1.Node{node}
+
+	now := time.Now()
+	withQuota := func(quotaName string, priority int32, created time.Time) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Labels = map[string]string{extension.LabelQuotaName: quotaName}
+			pod.Spec.Priority = pointer.Int32(priority)
+			pod.CreationTimestamp = metav1.Time{Time: created}
+		}
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("over-share-big", 5000, 0, "n1", withQuota("over-share", 0, now.Add(-time.Minute))),
+		test.BuildTestPod("over-share-old", 1000, 0, "n1", withQuota("over-share", 0, now.Add(-time.Hour))),
+		test.BuildTestPod("over-share-new", 1000, 0, "n1", withQuota("over-share", 0, now.Add(-2*time.Minute))),
+		test.BuildTestPod("over-share-high", 2000, 0, "n1", withQuota("over-share", 100, now.Add(-time.Minute))),
+		test.BuildTestPod("starved-1", 2000, 0, "n1", withQuota("starved", 0, now.Add(-time.Minute))),
+	}
+
+	tests := []struct {
+		name            string
+		args            *deschedulerconfig.RebalanceForQuotaFairnessArgs
+		quotas          []*v1alpha1.ElasticQuota
+		expectedEvicted []string
+	}{
+		{
+			name: "evict the borrowers of the over-share quota for the starved quota",
+			quotas: []*v1alpha1.ElasticQuota{
+				newTestQuota("over-share", "parent", 2, 8, 4, 8),
+				newTestQuota("starved", "parent", 4, 2, 6, 6),
+			},
+			// over-share-big releases more than the excess, and the lower priority and newer pods are evicted first
+			expectedEvicted: []string{"over-share-new", "over-share-old", "over-share-high"},
+		},
+		{
+			name: "evict only to cover the deficit",
+			quotas: []*v1alpha1.ElasticQuota{
+				newTestQuota("over-share", "parent", 2, 8, 4, 8),
+				newTestQuota("starved", "parent", 4, 4, 6, 5),
+			},
+			expectedEvicted: []string{"over-share-new"},
+		},
+		{
+			name: "respect maxEvictionsPerQuota",
+			args: &deschedulerconfig.RebalanceForQuotaFairnessArgs{
+				Resources:            []corev1.ResourceName{corev1.ResourceCPU},
+				MaxEvictionsPerQuota: 2,
+			},
+			quotas: []*v1alpha1.ElasticQuota{
+				newTestQuota("over-share", "parent", 2, 8, 4, 8),
+				newTestQuota("starved", "parent", 4, 2, 6, 6),
+			},
+			expectedEvicted: []string{"over-share-new", "over-share-old"},
+		},
+		{
+			name: "no starved quota",
+			quotas: []*v1alpha1.ElasticQuota{
+				newTestQuota("over-share", "parent", 2, 8, 4, 8),
+				newTestQuota("starved", "parent", 4, 6, 6, 6),
+			},
+		},
+		{
+			name: "the starved quota is not a sibling",
+			quotas: []*v1alpha1.ElasticQuota{
+				newTestQuota("over-share", "parent", 2, 8, 4, 8),
+				newTestQuota("starved", "other-parent", 4, 2, 6, 6),
+			},
+		},
+		{
+			name: "dry run",
+			args: &deschedulerconfig.RebalanceForQuotaFairnessArgs{
+				DryRun:               true,
+				Resources:            []corev1.ResourceName{corev1.ResourceCPU},
+				MaxEvictionsPerQuota: 5,
+			},
+			quotas: []*v1alpha1.ElasticQuota{
+				newTestQuota("over-share", "parent", 2, 8, 4, 8),
+				newTestQuota("starved", "parent", 4, 2, 6, 6),
+			},
+		},
+		{
+			name: "paused",
+			args: &deschedulerconfig.RebalanceForQuotaFairnessArgs{
+				Paused:               true,
+				Resources:            []corev1.ResourceName{corev1.ResourceCPU},
+				MaxEvictionsPerQuota: 5,
+			},
+			quotas: []*v1alpha1.ElasticQuota{
+				newTestQuota("over-share", "parent", 2, 8, 4, 8),
+				newTestQuota("starved", "parent", 4, 2, 6, 6),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			var quotaObjs []runtime.Object
+			for _, quota := range tt.quotas {
+				quotaObjs = append(quotaObjs, quota)
+			}
+			schedSharedInformerFactory := externalversions.NewSharedInformerFactory(schedfake.NewSimpleClientset(quotaObjs...), 0)
+			quotaInformer := schedSharedInformerFactory.Scheduling().V1alpha1().ElasticQuotas()
+			quotaInformer.Informer()
+			schedSharedInformerFactory.Start(ctx.Done())
+			schedSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+
+			args := tt.args
+			if args == nil {
+				args = &deschedulerconfig.RebalanceForQuotaFairnessArgs{
+					Resources:            []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory},
+					MaxEvictionsPerQuota: 5,
+				}
+			}
+
+			fh, err := frameworktesting.NewPluginTestEnv(ctx, objs, nil,
+				func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+					reg.Register(RebalanceForQuotaFairnessName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+						return newRebalanceForQuotaFairness(args.(*deschedulerconfig.RebalanceForQuotaFairnessArgs), handle, quotaInformer.Lister())
+					})
+					profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: RebalanceForQuotaFairnessName})
+					profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+						Name: RebalanceForQuotaFairnessName,
+						Args: args,
+					})
+				},
+			)
+			assert.NoError(t, err)
+
+			fh.RunBalancePlugins(ctx, nodes)
+			evictedPods := fh.EvictedPods()
+
+			if tt.expectedEvicted == nil {
+				assert.Empty(t, evictedPods)
+				return
+			}
+			sort.Strings(evictedPods)
+			expected := append([]string{}, tt.expectedEvicted...)
+			sort.Strings(expected)
+			assert.Equal(t, expected, evictedPods)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	RemoveFailedReadinessPodsName = "RemoveFailedReadinessPods"
+)
+
+var _ framework.DeschedulePlugin = &RemoveFailedReadinessPods{}
+
+// RemoveFailedReadinessPods evicts running pods whose readiness probe has failed continuously
+// for longer than MinUnreadyDuration, since they hold the capacity of the node without serving.
+type RemoveFailedReadinessPods struct {
+	handle    framework.Handle
+	podFilter framework.FilterFunc
+	args      *deschedulerconfig.RemoveFailedReadinessPodsArgs
+	clock     clock.Clock
+}
+
+// NewRemoveFailedReadinessPods builds plugin from its arguments while passing a handle
+func NewRemoveFailedReadinessPods(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	readinessArgs, ok := args.(*deschedulerconfig.RemoveFailedReadinessPodsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemoveFailedReadinessPodsArgs, got %T", args)
+	}
+	if err := validation.ValidateRemoveFailedReadinessPodsArgs(nil, readinessArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if readinessArgs.Namespaces != nil {
+		excludedNamespaces = sets.NewString(readinessArgs.Namespaces.Exclude...)
+		includedNamespaces = sets.NewString(readinessArgs.Namespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemoveFailedReadinessPods{
+		handle:    handle,
+		podFilter: podFilter,
+		args:      readinessArgs,
+		clock:     clock.RealClock{},
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *RemoveFailedReadinessPods) Name() string {
+	return RemoveFailedReadinessPodsName
+}
+
+// Deschedule extension point implementation for the plugin
+func (pl *RemoveFailedReadinessPods) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("RemoveFailedReadinessPods is paused and will do nothing.")
+		return nil
+	}
+
+	for i, node := range nodes {
+		if framework.ExceedCycleDeadline(ctx, nodes[i:]) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d nodes left unprocessed", RemoveFailedReadinessPodsName, len(nodes)-i)
+			break
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			since, failed := readinessFailedSince(pod)
+			if !failed {
+				continue
+			}
+			unreadyDuration := pl.clock.Since(since)
+			if unreadyDuration < pl.args.MinUnreadyDuration.Duration {
+				continue
+			}
+			klog.V(4).InfoS("Pod has failed readiness for too long", "pod", klog.KObj(pod), "node", klog.KObj(node), "unreadyDuration", unreadyDuration)
+			if !pl.handle.Evictor().PreEvictionFilter(pod) {
+				klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			evictionOptions := framework.EvictOptions{
+				PluginName: RemoveFailedReadinessPodsName,
+				Reason:     fmt.Sprintf("readiness probe has failed for %v", unreadyDuration.Round(time.Second)),
+			}
+			if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		}
+	}
+	return nil
+}
+
+// readinessFailedSince returns since when the readiness probe of the running pod has been failing.
+// The pods in the initialDelaySeconds of their readiness probes are not considered failed yet,
+// so the failure starts counting after the initial delay at the earliest.
+func readinessFailedSince(pod *corev1.Pod) (time.Time, bool) {
+	if pod.Status.Phase != corev1.PodRunning || pod.Status.StartTime == nil {
+		return time.Time{}, false
+	}
+	var readyCondition *corev1.PodCondition
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == corev1.PodReady {
+			readyCondition = &pod.Status.Conditions[i]
+			break
+		}
+	}
+	if readyCondition == nil || readyCondition.Status == corev1.ConditionTrue {
+		return time.Time{}, false
+	}
+
+	containerReady := make(map[string]bool, len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.ContainerStatuses {
+		containerReady[status.Name] = status.Ready
+	}
+	var probeFailed bool
+	var initialDelaySeconds int32
+	for _, container := range pod.Spec.Containers {
+		if container.ReadinessProbe == nil {
+			continue
+		}
+		if container.ReadinessProbe.InitialDelaySeconds > initialDelaySeconds {
+			initialDelaySeconds = container.ReadinessProbe.InitialDelaySeconds
+		}
+		if !containerReady[container.Name] {
+			probeFailed = true
+		}
+	}
+	if !probeFailed {
+		return time.Time{}, false
+	}
+
+	since := readyCondition.LastTransitionTime.Time
+	if startupEnd := pod.Status.StartTime.Add(time.Duration(initialDelaySeconds) * time.Second); startupEnd.After(since) {
+		since = startupEnd
+	}
+	return since, true
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestNewRemoveFailedReadinessPodsWithInvalidArgs(t *testing.T) {
+	_, err := NewRemoveFailedReadinessPods(&deschedulerconfig.RemoveFailedReadinessPodsArgs{}, nil)
+	assert.Error(t, err)
+	_, err = NewRemoveFailedReadinessPods(&deschedulerconfig.LowNodeLoadArgs{}, nil)
+	assert.Error(t, err)
+}
+
+func TestRemoveFailedReadinessPods(t *testing.T) {
+	node := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	nodes := []*corev1.Node{node}
+
+	now := time.Now()
+	withReadiness := func(ready bool, started, lastTransition time.Time, initialDelaySeconds int32) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Spec.Containers[0].Name = "app"
+			pod.Spec.Containers[0].ReadinessProbe = &corev1.Probe{InitialDelaySeconds: initialDelaySeconds}
+			pod.Status.Phase = corev1.PodRunning
+			pod.Status.StartTime = &metav1.Time{Time: started}
+			status := corev1.ConditionFalse
+			if ready {
+				status = corev1.ConditionTrue
+			}
+			pod.Status.Conditions = []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: status, LastTransitionTime: metav1.Time{Time: lastTransition}},
+			}
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app", Ready: ready}}
+		}
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("ready", 100, 0, "n1", withReadiness(true, now.Add(-2*time.Hour), now.Add(-2*time.Hour), 0)),
+		test.BuildTestPod("recently-unready", 100, 0, "n1", withReadiness(false, now.Add(-2*time.Hour), now.Add(-time.Minute), 0)),
+		test.BuildTestPod("long-unready", 100, 0, "n1", withReadiness(false, now.Add(-2*time.Hour), now.Add(-time.Hour), 0)),
+		test.BuildTestPod("long-unready-2", 100, 0, "n1", withReadiness(false, now.Add(-2*time.Hour), now.Add(-time.Hour), 0)),
+		test.BuildTestPod("in-initial-delay", 100, 0, "n1", withReadiness(false, now.Add(-time.Hour), now.Add(-time.Hour), 7200)),
+	}
+
+	tests := []struct {
+		name             string
+		args             *deschedulerconfig.RemoveFailedReadinessPodsArgs
+		maxEvictionTotal *uint
+		expectedEvicted  []string
+		expectedCount    int
+	}{
+		{
+			name: "evict pods unready for longer than minUnreadyDuration",
+			args: &deschedulerconfig.RemoveFailedReadinessPodsArgs{
+				MinUnreadyDuration: metav1.Duration{Duration: 10 * time.Minute},
+			},
+			expectedEvicted: []string{"long-unready", "long-unready-2"},
+			expectedCount:   2,
+		},
+		{
+			name: "evict nothing with a longer minUnreadyDuration",
+			args: &deschedulerconfig.RemoveFailedReadinessPodsArgs{
+				MinUnreadyDuration: metav1.Duration{Duration: 3 * time.Hour},
+			},
+		},
+		{
+			name: "respect the eviction limits",
+			args: &deschedulerconfig.RemoveFailedReadinessPodsArgs{
+				MinUnreadyDuration: metav1.Duration{Duration: 10 * time.Minute},
+			},
+			maxEvictionTotal: func() *uint { v := uint(1); return &v }(),
+			expectedCount:    1,
+		},
+		{
+			name: "respect the namespaces",
+			args: &deschedulerconfig.RemoveFailedReadinessPodsArgs{
+				MinUnreadyDuration: metav1.Duration{Duration: 10 * time.Minute},
+				Namespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"default"},
+				},
+			},
+		},
+		{
+			name: "paused",
+			args: &deschedulerconfig.RemoveFailedReadinessPodsArgs{
+				Paused:             true,
+				MinUnreadyDuration: metav1.Duration{Duration: 10 * time.Minute},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fh, err := frameworktesting.NewPluginTestEnv(ctx, objs, tt.maxEvictionTotal,
+				func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+					reg.Register(RemoveFailedReadinessPodsName, NewRemoveFailedReadinessPods)
+					profile.Plugins.Deschedule.Enabled = append(profile.Plugins.Deschedule.Enabled, deschedulerconfig.Plugin{Name: RemoveFailedReadinessPodsName})
+					profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+						Name: RemoveFailedReadinessPodsName,
+						Args: tt.args,
+					})
+				},
+			)
+			assert.NoError(t, err)
+
+			fh.RunDeschedulePlugins(ctx, nodes)
+			evictedPods := fh.EvictedPods()
+
+			assert.Len(t, evictedPods, tt.expectedCount)
+			if tt.expectedEvicted != nil {
+				sort.Strings(evictedPods)
+				assert.Equal(t, tt.expectedEvicted, evictedPods)
+			}
+		})
+	}
+}
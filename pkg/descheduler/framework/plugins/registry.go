@@ -17,14 +17,34 @@ limitations under the License.
 package plugins
 
 import (
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/gpuidle"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/loadaware"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/missingreferences"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/nodeselector"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/nodetaints"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/outdatedrequests"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/quotafairness"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/readiness"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/requestlimitratio"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/scaledown"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/zonebalance"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
 )
 
 func NewInTreeRegistry() runtime.Registry {
 	registry := runtime.Registry{
-		loadaware.LowNodeLoadName: loadaware.NewLowNodeLoad,
+		loadaware.LowNodeLoadName:                                  loadaware.NewLowNodeLoad,
+		scaledown.DrainScaleDownCandidatesName:                     scaledown.NewDrainScaleDownCandidates,
+		outdatedrequests.RemovePodsWithOutdatedRequestsName:        outdatedrequests.NewRemovePodsWithOutdatedRequests,
+		nodeselector.RemovePodsViolatingNodeSelectorName:           nodeselector.NewRemovePodsViolatingNodeSelector,
+		nodetaints.RemovePodsViolatingNodeTaintEffectsName:         nodetaints.NewRemovePodsViolatingNodeTaintEffects,
+		zonebalance.BalanceAcrossZonesName:                         zonebalance.NewBalanceAcrossZones,
+		readiness.RemoveFailedReadinessPodsName:                    readiness.NewRemoveFailedReadinessPods,
+		missingreferences.RemovePodsWithMissingReferencesName:      missingreferences.NewRemovePodsWithMissingReferences,
+		quotafairness.RebalanceForQuotaFairnessName:                quotafairness.NewRebalanceForQuotaFairness,
+		gpuidle.RemoveIdleGPUPodsName:                              gpuidle.NewRemoveIdleGPUPods,
+		requestlimitratio.RemovePodsViolatingRequestLimitRatioName: requestlimitratio.NewRemovePodsViolatingRequestLimitRatio,
 	}
 	kubernetes.SetupK8sDeschedulerPlugins(registry)
 	return registry
@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestlimitratio
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	RemovePodsViolatingRequestLimitRatioName = "RemovePodsViolatingRequestLimitRatio"
+)
+
+var _ framework.DeschedulePlugin = &RemovePodsViolatingRequestLimitRatio{}
+
+// RemovePodsViolatingRequestLimitRatio evicts pods having a container whose ratio of request to limit falls outside
+// the configured band, so that they are recreated with the specs corrected by the admission mutators.
+type RemovePodsViolatingRequestLimitRatio struct {
+	handle    framework.Handle
+	podFilter framework.FilterFunc
+	args      *deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs
+}
+
+// NewRemovePodsViolatingRequestLimitRatio builds plugin from its arguments while passing a handle
+func NewRemovePodsViolatingRequestLimitRatio(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	ratioArgs, ok := args.(*deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsViolatingRequestLimitRatioArgs, got %T", args)
+	}
+	if err := validation.ValidateRemovePodsViolatingRequestLimitRatioArgs(nil, ratioArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if ratioArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(ratioArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(ratioArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsViolatingRequestLimitRatio{
+		handle:    handle,
+		podFilter: podFilter,
+		args:      ratioArgs,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *RemovePodsViolatingRequestLimitRatio) Name() string {
+	return RemovePodsViolatingRequestLimitRatioName
+}
+
+// Deschedule extension point implementation for the plugin
+func (pl *RemovePodsViolatingRequestLimitRatio) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("RemovePodsViolatingRequestLimitRatio is paused and will do nothing.")
+		return nil
+	}
+
+	for i, node := range nodes {
+		if framework.ExceedCycleDeadline(ctx, nodes[i:]) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d nodes left unprocessed", RemovePodsViolatingRequestLimitRatioName, len(nodes)-i)
+			break
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			violation := pl.findViolation(pod)
+			if violation == "" {
+				continue
+			}
+			klog.V(4).InfoS("Pod violates the request/limit ratio policy", "pod", klog.KObj(pod), "node", klog.KObj(node), "violation", violation)
+			if pl.args.NodeFit && !nodeutil.PodFitsAnyOtherNode(pl.handle.GetPodsAssignedToNodeFunc(), pod, nodes) {
+				klog.V(4).InfoS("Pod aborted eviction because it does not fit any other node", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			if !pl.handle.Evictor().PreEvictionFilter(pod) {
+				klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			evictionOptions := framework.EvictOptions{
+				PluginName: RemovePodsViolatingRequestLimitRatioName,
+				Reason:     violation,
+			}
+			if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		}
+	}
+	return nil
+}
+
+// findViolation returns the description of the first container resource whose request/limit ratio falls outside the band,
+// or empty if the pod complies with the policy. The resources without limit are not checked since the ratio is undefined.
+func (pl *RemovePodsViolatingRequestLimitRatio) findViolation(pod *corev1.Pod) string {
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		for resourceName, limit := range container.Resources.Limits {
+			minRatio, hasMin := pl.args.MinRequestLimitRatios[resourceName]
+			maxRatio, hasMax := pl.args.MaxRequestLimitRatios[resourceName]
+			if !hasMin && !hasMax || limit.IsZero() {
+				continue
+			}
+			// the request defaults to the limit if not specified
+			ratio := deschedulerconfig.Percentage(100)
+			if request, ok := container.Resources.Requests[resourceName]; ok {
+				ratio = deschedulerconfig.Percentage(float64(request.MilliValue()) * 100 / float64(limit.MilliValue()))
+			}
+			if hasMin && ratio < minRatio {
+				return fmt.Sprintf("%s request/limit ratio %.1f%% of container %s is below %.1f%%", resourceName, ratio, container.Name, minRatio)
+			}
+			if hasMax && ratio > maxRatio {
+				return fmt.Sprintf("%s request/limit ratio %.1f%% of container %s is above %.1f%%", resourceName, ratio, container.Name, maxRatio)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestlimitratio
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestNewRemovePodsViolatingRequestLimitRatioWithInvalidArgs(t *testing.T) {
+	_, err := NewRemovePodsViolatingRequestLimitRatio(&deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{}, nil)
+	assert.Error(t, err)
+	_, err = NewRemovePodsViolatingRequestLimitRatio(&deschedulerconfig.LowNodeLoadArgs{}, nil)
+	assert.Error(t, err)
+}
+
+func TestRemovePodsViolatingRequestLimitRatio(t *testing.T) {
+	nodes := []*corev1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+	}
+
+	withLimits := func(cpu, memory int64) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			if cpu > 0 {
+				pod.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU] = *resource.NewMilliQuantity(cpu, resource.DecimalSI)
+			}
+			if memory > 0 {
+				pod.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory] = *resource.NewQuantity(memory, resource.DecimalSI)
+			}
+		}
+	}
+	pods := []*corev1.Pod{
+		test.BuildTestPod("compliant", 100, 1000, "n1", withLimits(0, 1500)),
+		test.BuildTestPod("low-ratio", 100, 200, "n1", withLimits(0, 1000)),
+		test.BuildTestPod("without-limit", 100, 200, "n1", withLimits(0, 0)),
+		test.BuildTestPod("high-ratio", 100, 200, "n1", withLimits(100, 0)),
+		test.BuildTestPod("low-ratio-unschedulable", 100, 200, "n1", func(pod *corev1.Pod) {
+			withLimits(0, 1000)(pod)
+			pod.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+		}),
+	}
+
+	tests := []struct {
+		name             string
+		args             *deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs
+		maxEvictionTotal *uint
+		expectedEvicted  []string
+		expectedCount    int
+	}{
+		{
+			name: "evict pods below the min ratio and which fit other nodes",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				NodeFit:               true,
+				MinRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 50},
+			},
+			expectedEvicted: []string{"low-ratio"},
+			expectedCount:   1,
+		},
+		{
+			name: "evict all pods below the min ratio without nodeFit",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				MinRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 50},
+			},
+			expectedEvicted: []string{"low-ratio", "low-ratio-unschedulable"},
+			expectedCount:   2,
+		},
+		{
+			name: "evict pods above the max ratio",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				MaxRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceCPU: 80},
+			},
+			expectedEvicted: []string{"high-ratio"},
+			expectedCount:   1,
+		},
+		{
+			name: "respect the eviction limits",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				MinRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 50},
+			},
+			maxEvictionTotal: func() *uint { v := uint(1); return &v }(),
+			expectedCount:    1,
+		},
+		{
+			name: "respect the evictable namespaces",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				MinRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 50},
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"default"},
+				},
+			},
+		},
+		{
+			name: "paused",
+			args: &deschedulerconfig.RemovePodsViolatingRequestLimitRatioArgs{
+				Paused:                true,
+				MinRequestLimitRatios: deschedulerconfig.ResourceThresholds{corev1.ResourceMemory: 50},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fh, err := frameworktesting.NewPluginTestEnv(ctx, objs, tt.maxEvictionTotal,
+				func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+					reg.Register(RemovePodsViolatingRequestLimitRatioName, NewRemovePodsViolatingRequestLimitRatio)
+					profile.Plugins.Deschedule.Enabled = append(profile.Plugins.Deschedule.Enabled, deschedulerconfig.Plugin{Name: RemovePodsViolatingRequestLimitRatioName})
+					profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+						Name: RemovePodsViolatingRequestLimitRatioName,
+						Args: tt.args,
+					})
+				},
+			)
+			assert.NoError(t, err)
+
+			fh.RunDeschedulePlugins(ctx, nodes)
+			evictedPods := fh.EvictedPods()
+
+			assert.Len(t, evictedPods, tt.expectedCount)
+			if tt.expectedEvicted != nil {
+				sort.Strings(evictedPods)
+				assert.Equal(t, tt.expectedEvicted, evictedPods)
+			}
+		})
+	}
+}
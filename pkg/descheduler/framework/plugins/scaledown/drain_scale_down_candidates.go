@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaledown
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	DrainScaleDownCandidatesName = "DrainScaleDownCandidates"
+)
+
+var _ framework.DeschedulePlugin = &DrainScaleDownCandidates{}
+
+// DrainScaleDownCandidates evicts pods from the nodes marked by the autoscaler to be removed,
+// so that the nodes can be consolidated sooner.
+// The nodes whose kubelet stopped heartbeating for longer than StaleHeartbeatThreshold are drained as well,
+// so that their pods are rescheduled elsewhere before the nodes turn NotReady.
+type DrainScaleDownCandidates struct {
+	handle     framework.Handle
+	podFilter  framework.FilterFunc
+	args       *deschedulerconfig.DrainScaleDownCandidatesArgs
+	markerKeys sets.String
+	clock      clock.Clock
+}
+
+// NewDrainScaleDownCandidates builds plugin from its arguments while passing a handle
+func NewDrainScaleDownCandidates(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	drainArgs, ok := args.(*deschedulerconfig.DrainScaleDownCandidatesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type DrainScaleDownCandidatesArgs, got %T", args)
+	}
+	if err := validation.ValidateDrainScaleDownCandidatesArgs(nil, drainArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if drainArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(drainArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(drainArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &DrainScaleDownCandidates{
+		handle:     handle,
+		podFilter:  podFilter,
+		args:       drainArgs,
+		markerKeys: sets.NewString(drainArgs.MarkerKeys...),
+		clock:      clock.RealClock{},
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *DrainScaleDownCandidates) Name() string {
+	return DrainScaleDownCandidatesName
+}
+
+// Deschedule extension point implementation for the plugin
+func (pl *DrainScaleDownCandidates) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("DrainScaleDownCandidates is paused and will do nothing.")
+		return nil
+	}
+
+	for i, node := range nodes {
+		if framework.ExceedCycleDeadline(ctx, nodes[i:]) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d nodes left unprocessed", DrainScaleDownCandidatesName, len(nodes)-i)
+			break
+		}
+		reason, ok := pl.getDrainReason(node)
+		if !ok {
+			continue
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pods on scale-down candidate node", "node", klog.KObj(node))
+			continue
+		}
+		klog.V(4).InfoS("Draining scale-down candidate node", "node", klog.KObj(node), "reason", reason, "pods", len(pods))
+		for _, pod := range pods {
+			if !pl.handle.Evictor().PreEvictionFilter(pod) {
+				klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			evictionOptions := framework.EvictOptions{
+				PluginName: DrainScaleDownCandidatesName,
+				Reason:     reason,
+			}
+			if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		}
+	}
+	return nil
+}
+
+// getDrainReason returns the reason to drain the node if it is marked for scale-down or its heartbeat is stale.
+func (pl *DrainScaleDownCandidates) getDrainReason(node *corev1.Node) (string, bool) {
+	if markerKey, ok := pl.getScaleDownMarker(node); ok {
+		return fmt.Sprintf("node is marked with %s for scale-down", markerKey), true
+	}
+	if pl.args.StaleHeartbeatThreshold != nil {
+		if staleDuration, ok := pl.getStaleHeartbeatDuration(node); ok {
+			return fmt.Sprintf("node has not heartbeated for %v", staleDuration.Round(time.Second)), true
+		}
+	}
+	return "", false
+}
+
+// getStaleHeartbeatDuration returns how long the kubelet has not heartbeated if it is longer than the threshold.
+// The heartbeat is read from the Ready condition, and a node without any heartbeat recorded is not considered stale.
+func (pl *DrainScaleDownCandidates) getStaleHeartbeatDuration(node *corev1.Node) (time.Duration, bool) {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != corev1.NodeReady || condition.LastHeartbeatTime.IsZero() {
+			continue
+		}
+		staleDuration := pl.clock.Since(condition.LastHeartbeatTime.Time)
+		return staleDuration, staleDuration > pl.args.StaleHeartbeatThreshold.Duration
+	}
+	return 0, false
+}
+
+// getScaleDownMarker returns the first marker key found in the taints or annotations of the node.
+func (pl *DrainScaleDownCandidates) getScaleDownMarker(node *corev1.Node) (string, bool) {
+	for _, taint := range node.Spec.Taints {
+		if pl.markerKeys.Has(taint.Key) {
+			return taint.Key, true
+		}
+	}
+	for key := range node.Annotations {
+		if pl.markerKeys.Has(key) {
+			return key, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaledown
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestNewDrainScaleDownCandidatesWithInvalidArgs(t *testing.T) {
+	_, err := NewDrainScaleDownCandidates(&deschedulerconfig.DrainScaleDownCandidatesArgs{}, nil)
+	assert.Error(t, err)
+	_, err = NewDrainScaleDownCandidates(&deschedulerconfig.LowNodeLoadArgs{}, nil)
+	assert.Error(t, err)
+}
+
+func TestDrainScaleDownCandidates(t *testing.T) {
+	taintedNode := test.BuildTestNode("tainted", 4000, 3000, 10, func(node *corev1.Node) {
+		node.Spec.Taints = []corev1.Taint{
+			{Key: "ToBeDeletedByClusterAutoscaler", Value: "1700000000", Effect: corev1.TaintEffectNoSchedule},
+		}
+	})
+	annotatedNode := test.BuildTestNode("annotated", 4000, 3000, 10, func(node *corev1.Node) {
+		node.Annotations = map[string]string{"example.com/scale-down": "true"}
+	})
+	normalNode := test.BuildTestNode("normal", 4000, 3000, 10, nil)
+	withHeartbeat := func(heartbeat time.Time) func(node *corev1.Node) {
+		return func(node *corev1.Node) {
+			node.Status.Conditions[0].LastHeartbeatTime = metav1.Time{Time: heartbeat}
+		}
+	}
+	freshNode := test.BuildTestNode("fresh", 4000, 3000, 10, withHeartbeat(time.Now().Add(-10*time.Second)))
+	staleNode := test.BuildTestNode("stale", 4000, 3000, 10, withHeartbeat(time.Now().Add(-10*time.Minute)))
+	nodes := []*corev1.Node{taintedNode, annotatedNode, normalNode, freshNode, staleNode}
+
+	criticalPriority := int32(2000000000)
+	pods := []*corev1.Pod{
+		test.BuildTestPod("tainted-rs", 100, 0, "tainted", test.SetRSOwnerRef),
+		test.BuildTestPod("tainted-ss", 100, 0, "tainted", test.SetSSOwnerRef),
+		test.BuildTestPod("tainted-ds", 100, 0, "tainted", test.SetDSOwnerRef),
+		test.BuildTestPod("tainted-bare", 100, 0, "tainted", nil),
+		test.BuildTestPod("tainted-mirror", 100, 0, "tainted", func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Annotations = test.GetMirrorPodAnnotation()
+		}),
+		test.BuildTestPod("tainted-critical", 100, 0, "tainted", func(pod *corev1.Pod) {
+			test.SetRSOwnerRef(pod)
+			test.SetPodPriority(pod, criticalPriority)
+		}),
+		test.BuildTestPod("annotated-rs", 100, 0, "annotated", test.SetRSOwnerRef),
+		test.BuildTestPod("normal-rs", 100, 0, "normal", test.SetRSOwnerRef),
+		test.BuildTestPod("fresh-rs", 100, 0, "fresh", test.SetRSOwnerRef),
+		test.BuildTestPod("stale-rs", 100, 0, "stale", test.SetRSOwnerRef),
+		test.BuildTestPod("stale-ds", 100, 0, "stale", test.SetDSOwnerRef),
+	}
+
+	tests := []struct {
+		name             string
+		args             *deschedulerconfig.DrainScaleDownCandidatesArgs
+		maxEvictionTotal *uint
+		expectedEvicted  []string
+		expectedCount    int
+	}{
+		{
+			name: "drain nodes marked with the taint",
+			args: &deschedulerconfig.DrainScaleDownCandidatesArgs{
+				MarkerKeys: []string{"ToBeDeletedByClusterAutoscaler", "DeletionCandidateOfClusterAutoscaler"},
+			},
+			expectedEvicted: []string{"tainted-rs", "tainted-ss"},
+			expectedCount:   2,
+		},
+		{
+			name: "drain nodes marked with the configured annotation",
+			args: &deschedulerconfig.DrainScaleDownCandidatesArgs{
+				MarkerKeys: []string{"example.com/scale-down"},
+			},
+			expectedEvicted: []string{"annotated-rs"},
+			expectedCount:   1,
+		},
+		{
+			name: "drain nodes with stale heartbeats",
+			args: &deschedulerconfig.DrainScaleDownCandidatesArgs{
+				MarkerKeys:              []string{"example.com/scale-down"},
+				StaleHeartbeatThreshold: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			expectedEvicted: []string{"annotated-rs", "stale-rs"},
+			expectedCount:   2,
+		},
+		{
+			name: "do not drain nodes with stale heartbeats if the threshold is not set",
+			args: &deschedulerconfig.DrainScaleDownCandidatesArgs{
+				MarkerKeys: []string{"DeletionCandidateOfClusterAutoscaler"},
+			},
+		},
+		{
+			name: "respect the eviction limits",
+			args: &deschedulerconfig.DrainScaleDownCandidatesArgs{
+				MarkerKeys: []string{"ToBeDeletedByClusterAutoscaler", "example.com/scale-down"},
+			},
+			maxEvictionTotal: func() *uint { v := uint(1); return &v }(),
+			expectedCount:    1,
+		},
+		{
+			name: "respect the evictable namespaces",
+			args: &deschedulerconfig.DrainScaleDownCandidatesArgs{
+				MarkerKeys: []string{"ToBeDeletedByClusterAutoscaler"},
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"default"},
+				},
+			},
+		},
+		{
+			name: "paused",
+			args: &deschedulerconfig.DrainScaleDownCandidatesArgs{
+				Paused:     true,
+				MarkerKeys: []string{"ToBeDeletedByClusterAutoscaler"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fh, err := frameworktesting.NewPluginTestEnv(ctx, objs, tt.maxEvictionTotal,
+				func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+					reg.Register(DrainScaleDownCandidatesName, NewDrainScaleDownCandidates)
+					profile.Plugins.Deschedule.Enabled = append(profile.Plugins.Deschedule.Enabled, deschedulerconfig.Plugin{Name: DrainScaleDownCandidatesName})
+					profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+						Name: DrainScaleDownCandidatesName,
+						Args: tt.args,
+					})
+				},
+			)
+			assert.NoError(t, err)
+
+			fh.RunDeschedulePlugins(ctx, nodes)
+			evictedPods := fh.EvictedPods()
+
+			assert.Len(t, evictedPods, tt.expectedCount)
+			if tt.expectedEvicted != nil {
+				sort.Strings(evictedPods)
+				assert.Equal(t, tt.expectedEvicted, evictedPods)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zonebalance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	BalanceAcrossZonesName = "BalanceAcrossZones"
+)
+
+var _ framework.BalancePlugin = &BalanceAcrossZones{}
+
+// BalanceAcrossZones evicts pods of a workload from the zones holding more of its pods than the others,
+// so that the pods of the workload are spread evenly across the zones after they are rescheduled.
+type BalanceAcrossZones struct {
+	handle    framework.Handle
+	podFilter framework.FilterFunc
+	args      *deschedulerconfig.BalanceAcrossZonesArgs
+}
+
+// NewBalanceAcrossZones builds plugin from its arguments while passing a handle
+func NewBalanceAcrossZones(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	zoneArgs, ok := args.(*deschedulerconfig.BalanceAcrossZonesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type BalanceAcrossZonesArgs, got %T", args)
+	}
+	if err := validation.ValidateBalanceAcrossZonesArgs(nil, zoneArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if zoneArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(zoneArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(zoneArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &BalanceAcrossZones{
+		handle:    handle,
+		podFilter: podFilter,
+		args:      zoneArgs,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *BalanceAcrossZones) Name() string {
+	return BalanceAcrossZonesName
+}
+
+// workloadZonePods holds the pods of a workload grouped by zone.
+type workloadZonePods map[string][]*corev1.Pod
+
+// Balance extension point implementation for the plugin
+func (pl *BalanceAcrossZones) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("BalanceAcrossZones is paused and will do nothing.")
+		return nil
+	}
+
+	zoneNodes := map[string][]*corev1.Node{}
+	var zonedNodes []*corev1.Node
+	for _, node := range nodes {
+		zone, ok := node.Labels[pl.args.ZoneLabelKey]
+		if !ok || zone == "" {
+			continue
+		}
+		zoneNodes[zone] = append(zoneNodes[zone], node)
+		zonedNodes = append(zonedNodes, node)
+	}
+	if len(zoneNodes) < 2 {
+		klog.V(4).InfoS("Skip balancing because the nodes span less than two zones", "zoneLabelKey", pl.args.ZoneLabelKey, "zones", len(zoneNodes))
+		return nil
+	}
+
+	workloads := map[types.UID]workloadZonePods{}
+	for zone, nodesInZone := range zoneNodes {
+		for _, node := range nodesInZone {
+			pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+			if err != nil {
+				klog.ErrorS(err, "Failed to list pods on node", "node", klog.KObj(node))
+				continue
+			}
+			for _, pod := range pods {
+				ownerRef := metav1.GetControllerOf(pod)
+				if ownerRef == nil {
+					continue
+				}
+				if workloads[ownerRef.UID] == nil {
+					workloads[ownerRef.UID] = workloadZonePods{}
+				}
+				workloads[ownerRef.UID][zone] = append(workloads[ownerRef.UID][zone], pod)
+			}
+		}
+	}
+
+	workloadUIDs := make([]types.UID, 0, len(workloads))
+	for uid := range workloads {
+		workloadUIDs = append(workloadUIDs, uid)
+	}
+	sort.Slice(workloadUIDs, func(i, j int) bool {
+		return workloadUIDs[i] < workloadUIDs[j]
+	})
+	for i, uid := range workloadUIDs {
+		// the remaining workloads may have pods on any node of the zones
+		if framework.ExceedCycleDeadline(ctx, zonedNodes) {
+			klog.Infof("%s stops as the descheduling cycle exceeded its max duration, %d workloads left unprocessed", BalanceAcrossZonesName, len(workloadUIDs)-i)
+			break
+		}
+		pl.balanceWorkload(ctx, uid, workloads[uid], zoneNodes)
+	}
+	return nil
+}
+
+// balanceWorkload evicts pods of the workload from the most populated zone until the difference between the most
+// and the least populated zones is within MaxSkew, assuming each evicted pod is rescheduled to the least populated zone.
+func (pl *BalanceAcrossZones) balanceWorkload(ctx context.Context, uid types.UID, zonePods workloadZonePods, zoneNodes map[string][]*corev1.Node) {
+	zones := make([]string, 0, len(zoneNodes))
+	counts := map[string]int{}
+	candidates := map[string][]*corev1.Pod{}
+	for zone := range zoneNodes {
+		zones = append(zones, zone)
+		counts[zone] = len(zonePods[zone])
+		candidates[zone] = append([]*corev1.Pod{}, zonePods[zone]...)
+	}
+	sort.Strings(zones)
+
+	for {
+		// the most populated zone is only chosen among the zones which still have pods to evict
+		maxZone, minZone := "", zones[0]
+		for _, zone := range zones {
+			if len(candidates[zone]) > 0 && (maxZone == "" || counts[zone] > counts[maxZone]) {
+				maxZone = zone
+			}
+			if counts[zone] < counts[minZone] {
+				minZone = zone
+			}
+		}
+		if maxZone == "" || counts[maxZone]-counts[minZone] <= int(pl.args.MaxSkew) {
+			return
+		}
+
+		pod := candidates[maxZone][len(candidates[maxZone])-1]
+		candidates[maxZone] = candidates[maxZone][:len(candidates[maxZone])-1]
+
+		if pl.args.NodeFit && !nodeutil.PodFitsAnyNode(pl.handle.GetPodsAssignedToNodeFunc(), pod, zoneNodes[minZone]) {
+			klog.V(4).InfoS("Pod aborted eviction because it does not fit any node in the least populated zone", "pod", klog.KObj(pod), "zone", minZone)
+			continue
+		}
+		if !pl.handle.Evictor().PreEvictionFilter(pod) {
+			klog.V(4).InfoS("Pod aborted eviction because it was filtered by PreEvictionFilter", "pod", klog.KObj(pod))
+			continue
+		}
+		evictionOptions := framework.EvictOptions{
+			PluginName: BalanceAcrossZonesName,
+			Reason:     fmt.Sprintf("zone %s has %d pods of the workload while zone %s has %d", maxZone, counts[maxZone], minZone, counts[minZone]),
+		}
+		if !pl.handle.Evictor().Evict(ctx, pod, evictionOptions) {
+			klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "workload", uid)
+			continue
+		}
+		klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "workload", uid, "zone", maxZone)
+		counts[maxZone]--
+		counts[minZone]++
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zonebalance
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestNewBalanceAcrossZonesWithInvalidArgs(t *testing.T) {
+	_, err := NewBalanceAcrossZones(&deschedulerconfig.BalanceAcrossZonesArgs{}, nil)
+	assert.Error(t, err)
+	_, err = NewBalanceAcrossZones(&deschedulerconfig.LowNodeLoadArgs{}, nil)
+	assert.Error(t, err)
+}
+
+func TestBalanceAcrossZones(t *testing.T) {
+	withZone := func(zone string) func(node *corev1.Node) {
+		return func(node *corev1.Node) {
+			node.Labels = map[string]string{corev1.LabelTopologyZone: zone}
+		}
+	}
+	unschedulable := func(node *corev1.Node) {
+		node.Spec.Unschedulable = true
+	}
+	zoneANode := test.BuildTestNode("zone-a-node", 4000, 3000, 10, withZone("zone-a"))
+	zoneBNode := test.BuildTestNode("zone-b-node", 4000, 3000, 10, withZone("zone-b"))
+	zoneCNode := test.BuildTestNode("zone-c-node", 4000, 3000, 10, withZone("zone-c"))
+	unschedulableZoneCNode := test.BuildTestNode("zone-c-node", 4000, 3000, 10, func(node *corev1.Node) {
+		withZone("zone-c")(node)
+		unschedulable(node)
+	})
+	noZoneNode := test.BuildTestNode("no-zone-node", 4000, 3000, 10, nil)
+
+	withOwner := func(name string, uid types.UID) func(pod *corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{
+				{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: name, UID: uid, Controller: func() *bool { v := true; return &v }()},
+			}
+		}
+	}
+	skewedOwner := withOwner("skewed", "skewed-uid")
+	balancedOwner := withOwner("balanced", "balanced-uid")
+	pods := []*corev1.Pod{
+		// the skewed workload has 5 pods in zone-a, 1 pod in zone-b and no pod in zone-c
+		test.BuildTestPod("skewed-a-1", 100, 0, "zone-a-node", skewedOwner),
+		test.BuildTestPod("skewed-a-2", 100, 0, "zone-a-node", skewedOwner),
+		test.BuildTestPod("skewed-a-3", 100, 0, "zone-a-node", skewedOwner),
+		test.BuildTestPod("skewed-a-4", 100, 0, "zone-a-node", skewedOwner),
+		test.BuildTestPod("skewed-a-5", 100, 0, "zone-a-node", skewedOwner),
+		test.BuildTestPod("skewed-b-1", 100, 0, "zone-b-node", skewedOwner),
+		// the balanced workload has 1 pod in each zone
+		test.BuildTestPod("balanced-a-1", 100, 0, "zone-a-node", balancedOwner),
+		test.BuildTestPod("balanced-b-1", 100, 0, "zone-b-node", balancedOwner),
+		test.BuildTestPod("balanced-c-1", 100, 0, "zone-c-node", balancedOwner),
+		// pods without a controller and pods on the nodes without zone are ignored
+		test.BuildTestPod("bare-a-1", 100, 0, "zone-a-node", nil),
+		test.BuildTestPod("no-zone-1", 100, 0, "no-zone-node", skewedOwner),
+		test.BuildTestPod("no-zone-2", 100, 0, "no-zone-node", skewedOwner),
+	}
+
+	tests := []struct {
+		name             string
+		args             *deschedulerconfig.BalanceAcrossZonesArgs
+		nodes            []*corev1.Node
+		maxEvictionTotal *uint
+		expectedCount    int
+	}{
+		{
+			name: "evict pods from the over-represented zone",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				ZoneLabelKey: corev1.LabelTopologyZone,
+				MaxSkew:      1,
+				NodeFit:      true,
+			},
+			nodes:         []*corev1.Node{zoneANode, zoneBNode, zoneCNode, noZoneNode},
+			expectedCount: 3,
+		},
+		{
+			name: "tolerate the configured maxSkew",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				ZoneLabelKey: corev1.LabelTopologyZone,
+				MaxSkew:      2,
+				NodeFit:      true,
+			},
+			nodes:         []*corev1.Node{zoneANode, zoneBNode, zoneCNode, noZoneNode},
+			expectedCount: 2,
+		},
+		{
+			name: "respect the eviction limits",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				ZoneLabelKey: corev1.LabelTopologyZone,
+				MaxSkew:      1,
+				NodeFit:      true,
+			},
+			nodes:            []*corev1.Node{zoneANode, zoneBNode, zoneCNode, noZoneNode},
+			maxEvictionTotal: func() *uint { v := uint(1); return &v }(),
+			expectedCount:    1,
+		},
+		{
+			name: "skip eviction if the pods do not fit the least populated zone",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				ZoneLabelKey: corev1.LabelTopologyZone,
+				MaxSkew:      1,
+				NodeFit:      true,
+			},
+			nodes: []*corev1.Node{zoneANode, zoneBNode, unschedulableZoneCNode, noZoneNode},
+		},
+		{
+			name: "skip if the nodes span only one zone",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				ZoneLabelKey: corev1.LabelTopologyZone,
+				MaxSkew:      1,
+			},
+			nodes: []*corev1.Node{zoneANode, noZoneNode},
+		},
+		{
+			name: "respect the evictable namespaces",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				ZoneLabelKey: corev1.LabelTopologyZone,
+				MaxSkew:      1,
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"default"},
+				},
+			},
+			nodes: []*corev1.Node{zoneANode, zoneBNode, zoneCNode, noZoneNode},
+		},
+		{
+			name: "paused",
+			args: &deschedulerconfig.BalanceAcrossZonesArgs{
+				Paused:       true,
+				ZoneLabelKey: corev1.LabelTopologyZone,
+				MaxSkew:      1,
+			},
+			nodes: []*corev1.Node{zoneANode, zoneBNode, zoneCNode, noZoneNode},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tt.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fh, err := frameworktesting.NewPluginTestEnv(ctx, objs, tt.maxEvictionTotal,
+				func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+					reg.Register(BalanceAcrossZonesName, NewBalanceAcrossZones)
+					profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: BalanceAcrossZonesName})
+					profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+						Name: BalanceAcrossZonesName,
+						Args: tt.args,
+					})
+				},
+			)
+			assert.NoError(t, err)
+
+			fh.RunBalancePlugins(ctx, tt.nodes)
+			evictedPods := fh.EvictedPods()
+
+			assert.Len(t, evictedPods, tt.expectedCount)
+			for _, name := range evictedPods {
+				assert.True(t, strings.HasPrefix(name, "skewed-a-"), "unexpected evicted pod %s", name)
+			}
+		})
+	}
+}
@@ -19,10 +19,12 @@ package runtime
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
 )
 
 type EvictionLimiter interface {
@@ -33,6 +35,36 @@ type EvictionLimiter interface {
 	TotalEvicted() uint
 }
 
+// EvictionClaimer is optionally implemented by the EvictionLimiter shared by the profiles. A pod selected by
+// multiple profiles in a descheduling cycle is evicted once and attributed to the profile claiming it first,
+// which is the first one in the config order when the profiles run one at a time, as they are launched in order.
+type EvictionClaimer interface {
+	// Claim returns the profile which has claimed the pod, and whether the pod is claimed by the given profile.
+	Claim(pod *corev1.Pod, profileName string) (string, bool)
+	// Release gives up the claim of the profile on the pod which failed to be evicted.
+	Release(pod *corev1.Pod, profileName string)
+}
+
+// EvictionReserver is optionally implemented by the EvictionLimiter to check the eviction limits and count
+// the pod against them at once, so that the profiles running concurrently cannot exceed the limits.
+type EvictionReserver interface {
+	// Reserve counts the pod against the limits and returns true if no limit is reached.
+	Reserve(pod *corev1.Pod, ignoreNodeLimit bool) bool
+	// Unreserve gives back the counts reserved for the pod which failed to be evicted.
+	Unreserve(pod *corev1.Pod)
+	// Confirm completes the eviction of the reserved pod.
+	Confirm(pod *corev1.Pod)
+}
+
+// NodeLimitIgnorer is optionally implemented by the EvictionLimiter to check the eviction limits except
+// the limit of evicted pods per node, which is requested by EvictOptions.IgnoreNodeLimit.
+type NodeLimitIgnorer interface {
+	AllowEvictIgnoringNodeLimit(pod *corev1.Pod) bool
+}
+
+// EvictionEventReason is the reason of the Events emitted on the evicted pods.
+const EvictionEventReason = "Descheduled"
+
 var _ EvictionLimiter = &evictorProxy{}
 var _ framework.Evictor = &evictorProxy{}
 
@@ -62,6 +94,21 @@ func (e *evictorProxy) AllowEvict(pod *corev1.Pod) bool {
 	return true
 }
 
+func (e *evictorProxy) allowEvict(pod *corev1.Pod, ignoreNodeLimit bool) bool {
+	if ignorer, ok := e.evictionLimiter.(NodeLimitIgnorer); ok && ignoreNodeLimit {
+		return ignorer.AllowEvictIgnoringNodeLimit(pod)
+	}
+	return e.AllowEvict(pod)
+}
+
+// reserve reserves the eviction of the pod if the limiter supports it, otherwise it only checks the limits.
+func (e *evictorProxy) reserve(reserver EvictionReserver, pod *corev1.Pod, ignoreNodeLimit bool) bool {
+	if reserver != nil {
+		return reserver.Reserve(pod, ignoreNodeLimit)
+	}
+	return e.allowEvict(pod, ignoreNodeLimit)
+}
+
 func (e *evictorProxy) Done(pod *corev1.Pod) {
 	if e.evictionLimiter != nil {
 		e.evictionLimiter.Done(pod)
@@ -77,6 +124,10 @@ func (e *evictorProxy) TotalEvicted() uint {
 
 // Filter checks if a pod can be evicted
 func (e *evictorProxy) Filter(pod *corev1.Pod) bool {
+	if !e.handle.namespaceSelected(pod.Namespace) || e.handle.orphanPodSkipped(pod) || e.handle.terminatingPodSkipped(pod) ||
+		e.handle.criticalPodSkipped(pod) {
+		return false
+	}
 	for _, v := range e.handle.filterPlugins {
 		if !v.Filter(pod) {
 			return false
@@ -86,6 +137,10 @@ func (e *evictorProxy) Filter(pod *corev1.Pod) bool {
 }
 
 func (e *evictorProxy) PreEvictionFilter(pod *corev1.Pod) bool {
+	if !e.handle.namespaceSelected(pod.Namespace) || e.handle.orphanPodSkipped(pod) || e.handle.terminatingPodSkipped(pod) ||
+		e.handle.criticalPodSkipped(pod) {
+		return false
+	}
 	for _, v := range e.handle.filterPlugins {
 		if !v.PreEvictionFilter(pod) {
 			return false
@@ -99,17 +154,81 @@ func (e *evictorProxy) Evict(ctx context.Context, pod *corev1.Pod, opts framewor
 	if len(e.handle.evictPlugins) == 0 {
 		panic("No Evictor plugin is registered in the frameworkImpl.")
 	}
-	if !e.AllowEvict(pod) {
+	dryRun := e.dryRun || framework.IsDryRun(ctx)
+	ctx, span := startSpan(ctx, "Evict",
+		attribute.String("pod", klog.KObj(pod).String()),
+		attribute.String("node", pod.Spec.NodeName),
+		attribute.String("plugin", opts.PluginName),
+		attribute.String("reason", opts.Reason),
+		attribute.Bool("dryRun", dryRun))
+	defer span.End()
+
+	// the terminating pods are going away anyway, evicting them again must not count against the limits
+	if e.handle.terminatingPodSkipped(pod) {
+		klog.V(4).InfoS("Pod is terminating, skip evicting it", "pod", klog.KObj(pod), "strategy", opts.PluginName)
+		span.SetAttributes(attribute.Bool("evicted", false))
+		return false
+	}
+	if e.handle.criticalPodSkipped(pod) {
+		klog.V(4).InfoS("Pod has a system critical priority class, skip evicting it", "pod", klog.KObj(pod), "strategy", opts.PluginName)
+		span.SetAttributes(attribute.Bool("evicted", false))
+		return false
+	}
+	claimer, _ := e.evictionLimiter.(EvictionClaimer)
+	if claimer != nil {
+		if claimedBy, claimed := claimer.Claim(pod, e.handle.profileName); !claimed {
+			klog.V(4).InfoS("Pod is already evicted by another profile in this cycle, skip evicting it",
+				"pod", klog.KObj(pod), "profile", e.handle.profileName, "claimedBy", claimedBy, "strategy", opts.PluginName)
+			metrics.ProfileEvictionCollisions.WithLabelValues(e.handle.profileName, claimedBy).Inc()
+			span.SetAttributes(attribute.Bool("evicted", false))
+			return false
+		}
+	}
+	reserver, _ := e.evictionLimiter.(EvictionReserver)
+	if !e.reserve(reserver, pod, opts.IgnoreNodeLimit) {
+		if claimer != nil {
+			claimer.Release(pod, e.handle.profileName)
+		}
+		span.SetAttributes(attribute.Bool("evicted", false))
 		return false
 	}
-	if e.dryRun {
+	if dryRun {
 		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.PluginName, "node", pod.Spec.NodeName)
 	} else {
 		succeeded := e.handle.evictPlugins[0].Evict(ctx, pod, opts)
 		if !succeeded {
+			if reserver != nil {
+				reserver.Unreserve(pod)
+			}
+			if claimer != nil {
+				claimer.Release(pod, e.handle.profileName)
+			}
+			span.SetAttributes(attribute.Bool("evicted", false))
 			return false
 		}
 	}
-	e.Done(pod)
+	if !dryRun {
+		e.recordEvictionEvent(pod, opts)
+	}
+	if reserver != nil {
+		reserver.Confirm(pod)
+	} else {
+		e.Done(pod)
+	}
+	span.SetAttributes(attribute.Bool("evicted", true))
 	return true
 }
+
+// recordEvictionEvent emits an Event on the evicted pod if enabled, so that the eviction can be observed
+// by kubectl describe and the event-driven tooling. The events exceeding the rate limit are dropped.
+func (e *evictorProxy) recordEvictionEvent(pod *corev1.Pod, opts framework.EvictOptions) {
+	if e.handle.evictionEventLimiter == nil || e.handle.eventRecorder == nil {
+		return
+	}
+	if !e.handle.evictionEventLimiter.TryAccept() {
+		klog.V(4).InfoS("Dropped the eviction event since the rate limit is exceeded", "pod", klog.KObj(pod), "strategy", opts.PluginName)
+		return
+	}
+	e.handle.eventRecorder.Eventf(pod, nil, corev1.EventTypeNormal, EvictionEventReason, "Evicting",
+		"Evicted from node %q by plugin %s of profile %s: %s", pod.Spec.NodeName, opts.PluginName, e.handle.profileName, opts.Reason)
+}
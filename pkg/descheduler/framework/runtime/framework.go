@@ -21,9 +21,13 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -31,16 +35,24 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
 
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
 )
 
 type frameworkImpl struct {
+	profileName               string
 	dryRun                    bool
+	evictOrphanPods           bool
+	countTerminatingPods      bool
+	evictCriticalPods         bool
 	clientSet                 clientset.Interface
 	kubeConfig                *restclient.Config
 	eventRecorder             events.EventRecorder
+	evictionEventLimiter      flowcontrol.RateLimiter
 	evictionLimiter           EvictionLimiter
 	sharedInformerFactory     informers.SharedInformerFactory
 	getPodsAssignedToNodeFunc framework.GetPodsAssignedToNodeFunc
@@ -49,6 +61,11 @@ type frameworkImpl struct {
 	evictPlugins              []framework.EvictPlugin
 	filterPlugins             []framework.FilterPlugin
 	nodeSelector              *metav1.LabelSelector
+	namespaceSelector         labels.Selector
+
+	namespacesLock sync.RWMutex
+	// namespaces is the set of namespaces matching the namespaceSelector, resolved in every descheduling cycle.
+	namespaces sets.String
 }
 
 // Option for the frameworkImpl.
@@ -56,12 +73,16 @@ type Option func(*frameworkOptions)
 
 type frameworkOptions struct {
 	dryRun                    bool
+	evictOrphanPods           bool
+	countTerminatingPods      bool
+	evictCriticalPods         bool
 	clientSet                 clientset.Interface
 	kubeConfig                *restclient.Config
 	eventRecorder             events.EventRecorder
 	sharedInformerFactory     informers.SharedInformerFactory
 	getPodsAssignedToNodeFunc framework.GetPodsAssignedToNodeFunc
 	evictionLimiter           EvictionLimiter
+	evictionEventLimiter      flowcontrol.RateLimiter
 	captureProfile            CaptureProfile
 }
 
@@ -71,6 +92,27 @@ func WithDryRun(dryRun bool) Option {
 	}
 }
 
+// WithEvictOrphanPods allows the pods without any ownerReferences to pass the Evictor's filters.
+func WithEvictOrphanPods(evictOrphanPods bool) Option {
+	return func(o *frameworkOptions) {
+		o.evictOrphanPods = evictOrphanPods
+	}
+}
+
+// WithCountTerminatingPods allows the terminating pods to pass the Evictor's filters and to count against the eviction limits.
+func WithCountTerminatingPods(countTerminatingPods bool) Option {
+	return func(o *frameworkOptions) {
+		o.countTerminatingPods = countTerminatingPods
+	}
+}
+
+// WithEvictSystemCriticalPriorityClassPods allows the pods with the system critical priority classes to pass the Evictor's filters.
+func WithEvictSystemCriticalPriorityClassPods(evictCriticalPods bool) Option {
+	return func(o *frameworkOptions) {
+		o.evictCriticalPods = evictCriticalPods
+	}
+}
+
 // WithClientSet sets clientSet for the scheduling Framework.
 func WithClientSet(clientSet clientset.Interface) Option {
 	return func(o *frameworkOptions) {
@@ -120,6 +162,14 @@ func WithEvictionLimiter(limiter EvictionLimiter) Option {
 	}
 }
 
+// WithEvictionEventLimiter enables the Events emitted on the evicted pods describing the plugin and the reason,
+// the events exceeding the rate limit are dropped. No event is emitted by the framework if the limiter is nil.
+func WithEvictionEventLimiter(limiter flowcontrol.RateLimiter) Option {
+	return func(o *frameworkOptions) {
+		o.evictionEventLimiter = limiter
+	}
+}
+
 func NewFramework(r Registry, profile *deschedulerconfig.DeschedulerProfile, opts ...Option) (framework.Handle, error) {
 	options := &frameworkOptions{}
 	for _, optFnc := range opts {
@@ -128,14 +178,26 @@ func NewFramework(r Registry, profile *deschedulerconfig.DeschedulerProfile, opt
 
 	f := &frameworkImpl{
 		dryRun:                    options.dryRun,
+		evictOrphanPods:           options.evictOrphanPods,
+		countTerminatingPods:      options.countTerminatingPods,
+		evictCriticalPods:         options.evictCriticalPods,
 		clientSet:                 options.clientSet,
 		kubeConfig:                options.kubeConfig,
 		eventRecorder:             options.eventRecorder,
+		evictionEventLimiter:      options.evictionEventLimiter,
 		evictionLimiter:           options.evictionLimiter,
 		sharedInformerFactory:     options.sharedInformerFactory,
 		getPodsAssignedToNodeFunc: options.getPodsAssignedToNodeFunc,
 	}
 
+	if profile != nil {
+		f.profileName = profile.Name
+	}
+	// disabled profile still runs plugins in dry-run mode to expose the candidates
+	if profile != nil && profile.Disabled {
+		f.dryRun = true
+	}
+
 	if profile == nil || profile.Plugins == nil {
 		return f, nil
 	}
@@ -149,12 +211,22 @@ func NewFramework(r Registry, profile *deschedulerconfig.DeschedulerProfile, opt
 		pluginConfig[name] = profile.PluginConfig[i].Args
 	}
 	outputProfile := deschedulerconfig.DeschedulerProfile{
-		Name:         profile.Name,
-		Plugins:      profile.Plugins,
-		NodeSelector: profile.NodeSelector,
+		Name:              profile.Name,
+		Plugins:           profile.Plugins,
+		NodeSelector:      profile.NodeSelector,
+		NamespaceSelector: profile.NamespaceSelector,
+		Disabled:          profile.Disabled,
 	}
 
 	f.nodeSelector = profile.NodeSelector
+	if profile.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(profile.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
+		}
+		f.namespaceSelector = selector
+		f.namespaces = sets.NewString()
+	}
 
 	pluginsMap := make(map[string]framework.Plugin)
 
@@ -304,6 +376,13 @@ func (f *frameworkImpl) Evictor() framework.Evictor {
 	}
 }
 
+func (f *frameworkImpl) totalEvicted() uint {
+	if f.evictionLimiter != nil {
+		return f.evictionLimiter.TotalEvicted()
+	}
+	return 0
+}
+
 func (f *frameworkImpl) GetPodsAssignedToNodeFunc() framework.GetPodsAssignedToNodeFunc {
 	return f.getPodsAssignedToNodeFunc
 }
@@ -316,14 +395,69 @@ func (f *frameworkImpl) NodeSelector() *metav1.LabelSelector {
 	return f.nodeSelector
 }
 
+func (f *frameworkImpl) ProfileName() string {
+	return f.profileName
+}
+
+// resolveNamespaces resolves the namespaces matching the namespaceSelector of the profile.
+func (f *frameworkImpl) resolveNamespaces() {
+	if f.namespaceSelector == nil || f.sharedInformerFactory == nil {
+		return
+	}
+	namespaces, err := f.sharedInformerFactory.Core().V1().Namespaces().Lister().List(f.namespaceSelector)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list namespaces matching the namespaceSelector", "selector", f.namespaceSelector.String())
+		return
+	}
+	selected := sets.NewString()
+	for _, ns := range namespaces {
+		selected.Insert(ns.Name)
+	}
+	f.namespacesLock.Lock()
+	f.namespaces = selected
+	f.namespacesLock.Unlock()
+}
+
+// namespaceSelected returns whether the pods in the namespace are considered by the profile.
+func (f *frameworkImpl) namespaceSelected(namespace string) bool {
+	if f.namespaceSelector == nil {
+		return true
+	}
+	f.namespacesLock.RLock()
+	defer f.namespacesLock.RUnlock()
+	return f.namespaces.Has(namespace)
+}
+
+// orphanPodSkipped returns whether the pod is skipped because it has no owner and orphan pods are not evictable.
+func (f *frameworkImpl) orphanPodSkipped(pod *corev1.Pod) bool {
+	return !f.evictOrphanPods && len(pod.OwnerReferences) == 0
+}
+
+// terminatingPodSkipped returns whether the pod is skipped because it is already terminating and not counted.
+func (f *frameworkImpl) terminatingPodSkipped(pod *corev1.Pod) bool {
+	return !f.countTerminatingPods && utils.IsPodTerminating(pod)
+}
+
+// criticalPodSkipped returns whether the pod is skipped because it has a system critical priority class.
+func (f *frameworkImpl) criticalPodSkipped(pod *corev1.Pod) bool {
+	return !f.evictCriticalPods && utils.IsSystemCriticalPriorityClassPod(pod)
+}
+
 func (f *frameworkImpl) RunDeschedulePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	f.resolveNamespaces()
 	var errs []error
 	for _, pl := range f.deschedulePlugins {
 		childCtx := framework.PluginNameWithContext(ctx, pl.Name())
+		childCtx, span := startSpan(childCtx, "Deschedule", attribute.String("plugin", pl.Name()), attribute.Int("nodes", len(nodes)))
+		evictedBefore := f.totalEvicted()
 		status := pl.Deschedule(childCtx, nodes)
 		if status != nil && status.Err != nil {
 			errs = append(errs, status.Err)
+			span.RecordError(status.Err)
+			span.SetStatus(codes.Error, status.Err.Error())
 		}
+		span.SetAttributes(attribute.Int64("evicted", int64(f.totalEvicted()-evictedBefore)))
+		span.End()
 	}
 
 	aggrErr := errors.NewAggregate(errs)
@@ -337,13 +471,20 @@ func (f *frameworkImpl) RunDeschedulePlugins(ctx context.Context, nodes []*corev
 }
 
 func (f *frameworkImpl) RunBalancePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	f.resolveNamespaces()
 	var errs []error
 	for _, pl := range f.balancePlugins {
 		childCtx := framework.PluginNameWithContext(ctx, pl.Name())
+		childCtx, span := startSpan(childCtx, "Balance", attribute.String("plugin", pl.Name()), attribute.Int("nodes", len(nodes)))
+		evictedBefore := f.totalEvicted()
 		status := pl.Balance(childCtx, nodes)
 		if status != nil && status.Err != nil {
 			errs = append(errs, status.Err)
+			span.RecordError(status.Err)
+			span.SetStatus(codes.Error, status.Err.Error())
 		}
+		span.SetAttributes(attribute.Int64("evicted", int64(f.totalEvicted()-evictedBefore)))
+		span.End()
 	}
 
 	aggrErr := errors.NewAggregate(errs)
@@ -19,19 +19,31 @@ package runtime
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/component-base/metrics/testutil"
 
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/metrics"
 )
 
 const (
@@ -582,3 +594,610 @@ func TestRunBalancePlugins(t *testing.T) {
 		})
 	}
 }
+
+var _ framework.DeschedulePlugin = &TestEvictingPlugin{}
+
+type TestEvictingPlugin struct {
+	handle framework.Handle
+	pods   []*corev1.Pod
+}
+
+func (pl *TestEvictingPlugin) Name() string {
+	return "test-evicting-plugin"
+}
+
+func (pl *TestEvictingPlugin) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	for _, pod := range pl.pods {
+		pl.handle.Evictor().Evict(ctx, pod, framework.EvictOptions{})
+	}
+	return &framework.Status{}
+}
+
+func TestRunPluginsWithTracing(t *testing.T) {
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod-2"}},
+	}
+	registryClone := Registry{}
+	assert.NoError(t, registryClone.Merge(registry))
+	registryClone["test-evicting-plugin"] = func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+		return &TestEvictingPlugin{handle: handle, pods: pods}, nil
+	}
+	profile := &deschedulerconfig.DeschedulerProfile{
+		Name: testProfileName,
+		Plugins: &deschedulerconfig.Plugins{
+			Evict: deschedulerconfig.PluginSet{
+				Enabled: []deschedulerconfig.Plugin{{Name: evictorPluginName}},
+			},
+			Deschedule: deschedulerconfig.PluginSet{
+				Enabled: []deschedulerconfig.Plugin{{Name: "test-evicting-plugin"}},
+			},
+		},
+	}
+	f, err := NewFramework(registryClone, profile, WithDryRun(true))
+	assert.NoError(t, err)
+
+	t.Run("tracing disabled", func(t *testing.T) {
+		status := f.RunDeschedulePlugins(context.TODO(), nil)
+		assert.NoError(t, status.Err)
+	})
+
+	t.Run("tracing enabled", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		ctx, rootSpan := tp.Tracer(TracerName).Start(context.TODO(), "DeschedulingCycle")
+		status := f.RunDeschedulePlugins(ctx, nil)
+		assert.NoError(t, status.Err)
+		rootSpan.End()
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 4)
+		spansByName := map[string][]tracetest.SpanStub{}
+		for _, span := range spans {
+			spansByName[span.Name] = append(spansByName[span.Name], span)
+		}
+		assert.Len(t, spansByName["DeschedulingCycle"], 1)
+		assert.Len(t, spansByName["Deschedule"], 1)
+		assert.Len(t, spansByName["Evict"], 2)
+
+		root := spansByName["DeschedulingCycle"][0]
+		pluginSpan := spansByName["Deschedule"][0]
+		assert.Equal(t, root.SpanContext.SpanID(), pluginSpan.Parent.SpanID())
+		assert.Contains(t, pluginSpan.Attributes, attribute.String("plugin", "test-evicting-plugin"))
+		for _, evictSpan := range spansByName["Evict"] {
+			assert.Equal(t, pluginSpan.SpanContext.SpanID(), evictSpan.Parent.SpanID())
+			assert.Contains(t, evictSpan.Attributes, attribute.Bool("evicted", true))
+		}
+	})
+}
+
+func TestNewFrameworkWithDisabledProfile(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod"}}
+	tests := []struct {
+		name        string
+		disabled    bool
+		wantEvicted bool
+	}{
+		{
+			name:        "enabled profile evicts via evict plugin",
+			disabled:    false,
+			wantEvicted: false,
+		},
+		{
+			name:        "disabled profile evicts in dry-run mode",
+			disabled:    true,
+			wantEvicted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &deschedulerconfig.DeschedulerProfile{
+				Name: testProfileName,
+				Plugins: &deschedulerconfig.Plugins{
+					Evict: deschedulerconfig.PluginSet{
+						Enabled: []deschedulerconfig.Plugin{{Name: evictorPluginName}},
+					},
+				},
+				Disabled: tt.disabled,
+			}
+			f, err := NewFramework(registry, profile)
+			assert.NoError(t, err)
+			// TestEvictorPlugin always fails to evict, so only dry-run mode reports evicted
+			assert.Equal(t, tt.wantEvicted, f.Evictor().Evict(context.TODO(), pod, framework.EvictOptions{}))
+		})
+	}
+}
+
+var _ framework.DeschedulePlugin = &TestFilteringEvictingPlugin{}
+
+type TestFilteringEvictingPlugin struct {
+	handle  framework.Handle
+	pods    []*corev1.Pod
+	evicted []string
+}
+
+func (pl *TestFilteringEvictingPlugin) Name() string {
+	return "test-filtering-evicting-plugin"
+}
+
+func (pl *TestFilteringEvictingPlugin) Deschedule(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	evictor := pl.handle.Evictor()
+	for _, pod := range pl.pods {
+		if evictor.Filter(pod) && evictor.Evict(ctx, pod, framework.EvictOptions{}) {
+			pl.evicted = append(pl.evicted, pod.Name)
+		}
+	}
+	return &framework.Status{}
+}
+
+func TestNewFrameworkWithNamespaceSelector(t *testing.T) {
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "aggressive", Name: "test-pod-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "late", Name: "test-pod-3"}},
+	}
+	fakeClient := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "aggressive", Labels: map[string]string{"descheduling": "aggressive"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	)
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	namespaceInformer := sharedInformerFactory.Core().V1().Namespaces().Informer()
+
+	plugin := &TestFilteringEvictingPlugin{pods: pods}
+	registryClone := Registry{}
+	assert.NoError(t, registryClone.Merge(registry))
+	registryClone[plugin.Name()] = func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+		plugin.handle = handle
+		return plugin, nil
+	}
+	profile := &deschedulerconfig.DeschedulerProfile{
+		Name: testProfileName,
+		Plugins: &deschedulerconfig.Plugins{
+			Evict: deschedulerconfig.PluginSet{
+				Enabled: []deschedulerconfig.Plugin{{Name: evictorPluginName}},
+			},
+			Deschedule: deschedulerconfig.PluginSet{
+				Enabled: []deschedulerconfig.Plugin{{Name: plugin.Name()}},
+			},
+		},
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"descheduling": "aggressive"},
+		},
+	}
+	f, err := NewFramework(registryClone, profile, WithDryRun(true), WithEvictOrphanPods(true), WithSharedInformerFactory(sharedInformerFactory))
+	assert.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	sharedInformerFactory.Start(stopCh)
+	sharedInformerFactory.WaitForCacheSync(stopCh)
+
+	status := f.RunDeschedulePlugins(context.TODO(), nil)
+	assert.NoError(t, status.Err)
+	assert.Equal(t, []string{"test-pod-1"}, plugin.evicted)
+
+	// the namespaces are resolved again in the next cycle
+	assert.NoError(t, namespaceInformer.GetStore().Add(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "late", Labels: map[string]string{"descheduling": "aggressive"}}}))
+	plugin.evicted = nil
+	status = f.RunDeschedulePlugins(context.TODO(), nil)
+	assert.NoError(t, status.Err)
+	assert.Equal(t, []string{"test-pod-1", "test-pod-3"}, plugin.evicted)
+}
+
+func TestNewFrameworkWithEvictOrphanPods(t *testing.T) {
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "orphan-pod"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "owned-pod",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "test-rs", UID: "test-rs-uid"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name            string
+		evictOrphanPods bool
+		wantEvicted     []string
+	}{
+		{
+			name:            "orphan pods are skipped by default",
+			evictOrphanPods: false,
+			wantEvicted:     []string{"owned-pod"},
+		},
+		{
+			name:            "orphan pods are evicted if enabled",
+			evictOrphanPods: true,
+			wantEvicted:     []string{"orphan-pod", "owned-pod"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &TestFilteringEvictingPlugin{pods: pods}
+			registryClone := Registry{}
+			assert.NoError(t, registryClone.Merge(registry))
+			registryClone[plugin.Name()] = func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+				plugin.handle = handle
+				return plugin, nil
+			}
+			profile := &deschedulerconfig.DeschedulerProfile{
+				Name: testProfileName,
+				Plugins: &deschedulerconfig.Plugins{
+					Evict: deschedulerconfig.PluginSet{
+						Enabled: []deschedulerconfig.Plugin{{Name: evictorPluginName}},
+					},
+					Deschedule: deschedulerconfig.PluginSet{
+						Enabled: []deschedulerconfig.Plugin{{Name: plugin.Name()}},
+					},
+				},
+			}
+			f, err := NewFramework(registryClone, profile, WithDryRun(true), WithEvictOrphanPods(tt.evictOrphanPods))
+			assert.NoError(t, err)
+
+			status := f.RunDeschedulePlugins(context.TODO(), nil)
+			assert.NoError(t, status.Err)
+			assert.Equal(t, tt.wantEvicted, plugin.evicted)
+			for _, pod := range pods {
+				assert.Equal(t, tt.evictOrphanPods || len(pod.OwnerReferences) > 0, f.Evictor().PreEvictionFilter(pod), pod.Name)
+			}
+		})
+	}
+}
+
+func TestNewFrameworkWithCountTerminatingPods(t *testing.T) {
+	ownerReferences := []metav1.OwnerReference{
+		{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "test-rs", UID: "test-rs-uid"},
+	}
+	runningPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "running-pod", OwnerReferences: ownerReferences}}
+	terminatingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "terminating-pod",
+			OwnerReferences:   ownerReferences,
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+	}
+	tests := []struct {
+		name                 string
+		countTerminatingPods bool
+		wantEvicted          []string
+		wantTotalEvicted     uint
+	}{
+		{
+			name:                 "terminating pods are skipped and not counted by default",
+			countTerminatingPods: false,
+			wantEvicted:          []string{"running-pod"},
+			wantTotalEvicted:     1,
+		},
+		{
+			name:                 "terminating pods are evicted and counted if enabled",
+			countTerminatingPods: true,
+			wantEvicted:          []string{"running-pod", "terminating-pod"},
+			wantTotalEvicted:     3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &TestFilteringEvictingPlugin{pods: []*corev1.Pod{runningPod, terminatingPod}}
+			registryClone := Registry{}
+			assert.NoError(t, registryClone.Merge(registry))
+			registryClone[plugin.Name()] = func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+				plugin.handle = handle
+				return plugin, nil
+			}
+			profile := &deschedulerconfig.DeschedulerProfile{
+				Name: testProfileName,
+				Plugins: &deschedulerconfig.Plugins{
+					Evict: deschedulerconfig.PluginSet{
+						Enabled: []deschedulerconfig.Plugin{{Name: evictorPluginName}},
+					},
+					Deschedule: deschedulerconfig.PluginSet{
+						Enabled: []deschedulerconfig.Plugin{{Name: plugin.Name()}},
+					},
+				},
+			}
+			evictionLimiter := evictions.NewEvictionLimiter(nil, nil, nil)
+			f, err := NewFramework(registryClone, profile, WithDryRun(true),
+				WithCountTerminatingPods(tt.countTerminatingPods), WithEvictionLimiter(evictionLimiter))
+			assert.NoError(t, err)
+
+			status := f.RunDeschedulePlugins(context.TODO(), nil)
+			assert.NoError(t, status.Err)
+			assert.Equal(t, tt.wantEvicted, plugin.evicted)
+			assert.Equal(t, tt.countTerminatingPods, f.Evictor().PreEvictionFilter(terminatingPod))
+			assert.True(t, f.Evictor().PreEvictionFilter(runningPod))
+
+			// evicting a terminating pod without filtering it first must not count against the limits either
+			assert.Equal(t, tt.countTerminatingPods, f.Evictor().Evict(context.TODO(), terminatingPod, framework.EvictOptions{}))
+			assert.Equal(t, tt.wantTotalEvicted, evictionLimiter.TotalEvicted())
+		})
+	}
+}
+
+func TestNewFrameworkWithEvictSystemCriticalPriorityClassPods(t *testing.T) {
+	ownerReferences := []metav1.OwnerReference{
+		{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "test-rs", UID: "test-rs-uid"},
+	}
+	normalPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "normal-pod", OwnerReferences: ownerReferences}}
+	clusterCriticalPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "cluster-critical-pod", OwnerReferences: ownerReferences},
+		Spec:       corev1.PodSpec{PriorityClassName: "system-cluster-critical"},
+	}
+	nodeCriticalPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "node-critical-pod", OwnerReferences: ownerReferences},
+		Spec:       corev1.PodSpec{PriorityClassName: "system-node-critical"},
+	}
+	tests := []struct {
+		name              string
+		evictCriticalPods bool
+		wantEvicted       []string
+	}{
+		{
+			name:              "critical pods are skipped by default",
+			evictCriticalPods: false,
+			wantEvicted:       []string{"normal-pod"},
+		},
+		{
+			name:              "critical pods are evicted if enabled",
+			evictCriticalPods: true,
+			wantEvicted:       []string{"normal-pod", "cluster-critical-pod", "node-critical-pod"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &TestFilteringEvictingPlugin{pods: []*corev1.Pod{normalPod, clusterCriticalPod, nodeCriticalPod}}
+			registryClone := Registry{}
+			assert.NoError(t, registryClone.Merge(registry))
+			registryClone[plugin.Name()] = func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+				plugin.handle = handle
+				return plugin, nil
+			}
+			profile := &deschedulerconfig.DeschedulerProfile{
+				Name: testProfileName,
+				Plugins: &deschedulerconfig.Plugins{
+					Evict: deschedulerconfig.PluginSet{
+						Enabled: []deschedulerconfig.Plugin{{Name: evictorPluginName}},
+					},
+					Deschedule: deschedulerconfig.PluginSet{
+						Enabled: []deschedulerconfig.Plugin{{Name: plugin.Name()}},
+					},
+				},
+			}
+			f, err := NewFramework(registryClone, profile, WithDryRun(true),
+				WithEvictSystemCriticalPriorityClassPods(tt.evictCriticalPods), WithEvictionLimiter(evictions.NewEvictionLimiter(nil, nil, nil)))
+			assert.NoError(t, err)
+
+			status := f.RunDeschedulePlugins(context.TODO(), nil)
+			assert.NoError(t, status.Err)
+			assert.Equal(t, tt.wantEvicted, plugin.evicted)
+			assert.True(t, f.Evictor().PreEvictionFilter(normalPod))
+			assert.Equal(t, tt.evictCriticalPods, f.Evictor().PreEvictionFilter(clusterCriticalPod))
+			assert.Equal(t, tt.evictCriticalPods, f.Evictor().Filter(nodeCriticalPod))
+
+			// evicting a critical pod without filtering it first must be rejected as well
+			assert.Equal(t, tt.evictCriticalPods, f.Evictor().Evict(context.TODO(), nodeCriticalPod, framework.EvictOptions{}))
+		})
+	}
+}
+
+func TestNewFrameworkWithOverlappingProfiles(t *testing.T) {
+	metrics.Register()
+
+	ownerReferences := []metav1.OwnerReference{
+		{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "test-rs", UID: "test-rs-uid"},
+	}
+	sharedPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "shared-pod", OwnerReferences: ownerReferences}}
+	otherPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other-pod", OwnerReferences: ownerReferences}}
+	limiter := evictions.NewEvictionLimiter(nil, nil, nil)
+
+	newProfile := func(profileName string, pods ...*corev1.Pod) (framework.Handle, *TestFilteringEvictingPlugin) {
+		plugin := &TestFilteringEvictingPlugin{pods: pods}
+		registryClone := Registry{}
+		assert.NoError(t, registryClone.Merge(registry))
+		registryClone[plugin.Name()] = func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+			plugin.handle = handle
+			return plugin, nil
+		}
+		profile := &deschedulerconfig.DeschedulerProfile{
+			Name: profileName,
+			Plugins: &deschedulerconfig.Plugins{
+				Evict: deschedulerconfig.PluginSet{
+					Enabled: []deschedulerconfig.Plugin{{Name: evictorPluginName}},
+				},
+				Deschedule: deschedulerconfig.PluginSet{
+					Enabled: []deschedulerconfig.Plugin{{Name: plugin.Name()}},
+				},
+			},
+		}
+		f, err := NewFramework(registryClone, profile, WithDryRun(true), WithEvictionLimiter(limiter))
+		assert.NoError(t, err)
+		return f, plugin
+	}
+	f1, plugin1 := newProfile("profile-1", sharedPod)
+	f2, plugin2 := newProfile("profile-2", sharedPod, otherPod)
+
+	collisions := func() float64 {
+		value, err := testutil.GetCounterMetricValue(metrics.ProfileEvictionCollisions.WithLabelValues("profile-2", "profile-1"))
+		assert.NoError(t, err)
+		return value
+	}
+	before := collisions()
+
+	limiter.Reset()
+	assert.NoError(t, f1.RunDeschedulePlugins(context.TODO(), nil).Err)
+	assert.NoError(t, f2.RunDeschedulePlugins(context.TODO(), nil).Err)
+	assert.Equal(t, []string{"shared-pod"}, plugin1.evicted)
+	assert.Equal(t, []string{"other-pod"}, plugin2.evicted)
+	assert.Equal(t, uint(2), limiter.TotalEvicted())
+	assert.Equal(t, before+1, collisions())
+
+	// the claims are cleared in the next descheduling cycle
+	limiter.Reset()
+	plugin2.evicted = nil
+	assert.NoError(t, f2.RunDeschedulePlugins(context.TODO(), nil).Err)
+	assert.Equal(t, []string{"shared-pod", "other-pod"}, plugin2.evicted)
+}
+
+func TestEvictInDryRunCycle(t *testing.T) {
+	profile := &deschedulerconfig.DeschedulerProfile{
+		Name: testProfileName,
+		Plugins: &deschedulerconfig.Plugins{
+			Evict: deschedulerconfig.PluginSet{
+				Enabled: []deschedulerconfig.Plugin{{Name: evictorPluginName}},
+			},
+		},
+	}
+	limiter := evictions.NewEvictionLimiter(nil, nil, nil)
+	f, err := NewFramework(registry, profile, WithEvictionLimiter(limiter))
+	assert.NoError(t, err)
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod"}}
+	// the test evictor always fails to evict
+	assert.False(t, f.Evictor().Evict(context.TODO(), pod, framework.EvictOptions{}))
+	assert.Equal(t, uint(0), limiter.TotalEvicted())
+
+	// the dry-run cycle does not call the evictor but counts against the limits
+	assert.True(t, f.Evictor().Evict(framework.WithDryRun(context.TODO()), pod, framework.EvictOptions{}))
+	assert.Equal(t, uint(1), limiter.TotalEvicted())
+}
+
+type succeedingEvictorPlugin struct {
+	TestEvictorPlugin
+}
+
+func (pl *succeedingEvictorPlugin) Name() string {
+	return evictorPluginName1
+}
+
+func (pl *succeedingEvictorPlugin) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	return true
+}
+
+func TestEvictEmitsEvictionEvents(t *testing.T) {
+	succeedingRegistry := Registry{
+		evictorPluginName1: func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+			return &succeedingEvictorPlugin{}, nil
+		},
+	}
+	profile := &deschedulerconfig.DeschedulerProfile{
+		Name: testProfileName,
+		Plugins: &deschedulerconfig.Plugins{
+			Evict: deschedulerconfig.PluginSet{
+				Enabled: []deschedulerconfig.Plugin{{Name: evictorPluginName1}},
+			},
+		},
+	}
+	pod1 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-1"}, Spec: corev1.PodSpec{NodeName: "node-1"}}
+	pod2 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-2"}, Spec: corev1.PodSpec{NodeName: "node-1"}}
+	opts := framework.EvictOptions{PluginName: "LowNodeLoad", Reason: "node is overutilized"}
+
+	// no event is emitted without the limiter
+	recorder := events.NewFakeRecorder(10)
+	f, err := NewFramework(succeedingRegistry, profile, WithEventRecorder(recorder))
+	assert.NoError(t, err)
+	assert.True(t, f.Evictor().Evict(context.TODO(), pod1, opts))
+	assert.Len(t, recorder.Events, 0)
+
+	recorder = events.NewFakeRecorder(10)
+	f, err = NewFramework(succeedingRegistry, profile, WithEventRecorder(recorder),
+		WithEvictionEventLimiter(flowcontrol.NewFakeAlwaysRateLimiter()))
+	assert.NoError(t, err)
+	assert.True(t, f.Evictor().Evict(context.TODO(), pod1, opts))
+	assert.Equal(t, `Normal Descheduled Evicted from node "node-1" by plugin LowNodeLoad of profile test-profile: node is overutilized`, <-recorder.Events)
+
+	// the events exceeding the rate limit are dropped
+	recorder = events.NewFakeRecorder(10)
+	f, err = NewFramework(succeedingRegistry, profile, WithEventRecorder(recorder),
+		WithEvictionEventLimiter(flowcontrol.NewFakeNeverRateLimiter()))
+	assert.NoError(t, err)
+	assert.True(t, f.Evictor().Evict(context.TODO(), pod2, opts))
+	assert.Len(t, recorder.Events, 0)
+
+	// no event is emitted in dry-run mode
+	recorder = events.NewFakeRecorder(10)
+	f, err = NewFramework(succeedingRegistry, profile, WithEventRecorder(recorder),
+		WithEvictionEventLimiter(flowcontrol.NewFakeAlwaysRateLimiter()))
+	assert.NoError(t, err)
+	assert.True(t, f.Evictor().Evict(framework.WithDryRun(context.TODO()), pod2, opts))
+	assert.Len(t, recorder.Events, 0)
+}
+
+func TestEvictConcurrently(t *testing.T) {
+	succeedingRegistry := Registry{
+		evictorPluginName1: func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+			return &succeedingEvictorPlugin{}, nil
+		},
+	}
+	newProfile := func(registry Registry, profileName, evictorName string, limiter EvictionLimiter) framework.Handle {
+		profile := &deschedulerconfig.DeschedulerProfile{
+			Name: profileName,
+			Plugins: &deschedulerconfig.Plugins{
+				Evict: deschedulerconfig.PluginSet{
+					Enabled: []deschedulerconfig.Plugin{{Name: evictorName}},
+				},
+			},
+		}
+		f, err := NewFramework(registry, profile, WithEvictionLimiter(limiter))
+		assert.NoError(t, err)
+		return f
+	}
+	evictConcurrently := func(profiles []framework.Handle, pods int) int32 {
+		var wg sync.WaitGroup
+		var evicted int32
+		for i := 0; i < pods; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("pod-%d", i)},
+					Spec:       corev1.PodSpec{NodeName: fmt.Sprintf("node-%d", i%2)},
+				}
+				if profiles[i%len(profiles)].Evictor().Evict(context.TODO(), pod, framework.EvictOptions{}) {
+					atomic.AddInt32(&evicted, 1)
+				}
+			}(i)
+		}
+		wg.Wait()
+		return evicted
+	}
+
+	maxPodsToEvictPerNode, maxPodsToEvictTotal := uint(2), uint(3)
+	limiter := evictions.NewEvictionLimiter(&maxPodsToEvictPerNode, nil, &maxPodsToEvictTotal)
+	profiles := []framework.Handle{
+		newProfile(succeedingRegistry, "profile-1", evictorPluginName1, limiter),
+		newProfile(succeedingRegistry, "profile-2", evictorPluginName1, limiter),
+	}
+	assert.Equal(t, int32(3), evictConcurrently(profiles, 20))
+	assert.Equal(t, uint(3), limiter.TotalEvicted())
+	assert.LessOrEqual(t, limiter.NodeEvicted("node-0"), maxPodsToEvictPerNode)
+	assert.LessOrEqual(t, limiter.NodeEvicted("node-1"), maxPodsToEvictPerNode)
+
+	// the slots reserved by the failed evictions are given back
+	limiter.Reset()
+	failingProfiles := []framework.Handle{
+		newProfile(registry, "profile-1", evictorPluginName, limiter),
+		newProfile(registry, "profile-2", evictorPluginName, limiter),
+	}
+	assert.Equal(t, int32(0), evictConcurrently(failingProfiles, 20))
+	assert.Equal(t, uint(0), limiter.TotalEvicted())
+	assert.Equal(t, int32(3), evictConcurrently(profiles, 20))
+}
+
+func TestNewFrameworkWithInvalidNamespaceSelector(t *testing.T) {
+	profile := &deschedulerconfig.DeschedulerProfile{
+		Name: testProfileName,
+		Plugins: &deschedulerconfig.Plugins{
+			Evict: deschedulerconfig.PluginSet{
+				Enabled: []deschedulerconfig.Plugin{{Name: evictorPluginName}},
+			},
+		},
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "descheduling", Operator: "Foo"}},
+		},
+	}
+	_, err := NewFramework(registry, profile)
+	assert.Error(t, err)
+}
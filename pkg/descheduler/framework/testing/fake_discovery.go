@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	policy "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+// SetupFakeDiscoveryWithPolicyResource makes the fake discovery report the eviction subresource,
+// so that the pods are evicted through the Eviction API of the fake client.
+func SetupFakeDiscoveryWithPolicyResource(fake *coretesting.Fake) {
+	fake.AddReactor("get", "group", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		fake.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: policy.SchemeGroupVersion.String(),
+				APIResources: []metav1.APIResource{
+					{
+						Name: util.EvictionSubResourceName,
+						Kind: util.EvictionKind,
+					},
+				},
+			},
+		}
+		return true, nil, nil
+	})
+	fake.AddReactor("get", "resource", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		fake.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{
+						Name: util.EvictionSubResourceName,
+						Kind: util.EvictionKind,
+					},
+				},
+			},
+		}
+		return true, nil, nil
+	})
+}
@@ -38,6 +38,8 @@ func NewFramework(fns []RegisterPluginFunc, profileName string, opts ...runtime.
 	for _, f := range fns {
 		f(&registry, profile)
 	}
+	// plugins are tested against the bare pods as well, the given opts can still disable it.
+	opts = append([]runtime.Option{runtime.WithEvictOrphanPods(true)}, opts...)
 	return runtime.NewFramework(registry, profile, opts...)
 }
 
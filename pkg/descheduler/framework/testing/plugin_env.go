@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes/defaultevictor"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+// PluginTestEnv is a framework running the plugins under test with the DefaultEvictor on a fake clientset.
+type PluginTestEnv struct {
+	framework.Handle
+	ClientSet *fake.Clientset
+
+	lock        sync.Mutex
+	evictedPods []string
+}
+
+// NewPluginTestEnv serves the objects with a fake clientset, waits for the node and pod informers to sync,
+// and creates the framework running the plugins registered by fns with the DefaultEvictor.
+// The evictions in total are limited to maxEvictionTotal if it is not nil.
+func NewPluginTestEnv(ctx context.Context, objs []runtime.Object, maxEvictionTotal *uint, fns ...RegisterPluginFunc) (*PluginTestEnv, error) {
+	env := &PluginTestEnv{
+		ClientSet: fake.NewSimpleClientset(objs...),
+	}
+	SetupFakeDiscoveryWithPolicyResource(&env.ClientSet.Fake)
+	env.ClientSet.PrependReactor("create", "pods", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		if action.GetSubresource() == "eviction" {
+			obj := action.(coretesting.CreateAction).GetObject()
+			env.lock.Lock()
+			env.evictedPods = append(env.evictedPods, obj.(metav1.Object).GetName())
+			env.lock.Unlock()
+		}
+		return false, nil, nil
+	})
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(env.ClientSet, 0)
+	_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+	getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(sharedInformerFactory.Core().V1().Pods())
+	if err != nil {
+		return nil, err
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	fns = append([]RegisterPluginFunc{registerDefaultEvictor}, fns...)
+	env.Handle, err = NewFramework(
+		fns,
+		"test",
+		frameworkruntime.WithClientSet(env.ClientSet),
+		frameworkruntime.WithEvictionLimiter(evictions.NewEvictionLimiter(nil, nil, maxEvictionTotal)),
+		frameworkruntime.WithEventRecorder(&events.FakeRecorder{}),
+		frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+		frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// EvictedPods returns the names of the pods evicted through the Eviction API of the fake clientset.
+func (e *PluginTestEnv) EvictedPods() []string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]string{}, e.evictedPods...)
+}
+
+func registerDefaultEvictor(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+	reg.Register(defaultevictor.PluginName, defaultevictor.New)
+	profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+	profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+	profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+		Name: defaultevictor.PluginName,
+		Args: &defaultevictor.DefaultEvictorArgs{},
+	})
+}
@@ -47,6 +47,9 @@ type Handle interface {
 	SharedInformerFactory() informers.SharedInformerFactory
 
 	NodeSelector() *metav1.LabelSelector
+
+	// ProfileName returns the name of the profile the plugins run in.
+	ProfileName() string
 }
 
 type PluginsRunner interface {
@@ -116,6 +119,12 @@ type EvictOptions struct {
 	Reason string
 	// DeleteOptions holds the arguments used to delete
 	DeleteOptions *metav1.DeleteOptions
+	// DestinationHint is the node the pod is expected to be rescheduled to. It is only a preference,
+	// evictors supporting it must not bind the pod to the node since the hint may become stale.
+	DestinationHint string
+	// IgnoreNodeLimit allows the pod to be evicted beyond the limit of evicted pods per node,
+	// e.g. to prevent the node from failing. The other eviction limits still apply.
+	IgnoreNodeLimit bool
 }
 
 func FillEvictOptionsFromContext(ctx context.Context, options *EvictOptions) {
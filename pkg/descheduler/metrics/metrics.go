@@ -38,8 +38,99 @@ var (
 			StabilityLevel: metrics.ALPHA,
 		}, []string{"result", "strategy", "namespace", "node"})
 
+	SafeModeActive = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "safe_mode_active",
+			Help:           "Whether the descheduler is in the safe mode and all the evictions are halted. 1 means active",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	DeschedulingPaused = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "paused",
+			Help:           "Whether descheduling is paused manually and the descheduling cycles are skipped. 1 means paused",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	InitialObservation = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "initial_observation",
+			Help:           "Whether the descheduler is in the initial observation period and the descheduling cycles run in dry-run mode. 1 means observing",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	NodeSelectorMatchesNoNode = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "node_selector_matches_no_node",
+			Help:           "Whether the node selector of the descheduler matches no node and all the descheduling is disabled. 1 means no node matches",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	SafeModeTriggered = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "safe_mode_triggered_total",
+			Help:           "Number of times the descheduler entered the safe mode because the eviction rate exceeded the limit",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	NodeOverutilizedSeconds = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      "koord",
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "node_overutilized_seconds",
+			Help:           "Duration in seconds the node has been continuously above the high thresholds of LowNodeLoad, by the node name, by the resource",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"node", "resource"})
+
+	ProfileEvictionCollisions = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "profile_eviction_collisions_total",
+			Help:           "Number of times a profile skipped evicting a pod already evicted by another profile in the same cycle, by the profile, by the profile which evicted the pod",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"profile", "claimed_by"})
+
+	TruncatedCycles = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "cycles_truncated_total",
+			Help:           "Number of descheduling cycles stopped early because they exceeded the max cycle duration",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	PodsSkippedForExistingMigration = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pods_skipped_existing_migration_total",
+			Help:           "Number of pods skipped to evict because they are being migrated by an active PodMigrationJob, by the strategy",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy"})
+
+	PodsSkippedForMigrationDisabled = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pods_skipped_migration_disabled_total",
+			Help:           "Number of pods skipped by the migration controller because they are exempted from migration by the annotation, by the namespace",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"namespace"})
+
 	metricsList = []metrics.Registerable{
 		PodsEvicted,
+		SafeModeActive,
+		SafeModeTriggered,
+		DeschedulingPaused,
+		InitialObservation,
+		NodeSelectorMatchesNoNode,
+		NodeOverutilizedSeconds,
+		ProfileEvictionCollisions,
+		TruncatedCycles,
+		PodsSkippedForExistingMigration,
+		PodsSkippedForMigrationDisabled,
 	}
 )
 
@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/scheduling"
 )
 
 // GetResourceRequest finds and returns the request value for a specific resource.
@@ -97,6 +98,11 @@ func IsCriticalPriorityPod(pod *corev1.Pod) bool {
 	return pod.Spec.Priority != nil && *pod.Spec.Priority >= SystemCriticalPriority
 }
 
+// IsSystemCriticalPriorityClassPod returns true if the pod has the system-cluster-critical or system-node-critical priority class.
+func IsSystemCriticalPriorityClassPod(pod *corev1.Pod) bool {
+	return pod.Spec.PriorityClassName == scheduling.SystemClusterCritical || pod.Spec.PriorityClassName == scheduling.SystemNodeCritical
+}
+
 // IsDaemonsetPod returns true if the pod is a IsDaemonsetPod.
 func IsDaemonsetPod(ownerRefList []metav1.OwnerReference) bool {
 	for _, ownerRef := range ownerRefList {
@@ -240,6 +240,54 @@ func TestIsCriticalPriorityPod(t *testing.T) {
 	}
 }
 
+func TestIsSystemCriticalPriorityClassPod(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{
+			name: "system-cluster-critical pod",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					PriorityClassName: "system-cluster-critical",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "system-node-critical pod",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					PriorityClassName: "system-node-critical",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "other priority class pod",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					PriorityClassName: "koord-prod",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "normal pod",
+			pod:  &corev1.Pod{},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSystemCriticalPriorityClassPod(tt.pod); got != tt.want {
+				t.Errorf("IsSystemCriticalPriorityClassPod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsDaemonsetPod(t *testing.T) {
 	tests := []struct {
 		name string
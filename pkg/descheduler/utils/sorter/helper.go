@@ -39,7 +39,7 @@ type MultiSorter struct {
 // Sort sorts the pods according to the cmp functions passed to OrderedBy.
 func (ms *MultiSorter) Sort(pods []*corev1.Pod) {
 	ms.pods = pods
-	sort.Sort(ms)
+	sort.Stable(ms)
 }
 
 // OrderedBy returns a Sorter sorted using the cmp functions, sorts in ascending order by default
@@ -17,8 +17,10 @@ limitations under the License.
 package sorter
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	schedulingcorev1helper "k8s.io/component-helpers/scheduling/corev1"
 	apiscorehelper "k8s.io/kubernetes/pkg/apis/core/helper"
@@ -125,6 +127,20 @@ func PodUsage(resourcesThatExceedThresholds map[corev1.ResourceName]resource.Qua
 	}
 }
 
+// PodResourceUsage compares pods by the actual usage of the resource
+func PodResourceUsage(resourceName corev1.ResourceName, podMetrics map[types.NamespacedName]*slov1alpha1.ResourceMap) CompareFn {
+	return func(p1, p2 *corev1.Pod) int {
+		p1Metric, p1Found := podMetrics[types.NamespacedName{Namespace: p1.Namespace, Name: p1.Name}]
+		p2Metric, p2Found := podMetrics[types.NamespacedName{Namespace: p2.Namespace, Name: p2.Name}]
+		if !p1Found || !p2Found {
+			return cmpBool(!p1Found, !p2Found)
+		}
+		p1Usage := p1Metric.ResourceList[resourceName]
+		p2Usage := p2Metric.ResourceList[resourceName]
+		return p1Usage.Cmp(p2Usage)
+	}
+}
+
 // PodCreationTimestamp compares the pods by the creation timestamp
 func PodCreationTimestamp(p1, p2 *corev1.Pod) int {
 	if p1.CreationTimestamp.Equal(&p2.CreationTimestamp) {
@@ -160,6 +176,47 @@ func EvictionCost(p1, p2 *corev1.Pod) int {
 	return -1
 }
 
+// OwnerKind compares pods by the kind of their top-level owner following the order of ownerKinds.
+// The pods owned by the kinds in front are less than the others, and the kinds not listed are the greatest.
+func OwnerKind(ownerKinds []string) CompareFn {
+	order := make(map[string]int, len(ownerKinds))
+	for i, kind := range ownerKinds {
+		order[kind] = i
+	}
+	rank := func(pod *corev1.Pod) int {
+		if r, ok := order[getTopLevelOwnerKind(pod)]; ok {
+			return r
+		}
+		return len(ownerKinds)
+	}
+	return func(p1, p2 *corev1.Pod) int {
+		rank1, rank2 := rank(p1), rank(p2)
+		if rank1 == rank2 {
+			return 0
+		}
+		if rank1 > rank2 {
+			return 1
+		}
+		return -1
+	}
+}
+
+// getTopLevelOwnerKind returns the kind of the top-level owner of the pod without querying the owners.
+// The ReplicaSets created by Deployments are recognized by the pod-template-hash label.
+func getTopLevelOwnerKind(pod *corev1.Pod) string {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil {
+		if len(pod.OwnerReferences) == 0 {
+			return ""
+		}
+		ownerRef = &pod.OwnerReferences[0]
+	}
+	if ownerRef.Kind == "ReplicaSet" && pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey] != "" {
+		return "Deployment"
+	}
+	return ownerRef.Kind
+}
+
 func PodSorter(cmp ...CompareFn) *MultiSorter {
 	comparators := []CompareFn{
 		KoordinatorPriorityClass,
@@ -177,3 +234,20 @@ func PodSorter(cmp ...CompareFn) *MultiSorter {
 func SortPodsByUsage(resourcesThatExceedThresholds map[corev1.ResourceName]resource.Quantity, pods []*corev1.Pod, podMetrics map[types.NamespacedName]*slov1alpha1.ResourceMap, nodeAllocatableMap map[string]corev1.ResourceList, resourceToWeightMap map[corev1.ResourceName]int64) {
 	PodSorter(Reverse(PodUsage(resourcesThatExceedThresholds, podMetrics, resourceToWeightMap))).Sort(pods)
 }
+
+// SortPodsByOwnerKindAndUsage sorts the pods by the kind of their top-level owner first, and then the same as SortPodsByUsage.
+func SortPodsByOwnerKindAndUsage(ownerKinds []string, resourcesThatExceedThresholds map[corev1.ResourceName]resource.Quantity, pods []*corev1.Pod, podMetrics map[types.NamespacedName]*slov1alpha1.ResourceMap, nodeAllocatableMap map[string]corev1.ResourceList, resourceToWeightMap map[corev1.ResourceName]int64) {
+	podSorter := PodSorter(Reverse(PodUsage(resourcesThatExceedThresholds, podMetrics, resourceToWeightMap)))
+	OrderedBy(append([]CompareFn{OwnerKind(ownerKinds)}, podSorter.cmp...)...).Sort(pods)
+}
+
+// SortPodsByEphemeralStorageUsage sorts the pods using the most ephemeral-storage first, and then the same as
+// SortPodsByOwnerKindAndUsage if ownerKinds is set, otherwise the same as SortPodsByUsage.
+func SortPodsByEphemeralStorageUsage(ownerKinds []string, resourcesThatExceedThresholds map[corev1.ResourceName]resource.Quantity, pods []*corev1.Pod, podMetrics map[types.NamespacedName]*slov1alpha1.ResourceMap, nodeAllocatableMap map[string]corev1.ResourceList, resourceToWeightMap map[corev1.ResourceName]int64) {
+	comparators := []CompareFn{Reverse(PodResourceUsage(corev1.ResourceEphemeralStorage, podMetrics))}
+	if len(ownerKinds) > 0 {
+		comparators = append(comparators, OwnerKind(ownerKinds))
+	}
+	podSorter := PodSorter(Reverse(PodUsage(resourcesThatExceedThresholds, podMetrics, resourceToWeightMap)))
+	OrderedBy(append(comparators, podSorter.cmp...)...).Sort(pods)
+}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
@@ -198,3 +199,32 @@ func TestSortPods2(t *testing.T) {
 	}
 	assert.Equal(t, expectedPodsOrder, podsOrder)
 }
+
+func TestSortPodsByOwnerKind(t *testing.T) {
+	buildPod := func(name, ownerKind string, labels map[string]string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    labels,
+			},
+		}
+		if ownerKind != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{
+				{Kind: ownerKind, Name: name + "-owner", Controller: pointer.Bool(true)},
+			}
+		}
+		return pod
+	}
+	deploymentPod := buildPod("deployment", "ReplicaSet", map[string]string{"pod-template-hash": "abc"})
+	replicaSetPod := buildPod("replicaset", "ReplicaSet", nil)
+	jobPod := buildPod("job", "Job", nil)
+	statefulSetPod := buildPod("statefulset", "StatefulSet", nil)
+	barePod := buildPod("bare", "", nil)
+
+	pods := []*corev1.Pod{barePod, statefulSetPod, deploymentPod, replicaSetPod, jobPod}
+	OrderedBy(OwnerKind([]string{"Job", "ReplicaSet", "Deployment"})).Sort(pods)
+	expected := []*corev1.Pod{jobPod, replicaSetPod, deploymentPod, barePod, statefulSetPod}
+	assert.Equal(t, expected[:3], pods[:3])
+	assert.ElementsMatch(t, expected[3:], pods[3:])
+}
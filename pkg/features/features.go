@@ -76,6 +76,10 @@ const (
 	// when add resource type: from parent to child
 	ElasticQuotaEnableUpdateResourceKey featuregate.Feature = "ElasticQuotaEnableUpdateResourceKey"
 
+	// ElasticQuotaForbidNamespacedParent forbids a quota bound to annotation namespaces from being a parent quota.
+	// The quota bound to namespaces is supposed to be a leaf quota consumed by the pods in these namespaces.
+	ElasticQuotaForbidNamespacedParent featuregate.Feature = "ElasticQuotaForbidNamespacedParent"
+
 	// DisableDefaultQuota disable default quota.
 	DisableDefaultQuota featuregate.Feature = "DisableDefaultQuota"
 
@@ -113,6 +117,7 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	ElasticQuotaIgnorePodOverhead:          {Default: false, PreRelease: featuregate.Alpha},
 	ElasticQuotaGuaranteeUsage:             {Default: false, PreRelease: featuregate.Alpha},
 	ElasticQuotaEnableUpdateResourceKey:    {Default: false, PreRelease: featuregate.Alpha},
+	ElasticQuotaForbidNamespacedParent:     {Default: false, PreRelease: featuregate.Alpha},
 	DisableDefaultQuota:                    {Default: false, PreRelease: featuregate.Alpha},
 	SupportParentQuotaSubmitPod:            {Default: false, PreRelease: featuregate.Alpha},
 	EnableQuotaAdmission:                   {Default: false, PreRelease: featuregate.Alpha},
@@ -584,6 +584,15 @@ func (r *nodeMetricInformer) collectNodeGPUMetric(queryparam metriccache.QueryPa
 	return result, nil
 }
 
+// metricAggregationTypes maps the aggregation types reported in the NodeMetric to the ones of the metric cache.
+var metricAggregationTypes = map[apiext.AggregationType]metriccache.AggregationType{
+	apiext.AVG: metriccache.AggregationTypeAVG,
+	apiext.P50: metriccache.AggregationTypeP50,
+	apiext.P90: metriccache.AggregationTypeP90,
+	apiext.P95: metriccache.AggregationTypeP95,
+	apiext.P99: metriccache.AggregationTypeP99,
+}
+
 func (r *nodeMetricInformer) collectNodeAggregateMetric(endTime time.Time, aggregatePolicy *slov1alpha1.AggregatePolicy) []slov1alpha1.AggregatedUsage {
 	var aggregateUsages []slov1alpha1.AggregatedUsage
 	if aggregatePolicy == nil {
@@ -592,15 +601,17 @@ func (r *nodeMetricInformer) collectNodeAggregateMetric(endTime time.Time, aggre
 	for _, d := range aggregatePolicy.Durations {
 		start := endTime.Add(-d.Duration)
 		aggregateUsage := slov1alpha1.AggregatedUsage{
-			Usage: map[apiext.AggregationType]slov1alpha1.ResourceMap{
-				apiext.AVG: r.queryNodeMetric(start, endTime, metriccache.AggregationTypeAVG, true),
-				apiext.P50: r.queryNodeMetric(start, endTime, metriccache.AggregationTypeP50, true),
-				apiext.P90: r.queryNodeMetric(start, endTime, metriccache.AggregationTypeP90, true),
-				apiext.P95: r.queryNodeMetric(start, endTime, metriccache.AggregationTypeP95, true),
-				apiext.P99: r.queryNodeMetric(start, endTime, metriccache.AggregationTypeP99, true),
-			},
+			Usage:    make(map[apiext.AggregationType]slov1alpha1.ResourceMap, len(apiext.KnownAggregationTypes)),
 			Duration: d,
 		}
+		for _, aggregationType := range apiext.KnownAggregationTypes {
+			metricAggregationType, ok := metricAggregationTypes[aggregationType]
+			if !ok {
+				klog.V(5).Infof("aggregation type %s is not supported by the metric cache, skip it", aggregationType)
+				continue
+			}
+			aggregateUsage.Usage[aggregationType] = r.queryNodeMetric(start, endTime, metricAggregationType, true)
+		}
 		aggregateUsages = append(aggregateUsages, aggregateUsage)
 	}
 	return aggregateUsages
@@ -673,15 +684,17 @@ func (r *nodeMetricInformer) collectSystemAggregateMetric(endTime time.Time, agg
 	for _, d := range aggregatePolicy.Durations {
 		start := endTime.Add(-d.Duration)
 		aggregateUsage := slov1alpha1.AggregatedUsage{
-			Usage: map[apiext.AggregationType]slov1alpha1.ResourceMap{
-				apiext.AVG: r.querySystemMetric(start, endTime, metriccache.AggregationTypeAVG, true),
-				apiext.P50: r.querySystemMetric(start, endTime, metriccache.AggregationTypeP50, true),
-				apiext.P90: r.querySystemMetric(start, endTime, metriccache.AggregationTypeP90, true),
-				apiext.P95: r.querySystemMetric(start, endTime, metriccache.AggregationTypeP95, true),
-				apiext.P99: r.querySystemMetric(start, endTime, metriccache.AggregationTypeP99, true),
-			},
+			Usage:    make(map[apiext.AggregationType]slov1alpha1.ResourceMap, len(apiext.KnownAggregationTypes)),
 			Duration: d,
 		}
+		for _, aggregationType := range apiext.KnownAggregationTypes {
+			metricAggregationType, ok := metricAggregationTypes[aggregationType]
+			if !ok {
+				klog.V(5).Infof("aggregation type %s is not supported by the metric cache, skip it", aggregationType)
+				continue
+			}
+			aggregateUsage.Usage[aggregationType] = r.querySystemMetric(start, endTime, metricAggregationType, true)
+		}
 		aggregateUsages = append(aggregateUsages, aggregateUsage)
 	}
 	return aggregateUsages
@@ -41,6 +41,8 @@ type LoadAwareSchedulingArgs struct {
 	EnableScheduleWhenNodeMetricsExpired *bool `json:"enableScheduleWhenNodeMetricsExpired,omitempty"`
 	// ResourceWeights indicates the weights of resources.
 	// The weights of CPU and Memory are both 1 by default.
+	// Each weight must be in the range [1, 100] and only the ratios between the weights matter,
+	// e.g. the weights all set to 100 score the same as the weights all set to 1.
 	ResourceWeights map[corev1.ResourceName]int64 `json:"resourceWeights,omitempty"`
 	// UsageThresholds indicates the resource utilization threshold of the whole machine.
 	// The default for CPU is 65%, and the default for memory is 95%.
@@ -55,6 +57,9 @@ type LoadAwareSchedulingArgs struct {
 	// EstimatedScalingFactors indicates the factor when estimating resource usage.
 	// The default value of CPU is 85%, and the default value of Memory is 70%.
 	EstimatedScalingFactors map[corev1.ResourceName]int64 `json:"estimatedScalingFactors,omitempty"`
+	// EstimatedScalingFactorsByQoS indicates the factor when estimating resource usage of pods in a specific QoS class.
+	// The factors are consulted before EstimatedScalingFactors, which are used for the resources missing here.
+	EstimatedScalingFactorsByQoS map[corev1.PodQOSClass]map[corev1.ResourceName]int64 `json:"estimatedScalingFactorsByQoS,omitempty"`
 	// EstimatedSecondsAfterPodScheduled indicates the force estimation duration
 	// after pod condition PodScheduled transition to True in seconds.
 	EstimatedSecondsAfterPodScheduled *int64 `json:"estimatedSecondsAfterPodScheduled,omitempty"`
@@ -79,6 +84,10 @@ type LoadAwareSchedulingAggregatedArgs struct {
 	ScoreAggregationType extension.AggregationType `json:"scoreAggregationType,omitempty"`
 	// ScoreAggregatedDuration indicates the statistical period of the percentile of Prod Pod's utilization when scoring
 	ScoreAggregatedDuration *metav1.Duration `json:"scoreAggregatedDuration,omitempty"`
+
+	// MetricSampleInterval indicates the interval at which the node utilization is sampled for aggregation.
+	// If set, UsageAggregatedDuration and ScoreAggregatedDuration must be multiples of it to avoid partial windows.
+	MetricSampleInterval *metav1.Duration `json:"metricSampleInterval,omitempty"`
 }
 
 // ScoringStrategyType is a "string" type.
@@ -362,6 +362,9 @@ func autoConvert_v1_LoadAwareSchedulingAggregatedArgs_To_config_LoadAwareSchedul
 	if err := metav1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.ScoreAggregatedDuration, &out.ScoreAggregatedDuration, s); err != nil {
 		return err
 	}
+	if err := metav1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.MetricSampleInterval, &out.MetricSampleInterval, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -380,6 +383,9 @@ func autoConvert_config_LoadAwareSchedulingAggregatedArgs_To_v1_LoadAwareSchedul
 	if err := metav1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.ScoreAggregatedDuration, &out.ScoreAggregatedDuration, s); err != nil {
 		return err
 	}
+	if err := metav1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.MetricSampleInterval, &out.MetricSampleInterval, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -400,6 +406,7 @@ func autoConvert_v1_LoadAwareSchedulingArgs_To_config_LoadAwareSchedulingArgs(in
 	}
 	out.Estimator = in.Estimator
 	out.EstimatedScalingFactors = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.EstimatedScalingFactors))
+	out.EstimatedScalingFactorsByQoS = *(*map[corev1.PodQOSClass]map[corev1.ResourceName]int64)(unsafe.Pointer(&in.EstimatedScalingFactorsByQoS))
 	out.EstimatedSecondsAfterPodScheduled = (*int64)(unsafe.Pointer(in.EstimatedSecondsAfterPodScheduled))
 	out.EstimatedSecondsAfterInitialized = (*int64)(unsafe.Pointer(in.EstimatedSecondsAfterInitialized))
 	out.AllowCustomizeEstimation = in.AllowCustomizeEstimation
@@ -432,6 +439,7 @@ func autoConvert_config_LoadAwareSchedulingArgs_To_v1_LoadAwareSchedulingArgs(in
 	}
 	out.Estimator = in.Estimator
 	out.EstimatedScalingFactors = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.EstimatedScalingFactors))
+	out.EstimatedScalingFactorsByQoS = *(*map[corev1.PodQOSClass]map[corev1.ResourceName]int64)(unsafe.Pointer(&in.EstimatedScalingFactorsByQoS))
 	out.EstimatedSecondsAfterPodScheduled = (*int64)(unsafe.Pointer(in.EstimatedSecondsAfterPodScheduled))
 	out.EstimatedSecondsAfterInitialized = (*int64)(unsafe.Pointer(in.EstimatedSecondsAfterInitialized))
 	out.AllowCustomizeEstimation = in.AllowCustomizeEstimation
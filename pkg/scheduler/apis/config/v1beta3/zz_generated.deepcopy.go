@@ -239,6 +239,11 @@ func (in *LoadAwareSchedulingAggregatedArgs) DeepCopyInto(out *LoadAwareScheduli
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.MetricSampleInterval != nil {
+		in, out := &in.MetricSampleInterval, &out.MetricSampleInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -304,6 +309,23 @@ func (in *LoadAwareSchedulingArgs) DeepCopyInto(out *LoadAwareSchedulingArgs) {
 			(*out)[key] = val
 		}
 	}
+	if in.EstimatedScalingFactorsByQoS != nil {
+		in, out := &in.EstimatedScalingFactorsByQoS, &out.EstimatedScalingFactorsByQoS
+		*out = make(map[corev1.PodQOSClass]map[corev1.ResourceName]int64, len(*in))
+		for key, val := range *in {
+			var outVal map[corev1.ResourceName]int64
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[corev1.ResourceName]int64, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.EstimatedSecondsAfterPodScheduled != nil {
 		in, out := &in.EstimatedSecondsAfterPodScheduled, &out.EstimatedSecondsAfterPodScheduled
 		*out = new(int64)
@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	schedconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
 
@@ -28,8 +29,45 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 )
 
+// GetLoadAwareSchedulingArgsWarnings returns warnings of the LoadAwareSchedulingArgs which are valid but implausible.
+func GetLoadAwareSchedulingArgsWarnings(args *config.LoadAwareSchedulingArgs) []string {
+	var warnings []string
+	if warning := getResourceWeightsWarning(args.ResourceWeights); warning != "" {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", field.NewPath("resourceWeights"), warning))
+	}
+	return warnings
+}
+
+// getResourceWeightsWarning warns the weights which are all equal but not the default 1.
+// Only the ratios between the weights matter, so such weights are likely expected to be differentiated.
+func getResourceWeightsWarning(weights map[corev1.ResourceName]int64) string {
+	if len(weights) < 2 {
+		return ""
+	}
+	var weight int64
+	for _, w := range weights {
+		if weight != 0 && w != weight {
+			return ""
+		}
+		weight = w
+	}
+	if weight == 1 {
+		return ""
+	}
+	return fmt.Sprintf("all the weights are %d which scores the same as the weights all set to 1, consider differentiating the weights by the importance of the resources", weight)
+}
+
 // ValidateLoadAwareSchedulingArgs validates that LoadAwareSchedulingArgs are correct.
 func ValidateLoadAwareSchedulingArgs(args *config.LoadAwareSchedulingArgs) error {
+	if allErrs := ValidateLoadAwareSchedulingArgsDetailed(args); len(allErrs) > 0 {
+		return allErrs.ToAggregate()
+	}
+	return nil
+}
+
+// ValidateLoadAwareSchedulingArgsDetailed validates the LoadAwareSchedulingArgs and returns the field errors found,
+// so that the callers can inspect the invalid fields rather than the aggregated message.
+func ValidateLoadAwareSchedulingArgsDetailed(args *config.LoadAwareSchedulingArgs) field.ErrorList {
 	var allErrs field.ErrorList
 
 	if args.NodeMetricExpirationSeconds != nil && *args.NodeMetricExpirationSeconds <= 0 {
@@ -45,6 +83,7 @@ func ValidateLoadAwareSchedulingArgs(args *config.LoadAwareSchedulingArgs) error
 	if err := validateEstimatedScalingFactors(args.EstimatedScalingFactors); err != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("estimatedScalingFactors"), args.EstimatedScalingFactors, err.Error()))
 	}
+	allErrs = append(allErrs, validateEstimatedScalingFactorsByQoS(args.EstimatedScalingFactorsByQoS, field.NewPath("estimatedScalingFactorsByQoS"))...)
 
 	for resourceName := range args.ResourceWeights {
 		if _, ok := args.EstimatedScalingFactors[resourceName]; !ok {
@@ -57,10 +96,7 @@ func ValidateLoadAwareSchedulingArgs(args *config.LoadAwareSchedulingArgs) error
 		allErrs = append(allErrs, err...)
 	}
 
-	if len(allErrs) == 0 {
-		return nil
-	}
-	return allErrs.ToAggregate()
+	return allErrs
 }
 
 func validateAggregatedArgs(
@@ -99,20 +135,30 @@ func validateAggregatedArgs(
 			aggregated.ScoreAggregatedDuration, "duration must be >= 0"))
 	}
 
+	if aggregated.MetricSampleInterval.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("metricSampleInterval"),
+			aggregated.MetricSampleInterval, "duration must be >= 0"))
+	} else if sampleInterval := aggregated.MetricSampleInterval.Duration; sampleInterval > 0 {
+		if d := aggregated.UsageAggregatedDuration.Duration; d > 0 && d%sampleInterval != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("usageAggregatedDuration"),
+				aggregated.UsageAggregatedDuration, fmt.Sprintf("duration must be a multiple of metricSampleInterval %v", sampleInterval)))
+		}
+		if d := aggregated.ScoreAggregatedDuration.Duration; d > 0 && d%sampleInterval != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("scoreAggregatedDuration"),
+				aggregated.ScoreAggregatedDuration, fmt.Sprintf("duration must be a multiple of metricSampleInterval %v", sampleInterval)))
+		}
+	}
+
 	return allErrs
 }
 
 func validateAggregationType(aggType extension.AggregationType, fldPath *field.Path) *field.Error {
-	validTypes := []string{
-		string(extension.AVG),
-		string(extension.P50), string(extension.P90),
-		string(extension.P95), string(extension.P99),
+	if extension.IsKnownAggregationType(aggType) {
+		return nil
 	}
-
-	for _, t := range validTypes {
-		if string(aggType) == t {
-			return nil
-		}
+	validTypes := make([]string, 0, len(extension.KnownAggregationTypes))
+	for _, t := range extension.KnownAggregationTypes {
+		validTypes = append(validTypes, string(t))
 	}
 	return field.NotSupported(fldPath, aggType, validTypes)
 }
@@ -141,52 +187,123 @@ func validateResourceThresholds(thresholds map[corev1.ResourceName]int64) error
 	return nil
 }
 
+// AllowEstimatedScalingFactorsAboveRequest allows the estimated scaling factors larger than 100, i.e. the pods are
+// estimated to use more than their requests, for the workloads which legitimately do so. The estimation is still
+// capped by the limits of the pods. It is disabled by default for safety.
+var AllowEstimatedScalingFactorsAboveRequest bool
+
+// MaxEstimatedScalingFactorAboveRequest is the upper bound of the estimated scaling factors
+// when AllowEstimatedScalingFactorsAboveRequest is enabled.
+var MaxEstimatedScalingFactorAboveRequest int64 = 500
+
+// maxEstimatedScalingFactor returns the upper bound of the estimated scaling factors.
+func maxEstimatedScalingFactor() int64 {
+	if AllowEstimatedScalingFactorsAboveRequest {
+		return MaxEstimatedScalingFactorAboveRequest
+	}
+	return 100
+}
+
 func validateEstimatedScalingFactors(scalingFactors map[corev1.ResourceName]int64) error {
+	maxScalingFactor := maxEstimatedScalingFactor()
 	for resourceName, scalingFactor := range scalingFactors {
 		if scalingFactor <= 0 {
 			return fmt.Errorf("estimated resource ScalingFactor of %v should be a positive value, got %v", resourceName, scalingFactor)
 		}
-		if scalingFactor > 100 {
-			return fmt.Errorf("estimated resource ScalingFactor of %v should be less than 100, got %v", resourceName, scalingFactor)
+		if scalingFactor > maxScalingFactor {
+			return fmt.Errorf("estimated resource ScalingFactor of %v should be less than %v, got %v", resourceName, maxScalingFactor, scalingFactor)
 		}
 	}
 	return nil
 }
 
+func validateEstimatedScalingFactorsByQoS(scalingFactorsByQoS map[corev1.PodQOSClass]map[corev1.ResourceName]int64, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	maxScalingFactor := maxEstimatedScalingFactor()
+	for qosClass, scalingFactors := range scalingFactorsByQoS {
+		qosPath := fldPath.Key(string(qosClass))
+		switch qosClass {
+		case corev1.PodQOSGuaranteed, corev1.PodQOSBurstable, corev1.PodQOSBestEffort:
+		default:
+			allErrs = append(allErrs, field.NotSupported(qosPath, qosClass,
+				[]string{string(corev1.PodQOSGuaranteed), string(corev1.PodQOSBurstable), string(corev1.PodQOSBestEffort)}))
+			continue
+		}
+		for resourceName, scalingFactor := range scalingFactors {
+			if scalingFactor < 1 || scalingFactor > maxScalingFactor {
+				allErrs = append(allErrs, field.Invalid(qosPath.Key(string(resourceName)), scalingFactor, fmt.Sprintf("must be in the range [1, %d]", maxScalingFactor)))
+			}
+		}
+	}
+	return allErrs
+}
+
 func ValidateElasticQuotaArgs(elasticArgs *config.ElasticQuotaArgs) error {
+	if allErrs := ValidateElasticQuotaArgsDetailed(elasticArgs); len(allErrs) > 0 {
+		return allErrs.ToAggregate()
+	}
+	return nil
+}
+
+// ValidateElasticQuotaArgsDetailed returns the field errors of the ElasticQuotaArgs.
+func ValidateElasticQuotaArgsDetailed(elasticArgs *config.ElasticQuotaArgs) field.ErrorList {
+	var allErrs field.ErrorList
+
 	for resName, q := range elasticArgs.DefaultQuotaGroupMax {
 		if q.Cmp(*resource.NewQuantity(0, resource.DecimalSI)) == -1 {
-			return fmt.Errorf("elasticQuotaArgs error, defaultQuotaGroupMax should be a positive value, resourceName:%v, got %v",
-				resName, q)
+			allErrs = append(allErrs, field.Invalid(field.NewPath("defaultQuotaGroupMax").Key(string(resName)), q.String(), "should be a positive value"))
 		}
 	}
 
 	for resName, q := range elasticArgs.SystemQuotaGroupMax {
 		if q.Cmp(*resource.NewQuantity(0, resource.DecimalSI)) == -1 {
-			return fmt.Errorf("elasticQuotaArgs error, systemQuotaGroupMax should be a positive value, resourceName:%v, got %v",
-				resName, q)
+			allErrs = append(allErrs, field.Invalid(field.NewPath("systemQuotaGroupMax").Key(string(resName)), q.String(), "should be a positive value"))
 		}
 	}
 
 	if elasticArgs.DelayEvictTime.Duration < 0 {
-		return fmt.Errorf("elasticQuotaArgs error, DelayEvictTime should be a positive value")
+		allErrs = append(allErrs, field.Invalid(field.NewPath("delayEvictTime"), elasticArgs.DelayEvictTime.Duration.String(), "should be a positive value"))
 	}
 
 	if elasticArgs.RevokePodInterval.Duration < 0 {
-		return fmt.Errorf("elasticQuotaArgs error, RevokePodCycle should be a positive value")
+		allErrs = append(allErrs, field.Invalid(field.NewPath("revokePodInterval"), elasticArgs.RevokePodInterval.Duration.String(), "should be a positive value"))
 	}
 
-	return nil
+	// the revoke loop has to run at least once during the delay, otherwise the delay is meaningless.
+	if elasticArgs.DelayEvictTime.Duration > 0 && elasticArgs.RevokePodInterval.Duration > elasticArgs.DelayEvictTime.Duration {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("revokePodInterval"), elasticArgs.RevokePodInterval.Duration.String(),
+			fmt.Sprintf("should not be greater than delayEvictTime %v", elasticArgs.DelayEvictTime.Duration)))
+	}
+
+	return allErrs
 }
 
+// MaxCoschedulingControllerWorkers is the upper bound of CoschedulingArgs.ControllerWorkers.
+// Each worker is a goroutine sending requests to the API server, a larger value is likely a typo.
+var MaxCoschedulingControllerWorkers int64 = 1000
+
 func ValidateCoschedulingArgs(coeSchedulingArgs *config.CoschedulingArgs) error {
+	if allErrs := ValidateCoschedulingArgsDetailed(coeSchedulingArgs); len(allErrs) > 0 {
+		return allErrs.ToAggregate()
+	}
+	return nil
+}
+
+// ValidateCoschedulingArgsDetailed returns the field errors of the CoschedulingArgs.
+func ValidateCoschedulingArgsDetailed(coeSchedulingArgs *config.CoschedulingArgs) field.ErrorList {
+	var allErrs field.ErrorList
 	if coeSchedulingArgs.DefaultTimeout.Duration < 0 {
-		return fmt.Errorf("coeSchedulingArgs DefaultTimeoutSeconds invalid")
+		allErrs = append(allErrs, field.Invalid(field.NewPath("defaultTimeout"), coeSchedulingArgs.DefaultTimeout.Duration.String(),
+			"coeSchedulingArgs DefaultTimeoutSeconds invalid"))
 	}
 	if coeSchedulingArgs.ControllerWorkers < 1 {
-		return fmt.Errorf("coeSchedulingArgs ControllerWorkers invalid")
+		allErrs = append(allErrs, field.Invalid(field.NewPath("controllerWorkers"), coeSchedulingArgs.ControllerWorkers,
+			"coeSchedulingArgs ControllerWorkers invalid"))
+	} else if coeSchedulingArgs.ControllerWorkers > MaxCoschedulingControllerWorkers {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("controllerWorkers"), coeSchedulingArgs.ControllerWorkers,
+			fmt.Sprintf("must be less than or equal to %d", MaxCoschedulingControllerWorkers)))
 	}
-	return nil
+	return allErrs
 }
 
 func validateResources(resources []schedconfig.ResourceSpec, p *field.Path) field.ErrorList {
@@ -200,19 +317,59 @@ func validateResources(resources []schedconfig.ResourceSpec, p *field.Path) fiel
 	return allErrs
 }
 
+// supportedDeviceScoringStrategyTypes are the device packing strategies supported by the DeviceShare plugin,
+// MostAllocated packs the pods onto the fewest devices and LeastAllocated spreads the pods across the devices.
+var supportedDeviceScoringStrategyTypes = []string{string(config.LeastAllocated), string(config.MostAllocated)}
+
 func ValidateDeviceShareArgs(path *field.Path, args *config.DeviceShareArgs) error {
+	if allErrs := ValidateDeviceShareArgsDetailed(path, args); len(allErrs) > 0 {
+		return allErrs.ToAggregate()
+	}
+	return nil
+}
+
+// ValidateDeviceShareArgsDetailed returns the field errors of the DeviceShareArgs.
+func ValidateDeviceShareArgsDetailed(path *field.Path, args *config.DeviceShareArgs) field.ErrorList {
 	var allErrs field.ErrorList
 	if args.ScoringStrategy != nil {
+		switch args.ScoringStrategy.Type {
+		case config.LeastAllocated, config.MostAllocated:
+		default:
+			allErrs = append(allErrs, field.NotSupported(path.Child("scoringStrategy", "type"), args.ScoringStrategy.Type, supportedDeviceScoringStrategyTypes))
+		}
 		allErrs = append(allErrs, validateResources(args.ScoringStrategy.Resources, path.Child("resources"))...)
 	}
 
-	if len(allErrs) == 0 {
-		return nil
+	return allErrs
+}
+
+// MaxReservationGCDurationSeconds is the upper bound of ReservationArgs.GCDurationSeconds.
+// The expired and succeeded reservations are kept until garbage collected, a larger value is likely a typo.
+var MaxReservationGCDurationSeconds int64 = 30 * 24 * 60 * 60
+
+// RejectReservationGCDurationAboveMax rejects the GCDurationSeconds larger than MaxReservationGCDurationSeconds.
+// It is disabled by default to keep the existing configurations working, only a warning is reported then.
+var RejectReservationGCDurationAboveMax bool
+
+// GetReservationArgsWarnings returns warnings of the ReservationArgs which are valid but implausible.
+func GetReservationArgsWarnings(path *field.Path, args *config.ReservationArgs) []string {
+	var warnings []string
+	if !RejectReservationGCDurationAboveMax && args.GCDurationSeconds > MaxReservationGCDurationSeconds {
+		warnings = append(warnings, fmt.Sprintf("%s: %d is larger than %d seconds, the expired and succeeded reservations will be kept for a long time",
+			path.Child("GcDuration"), args.GCDurationSeconds, MaxReservationGCDurationSeconds))
 	}
-	return allErrs.ToAggregate()
+	return warnings
 }
 
 func ValidateReservationArgs(path *field.Path, args *config.ReservationArgs) error {
+	if allErrs := ValidateReservationArgsDetailed(path, args); len(allErrs) > 0 {
+		return allErrs.ToAggregate()
+	}
+	return nil
+}
+
+// ValidateReservationArgsDetailed returns the field errors of the ReservationArgs.
+func ValidateReservationArgsDetailed(path *field.Path, args *config.ReservationArgs) field.ErrorList {
 	var allErrs field.ErrorList
 
 	if args.MinCandidateNodesPercentage < 0 || args.MinCandidateNodesPercentage > 100 {
@@ -237,15 +394,91 @@ func ValidateReservationArgs(path *field.Path, args *config.ReservationArgs) err
 			args.GCDurationSeconds,
 			"must be non-negative",
 		))
+	} else if RejectReservationGCDurationAboveMax && args.GCDurationSeconds > MaxReservationGCDurationSeconds {
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("GcDuration"),
+			args.GCDurationSeconds,
+			fmt.Sprintf("must be less than or equal to %d", MaxReservationGCDurationSeconds),
+		))
 	}
 
-	if len(allErrs) == 0 {
+	return allErrs
+}
+
+// reservationPluginName is the name of the Reservation plugin, which cannot be imported here due to cyclic imports.
+const reservationPluginName = "Reservation"
+
+// ValidateReservationArgsWithPluginEnabled validates that the ReservationArgs can take effect.
+// Reservation GC and candidate sampling only work when the Reservation plugin is enabled in the profile.
+func ValidateReservationArgsWithPluginEnabled(path *field.Path, args *config.ReservationArgs, pluginEnabled bool) error {
+	if args == nil || pluginEnabled {
 		return nil
 	}
+	allErrs := field.ErrorList{
+		field.Invalid(path, reservationPluginName,
+			"ReservationArgs is configured but the Reservation plugin is not enabled in the profile, reservations will not be scheduled or garbage collected"),
+	}
 	return allErrs.ToAggregate()
 }
 
+// ValidateProfilesReservationArgs validates the ReservationArgs configured in each profile
+// with the enabled state of the Reservation plugin in the profile.
+func ValidateProfilesReservationArgs(profiles []schedconfig.KubeSchedulerProfile) error {
+	var errs []error
+	for i := range profiles {
+		profile := &profiles[i]
+		for j, pluginConfig := range profile.PluginConfig {
+			args, ok := pluginConfig.Args.(*config.ReservationArgs)
+			if !ok {
+				continue
+			}
+			path := field.NewPath("profiles").Index(i).Child("pluginConfig").Index(j)
+			if err := ValidateReservationArgsWithPluginEnabled(path, args, isPluginEnabled(profile.Plugins, reservationPluginName)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func isPluginEnabled(plugins *schedconfig.Plugins, pluginName string) bool {
+	if plugins == nil {
+		return false
+	}
+	for _, name := range plugins.Names() {
+		if name == pluginName {
+			return true
+		}
+	}
+	for _, plugin := range plugins.MultiPoint.Enabled {
+		if plugin.Name == pluginName {
+			return true
+		}
+	}
+	return false
+}
+
+// supportedNUMAScoringStrategyTypes are the scoring strategies of the nodes and the NUMA nodes supported by the NodeNUMAResource plugin.
+var supportedNUMAScoringStrategyTypes = []string{string(config.LeastAllocated), string(config.MostAllocated)}
+
+func validateNUMAScoringStrategyType(strategyType config.ScoringStrategyType, fldPath *field.Path) field.ErrorList {
+	switch strategyType {
+	case config.LeastAllocated, config.MostAllocated:
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(fldPath, strategyType, supportedNUMAScoringStrategyTypes)}
+	}
+}
+
 func ValidateNodeNUMAResourceArgs(path *field.Path, args *config.NodeNUMAResourceArgs) error {
+	if allErrs := ValidateNodeNUMAResourceArgsDetailed(path, args); len(allErrs) > 0 {
+		return allErrs.ToAggregate()
+	}
+	return nil
+}
+
+// ValidateNodeNUMAResourceArgsDetailed returns the field errors of the NodeNUMAResourceArgs.
+func ValidateNodeNUMAResourceArgsDetailed(path *field.Path, args *config.NodeNUMAResourceArgs) field.ErrorList {
 	var allErrs field.ErrorList
 	if args.DefaultCPUBindPolicy != "" &&
 		args.DefaultCPUBindPolicy != config.CPUBindPolicyFullPCPUs &&
@@ -256,17 +489,16 @@ func ValidateNodeNUMAResourceArgs(path *field.Path, args *config.NodeNUMAResourc
 	if args.ScoringStrategy == nil {
 		allErrs = append(allErrs, field.Required(path.Child("scoringStrategy"), "scoring strategy must be specified"))
 	} else {
+		allErrs = append(allErrs, validateNUMAScoringStrategyType(args.ScoringStrategy.Type, path.Child("scoringStrategy", "type"))...)
 		allErrs = append(allErrs, validateResources(args.ScoringStrategy.Resources, path.Child("resources"))...)
 	}
 
 	if args.NUMAScoringStrategy == nil {
 		allErrs = append(allErrs, field.Required(path.Child("numaScoringStrategy"), "NUMA scoring strategy must be specified"))
 	} else {
+		allErrs = append(allErrs, validateNUMAScoringStrategyType(args.NUMAScoringStrategy.Type, path.Child("numaScoringStrategy", "type"))...)
 		allErrs = append(allErrs, validateResources(args.NUMAScoringStrategy.Resources, path.Child("resources"))...)
 	}
 
-	if len(allErrs) == 0 {
-		return nil
-	}
-	return allErrs.ToAggregate()
+	return allErrs
 }
@@ -0,0 +1,679 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	schedconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+)
+
+func TestValidateProfilesReservationArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		plugins *schedconfig.Plugins
+		args    *config.ReservationArgs
+		wantErr string
+	}{
+		{
+			name: "reservation plugin enabled",
+			plugins: &schedconfig.Plugins{
+				PreFilter: schedconfig.PluginSet{Enabled: []schedconfig.Plugin{{Name: reservationPluginName}}},
+				Filter:    schedconfig.PluginSet{Enabled: []schedconfig.Plugin{{Name: reservationPluginName}}},
+			},
+			args: &config.ReservationArgs{GCDurationSeconds: 3600},
+		},
+		{
+			name: "reservation plugin enabled by multiPoint",
+			plugins: &schedconfig.Plugins{
+				MultiPoint: schedconfig.PluginSet{Enabled: []schedconfig.Plugin{{Name: reservationPluginName}}},
+			},
+			args: &config.ReservationArgs{GCDurationSeconds: 3600},
+		},
+		{
+			name: "reservation args not configured",
+			plugins: &schedconfig.Plugins{
+				Filter: schedconfig.PluginSet{Enabled: []schedconfig.Plugin{{Name: "NodeResourcesFit"}}},
+			},
+		},
+		{
+			name: "reservation args configured but plugin disabled",
+			plugins: &schedconfig.Plugins{
+				Filter: schedconfig.PluginSet{Enabled: []schedconfig.Plugin{{Name: "NodeResourcesFit"}}},
+			},
+			args:    &config.ReservationArgs{GCDurationSeconds: 3600},
+			wantErr: `profiles[0].pluginConfig[0]: Invalid value: "Reservation": ReservationArgs is configured but the Reservation plugin is not enabled in the profile, reservations will not be scheduled or garbage collected`,
+		},
+		{
+			name:    "reservation args configured but plugins are nil",
+			args:    &config.ReservationArgs{GCDurationSeconds: 3600},
+			wantErr: `profiles[0].pluginConfig[0]: Invalid value: "Reservation": ReservationArgs is configured but the Reservation plugin is not enabled in the profile, reservations will not be scheduled or garbage collected`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := schedconfig.KubeSchedulerProfile{
+				SchedulerName: "koord-scheduler",
+				Plugins:       tt.plugins,
+			}
+			if tt.args != nil {
+				profile.PluginConfig = append(profile.PluginConfig, schedconfig.PluginConfig{
+					Name: reservationPluginName,
+					Args: tt.args,
+				})
+			}
+			err := ValidateProfilesReservationArgs([]schedconfig.KubeSchedulerProfile{profile})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLoadAwareSchedulingArgs_EstimatedScalingFactorsByQoS(t *testing.T) {
+	tests := []struct {
+		name                string
+		scalingFactorsByQoS map[corev1.PodQOSClass]map[corev1.ResourceName]int64
+		wantErr             string
+	}{
+		{
+			name: "valid factors",
+			scalingFactorsByQoS: map[corev1.PodQOSClass]map[corev1.ResourceName]int64{
+				corev1.PodQOSGuaranteed: {corev1.ResourceCPU: 100, corev1.ResourceMemory: 1},
+				corev1.PodQOSBestEffort: {corev1.ResourceCPU: 50},
+			},
+		},
+		{
+			name: "factor less than 1",
+			scalingFactorsByQoS: map[corev1.PodQOSClass]map[corev1.ResourceName]int64{
+				corev1.PodQOSBurstable: {corev1.ResourceCPU: 0},
+			},
+			wantErr: "estimatedScalingFactorsByQoS[Burstable][cpu]",
+		},
+		{
+			name: "factor greater than 100",
+			scalingFactorsByQoS: map[corev1.PodQOSClass]map[corev1.ResourceName]int64{
+				corev1.PodQOSGuaranteed: {corev1.ResourceMemory: 101},
+			},
+			wantErr: "estimatedScalingFactorsByQoS[Guaranteed][memory]",
+		},
+		{
+			name: "unknown QoS class",
+			scalingFactorsByQoS: map[corev1.PodQOSClass]map[corev1.ResourceName]int64{
+				"LS": {corev1.ResourceCPU: 50},
+			},
+			wantErr: "estimatedScalingFactorsByQoS[LS]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLoadAwareSchedulingArgs(&config.LoadAwareSchedulingArgs{
+				EstimatedScalingFactorsByQoS: tt.scalingFactorsByQoS,
+			})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLoadAwareSchedulingArgs_EstimatedScalingFactorsAboveRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		allow          bool
+		scalingFactors map[corev1.ResourceName]int64
+		byQoS          map[corev1.PodQOSClass]map[corev1.ResourceName]int64
+		wantErr        string
+	}{
+		{
+			name:           "factor above 100 is rejected by default",
+			scalingFactors: map[corev1.ResourceName]int64{corev1.ResourceCPU: 150},
+			wantErr:        "estimated resource ScalingFactor of cpu should be less than 100, got 150",
+		},
+		{
+			name:    "factor by QoS above 100 is rejected by default",
+			byQoS:   map[corev1.PodQOSClass]map[corev1.ResourceName]int64{corev1.PodQOSBurstable: {corev1.ResourceMemory: 150}},
+			wantErr: "estimatedScalingFactorsByQoS[Burstable][memory]: Invalid value: 150: must be in the range [1, 100]",
+		},
+		{
+			name:           "factor above 100 is allowed",
+			allow:          true,
+			scalingFactors: map[corev1.ResourceName]int64{corev1.ResourceCPU: 150},
+			byQoS:          map[corev1.PodQOSClass]map[corev1.ResourceName]int64{corev1.PodQOSBurstable: {corev1.ResourceMemory: 150}},
+		},
+		{
+			name:           "factor at the allowed upper bound",
+			allow:          true,
+			scalingFactors: map[corev1.ResourceName]int64{corev1.ResourceCPU: MaxEstimatedScalingFactorAboveRequest},
+		},
+		{
+			name:           "factor above the allowed upper bound",
+			allow:          true,
+			scalingFactors: map[corev1.ResourceName]int64{corev1.ResourceCPU: MaxEstimatedScalingFactorAboveRequest + 1},
+			wantErr:        "estimated resource ScalingFactor of cpu should be less than 500, got 501",
+		},
+		{
+			name:    "factor by QoS above the allowed upper bound",
+			allow:   true,
+			byQoS:   map[corev1.PodQOSClass]map[corev1.ResourceName]int64{corev1.PodQOSBurstable: {corev1.ResourceMemory: 501}},
+			wantErr: "estimatedScalingFactorsByQoS[Burstable][memory]: Invalid value: 501: must be in the range [1, 500]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(allow bool) {
+				AllowEstimatedScalingFactorsAboveRequest = allow
+			}(AllowEstimatedScalingFactorsAboveRequest)
+			AllowEstimatedScalingFactorsAboveRequest = tt.allow
+
+			err := ValidateLoadAwareSchedulingArgs(&config.LoadAwareSchedulingArgs{
+				EstimatedScalingFactors:      tt.scalingFactors,
+				EstimatedScalingFactorsByQoS: tt.byQoS,
+			})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetLoadAwareSchedulingArgsWarnings_ResourceWeights(t *testing.T) {
+	tests := []struct {
+		name        string
+		weights     map[corev1.ResourceName]int64
+		wantWarning bool
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:    "default weights",
+			weights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 1, corev1.ResourceMemory: 1},
+		},
+		{
+			name:    "single resource",
+			weights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 100},
+		},
+		{
+			name:    "differentiated weights",
+			weights: map[corev1.ResourceName]int64{corev1.ResourceCPU: 100, corev1.ResourceMemory: 50},
+		},
+		{
+			name:        "all weights equal",
+			weights:     map[corev1.ResourceName]int64{corev1.ResourceCPU: 100, corev1.ResourceMemory: 100},
+			wantWarning: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &config.LoadAwareSchedulingArgs{
+				ResourceWeights:         tt.weights,
+				EstimatedScalingFactors: map[corev1.ResourceName]int64{corev1.ResourceCPU: 85, corev1.ResourceMemory: 70},
+			}
+			assert.NoError(t, ValidateLoadAwareSchedulingArgs(args))
+			warnings := GetLoadAwareSchedulingArgsWarnings(args)
+			if tt.wantWarning {
+				assert.Len(t, warnings, 1)
+				assert.Contains(t, warnings[0], "resourceWeights")
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestValidateLoadAwareSchedulingArgs_AggregatedMetricSampleInterval(t *testing.T) {
+	tests := []struct {
+		name       string
+		aggregated *config.LoadAwareSchedulingAggregatedArgs
+		wantErr    []string
+	}{
+		{
+			name: "sample interval not set",
+			aggregated: &config.LoadAwareSchedulingAggregatedArgs{
+				UsageAggregatedDuration: metav1.Duration{Duration: 5*time.Minute + 7*time.Second},
+			},
+		},
+		{
+			name: "aligned durations",
+			aggregated: &config.LoadAwareSchedulingAggregatedArgs{
+				UsageAggregatedDuration: metav1.Duration{Duration: 5 * time.Minute},
+				ScoreAggregatedDuration: metav1.Duration{Duration: 30 * time.Minute},
+				MetricSampleInterval:    metav1.Duration{Duration: time.Minute},
+			},
+		},
+		{
+			name: "durations use the default period",
+			aggregated: &config.LoadAwareSchedulingAggregatedArgs{
+				MetricSampleInterval: metav1.Duration{Duration: 15 * time.Second},
+			},
+		},
+		{
+			name: "misaligned durations",
+			aggregated: &config.LoadAwareSchedulingAggregatedArgs{
+				UsageAggregatedDuration: metav1.Duration{Duration: 90 * time.Second},
+				ScoreAggregatedDuration: metav1.Duration{Duration: 5*time.Minute + 30*time.Second},
+				MetricSampleInterval:    metav1.Duration{Duration: time.Minute},
+			},
+			wantErr: []string{"aggregated.usageAggregatedDuration", "aggregated.scoreAggregatedDuration"},
+		},
+		{
+			name: "negative sample interval",
+			aggregated: &config.LoadAwareSchedulingAggregatedArgs{
+				MetricSampleInterval: metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: []string{"aggregated.metricSampleInterval"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLoadAwareSchedulingArgs(&config.LoadAwareSchedulingArgs{
+				Aggregated: tt.aggregated,
+			})
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, wantErr := range tt.wantErr {
+				assert.ErrorContains(t, err, wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAggregationType(t *testing.T) {
+	fldPath := field.NewPath("aggregated").Child("usageAggregationType")
+	for _, aggType := range extension.KnownAggregationTypes {
+		assert.Nil(t, validateAggregationType(aggType, fldPath), aggType)
+	}
+
+	const p80 extension.AggregationType = "p80"
+	err := validateAggregationType(p80, fldPath)
+	assert.NotNil(t, err)
+	assert.Equal(t, field.ErrorTypeNotSupported, err.Type)
+
+	knownAggregationTypes := extension.KnownAggregationTypes
+	defer func() {
+		extension.KnownAggregationTypes = knownAggregationTypes
+	}()
+	extension.KnownAggregationTypes = append(append([]extension.AggregationType{}, knownAggregationTypes...), p80)
+	assert.Nil(t, validateAggregationType(p80, fldPath))
+}
+
+func TestValidateElasticQuotaArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *config.ElasticQuotaArgs
+		wantErrs []string
+	}{
+		{
+			name: "valid args",
+			args: &config.ElasticQuotaArgs{
+				DelayEvictTime:    metav1.Duration{Duration: 120 * time.Second},
+				RevokePodInterval: metav1.Duration{Duration: time.Second},
+			},
+		},
+		{
+			name: "negative delayEvictTime",
+			args: &config.ElasticQuotaArgs{
+				DelayEvictTime:    metav1.Duration{Duration: -time.Second},
+				RevokePodInterval: metav1.Duration{Duration: time.Second},
+			},
+			wantErrs: []string{"delayEvictTime: Invalid value"},
+		},
+		{
+			name: "negative revokePodInterval",
+			args: &config.ElasticQuotaArgs{
+				DelayEvictTime:    metav1.Duration{Duration: 120 * time.Second},
+				RevokePodInterval: metav1.Duration{Duration: -time.Second},
+			},
+			wantErrs: []string{"revokePodInterval: Invalid value"},
+		},
+		{
+			name: "all negative durations are reported together",
+			args: &config.ElasticQuotaArgs{
+				DelayEvictTime:    metav1.Duration{Duration: -time.Second},
+				RevokePodInterval: metav1.Duration{Duration: -time.Second},
+			},
+			wantErrs: []string{"delayEvictTime: Invalid value", "revokePodInterval: Invalid value"},
+		},
+		{
+			name: "revokePodInterval greater than delayEvictTime",
+			args: &config.ElasticQuotaArgs{
+				DelayEvictTime:    metav1.Duration{Duration: time.Second},
+				RevokePodInterval: metav1.Duration{Duration: time.Minute},
+			},
+			wantErrs: []string{"revokePodInterval: Invalid value", "should not be greater than delayEvictTime"},
+		},
+		{
+			name: "negative defaultQuotaGroupMax",
+			args: &config.ElasticQuotaArgs{
+				DefaultQuotaGroupMax: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("-1")},
+			},
+			wantErrs: []string{"defaultQuotaGroupMax[cpu]: Invalid value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateElasticQuotaArgs(tt.args)
+			if len(tt.wantErrs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, wantErr := range tt.wantErrs {
+				assert.ErrorContains(t, err, wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDeviceShareArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy *config.ScoringStrategy
+		wantErr  string
+	}{
+		{
+			name: "scoring strategy not set",
+		},
+		{
+			name:     "binpack",
+			strategy: &config.ScoringStrategy{Type: config.MostAllocated},
+		},
+		{
+			name:     "spread",
+			strategy: &config.ScoringStrategy{Type: config.LeastAllocated},
+		},
+		{
+			name:     "unsupported strategy",
+			strategy: &config.ScoringStrategy{Type: config.BalancedAllocation},
+			wantErr:  `scoringStrategy.type: Unsupported value: "BalancedAllocation": supported values: "LeastAllocated", "MostAllocated"`,
+		},
+		{
+			name:     "empty strategy type",
+			strategy: &config.ScoringStrategy{},
+			wantErr:  `scoringStrategy.type: Unsupported value: ""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDeviceShareArgs(nil, &config.DeviceShareArgs{ScoringStrategy: tt.strategy})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateNodeNUMAResourceArgs_ScoringStrategyType(t *testing.T) {
+	tests := []struct {
+		name         string
+		strategy     config.ScoringStrategyType
+		numaStrategy config.ScoringStrategyType
+		wantErr      string
+	}{
+		{
+			name:         "spread nodes and binpack NUMA nodes",
+			strategy:     config.LeastAllocated,
+			numaStrategy: config.MostAllocated,
+		},
+		{
+			name:         "binpack nodes and spread NUMA nodes",
+			strategy:     config.MostAllocated,
+			numaStrategy: config.LeastAllocated,
+		},
+		{
+			name:         "unsupported scoring strategy",
+			strategy:     config.BalancedAllocation,
+			numaStrategy: config.MostAllocated,
+			wantErr:      `scoringStrategy.type: Unsupported value: "BalancedAllocation": supported values: "LeastAllocated", "MostAllocated"`,
+		},
+		{
+			name:         "unknown NUMA scoring strategy",
+			strategy:     config.LeastAllocated,
+			numaStrategy: "unknown",
+			wantErr:      `numaScoringStrategy.type: Unsupported value: "unknown"`,
+		},
+		{
+			name:         "empty NUMA scoring strategy type",
+			strategy:     config.LeastAllocated,
+			numaStrategy: "",
+			wantErr:      `numaScoringStrategy.type: Unsupported value: ""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNodeNUMAResourceArgs(nil, &config.NodeNUMAResourceArgs{
+				ScoringStrategy:     &config.ScoringStrategy{Type: tt.strategy},
+				NUMAScoringStrategy: &config.ScoringStrategy{Type: tt.numaStrategy},
+			})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateCoschedulingArgs_ControllerWorkers(t *testing.T) {
+	tests := []struct {
+		name              string
+		controllerWorkers int64
+		wantErr           string
+	}{
+		{
+			name:              "minimum",
+			controllerWorkers: 1,
+		},
+		{
+			name:              "zero",
+			controllerWorkers: 0,
+			wantErr:           "ControllerWorkers invalid",
+		},
+		{
+			name:              "at the upper bound",
+			controllerWorkers: MaxCoschedulingControllerWorkers,
+		},
+		{
+			name:              "above the upper bound",
+			controllerWorkers: MaxCoschedulingControllerWorkers + 1,
+			wantErr:           "controllerWorkers: Invalid value: 1001: must be less than or equal to 1000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCoschedulingArgs(&config.CoschedulingArgs{
+				DefaultTimeout:    metav1.Duration{Duration: time.Minute},
+				ControllerWorkers: tt.controllerWorkers,
+			})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateReservationArgs_GCDurationSecondsUpperBound(t *testing.T) {
+	tests := []struct {
+		name              string
+		gcDurationSeconds int64
+		reject            bool
+		wantWarning       bool
+		wantErr           string
+	}{
+		{
+			name:              "within the upper bound",
+			gcDurationSeconds: 86400,
+		},
+		{
+			name:              "at the upper bound",
+			gcDurationSeconds: MaxReservationGCDurationSeconds,
+		},
+		{
+			name:              "above the upper bound",
+			gcDurationSeconds: MaxReservationGCDurationSeconds + 1,
+			wantWarning:       true,
+		},
+		{
+			name:              "at the upper bound and rejected",
+			gcDurationSeconds: MaxReservationGCDurationSeconds,
+			reject:            true,
+		},
+		{
+			name:              "above the upper bound and rejected",
+			gcDurationSeconds: MaxReservationGCDurationSeconds + 1,
+			reject:            true,
+			wantErr:           "GcDuration: Invalid value: 2592001: must be less than or equal to 2592000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(reject bool) {
+				RejectReservationGCDurationAboveMax = reject
+			}(RejectReservationGCDurationAboveMax)
+			RejectReservationGCDurationAboveMax = tt.reject
+
+			args := &config.ReservationArgs{GCDurationSeconds: tt.gcDurationSeconds}
+			err := ValidateReservationArgs(nil, args)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+			warnings := GetReservationArgsWarnings(nil, args)
+			if tt.wantWarning {
+				assert.Len(t, warnings, 1)
+				assert.Contains(t, warnings[0], "GcDuration")
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestValidateArgsDetailed(t *testing.T) {
+	fieldsOf := func(errs field.ErrorList) []string {
+		var fields []string
+		for _, err := range errs {
+			fields = append(fields, err.Field)
+		}
+		return fields
+	}
+	tests := []struct {
+		name       string
+		validate   func() field.ErrorList
+		wantFields []string
+	}{
+		{
+			name: "LoadAwareSchedulingArgs",
+			validate: func() field.ErrorList {
+				return ValidateLoadAwareSchedulingArgsDetailed(&config.LoadAwareSchedulingArgs{
+					NodeMetricExpirationSeconds: pointer.Int64(-1),
+					ResourceWeights:             map[corev1.ResourceName]int64{corev1.ResourceCPU: -1},
+					EstimatedScalingFactors:     map[corev1.ResourceName]int64{corev1.ResourceCPU: 80},
+					UsageThresholds:             map[corev1.ResourceName]int64{corev1.ResourceMemory: 101},
+				})
+			},
+			wantFields: []string{"nodeMetricExpiredSeconds", "resourceWeights", "usageThresholds"},
+		},
+		{
+			name: "valid LoadAwareSchedulingArgs",
+			validate: func() field.ErrorList {
+				return ValidateLoadAwareSchedulingArgsDetailed(&config.LoadAwareSchedulingArgs{
+					ResourceWeights:         map[corev1.ResourceName]int64{corev1.ResourceCPU: 1},
+					EstimatedScalingFactors: map[corev1.ResourceName]int64{corev1.ResourceCPU: 80},
+				})
+			},
+		},
+		{
+			name: "ElasticQuotaArgs",
+			validate: func() field.ErrorList {
+				return ValidateElasticQuotaArgsDetailed(&config.ElasticQuotaArgs{
+					DefaultQuotaGroupMax: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("-1")},
+					DelayEvictTime:       metav1.Duration{Duration: -time.Second},
+				})
+			},
+			wantFields: []string{"defaultQuotaGroupMax[cpu]", "delayEvictTime"},
+		},
+		{
+			name: "CoschedulingArgs",
+			validate: func() field.ErrorList {
+				return ValidateCoschedulingArgsDetailed(&config.CoschedulingArgs{
+					DefaultTimeout:    metav1.Duration{Duration: -time.Second},
+					ControllerWorkers: 0,
+				})
+			},
+			wantFields: []string{"defaultTimeout", "controllerWorkers"},
+		},
+		{
+			name: "DeviceShareArgs",
+			validate: func() field.ErrorList {
+				return ValidateDeviceShareArgsDetailed(field.NewPath("args"), &config.DeviceShareArgs{
+					ScoringStrategy: &config.ScoringStrategy{Type: "unknown"},
+				})
+			},
+			wantFields: []string{"args.scoringStrategy.type"},
+		},
+		{
+			name: "ReservationArgs",
+			validate: func() field.ErrorList {
+				return ValidateReservationArgsDetailed(field.NewPath("args"), &config.ReservationArgs{
+					MinCandidateNodesPercentage: 101,
+					MinCandidateNodesAbsolute:   -1,
+					GCDurationSeconds:           -1,
+				})
+			},
+			wantFields: []string{"args.MinCandidateNodesPercentage", "args.MinCandidateNodesAbsolute", "args.GcDuration"},
+		},
+		{
+			name: "NodeNUMAResourceArgs",
+			validate: func() field.ErrorList {
+				return ValidateNodeNUMAResourceArgsDetailed(field.NewPath("args"), &config.NodeNUMAResourceArgs{
+					DefaultCPUBindPolicy: "unknown",
+				})
+			},
+			wantFields: []string{"args.defaultCPUBindPolicy", "args.scoringStrategy", "args.numaScoringStrategy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantFields, fieldsOf(tt.validate()))
+		})
+	}
+}
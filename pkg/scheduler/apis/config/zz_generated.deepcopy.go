@@ -183,6 +183,7 @@ func (in *LoadAwareSchedulingAggregatedArgs) DeepCopyInto(out *LoadAwareScheduli
 	}
 	out.UsageAggregatedDuration = in.UsageAggregatedDuration
 	out.ScoreAggregatedDuration = in.ScoreAggregatedDuration
+	out.MetricSampleInterval = in.MetricSampleInterval
 	return
 }
 
@@ -243,6 +244,23 @@ func (in *LoadAwareSchedulingArgs) DeepCopyInto(out *LoadAwareSchedulingArgs) {
 			(*out)[key] = val
 		}
 	}
+	if in.EstimatedScalingFactorsByQoS != nil {
+		in, out := &in.EstimatedScalingFactorsByQoS, &out.EstimatedScalingFactorsByQoS
+		*out = make(map[v1.PodQOSClass]map[v1.ResourceName]int64, len(*in))
+		for key, val := range *in {
+			var outVal map[v1.ResourceName]int64
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[v1.ResourceName]int64, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.EstimatedSecondsAfterPodScheduled != nil {
 		in, out := &in.EstimatedSecondsAfterPodScheduled, &out.EstimatedSecondsAfterPodScheduled
 		*out = new(int64)
@@ -39,16 +39,18 @@ const (
 )
 
 type DefaultEstimator struct {
-	resourceWeights map[corev1.ResourceName]int64
-	scalingFactors  map[corev1.ResourceName]int64
-	allowCustomize  bool
+	resourceWeights     map[corev1.ResourceName]int64
+	scalingFactors      map[corev1.ResourceName]int64
+	scalingFactorsByQoS map[corev1.PodQOSClass]map[corev1.ResourceName]int64
+	allowCustomize      bool
 }
 
 func NewDefaultEstimator(args *config.LoadAwareSchedulingArgs, handle framework.Handle) (Estimator, error) {
 	return &DefaultEstimator{
-		resourceWeights: args.ResourceWeights,
-		scalingFactors:  args.EstimatedScalingFactors,
-		allowCustomize:  args.AllowCustomizeEstimation,
+		resourceWeights:     args.ResourceWeights,
+		scalingFactors:      args.EstimatedScalingFactors,
+		scalingFactorsByQoS: args.EstimatedScalingFactorsByQoS,
+		allowCustomize:      args.AllowCustomizeEstimation,
 	}, nil
 }
 
@@ -61,10 +63,11 @@ func (e *DefaultEstimator) EstimatePod(pod *corev1.Pod) (map[corev1.ResourceName
 	if e.allowCustomize {
 		factors = extension.GetCustomEstimatedScalingFactors(pod)
 	}
+	scalingFactors := e.getScalingFactors(pod)
 	if len(factors) == 0 {
-		factors = e.scalingFactors
+		factors = scalingFactors
 	} else {
-		for k, v := range e.scalingFactors {
+		for k, v := range scalingFactors {
 			if _, ok := factors[k]; !ok {
 				factors[k] = v
 			}
@@ -73,6 +76,23 @@ func (e *DefaultEstimator) EstimatePod(pod *corev1.Pod) (map[corev1.ResourceName
 	return estimatedPodUsed(pod, e.resourceWeights, factors), nil
 }
 
+// getScalingFactors returns the scaling factors of the pod's QoS class,
+// falling back to the flat scaling factors for the resources not configured.
+func (e *DefaultEstimator) getScalingFactors(pod *corev1.Pod) map[corev1.ResourceName]int64 {
+	qosFactors := e.scalingFactorsByQoS[extension.GetKubeQosClass(pod)]
+	if len(qosFactors) == 0 {
+		return e.scalingFactors
+	}
+	factors := make(map[corev1.ResourceName]int64, len(e.scalingFactors))
+	for k, v := range e.scalingFactors {
+		factors[k] = v
+	}
+	for k, v := range qosFactors {
+		factors[k] = v
+	}
+	return factors
+}
+
 func estimatedPodUsed(pod *corev1.Pod, resourceWeights map[corev1.ResourceName]int64, scalingFactors map[corev1.ResourceName]int64) map[corev1.ResourceName]int64 {
 	requests, limits := resourceapi.PodRequests(pod, resourceapi.PodResourcesOptions{}), resourceapi.PodLimits(pod, resourceapi.PodResourcesOptions{})
 	estimatedUsed := make(map[corev1.ResourceName]int64)
@@ -288,6 +288,127 @@ func TestDefaultEstimatorEstimatePod(t *testing.T) {
 	}
 }
 
+func TestDefaultEstimatorEstimatePodByQoS(t *testing.T) {
+	guaranteedPod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Limits: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("8Gi"),
+						},
+						Requests: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("8Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+	burstablePod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Limits: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("8Gi"),
+						},
+						Requests: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourceCPU:    resource.MustParse("2"),
+							corev1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+	bestEffortPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				extension.LabelPodQoS: string(extension.QoSBE),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Priority: ptr.To(extension.PriorityBatchValueMin),
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Limits: map[corev1.ResourceName]resource.Quantity{
+							extension.BatchCPU:    resource.MustParse("4000"),
+							extension.BatchMemory: resource.MustParse("8Gi"),
+						},
+						Requests: map[corev1.ResourceName]resource.Quantity{
+							extension.BatchCPU:    resource.MustParse("4000"),
+							extension.BatchMemory: resource.MustParse("8Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+	scalingFactorsByQoS := map[corev1.PodQOSClass]map[corev1.ResourceName]int64{
+		corev1.PodQOSGuaranteed: {
+			corev1.ResourceCPU: 100,
+		},
+		corev1.PodQOSBestEffort: {
+			corev1.ResourceCPU:    50,
+			corev1.ResourceMemory: 50,
+		},
+	}
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want map[corev1.ResourceName]int64
+	}{
+		{
+			name: "guaranteed pod uses its QoS factors and falls back to the flat factors",
+			pod:  guaranteedPod,
+			want: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    4000,
+				corev1.ResourceMemory: 6012954214, // 5.6Gi
+			},
+		},
+		{
+			name: "best-effort pod uses its QoS factors",
+			pod:  bestEffortPod,
+			want: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    2000,
+				corev1.ResourceMemory: 4294967296, // 4Gi
+			},
+		},
+		{
+			name: "burstable pod without QoS factors uses the flat factors",
+			pod:  burstablePod,
+			want: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    3400,
+				corev1.ResourceMemory: 6012954214, // 5.6Gi
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v1beta3args v1beta3.LoadAwareSchedulingArgs
+			v1beta3args.EstimatedScalingFactorsByQoS = scalingFactorsByQoS
+			v1beta3.SetDefaults_LoadAwareSchedulingArgs(&v1beta3args)
+			var loadAwareSchedulingArgs config.LoadAwareSchedulingArgs
+			err := v1beta3.Convert_v1beta3_LoadAwareSchedulingArgs_To_config_LoadAwareSchedulingArgs(&v1beta3args, &loadAwareSchedulingArgs, nil)
+			assert.NoError(t, err)
+			estimator, err := NewDefaultEstimator(&loadAwareSchedulingArgs, nil)
+			assert.NoError(t, err)
+
+			got, err := estimator.EstimatePod(tt.pod)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestDefaultEstimatorEstimateNode(t *testing.T) {
 	tests := []struct {
 		name string
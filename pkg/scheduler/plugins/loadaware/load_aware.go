@@ -82,6 +82,9 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 	if err := validation.ValidateLoadAwareSchedulingArgs(pluginArgs); err != nil {
 		return nil, err
 	}
+	for _, warning := range validation.GetLoadAwareSchedulingArgsWarnings(pluginArgs) {
+		klog.Warningf("LoadAwareSchedulingArgs: %s", warning)
+	}
 
 	frameworkExtender, ok := handle.(frameworkext.ExtendedHandle)
 	if !ok {
@@ -112,6 +112,9 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 	if err := validation.ValidateReservationArgs(nil, pluginArgs); err != nil {
 		return nil, err
 	}
+	for _, warning := range validation.GetReservationArgsWarnings(nil, pluginArgs) {
+		klog.Warningf("ReservationArgs: %s", warning)
+	}
 	extendedHandle, ok := handle.(frameworkext.ExtendedHandle)
 	if !ok {
 		return nil, fmt.Errorf("want handle to be of type frameworkext.ExtendedHandle, got %T", handle)
@@ -19,6 +19,7 @@ package webhook
 import (
 	"github.com/koordinator-sh/koordinator/pkg/features"
 	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/elasticquota"
 	"github.com/koordinator-sh/koordinator/pkg/webhook/elasticquota/mutating"
 	"github.com/koordinator-sh/koordinator/pkg/webhook/elasticquota/validating"
 )
@@ -33,4 +34,5 @@ func init() {
 	})
 
 	RegisterDebugAPIProvider("/elasticQuota", &validating.ElasticQuotaValidatingHandler{})
+	RegisterDebugAPIProvider(elasticquota.QuotaTopologyResyncPath, elasticquota.NewQuotaTopologyResyncHandler())
 }
@@ -66,6 +66,10 @@ func (c *QuotaMetaChecker) AdmitQuota(ctx context.Context, req admission.Request
 	}
 
 	quotaObj := obj.(*v1alpha1.ElasticQuota)
+	// reject malformed quantities before they are rewritten into the annotations
+	if errs := validateQuotaResourceQuantities(quotaObj); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
 	return c.QuotaTopo.fillQuotaDefaultInformation(quotaObj)
 }
 
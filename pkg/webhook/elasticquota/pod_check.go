@@ -39,8 +39,8 @@ import (
 // Supporting the parentQuotaGroup to submit pods is the future work.
 
 func (qt *quotaTopology) ValidateAddPod(pod *corev1.Pod) error {
-	qt.lock.Lock()
-	defer qt.lock.Unlock()
+	qt.lock.RLock()
+	defer qt.lock.RUnlock()
 
 	featureGate := utilfeature.DefaultFeatureGate
 	if featureGate.Enabled(features.SupportParentQuotaSubmitPod) {
@@ -19,9 +19,12 @@ package elasticquota
 import (
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/metrics"
 )
 
 // webhook works with multiple copies at the same time, so it needs to watch other copies' writes to task effect locally.
@@ -52,6 +55,7 @@ func (qt *quotaTopology) OnQuotaAdd(obj interface{}) {
 		qt.namespaceToQuotaMap[ns] = quota.Name
 	}
 
+	recordQuotaUsageMetrics(quota)
 	klog.V(5).Infof("OnQuotaAdd success: %v.%v", quota.Namespace, quota.Name)
 }
 
@@ -91,6 +95,7 @@ func (qt *quotaTopology) OnQuotaUpdate(oldObj, newObj interface{}) {
 		}
 	}
 
+	recordQuotaUsageMetrics(newQuota)
 	klog.V(5).Infof("OnQuotaUpdate success: %v.%v", newQuota.Namespace, newQuota.Name)
 }
 
@@ -114,5 +119,27 @@ func (qt *quotaTopology) OnQuotaDelete(obj interface{}) {
 	for _, ns := range namespaces {
 		delete(qt.namespaceToQuotaMap, ns)
 	}
+	metrics.ResetQuotaUsage(quota.Name)
 	klog.V(5).Infof("OnQuotaDelete success: %v.%v", quota.Namespace, quota.Name)
 }
+
+func recordQuotaUsageMetrics(quota *v1alpha1.ElasticQuota) {
+	metrics.ResetQuotaUsage(quota.Name)
+	metrics.RecordQuotaUsedRatio(quota.Name, quota.Status.Used, quota.Spec.Max)
+
+	// the runtime is calculated by the scheduler, skip if it is not reported yet
+	if quota.Annotations[extension.AnnotationRuntime] == "" {
+		return
+	}
+	runtime, err := extension.GetRuntime(quota)
+	if err != nil {
+		return
+	}
+	var request corev1.ResourceList
+	if quota.Annotations[extension.AnnotationRequest] != "" {
+		if request, err = extension.GetRequest(quota); err != nil {
+			request = nil
+		}
+	}
+	metrics.RecordQuotaMinGuaranteeMet(quota.Name, quota.Spec.Min, request, runtime)
+}
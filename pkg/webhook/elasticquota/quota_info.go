@@ -17,6 +17,8 @@ limitations under the License.
 package elasticquota
 
 import (
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
@@ -33,6 +35,11 @@ type QuotaInfo struct {
 	TreeID            string
 	IsTreeRoot        bool
 	CalculateInfo     QuotaCalculateInfo
+	// SharedWeight is the sharedWeight of the quota after defaulting, see resolveSharedWeight.
+	SharedWeight v1.ResourceList
+	// TempMax temporarily raises the Max of CalculateInfo until TempMaxExpireTime.
+	TempMax           v1.ResourceList
+	TempMaxExpireTime time.Time
 }
 
 type QuotaCalculateInfo struct {
@@ -75,6 +82,8 @@ func NewQuotaInfoFromQuota(quota *v1alpha1.ElasticQuota) *QuotaInfo {
 	quotaInfo.AllowForceUpdate = extension.IsAllowForceUpdate(quota)
 	quotaInfo.CalculateInfo.Allocated, _ = extension.GetAllocated(quota)
 	quotaInfo.CalculateInfo.Guaranteed, _ = extension.GetGuaranteed(quota)
+	quotaInfo.TempMax, quotaInfo.TempMaxExpireTime, _ = extension.GetQuotaTempMax(quota)
+	quotaInfo.SharedWeight, _ = resolveSharedWeight(quota)
 
 	return quotaInfo
 }
@@ -87,13 +96,25 @@ func (qi *QuotaInfo) setMinQuotaNoLock(res v1.ResourceList) {
 	qi.CalculateInfo.Min = res.DeepCopy()
 }
 
+// GetEffectiveMax returns the Max raised by the TempMax if the TempMax has not expired at now.
+func (qi *QuotaInfo) GetEffectiveMax(now time.Time) v1.ResourceList {
+	effectiveMax := qi.CalculateInfo.Max.DeepCopy()
+	if len(qi.TempMax) == 0 || !now.Before(qi.TempMaxExpireTime) {
+		return effectiveMax
+	}
+	for resourceName, quantity := range qi.TempMax {
+		effectiveMax[resourceName] = quantity.DeepCopy()
+	}
+	return effectiveMax
+}
+
 func (qi *QuotaInfo) GetQuotaSummary() *QuotaInfoSummary {
 	quotaInfoSummary := NewQuotaInfoSummary()
 	quotaInfoSummary.Name = qi.Name
 	quotaInfoSummary.ParentName = qi.ParentName
 	quotaInfoSummary.IsParent = qi.IsParent
 	quotaInfoSummary.AllowLentResource = qi.AllowLentResource
-	quotaInfoSummary.Max = qi.CalculateInfo.Max.DeepCopy()
+	quotaInfoSummary.Max = qi.GetEffectiveMax(time.Now())
 	quotaInfoSummary.Min = qi.CalculateInfo.Min.DeepCopy()
 	return quotaInfoSummary
 }
@@ -19,75 +19,154 @@ package elasticquota
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
 	"github.com/koordinator-sh/koordinator/pkg/webhook/metrics"
 )
 
+var (
+	// AllowedQuotaResources is a comma-separated list of the resources which are allowed in quota's min and max.
+	// Empty means all resources are allowed.
+	AllowedQuotaResources string
+	// MaxNamespacesPerQuota is the maximum number of namespaces a quota can bind via annotation.
+	// Zero means unlimited.
+	MaxNamespacesPerQuota int
+	// QuotaTreeCeilings is a JSON map from the quota tree id to the ceiling of the tree, the sum of the max
+	// of the top-level quotas in the tree can not exceed the ceiling.
+	QuotaTreeCeilings string
+	// DeriveQuotaTreeCeilingFromRoot uses the max of the tree root quota as the ceiling of the trees
+	// not configured in QuotaTreeCeilings.
+	DeriveQuotaTreeCeilingFromRoot bool
+)
+
+func InitFlags(fs *flag.FlagSet) {
+	fs.StringVar(&AllowedQuotaResources, "allowed-quota-resources", AllowedQuotaResources,
+		"A comma-separated list of the resources which are allowed in ElasticQuota's min and max. Empty means all resources are allowed.")
+	fs.IntVar(&MaxNamespacesPerQuota, "max-namespaces-per-quota", MaxNamespacesPerQuota,
+		"The maximum number of namespaces an ElasticQuota can bind via annotation. Zero means unlimited.")
+	fs.StringVar(&QuotaTreeCeilings, "quota-tree-ceilings", QuotaTreeCeilings,
+		"A JSON map from the quota tree id to the ceiling of the tree, e.g. {\"tree-a\":{\"cpu\":\"100\",\"memory\":\"200Gi\"}}. "+
+			"The sum of the max of the top-level quotas in a tree can not exceed its ceiling.")
+	fs.BoolVar(&DeriveQuotaTreeCeilingFromRoot, "derive-quota-tree-ceiling-from-root", DeriveQuotaTreeCeilingFromRoot,
+		"Use the max of the tree root quota as the ceiling of the quota trees not configured in quota-tree-ceilings.")
+}
+
 type quotaTopology struct {
-	lock sync.Mutex
+	// lock guards the maps below, read paths only take the read lock
+	lock sync.RWMutex
+	// resyncLock guards against concurrent resyncs
+	resyncLock sync.Mutex
 	// quotaInfoMap stores all quota information
 	quotaInfoMap map[string]*QuotaInfo
 	// namespaceMap key: annotationNamespace, val: quotaName
 	namespaceToQuotaMap map[string]string
 	// quotaHierarchyInfo stores the quota's all children
 	quotaHierarchyInfo map[string]map[string]struct{}
+	// allowedQuotaResources stores the resources allowed in quota's min and max, empty means all resources are allowed
+	allowedQuotaResources sets.Set[corev1.ResourceName]
+	// maxNamespacesPerQuota is the maximum number of namespaces a quota can bind, zero means unlimited
+	maxNamespacesPerQuota int
+	// treeCeilings stores the configured ceiling of the quota trees, key: treeID
+	treeCeilings map[string]corev1.ResourceList
+	// deriveTreeCeilingFromRoot uses the max of the tree root quota as the ceiling of the trees not in treeCeilings
+	deriveTreeCeilingFromRoot bool
 
 	client client.Client
 }
 
 func NewQuotaTopology(client client.Client) *quotaTopology {
 	topology := &quotaTopology{
-		quotaInfoMap:        make(map[string]*QuotaInfo),
-		quotaHierarchyInfo:  make(map[string]map[string]struct{}),
-		namespaceToQuotaMap: make(map[string]string),
-		client:              client,
+		quotaInfoMap:              make(map[string]*QuotaInfo),
+		quotaHierarchyInfo:        make(map[string]map[string]struct{}),
+		namespaceToQuotaMap:       make(map[string]string),
+		allowedQuotaResources:     parseAllowedQuotaResources(AllowedQuotaResources),
+		maxNamespacesPerQuota:     MaxNamespacesPerQuota,
+		treeCeilings:              parseQuotaTreeCeilings(QuotaTreeCeilings),
+		deriveTreeCeilingFromRoot: DeriveQuotaTreeCeilingFromRoot,
+		client:                    client,
 	}
 	topology.quotaHierarchyInfo[extension.RootQuotaName] = make(map[string]struct{})
 	return topology
 }
 
+// ValidAddQuota validates the quota to be added and adds it to the topology.
+// The parent quota must be created before its children, a quota whose non-root parent does not exist is rejected.
 func (qt *quotaTopology) ValidAddQuota(quota *v1alpha1.ElasticQuota) error {
 	if quota == nil {
 		return fmt.Errorf("AddQuota param is nil")
 	}
 
+	start := time.Now()
 	qt.lock.Lock()
-	defer qt.lock.Unlock()
+	defer func() {
+		qt.lock.Unlock()
+		metrics.RecordQuotaAdmissionDuration(metrics.QuotaAdmissionAdd, start)
+	}()
 
 	if _, exist := qt.quotaInfoMap[quota.Name]; exist {
 		return fmt.Errorf("AddQuota quota already exist:%v", quota.Name)
 	}
 
+	if parentName := extension.GetParentQuotaName(quota); quota.Name != extension.RootQuotaName && parentName != extension.RootQuotaName {
+		if _, exist := qt.quotaInfoMap[parentName]; !exist {
+			return fmt.Errorf("AddQuota quota %v's parent %v does not exist, the parent quota must be created before its children", quota.Name, parentName)
+		}
+	}
+
 	annotationNamespaces := extension.GetAnnotationQuotaNamespaces(quota)
+	if err := checkNamespacesValid(quota.Name, annotationNamespaces); err != nil {
+		return err
+	}
+	if err := qt.checkNamespacesCount(quota.Name, annotationNamespaces); err != nil {
+		return err
+	}
 	for _, namespace := range annotationNamespaces {
 		if quotaName, exist := qt.namespaceToQuotaMap[namespace]; exist {
 			return fmt.Errorf("AddQuota quota %s's annotation namespace %s is already bound to quota %s", quota.Name, namespace, quotaName)
 		}
 	}
 
+	if err := checkReservedQuotaMin(quota); err != nil {
+		return err
+	}
+
 	if err := qt.validateQuotaSelfItem(quota); err != nil {
 		return err
 	}
 
+	if err := checkTempMax(nil, quota, time.Now()); err != nil {
+		return err
+	}
+
 	quotaInfo := NewQuotaInfoFromQuota(quota)
 
 	if err := qt.validateQuotaTopology(nil, quotaInfo, nil); err != nil {
 		return err
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(features.ElasticQuotaForbidNamespacedParent) {
+		if err := qt.checkNamespacedParent(quotaInfo, annotationNamespaces); err != nil {
+			return err
+		}
+	}
+
 	qt.quotaInfoMap[quotaInfo.Name] = quotaInfo
 	qt.quotaHierarchyInfo[quotaInfo.Name] = make(map[string]struct{})
 	if qt.quotaHierarchyInfo[quotaInfo.ParentName] == nil {
@@ -115,10 +194,20 @@ func (qt *quotaTopology) ValidUpdateQuota(oldQuota, newQuota *v1alpha1.ElasticQu
 		return err
 	}
 
+	start := time.Now()
 	qt.lock.Lock()
-	defer qt.lock.Unlock()
+	defer func() {
+		qt.lock.Unlock()
+		metrics.RecordQuotaAdmissionDuration(metrics.QuotaAdmissionUpdate, start)
+	}()
 
 	annotationNamespaces := extension.GetAnnotationQuotaNamespaces(newQuota)
+	if err := checkNamespacesValid(quotaName, annotationNamespaces); err != nil {
+		return err
+	}
+	if err := qt.checkNamespacesCount(quotaName, annotationNamespaces); err != nil {
+		return err
+	}
 	for _, namespace := range annotationNamespaces {
 		if oldQuotaName, exist := qt.namespaceToQuotaMap[namespace]; exist && oldQuotaName != quotaName {
 			return fmt.Errorf("UpdadteQuota, quota %s update namespaces, but namespace %s is already bound to quota %s",
@@ -131,16 +220,30 @@ func (qt *quotaTopology) ValidUpdateQuota(oldQuota, newQuota *v1alpha1.ElasticQu
 		return fmt.Errorf("UpdateQuota quota not exist in quotaInfoMap:%v", quotaName)
 	}
 
+	if err := checkReservedQuotaMin(newQuota); err != nil {
+		return err
+	}
+
 	if err := qt.validateQuotaSelfItem(newQuota); err != nil {
 		return err
 	}
 
+	if err := checkTempMax(oldQuota, newQuota, time.Now()); err != nil {
+		return err
+	}
+
 	oldAnnotationNamespaces := extension.GetAnnotationQuotaNamespaces(oldQuota)
 	newQuotaInfo := NewQuotaInfoFromQuota(newQuota)
 	if err := qt.validateQuotaTopology(oldQuotaInfo, newQuotaInfo, oldAnnotationNamespaces); err != nil {
 		return err
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(features.ElasticQuotaForbidNamespacedParent) {
+		if err := qt.checkNamespacedParent(newQuotaInfo, annotationNamespaces); err != nil {
+			return err
+		}
+	}
+
 	qt.quotaInfoMap[quotaName] = newQuotaInfo
 	if oldQuotaInfo.ParentName != newQuotaInfo.ParentName {
 		delete(qt.quotaHierarchyInfo[oldQuotaInfo.ParentName], oldQuotaInfo.Name)
@@ -157,11 +260,15 @@ func (qt *quotaTopology) ValidUpdateQuota(oldQuota, newQuota *v1alpha1.ElasticQu
 }
 
 func (qt *quotaTopology) ValidDeleteQuota(quota *v1alpha1.ElasticQuota) error {
+	start := time.Now()
 	qt.lock.Lock()
-	defer qt.lock.Unlock()
+	defer func() {
+		qt.lock.Unlock()
+		metrics.RecordQuotaAdmissionDuration(metrics.QuotaAdmissionDelete, start)
+	}()
 
 	quotaName := quota.Name
-	if quotaName == extension.SystemQuotaName || quotaName == extension.RootQuotaName || quotaName == extension.DefaultQuotaName {
+	if isReservedQuota(quotaName) {
 		return fmt.Errorf("can not delete quotaGroup :%v", quotaName)
 	}
 	quotaInfo, exist := qt.quotaInfoMap[quotaName]
@@ -217,8 +324,8 @@ func (qt *quotaTopology) fillQuotaDefaultInformation(quota *v1alpha1.ElasticQuot
 		return nil
 	}
 
-	qt.lock.Lock()
-	defer qt.lock.Unlock()
+	qt.lock.RLock()
+	defer qt.lock.RUnlock()
 
 	if quota.Labels == nil {
 		quota.Labels = make(map[string]string)
@@ -244,13 +351,17 @@ func (qt *quotaTopology) fillQuotaDefaultInformation(quota *v1alpha1.ElasticQuot
 		}
 	}
 
-	maxQuota, err := json.Marshal(&quota.Spec.Max)
+	sharedWeightMax, err := getSharedWeightMax(quota)
+	if err != nil {
+		return fmt.Errorf("fillDefaultQuotaInfo get shared weight resource keys failed:%v", err)
+	}
+	maxQuota, err := json.Marshal(&sharedWeightMax)
 	if err != nil {
 		return fmt.Errorf("fillDefaultQuotaInfo marshal quota max failed:%v", err)
 	}
 	if sharedWeight, exist := quota.Annotations[extension.AnnotationSharedWeight]; !exist || len(sharedWeight) == 0 {
 		quota.Annotations[extension.AnnotationSharedWeight] = string(maxQuota)
-		metrics.RecordQuotaSharedWeight(quota.Name, quota.Spec.Max)
+		metrics.RecordQuotaSharedWeight(quota.Name, sharedWeightMax)
 		klog.V(5).Infof("fill quota %v sharedWeight as max", quota.Name)
 	} else {
 		sharedWeightRL := make(corev1.ResourceList)
@@ -258,7 +369,9 @@ func (qt *quotaTopology) fillQuotaDefaultInformation(quota *v1alpha1.ElasticQuot
 		if err != nil {
 			return fmt.Errorf("fillDefaultQuotaInfo unmarshal sharedWeight failed:%v", err)
 		}
-		if fixedSharedWeight(sharedWeightRL, quota.Spec.Max) {
+		if extension.IsSharedWeightPinned(quota) {
+			klog.V(5).Infof("quota %v sharedWeight is pinned, skip fixing it by max", quota.Name)
+		} else if fixedSharedWeight(sharedWeightRL, sharedWeightMax) {
 			fixedSharedWeightRL, err := json.Marshal(&sharedWeightRL)
 			if err != nil {
 				return fmt.Errorf("fillDefaultQuotaInfo marshal fixedSharedWeight max failed:%v", err)
@@ -284,8 +397,8 @@ func NewQuotaTopologySummary() *QuotaTopologySummary {
 func (qt *quotaTopology) getQuotaTopologyInfo() *QuotaTopologySummary {
 	result := NewQuotaTopologySummary()
 
-	qt.lock.Lock()
-	defer qt.lock.Unlock()
+	qt.lock.RLock()
+	defer qt.lock.RUnlock()
 
 	for key, value := range qt.quotaInfoMap {
 		result.QuotaInfoMap[key] = value.GetQuotaSummary()
@@ -302,8 +415,8 @@ func (qt *quotaTopology) getQuotaTopologyInfo() *QuotaTopologySummary {
 }
 
 func (qt *quotaTopology) getQuotaInfo(name, namespace string) *QuotaInfo {
-	qt.lock.Lock()
-	defer qt.lock.Unlock()
+	qt.lock.RLock()
+	defer qt.lock.RUnlock()
 
 	info, ok := qt.quotaInfoMap[name]
 	if ok {
@@ -316,6 +429,64 @@ func (qt *quotaTopology) getQuotaInfo(name, namespace string) *QuotaInfo {
 	return nil
 }
 
+// GetEffectiveQuota returns the min, max and sharedWeight of the quota after defaulting, consistent with
+// fillQuotaDefaultInformation. The max is raised by the unexpired temp max. The results are copies.
+func (qt *quotaTopology) GetEffectiveQuota(name string) (min, max, sharedWeight corev1.ResourceList, err error) {
+	qt.lock.RLock()
+	defer qt.lock.RUnlock()
+
+	quotaInfo, exist := qt.quotaInfoMap[name]
+	if !exist {
+		return nil, nil, nil, fmt.Errorf("GetEffectiveQuota quota not exist:%v", name)
+	}
+	sharedWeight = quotaInfo.SharedWeight.DeepCopy()
+	if sharedWeight == nil {
+		sharedWeight = quotaInfo.CalculateInfo.Max.DeepCopy()
+	}
+	return quotaInfo.CalculateInfo.Min.DeepCopy(), quotaInfo.GetEffectiveMax(time.Now()), sharedWeight, nil
+}
+
+// resolveSharedWeight returns the sharedWeight filled by fillQuotaDefaultInformation without mutating the quota.
+// The sharedWeight defaults to the max, and its keys are fixed to be the same as the max unless it is pinned.
+// Only the resources listed in the shared weight resource keys are considered if the annotation is set.
+func resolveSharedWeight(quota *v1alpha1.ElasticQuota) (corev1.ResourceList, error) {
+	sharedWeightMax, err := getSharedWeightMax(quota)
+	if err != nil {
+		return nil, fmt.Errorf("resolveSharedWeight get shared weight resource keys failed:%v", err)
+	}
+	sharedWeight, exist := quota.Annotations[extension.AnnotationSharedWeight]
+	if !exist || len(sharedWeight) == 0 {
+		return sharedWeightMax, nil
+	}
+	sharedWeightRL := make(corev1.ResourceList)
+	if err := json.Unmarshal([]byte(sharedWeight), &sharedWeightRL); err != nil {
+		return nil, fmt.Errorf("resolveSharedWeight unmarshal sharedWeight failed:%v", err)
+	}
+	if !extension.IsSharedWeightPinned(quota) {
+		fixedSharedWeight(sharedWeightRL, sharedWeightMax)
+	}
+	return sharedWeightRL, nil
+}
+
+// getSharedWeightMax returns the max of the resources participating in the shared weight fairness,
+// which the sharedWeight defaults to and whose keys the sharedWeight is aligned with.
+func getSharedWeightMax(quota *v1alpha1.ElasticQuota) (corev1.ResourceList, error) {
+	resourceKeys, err := extension.GetSharedWeightResourceKeys(quota)
+	if err != nil {
+		return nil, err
+	}
+	if resourceKeys == nil {
+		return quota.Spec.Max.DeepCopy(), nil
+	}
+	sharedWeightMax := corev1.ResourceList{}
+	for _, key := range resourceKeys {
+		if quantity, ok := quota.Spec.Max[key]; ok {
+			sharedWeightMax[key] = quantity.DeepCopy()
+		}
+	}
+	return sharedWeightMax, nil
+}
+
 // fixedSharedWeight keep keys in sharedWeight and maxQuota same
 // if key in maxQuota not included in sharedWeight, add key/value in sharedWeight
 // if key in sharedWeight not included in maxQuota, delete key/value in sharedWeight
@@ -340,3 +511,25 @@ func fixedSharedWeight(sharedWeight, maxQuota corev1.ResourceList) bool {
 	}
 	return fixed
 }
+
+func parseAllowedQuotaResources(resources string) sets.Set[corev1.ResourceName] {
+	allowed := sets.New[corev1.ResourceName]()
+	for _, name := range strings.Split(resources, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			allowed.Insert(corev1.ResourceName(name))
+		}
+	}
+	return allowed
+}
+
+func parseQuotaTreeCeilings(ceilings string) map[string]corev1.ResourceList {
+	if ceilings == "" {
+		return nil
+	}
+	treeCeilings := map[string]corev1.ResourceList{}
+	if err := json.Unmarshal([]byte(ceilings), &treeCeilings); err != nil {
+		klog.ErrorS(err, "Failed to parse the quota tree ceilings, ignore them", "ceilings", ceilings)
+		return nil
+	}
+	return treeCeilings
+}
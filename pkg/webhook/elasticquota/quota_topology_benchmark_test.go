@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func newBenchmarkQuotaTopology(quotaNum int) *quotaTopology {
+	qt := newFakeQuotaTopology()
+	for i := 0; i < quotaNum; i++ {
+		name := fmt.Sprintf("quota-%d", i)
+		qt.quotaInfoMap[name] = NewQuotaInfo(false, false, name, extension.RootQuotaName)
+		qt.quotaInfoMap[name].CalculateInfo.Max = MakeResourceList().CPU(100).Mem(1048576).Obj()
+		qt.quotaInfoMap[name].CalculateInfo.Min = MakeResourceList().CPU(10).Mem(1024).Obj()
+		qt.quotaHierarchyInfo[extension.RootQuotaName][name] = struct{}{}
+		qt.namespaceToQuotaMap[fmt.Sprintf("namespace-%d", i)] = name
+	}
+	return qt
+}
+
+func BenchmarkQuotaTopology_getQuotaInfo(b *testing.B) {
+	qt := newBenchmarkQuotaTopology(1000)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			qt.getQuotaInfo("", fmt.Sprintf("namespace-%d", i%1000))
+			i++
+		}
+	})
+}
+
+func BenchmarkQuotaTopology_getQuotaTopologyInfo(b *testing.B) {
+	qt := newBenchmarkQuotaTopology(100)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			qt.getQuotaTopologyInfo()
+		}
+	})
+}
+
+func BenchmarkQuotaTopology_getQuotaInfoWithConcurrentWrites(b *testing.B) {
+	qt := newBenchmarkQuotaTopology(1000)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go func() {
+		for {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+			qt.lock.Lock()
+			qt.quotaInfoMap["quota-0"] = NewQuotaInfo(false, false, "quota-0", extension.RootQuotaName)
+			qt.lock.Unlock()
+		}
+	}()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			qt.getQuotaInfo(fmt.Sprintf("quota-%d", i%1000), "")
+			i++
+		}
+	})
+}
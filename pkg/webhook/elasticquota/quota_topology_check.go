@@ -19,10 +19,17 @@ package elasticquota
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
@@ -37,19 +44,30 @@ import (
 )
 
 func (qt *quotaTopology) validateQuotaSelfItem(quota *v1alpha1.ElasticQuota) error {
-	// min and max's each dimension should not have negative value
-	if resourceNames := quotav1.IsNegative(quota.Spec.Max); len(resourceNames) > 0 {
-		return fmt.Errorf("%v quota.Spec.Max's value < 0, in dimensions :%v", quota.Name, resourceNames)
+	// min and max's each dimension should be a valid quantity without negative value
+	if errs := validateQuotaResourceQuantities(quota); len(errs) > 0 {
+		return errs.ToAggregate()
 	}
 
-	if resourceNames := quotav1.IsNegative(quota.Spec.Min); len(resourceNames) > 0 {
-		return fmt.Errorf("%v quota.Spec.Min's value < 0, in dimensions :%v", quota.Name, resourceNames)
+	// min and max should only contain the allowed resources
+	if resourceNames := qt.getDisallowedResources(quota.Spec.Max); len(resourceNames) > 0 {
+		return fmt.Errorf("%v quota.Spec.Max contains disallowed resources :%v", quota.Name, resourceNames)
+	}
+
+	if resourceNames := qt.getDisallowedResources(quota.Spec.Min); len(resourceNames) > 0 {
+		return fmt.Errorf("%v quota.Spec.Min contains disallowed resources :%v", quota.Name, resourceNames)
 	}
 
 	var sharedRatio v1.ResourceList
 	// 1.check if sharewight is equal to
+	if extension.IsSharedWeightPinned(quota) && quota.Annotations[extension.AnnotationSharedWeight] == "" {
+		return fmt.Errorf("%v quota.Annotation[%v] is required when quota.Annotation[%v] is true", quota.Name, extension.AnnotationSharedWeight, extension.AnnotationSharedWeightPinned)
+	}
 	if quota.Annotations[extension.AnnotationSharedWeight] != "" {
 		if err := json.Unmarshal([]byte(quota.Annotations[extension.AnnotationSharedWeight]), &sharedRatio); err != nil {
+			if extension.IsSharedWeightPinned(quota) {
+				return fmt.Errorf("%v quota.Annotation[%v] is pinned but not a valid resource list: %w", quota.Name, extension.AnnotationSharedWeight, err)
+			}
 			return err
 		}
 
@@ -70,6 +88,17 @@ func (qt *quotaTopology) validateQuotaSelfItem(quota *v1alpha1.ElasticQuota) err
 		}
 	}
 
+	// check if all keys in AnnotationSharedWeightResourceKeys are included in max
+	sharedWeightResourceKeys, err := extension.GetSharedWeightResourceKeys(quota)
+	if err != nil {
+		return fmt.Errorf("%v quota.Annotation[%v]'s value is invalid: %w", quota.Name, extension.AnnotationSharedWeightResourceKeys, err)
+	}
+	for _, key := range sharedWeightResourceKeys {
+		if _, exist := quota.Spec.Max[key]; !exist {
+			return fmt.Errorf("resourceKey %v of quota %v is included in quota.Annotation[%v], which is not included in max", key, quota.Name, extension.AnnotationSharedWeightResourceKeys)
+		}
+	}
+
 	// 1. check if all key in AnnotationMaxStrictCheckResourceKeys in max >= that in used
 	resourceKeys, err := extension.GetMaxStrictCheckResourceKeys(quota)
 	if err != nil {
@@ -87,6 +116,52 @@ func (qt *quotaTopology) validateQuotaSelfItem(quota *v1alpha1.ElasticQuota) err
 	return nil
 }
 
+// isReservedQuota returns true if the quota is one of the quotas created by koordinator itself.
+func isReservedQuota(quotaName string) bool {
+	return quotaName == extension.SystemQuotaName || quotaName == extension.RootQuotaName || quotaName == extension.DefaultQuotaName
+}
+
+// checkReservedQuotaMin checks the reserved quotas carry no min. They are created without min and are not counted
+// in the min of the top-level quotas, a non-zero min would make the guaranteed resources of the quota tree overcommitted.
+func checkReservedQuotaMin(quota *v1alpha1.ElasticQuota) error {
+	if !isReservedQuota(quota.Name) || quotav1.IsZero(quota.Spec.Min) {
+		return nil
+	}
+	return fmt.Errorf("reserved quota %v can not carry a non-zero min :%v", quota.Name, quota.Spec.Min)
+}
+
+// validateQuotaResourceQuantities checks that each quantity in the quota's min and max parses and is non-negative.
+func validateQuotaResourceQuantities(quota *v1alpha1.ElasticQuota) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateResourceQuantities(quota.Spec.Max, specPath.Child("max"))...)
+	allErrs = append(allErrs, validateResourceQuantities(quota.Spec.Min, specPath.Child("min"))...)
+	return allErrs
+}
+
+func validateResourceQuantities(resources v1.ResourceList, fldPath *field.Path) field.ErrorList {
+	resourceNames := make([]v1.ResourceName, 0, len(resources))
+	for resourceName := range resources {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Slice(resourceNames, func(i, j int) bool {
+		return resourceNames[i] < resourceNames[j]
+	})
+
+	var allErrs field.ErrorList
+	for _, resourceName := range resourceNames {
+		quantity := resources[resourceName]
+		if _, err := resource.ParseQuantity(quantity.String()); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(string(resourceName)), quantity.String(), err.Error()))
+			continue
+		}
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(string(resourceName)), quantity.String(), "must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
+
 // validateQuotaTopology checks the quotaInfo's topology with its parent and its children.
 // oldQuotaInfo is null when validate a new create request, and is the current quotaInfo when validate a update request.
 func (qt *quotaTopology) validateQuotaTopology(oldQuotaInfo, newQuotaInfo *QuotaInfo, oldNamespaces []string) error {
@@ -102,6 +177,10 @@ func (qt *quotaTopology) validateQuotaTopology(oldQuotaInfo, newQuotaInfo *Quota
 		return err
 	}
 
+	if err := qt.checkTreeCeiling(newQuotaInfo); err != nil {
+		return err
+	}
+
 	// if the quotaInfo's parent is root and its IsParent is false, the following checks will be true, just return nil.
 	if newQuotaInfo.ParentName == extension.RootQuotaName && !newQuotaInfo.IsParent {
 		return nil
@@ -183,6 +262,22 @@ func (qt *quotaTopology) checkIsParentChange(oldQuotaInfo, quotaInfo *QuotaInfo,
 	return nil
 }
 
+// checkNamespacedParent forbids the quota bound to annotation namespaces from being a parent or having children.
+func (qt *quotaTopology) checkNamespacedParent(quotaInfo *QuotaInfo, namespaces []string) error {
+	if len(namespaces) == 0 {
+		return nil
+	}
+	if quotaInfo.IsParent {
+		return fmt.Errorf("quota %v is bound to namespaces %v by annotation %v, isParent is forbidden to be true",
+			quotaInfo.Name, namespaces, extension.AnnotationQuotaNamespaces)
+	}
+	if len(qt.quotaHierarchyInfo[quotaInfo.Name]) > 0 {
+		return fmt.Errorf("quota %v is bound to namespaces %v by annotation %v, but it has children quotas",
+			quotaInfo.Name, namespaces, extension.AnnotationQuotaNamespaces)
+	}
+	return nil
+}
+
 // checkParentQuotaInfo check parent exist
 func (qt *quotaTopology) checkParentQuotaInfo(quotaName, parentName string) error {
 	if parentName != extension.RootQuotaName {
@@ -371,6 +466,7 @@ func quotaFieldsCopy(q *v1alpha1.ElasticQuota) v1alpha1.ElasticQuota {
 			},
 			Annotations: map[string]string{
 				extension.AnnotationQuotaNamespaces: q.Annotations[extension.AnnotationQuotaNamespaces],
+				extension.AnnotationQuotaTempMax:    q.Annotations[extension.AnnotationQuotaTempMax],
 			},
 		},
 		Spec: *q.Spec.DeepCopy(),
@@ -463,3 +559,115 @@ func (qt *quotaTopology) checkParentGuaranteed(newGuarantee v1.ResourceList, sel
 
 	return qt.checkParentGuaranteed(newParentGuaranteed, parentInfo.Name, parentInfo.ParentName)
 }
+
+// checkNamespacesCount checks the quota doesn't bind more namespaces than maxNamespacesPerQuota.
+func (qt *quotaTopology) checkNamespacesCount(quotaName string, namespaces []string) error {
+	if qt.maxNamespacesPerQuota <= 0 || len(namespaces) <= qt.maxNamespacesPerQuota {
+		return nil
+	}
+	return fmt.Errorf("quota %s binds %d namespaces, exceeds the limit %d", quotaName, len(namespaces), qt.maxNamespacesPerQuota)
+}
+
+// checkTempMax checks the quota's temporarily raised max is not less than its max in each dimension,
+// and expires in the future. It is skipped if the temp max is not changed, so that an expired one doesn't
+// block other updates.
+func checkTempMax(oldQuota, newQuota *v1alpha1.ElasticQuota, now time.Time) error {
+	value := newQuota.Annotations[extension.AnnotationQuotaTempMax]
+	if value == "" || (oldQuota != nil && oldQuota.Annotations[extension.AnnotationQuotaTempMax] == value) {
+		return nil
+	}
+	tempMax, expireTime, err := extension.GetQuotaTempMax(newQuota)
+	if err != nil {
+		return fmt.Errorf("%v quota.Annotation[%v]'s value is invalid: %w", newQuota.Name, extension.AnnotationQuotaTempMax, err)
+	}
+	if !expireTime.After(now) {
+		return fmt.Errorf("%v quota.Annotation[%v]'s expireTime %v is not in the future", newQuota.Name, extension.AnnotationQuotaTempMax, expireTime.Format(time.RFC3339))
+	}
+	for key, val := range tempMax {
+		if maxVal, exist := newQuota.Spec.Max[key]; !exist {
+			return fmt.Errorf("resourceKey %v of quota %v is included in temp max, which is not included in max", key, newQuota.Name)
+		} else if val.Cmp(maxVal) == -1 {
+			return fmt.Errorf("resourceKey %v of quota %v temp max %v < max %v", key, newQuota.Name, val.String(), maxVal.String())
+		}
+	}
+	return nil
+}
+
+// checkNamespacesValid checks the namespaces bound by the quota's annotation are valid namespace names,
+// i.e. lowercase RFC 1123 labels, otherwise the binding would never match any namespace.
+func checkNamespacesValid(quotaName string, namespaces []string) error {
+	for _, namespace := range namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("quota %s's annotation namespace %q is invalid: %s", quotaName, namespace, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// checkTreeCeiling checks the sum of the max of the top-level quotas in the quota's tree doesn't exceed the tree's ceiling.
+// It only takes effect when the quota is the tree root or a top-level quota, i.e. a child of the tree root.
+func (qt *quotaTopology) checkTreeCeiling(quotaInfo *QuotaInfo) error {
+	if quotaInfo.TreeID == "" {
+		return nil
+	}
+	isTopLevel := qt.isTreeTopLevelQuota(quotaInfo)
+	if !quotaInfo.IsTreeRoot && !isTopLevel {
+		return nil
+	}
+
+	ceiling := qt.treeCeilings[quotaInfo.TreeID]
+	if ceiling == nil && qt.deriveTreeCeilingFromRoot {
+		if quotaInfo.IsTreeRoot {
+			ceiling = quotaInfo.CalculateInfo.Max
+		} else {
+			ceiling = qt.quotaInfoMap[quotaInfo.ParentName].CalculateInfo.Max
+		}
+	}
+	if len(ceiling) == 0 {
+		return nil
+	}
+
+	topLevelMaxSum := v1.ResourceList{}
+	if isTopLevel {
+		topLevelMaxSum = quotav1.Add(topLevelMaxSum, quotaInfo.CalculateInfo.Max)
+	}
+	for name, info := range qt.quotaInfoMap {
+		if name == quotaInfo.Name || info.TreeID != quotaInfo.TreeID || !qt.isTreeTopLevelQuota(info) {
+			continue
+		}
+		topLevelMaxSum = quotav1.Add(topLevelMaxSum, info.CalculateInfo.Max)
+	}
+
+	var exceeded []string
+	for resourceName, limit := range ceiling {
+		if sum, ok := topLevelMaxSum[resourceName]; ok && sum.Cmp(limit) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf("%s: %s > %s", resourceName, sum.String(), limit.String()))
+		}
+	}
+	if len(exceeded) > 0 {
+		sort.Strings(exceeded)
+		return fmt.Errorf("quota %s over-allocates tree %s, the sum of the top-level quotas' max exceeds the ceiling, %s",
+			quotaInfo.Name, quotaInfo.TreeID, strings.Join(exceeded, ", "))
+	}
+	return nil
+}
+
+// isTreeTopLevelQuota returns whether the quota is a child of its tree root.
+func (qt *quotaTopology) isTreeTopLevelQuota(quotaInfo *QuotaInfo) bool {
+	parentInfo, exist := qt.quotaInfoMap[quotaInfo.ParentName]
+	return exist && parentInfo.IsTreeRoot && parentInfo.TreeID == quotaInfo.TreeID
+}
+
+// getDisallowedResources returns the sorted resources of the resourceList which are not allowed in quota.
+func (qt *quotaTopology) getDisallowedResources(resourceList v1.ResourceList) []v1.ResourceName {
+	if qt.allowedQuotaResources.Len() == 0 {
+		return nil
+	}
+	disallowed := sets.New[v1.ResourceName]()
+	for resourceName := range resourceList {
+		if !qt.allowedQuotaResources.Has(resourceName) {
+			disallowed.Insert(resourceName)
+		}
+	}
+	return sets.List(disallowed)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+var errQuotaTopologyResyncInProgress = errors.New("quota topology resync is already in progress")
+
+// QuotaTopologyResyncSummary describes the corrections made to the in-memory quota topology by a resync.
+type QuotaTopologyResyncSummary struct {
+	// AddedQuotas are the quotas missing in memory
+	AddedQuotas []string `json:"addedQuotas,omitempty"`
+	// RemovedQuotas are the quotas which no longer exist
+	RemovedQuotas []string `json:"removedQuotas,omitempty"`
+	// UpdatedQuotas are the quotas whose information was stale
+	UpdatedQuotas []string `json:"updatedQuotas,omitempty"`
+	// UpdatedHierarchies are the quotas whose children were stale
+	UpdatedHierarchies []string `json:"updatedHierarchies,omitempty"`
+	// UpdatedNamespaces are the annotation namespaces whose bound quota was stale
+	UpdatedNamespaces []string `json:"updatedNamespaces,omitempty"`
+}
+
+func (s *QuotaTopologyResyncSummary) Corrections() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.AddedQuotas) + len(s.RemovedQuotas) + len(s.UpdatedQuotas) +
+		len(s.UpdatedHierarchies) + len(s.UpdatedNamespaces)
+}
+
+// Resync rebuilds the in-memory quota topology from the listed quotas, and returns the corrections made.
+func (qt *quotaTopology) Resync(ctx context.Context) (*QuotaTopologyResyncSummary, error) {
+	if !qt.resyncLock.TryLock() {
+		return nil, errQuotaTopologyResyncInProgress
+	}
+	defer qt.resyncLock.Unlock()
+
+	// The topology is rebuilt under the write lock, otherwise the quotas added, updated or deleted
+	// by the admission between the list and the swap would be lost.
+	qt.lock.Lock()
+	defer qt.lock.Unlock()
+
+	quotaList := &v1alpha1.ElasticQuotaList{}
+	if err := qt.client.List(ctx, quotaList); err != nil {
+		return nil, fmt.Errorf("failed to list quotas, err: %v", err)
+	}
+
+	quotaInfoMap := make(map[string]*QuotaInfo)
+	quotaHierarchyInfo := make(map[string]map[string]struct{})
+	quotaHierarchyInfo[extension.RootQuotaName] = make(map[string]struct{})
+	namespaceToQuotaMap := make(map[string]string)
+	for i := range quotaList.Items {
+		quota := &quotaList.Items[i]
+		quotaInfo := NewQuotaInfoFromQuota(quota)
+		quotaInfoMap[quotaInfo.Name] = quotaInfo
+		if quotaHierarchyInfo[quotaInfo.Name] == nil {
+			quotaHierarchyInfo[quotaInfo.Name] = make(map[string]struct{})
+		}
+		if quotaHierarchyInfo[quotaInfo.ParentName] == nil {
+			quotaHierarchyInfo[quotaInfo.ParentName] = make(map[string]struct{})
+		}
+		quotaHierarchyInfo[quotaInfo.ParentName][quotaInfo.Name] = struct{}{}
+		for _, namespace := range extension.GetAnnotationQuotaNamespaces(quota) {
+			namespaceToQuotaMap[namespace] = quota.Name
+		}
+	}
+
+	summary := &QuotaTopologyResyncSummary{}
+	for name, quotaInfo := range quotaInfoMap {
+		oldQuotaInfo, exist := qt.quotaInfoMap[name]
+		if !exist {
+			summary.AddedQuotas = append(summary.AddedQuotas, name)
+		} else if !isQuotaInfoEqual(oldQuotaInfo, quotaInfo) {
+			summary.UpdatedQuotas = append(summary.UpdatedQuotas, name)
+		}
+	}
+	for name := range qt.quotaInfoMap {
+		if _, exist := quotaInfoMap[name]; !exist {
+			summary.RemovedQuotas = append(summary.RemovedQuotas, name)
+		}
+	}
+	for name, children := range quotaHierarchyInfo {
+		if !isChildrenEqual(qt.quotaHierarchyInfo[name], children) {
+			summary.UpdatedHierarchies = append(summary.UpdatedHierarchies, name)
+		}
+	}
+	for name, children := range qt.quotaHierarchyInfo {
+		if _, exist := quotaHierarchyInfo[name]; !exist && len(children) > 0 {
+			summary.UpdatedHierarchies = append(summary.UpdatedHierarchies, name)
+		}
+	}
+	for namespace, quotaName := range namespaceToQuotaMap {
+		if qt.namespaceToQuotaMap[namespace] != quotaName {
+			summary.UpdatedNamespaces = append(summary.UpdatedNamespaces, namespace)
+		}
+	}
+	for namespace := range qt.namespaceToQuotaMap {
+		if _, exist := namespaceToQuotaMap[namespace]; !exist {
+			summary.UpdatedNamespaces = append(summary.UpdatedNamespaces, namespace)
+		}
+	}
+	sort.Strings(summary.AddedQuotas)
+	sort.Strings(summary.RemovedQuotas)
+	sort.Strings(summary.UpdatedQuotas)
+	sort.Strings(summary.UpdatedHierarchies)
+	sort.Strings(summary.UpdatedNamespaces)
+
+	qt.quotaInfoMap = quotaInfoMap
+	qt.quotaHierarchyInfo = quotaHierarchyInfo
+	qt.namespaceToQuotaMap = namespaceToQuotaMap
+
+	klog.Infof("resync quota topology success, corrections: %d, summary: %+v", summary.Corrections(), summary)
+	return summary, nil
+}
+
+func isQuotaInfoEqual(a, b *QuotaInfo) bool {
+	return a.IsParent == b.IsParent &&
+		a.AllowLentResource == b.AllowLentResource &&
+		a.AllowForceUpdate == b.AllowForceUpdate &&
+		a.Name == b.Name &&
+		a.ParentName == b.ParentName &&
+		a.TreeID == b.TreeID &&
+		a.IsTreeRoot == b.IsTreeRoot &&
+		quotav1.Equals(a.CalculateInfo.Max, b.CalculateInfo.Max) &&
+		quotav1.Equals(a.CalculateInfo.Min, b.CalculateInfo.Min) &&
+		quotav1.Equals(a.CalculateInfo.Guaranteed, b.CalculateInfo.Guaranteed) &&
+		quotav1.Equals(a.CalculateInfo.Allocated, b.CalculateInfo.Allocated) &&
+		quotav1.Equals(a.SharedWeight, b.SharedWeight) &&
+		quotav1.Equals(a.TempMax, b.TempMax) &&
+		a.TempMaxExpireTime.Equal(b.TempMaxExpireTime)
+}
+
+func isChildrenEqual(a, b map[string]struct{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name := range a {
+		if _, exist := b[name]; !exist {
+			return false
+		}
+	}
+	return true
+}
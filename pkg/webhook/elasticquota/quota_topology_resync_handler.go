@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+
+	"github.com/koordinator-sh/koordinator/pkg/webhook/metrics"
+)
+
+const QuotaTopologyResyncPath = "/quota-topology/resync"
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// QuotaTopologyResyncHandler forces the in-memory quota topology to resync when it is suspected to be drifted.
+// The caller should bring a bearer token which is allowed to update elasticquotas.
+type QuotaTopologyResyncHandler struct {
+	// authenticate returns the http status code and error if the request is not allowed to trigger the resync.
+	authenticate func(ctx context.Context, c client.Client, r *http.Request) (int, error)
+}
+
+func NewQuotaTopologyResyncHandler() *QuotaTopologyResyncHandler {
+	return &QuotaTopologyResyncHandler{
+		authenticate: authenticateByToken,
+	}
+}
+
+var _ http.Handler = &QuotaTopologyResyncHandler{}
+
+func (h *QuotaTopologyResyncHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	qt := quotaMetaCheck.QuotaTopo
+	if qt == nil {
+		http.Error(w, "quota topology is not initialized", http.StatusServiceUnavailable)
+		return
+	}
+
+	if code, err := h.authenticate(r.Context(), qt.client, r); err != nil {
+		klog.Warningf("reject to resync quota topology, err: %v", err)
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	summary, err := qt.Resync(r.Context())
+	metrics.RecordQuotaTopologyManualResync(err, summary.Corrections())
+	if err != nil {
+		klog.Errorf("failed to resync quota topology, err: %v", err)
+		code := http.StatusInternalServerError
+		if errors.Is(err, errQuotaTopologyResyncInProgress) {
+			code = http.StatusConflict
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	summaryJson, _ := json.Marshal(summary)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(summaryJson)
+}
+
+// authenticateByToken reviews the bearer token of the request, and checks whether the user can update elasticquotas.
+func authenticateByToken(ctx context.Context, c client.Client, r *http.Request) (int, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		return http.StatusUnauthorized, fmt.Errorf("missing bearer token")
+	}
+
+	tokenReview := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}
+	if err := c.Create(ctx, tokenReview); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to review token, err: %v", err)
+	}
+	if !tokenReview.Status.Authenticated {
+		return http.StatusUnauthorized, fmt.Errorf("invalid bearer token")
+	}
+
+	userInfo := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for k, v := range userInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	accessReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			UID:    userInfo.UID,
+			Groups: userInfo.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:    v1alpha1.SchemeGroupVersion.Group,
+				Resource: "elasticquotas",
+				Verb:     "update",
+			},
+		},
+	}
+	if err := c.Create(ctx, accessReview); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to review access, err: %v", err)
+	}
+	if !accessReview.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("user %s is not allowed to resync quota topology", userInfo.Username)
+	}
+	return http.StatusOK, nil
+}
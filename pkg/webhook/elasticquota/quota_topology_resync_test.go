@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func newFakeQuotaTopologyWithQuotas(t *testing.T, quotas ...*v1alpha1.ElasticQuota) *quotaTopology {
+	kubeClient := fake.NewClientBuilder().Build()
+	v1alpha1.AddToScheme(kubeClient.Scheme())
+	qt := newFakeQuotaTopology()
+	qt.client = kubeClient
+	for _, quota := range quotas {
+		err := kubeClient.Create(context.TODO(), quota)
+		assert.NoError(t, err)
+		qt.OnQuotaAdd(quota)
+	}
+	return qt
+}
+
+func TestQuotaTopology_Resync(t *testing.T) {
+	parentQuota := MakeQuota("parent-quota").Namespace("kube-system").IsParent(true).
+		Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).Min(MakeResourceList().CPU(64).Mem(51200).Obj()).Obj()
+	childQuota := MakeQuota("child-quota").Namespace("kube-system").IsParent(false).ParentName("parent-quota").
+		Annotations(map[string]string{extension.AnnotationQuotaNamespaces: `["namespace1"]`}).
+		Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).Min(MakeResourceList().CPU(64).Mem(51200).Obj()).Obj()
+	qt := newFakeQuotaTopologyWithQuotas(t, parentQuota, childQuota)
+
+	// no corrections if the topology is consistent
+	summary, err := qt.Resync(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, summary.Corrections())
+
+	// corrupt the in-memory topology
+	qt.lock.Lock()
+	delete(qt.quotaInfoMap, childQuota.Name)
+	qt.quotaInfoMap[parentQuota.Name].CalculateInfo.Max = MakeResourceList().CPU(1).Obj()
+	qt.quotaInfoMap["stale-quota"] = NewQuotaInfo(false, true, "stale-quota", extension.RootQuotaName)
+	delete(qt.quotaHierarchyInfo[parentQuota.Name], childQuota.Name)
+	qt.namespaceToQuotaMap["namespace1"] = "stale-quota"
+	qt.lock.Unlock()
+
+	summary, err = qt.Resync(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, &QuotaTopologyResyncSummary{
+		AddedQuotas:        []string{childQuota.Name},
+		RemovedQuotas:      []string{"stale-quota"},
+		UpdatedQuotas:      []string{parentQuota.Name},
+		UpdatedHierarchies: []string{parentQuota.Name},
+		UpdatedNamespaces:  []string{"namespace1"},
+	}, summary)
+	assert.Equal(t, 5, summary.Corrections())
+	assert.True(t, isQuotaInfoEqual(NewQuotaInfoFromQuota(parentQuota), qt.getQuotaInfo(parentQuota.Name, "")))
+	assert.True(t, isQuotaInfoEqual(NewQuotaInfoFromQuota(childQuota), qt.getQuotaInfo(childQuota.Name, "")))
+	assert.Nil(t, qt.getQuotaInfo("stale-quota", ""))
+	assert.Equal(t, map[string]struct{}{childQuota.Name: {}}, qt.quotaHierarchyInfo[parentQuota.Name])
+	assert.Equal(t, childQuota.Name, qt.getQuotaInfo("", "namespace1").Name)
+}
+
+func TestQuotaTopologyResyncHandler(t *testing.T) {
+	allow := func(ctx context.Context, c client.Client, r *http.Request) (int, error) {
+		return http.StatusOK, nil
+	}
+	deny := func(ctx context.Context, c client.Client, r *http.Request) (int, error) {
+		return http.StatusForbidden, fmt.Errorf("forbidden")
+	}
+	tests := []struct {
+		name         string
+		method       string
+		authenticate func(ctx context.Context, c client.Client, r *http.Request) (int, error)
+		corrupt      bool
+		wantCode     int
+		wantSummary  *QuotaTopologyResyncSummary
+	}{
+		{
+			name:         "method not allowed",
+			method:       http.MethodGet,
+			authenticate: allow,
+			wantCode:     http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "missing bearer token",
+			method:       http.MethodPost,
+			authenticate: authenticateByToken,
+			wantCode:     http.StatusUnauthorized,
+		},
+		{
+			name:         "forbidden",
+			method:       http.MethodPost,
+			authenticate: deny,
+			wantCode:     http.StatusForbidden,
+		},
+		{
+			name:         "repair corrupted topology",
+			method:       http.MethodPost,
+			authenticate: allow,
+			corrupt:      true,
+			wantCode:     http.StatusOK,
+			wantSummary: &QuotaTopologyResyncSummary{
+				AddedQuotas:        []string{"test-quota"},
+				UpdatedHierarchies: []string{extension.RootQuotaName},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quota := MakeQuota("test-quota").Namespace("kube-system").
+				Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).Obj()
+			qt := newFakeQuotaTopologyWithQuotas(t, quota)
+			if tt.corrupt {
+				qt.OnQuotaDelete(quota)
+			}
+			oldQuotaTopo := quotaMetaCheck.QuotaTopo
+			quotaMetaCheck.QuotaTopo = qt
+			defer func() {
+				quotaMetaCheck.QuotaTopo = oldQuotaTopo
+			}()
+
+			h := &QuotaTopologyResyncHandler{authenticate: tt.authenticate}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, QuotaTopologyResyncPath, nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantSummary != nil {
+				summary := &QuotaTopologyResyncSummary{}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), summary))
+				assert.Equal(t, tt.wantSummary, summary)
+				assert.NotNil(t, qt.getQuotaInfo(quota.Name, ""))
+			}
+		})
+	}
+}
@@ -20,11 +20,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -34,6 +41,7 @@ import (
 	koordfeatures "github.com/koordinator-sh/koordinator/pkg/features"
 	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
 	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/metrics"
 )
 
 func newFakeQuotaTopology() *quotaTopology {
@@ -67,13 +75,17 @@ func TestQuotaTopology_basicItemCheck(t *testing.T) {
 		{
 			name:  "max < 0",
 			quota: MakeQuota("temp").Max(MakeResourceList().CPU(-1).Mem(1048576).Obj()).Obj(),
-			err:   fmt.Errorf("%v quota.Spec.Max's value < 0, in dimensions :%v", "temp", "[cpu]"),
+			err: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "max").Key("cpu"), "-1", "must be greater than or equal to 0"),
+			}.ToAggregate(),
 		},
 		{
 			name: "min < 0",
 			quota: MakeQuota("temp").Min(MakeResourceList().CPU(-1).Mem(1048576).Obj()).
 				Max(MakeResourceList().CPU(1).Mem(1048576).Obj()).Obj(),
-			err: fmt.Errorf("%v quota.Spec.Min's value < 0, in dimensions :%v", "temp", "[cpu]"),
+			err: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "min").Key("cpu"), "-1", "must be greater than or equal to 0"),
+			}.ToAggregate(),
 		},
 		{
 			name: "min dimension larger than max",
@@ -93,6 +105,15 @@ func TestQuotaTopology_basicItemCheck(t *testing.T) {
 			quota: MakeQuota("temp").sharedWeight(MakeResourceList().CPU(-1).Mem(1048576).Obj()).Max(MakeResourceList().CPU(0).Mem(1048576).Obj()).Obj(),
 			err:   fmt.Errorf("%v quota.Annotation[%v]'s value < 0, in dimension :%v", "temp", extension.AnnotationSharedWeight, "[cpu]"),
 		},
+		{
+			name: "pinned annotation sharedWeight is invalid",
+			quota: MakeQuota("temp").Annotations(map[string]string{
+				extension.AnnotationSharedWeight:       "invalid",
+				extension.AnnotationSharedWeightPinned: "true",
+			}).Max(MakeResourceList().CPU(10).Mem(1048576).Obj()).Obj(),
+			err: fmt.Errorf("%v quota.Annotation[%v] is pinned but not a valid resource list: %w", "temp", extension.AnnotationSharedWeight,
+				json.Unmarshal([]byte("invalid"), &v1.ResourceList{})),
+		},
 		{
 			name: "annotation check max >= used",
 			quota: MakeQuota("temp").Annotations(map[string]string{extension.AnnotationMaxStrictCheckResourceKeys: `["cpu","memory"]`}).
@@ -126,6 +147,96 @@ func TestQuotaTopology_basicItemCheck(t *testing.T) {
 	}
 }
 
+func TestValidateQuotaResourceQuantities(t *testing.T) {
+	tests := []struct {
+		name  string
+		quota *v1alpha1.ElasticQuota
+		want  field.ErrorList
+	}{
+		{
+			name: "valid quantities",
+			quota: MakeQuota("temp").Min(MakeResourceList().CPU(1).Mem(1048576).Obj()).
+				Max(MakeResourceList().CPU(10).Mem(1048576).Obj()).Obj(),
+		},
+		{
+			name:  "zero quantities",
+			quota: MakeQuota("temp").Max(MakeResourceList().CPU(0).Mem(0).Obj()).Obj(),
+		},
+		{
+			name: "negative quantities in max and min",
+			quota: MakeQuota("temp").Min(MakeResourceList().CPU(-1).Mem(1048576).Obj()).
+				Max(MakeResourceList().CPU(-2).Mem(-1048576).Obj()).Obj(),
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "max").Key("cpu"), "-2", "must be greater than or equal to 0"),
+				field.Invalid(field.NewPath("spec", "max").Key("memory"), "-1048576", "must be greater than or equal to 0"),
+				field.Invalid(field.NewPath("spec", "min").Key("cpu"), "-1", "must be greater than or equal to 0"),
+			},
+		},
+		{
+			name: "negative fractional quantity",
+			quota: MakeQuota("temp").Max(v1.ResourceList{
+				v1.ResourceCPU: resource.MustParse("-500m"),
+			}).Obj(),
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "max").Key("cpu"), "-500m", "must be greater than or equal to 0"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateQuotaResourceQuantities(tt.quota)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuotaTopology_validateAllowedQuotaResources(t *testing.T) {
+	tests := []struct {
+		name             string
+		allowedResources string
+		quota            *v1alpha1.ElasticQuota
+		err              error
+	}{
+		{
+			name:             "empty allowed resources admit all",
+			allowedResources: "",
+			quota: MakeQuota("temp").Min(MakeResourceList().CPU(1).Mem(1048576).GPU(1).Obj()).
+				Max(MakeResourceList().CPU(10).Mem(1048576).GPU(1).Obj()).Obj(),
+			err: nil,
+		},
+		{
+			name:             "allowed resources",
+			allowedResources: "cpu, memory",
+			quota: MakeQuota("temp").Min(MakeResourceList().CPU(1).Mem(1048576).Obj()).
+				Max(MakeResourceList().CPU(10).Mem(1048576).Obj()).Obj(),
+			err: nil,
+		},
+		{
+			name:             "max contains disallowed resources",
+			allowedResources: "cpu",
+			quota: MakeQuota("temp").Min(MakeResourceList().CPU(1).Obj()).
+				Max(MakeResourceList().CPU(10).Mem(1048576).GPU(1).Obj()).Obj(),
+			err: fmt.Errorf("%v quota.Spec.Max contains disallowed resources :%v", "temp", "[memory nvidia.com/gpu]"),
+		},
+		{
+			name:             "min contains disallowed resources",
+			allowedResources: "cpu",
+			quota: MakeQuota("temp").Min(MakeResourceList().CPU(1).Mem(1048576).Obj()).
+				Max(MakeResourceList().CPU(10).Obj()).Obj(),
+			err: fmt.Errorf("%v quota.Spec.Min contains disallowed resources :%v", "temp", "[memory]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qt := newFakeQuotaTopology()
+			qt.allowedQuotaResources = parseAllowedQuotaResources(tt.allowedResources)
+			qt.fillQuotaDefaultInformation(tt.quota)
+			err := qt.validateQuotaSelfItem(tt.quota)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
 func TestQuotaTopology_fillQuotaDefaultInformation(t *testing.T) {
 	type quotaInfo struct {
 		initOne                      *v1alpha1.ElasticQuota
@@ -204,6 +315,18 @@ func TestQuotaTopology_fillQuotaDefaultInformation(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "quota with pinned annotation SharedWeight, SharedWeight.keys != maxQuota.keys",
+			quotas: []*quotaInfo{
+				{
+					initOne: MakeQuota("temp2").sharedWeight(MakeResourceList().CPU(0).Obj()).Max(MakeResourceList().Mem(1048576).Obj()).
+						Annotations(map[string]string{extension.AnnotationSharedWeightPinned: "true"}).TreeID("tree-1").Obj(),
+					expectLabelQuotaParent:       extension.RootQuotaName,
+					expectAnnotationSharedWeight: "{\"cpu\":\"0\"}",
+					expectedLabelQuotaTreeID:     "tree-1",
+				},
+			},
+		},
 		{
 			name: "quota with annotation SharedWeight, len(SharedWeight.keys) == len(maxQuota.keys), SharedWeight.keys != maxQuota.keys",
 			quotas: []*quotaInfo{
@@ -231,6 +354,119 @@ func TestQuotaTopology_fillQuotaDefaultInformation(t *testing.T) {
 		})
 	}
 }
+func TestQuotaTopology_fillQuotaDefaultInformationPinnedSharedWeight(t *testing.T) {
+	qt := newFakeQuotaTopology()
+	quota := MakeQuota("temp").sharedWeight(MakeResourceList().CPU(10).Obj()).Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+		Annotations(map[string]string{extension.AnnotationSharedWeightPinned: "true"}).Obj()
+	assert.NoError(t, qt.fillQuotaDefaultInformation(quota))
+	assert.Equal(t, "{\"cpu\":\"10\"}", quota.Annotations[extension.AnnotationSharedWeight])
+	assert.NoError(t, qt.validateQuotaSelfItem(quota))
+	qt.OnQuotaAdd(quota)
+
+	newQuota := quota.DeepCopy()
+	newQuota.Spec.Max = MakeResourceList().Mem(2097152).Obj()
+	assert.NoError(t, qt.fillQuotaDefaultInformation(newQuota))
+	assert.Equal(t, "{\"cpu\":\"10\"}", newQuota.Annotations[extension.AnnotationSharedWeight])
+	assert.NoError(t, qt.ValidUpdateQuota(quota, newQuota))
+	assert.Equal(t, "{\"cpu\":\"10\"}", newQuota.Annotations[extension.AnnotationSharedWeight])
+
+	// the pinned annotation requires an explicit sharedWeight
+	unpinned := MakeQuota("temp-1").Max(MakeResourceList().CPU(120).Obj()).
+		Annotations(map[string]string{extension.AnnotationSharedWeightPinned: "true"}).Obj()
+	assert.Error(t, qt.validateQuotaSelfItem(unpinned))
+}
+
+func TestQuotaTopology_fillQuotaDefaultInformationSharedWeightResourceKeys(t *testing.T) {
+	qt := newFakeQuotaTopology()
+	quota := MakeQuota("temp").Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+		Annotations(map[string]string{extension.AnnotationSharedWeightResourceKeys: `["cpu"]`}).Obj()
+	assert.NoError(t, qt.fillQuotaDefaultInformation(quota))
+	assert.Equal(t, "{\"cpu\":\"120\"}", quota.Annotations[extension.AnnotationSharedWeight])
+	assert.NoError(t, qt.validateQuotaSelfItem(quota))
+	assert.NoError(t, qt.ValidAddQuota(quota))
+
+	// the subset survives the updates of max
+	newQuota := quota.DeepCopy()
+	newQuota.Spec.Max = MakeResourceList().CPU(240).Mem(2097152).GPU(1).Obj()
+	assert.NoError(t, qt.fillQuotaDefaultInformation(newQuota))
+	assert.Equal(t, "{\"cpu\":\"120\"}", newQuota.Annotations[extension.AnnotationSharedWeight])
+	assert.NoError(t, qt.ValidUpdateQuota(quota, newQuota))
+	_, _, sharedWeight, err := qt.GetEffectiveQuota(newQuota.Name)
+	assert.NoError(t, err)
+	assert.True(t, quotav1.Equals(MakeResourceList().CPU(120).Obj(), sharedWeight), "got sharedWeight %v", sharedWeight)
+
+	// the resources not participating are removed from the sharedWeight
+	updatedQuota := newQuota.DeepCopy()
+	updatedQuota.Annotations[extension.AnnotationSharedWeight] = "{\"cpu\":\"10\",\"memory\":\"10\"}"
+	assert.NoError(t, qt.fillQuotaDefaultInformation(updatedQuota))
+	assert.Equal(t, "{\"cpu\":\"10\"}", updatedQuota.Annotations[extension.AnnotationSharedWeight])
+	assert.NoError(t, qt.ValidUpdateQuota(newQuota, updatedQuota))
+
+	// the resources participating must be included in max
+	invalid := MakeQuota("temp-1").Max(MakeResourceList().CPU(120).Obj()).
+		Annotations(map[string]string{extension.AnnotationSharedWeightResourceKeys: `["cpu","memory"]`}).Obj()
+	assert.NoError(t, qt.fillQuotaDefaultInformation(invalid))
+	err = qt.validateQuotaSelfItem(invalid)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "resourceKey memory of quota temp-1")
+
+	malformed := MakeQuota("temp-2").Max(MakeResourceList().CPU(120).Obj()).
+		Annotations(map[string]string{extension.AnnotationSharedWeightResourceKeys: "cpu"}).Obj()
+	assert.Error(t, qt.fillQuotaDefaultInformation(malformed))
+	assert.Error(t, qt.validateQuotaSelfItem(malformed))
+}
+
+func TestQuotaTopology_GetEffectiveQuota(t *testing.T) {
+	tests := []struct {
+		name  string
+		quota *v1alpha1.ElasticQuota
+	}{
+		{
+			name:  "sharedWeight defaults to max",
+			quota: MakeQuota("temp").Min(MakeResourceList().CPU(10).Obj()).Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).Obj(),
+		},
+		{
+			name: "sharedWeight is fixed by max",
+			quota: MakeQuota("temp").sharedWeight(MakeResourceList().CPU(10).GPU(1).Obj()).
+				Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).Obj(),
+		},
+		{
+			name: "pinned sharedWeight is kept",
+			quota: MakeQuota("temp").sharedWeight(MakeResourceList().CPU(10).Obj()).Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+				Annotations(map[string]string{extension.AnnotationSharedWeightPinned: "true"}).Obj(),
+		},
+		{
+			name: "child quota",
+			quota: MakeQuota("temp-bu1").ParentName("temp2").Min(MakeResourceList().CPU(5).Obj()).
+				Max(MakeResourceList().CPU(60).Obj()).Obj(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qt := newFakeQuotaTopology()
+			qt.OnQuotaAdd(MakeQuota("temp2").Max(MakeResourceList().CPU(120).Obj()).IsParent(true).Obj())
+			original := tt.quota.DeepCopy()
+			qt.OnQuotaAdd(tt.quota)
+
+			min, max, sharedWeight, err := qt.GetEffectiveQuota(tt.quota.Name)
+			assert.NoError(t, err)
+			assert.Equal(t, original, tt.quota)
+
+			filled := tt.quota.DeepCopy()
+			assert.NoError(t, qt.fillQuotaDefaultInformation(filled))
+			expectedSharedWeight := v1.ResourceList{}
+			assert.NoError(t, json.Unmarshal([]byte(filled.Annotations[extension.AnnotationSharedWeight]), &expectedSharedWeight))
+			assert.True(t, quotav1.Equals(expectedSharedWeight, sharedWeight), "expected sharedWeight %v, got %v", expectedSharedWeight, sharedWeight)
+			assert.True(t, quotav1.Equals(filled.Spec.Min, min))
+			assert.True(t, quotav1.Equals(filled.Spec.Max, max))
+		})
+	}
+
+	qt := newFakeQuotaTopology()
+	_, _, _, err := qt.GetEffectiveQuota("not-exist")
+	assert.Error(t, err)
+}
+
 func TestQuotaTopology_checkSubAndParentGroupMaxQuotaKeySame(t *testing.T) {
 	tests := []struct {
 		name                     string
@@ -428,6 +664,26 @@ func TestQuotaTopology_checkTreeID(t *testing.T) {
 				Min(MakeResourceList().CPU(10).Mem(20).Obj()).IsParent(false).TreeID("tree-1").Obj(),
 			expectErr: true,
 		},
+		{
+			name: "conflict with parent tree id",
+			parentQuota: MakeQuota("parent").Max(MakeResourceList().CPU(10).Mem(20).Obj()).
+				Min(MakeResourceList().CPU(10).Mem(20).Obj()).IsParent(true).TreeID("tree-1").Obj(),
+			quota: MakeQuota("temp").ParentName("parent").Max(MakeResourceList().CPU(10).Mem(20).Obj()).
+				Min(MakeResourceList().CPU(10).Mem(20).Obj()).IsParent(true).TreeID("tree-2").Obj(),
+			childQuota: MakeQuota("child").ParentName("temp").Max(MakeResourceList().CPU(10).Mem(20).Obj()).
+				Min(MakeResourceList().CPU(10).Mem(20).Obj()).IsParent(false).TreeID("tree-2").Obj(),
+			expectErr: true,
+		},
+		{
+			name: "conflict with child tree id",
+			parentQuota: MakeQuota("parent").Max(MakeResourceList().CPU(10).Mem(20).Obj()).
+				Min(MakeResourceList().CPU(10).Mem(20).Obj()).IsParent(true).TreeID("tree-1").Obj(),
+			quota: MakeQuota("temp").ParentName("parent").Max(MakeResourceList().CPU(10).Mem(20).Obj()).
+				Min(MakeResourceList().CPU(10).Mem(20).Obj()).IsParent(true).TreeID("tree-1").Obj(),
+			childQuota: MakeQuota("child").ParentName("temp").Max(MakeResourceList().CPU(10).Mem(20).Obj()).
+				Min(MakeResourceList().CPU(10).Mem(20).Obj()).IsParent(false).TreeID("tree-2").Obj(),
+			expectErr: true,
+		},
 		{
 			name: "child no tree id",
 			parentQuota: MakeQuota("parent").Max(MakeResourceList().CPU(10).Mem(20).Obj()).
@@ -588,6 +844,68 @@ func TestQuotaTopology_ValidAddQuota(t *testing.T) {
 		Min(MakeResourceList().CPU(64).Mem(51200).Obj()).IsParent(true).Obj()
 	err = qt.ValidAddQuota(sub3)
 	assert.NotNil(t, err)
+
+	// child explicitly sets a tree id conflicting with its parent
+	sub4 := MakeQuota("sub-4").ParentName("temp2").Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+		Min(MakeResourceList().CPU(64).Mem(51200).Obj()).IsParent(false).TreeID("tree-2").Obj()
+	assert.Nil(t, qt.fillQuotaDefaultInformation(sub4))
+	assert.Equal(t, "tree-2", sub4.Labels[extension.LabelQuotaTreeID])
+	err = qt.ValidAddQuota(sub4)
+	assert.Equal(t, "sub-4 tree id is different from parent temp2, [tree-2] vs [tree-1]", err.Error())
+
+	// child explicitly sets the same tree id with its parent
+	sub5 := MakeQuota("sub-5").ParentName("temp2").Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+		Min(MakeResourceList().CPU(32).Mem(25600).Obj()).IsParent(false).TreeID("tree-1").Obj()
+	assert.Nil(t, qt.fillQuotaDefaultInformation(sub5))
+	err = qt.ValidAddQuota(sub5)
+	assert.Nil(t, err)
+}
+
+func TestQuotaTopology_ValidAddQuotaWithParent(t *testing.T) {
+	qt := newFakeQuotaTopology()
+
+	// the parent has not been created yet
+	sub1 := MakeQuota("sub-1").ParentName("parent").Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+		Min(MakeResourceList().CPU(16).Mem(12800).Obj()).IsParent(false).Obj()
+	err := qt.ValidAddQuota(sub1)
+	assert.EqualError(t, err, "AddQuota quota sub-1's parent parent does not exist, the parent quota must be created before its children")
+	assert.Equal(t, 0, len(qt.quotaInfoMap))
+
+	parent := MakeQuota("parent").Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+		Min(MakeResourceList().CPU(64).Mem(51200).Obj()).IsParent(true).Obj()
+	assert.Nil(t, qt.fillQuotaDefaultInformation(parent))
+	assert.Nil(t, qt.ValidAddQuota(parent))
+
+	// the parent exists
+	assert.Nil(t, qt.fillQuotaDefaultInformation(sub1))
+	assert.Nil(t, qt.ValidAddQuota(sub1))
+	assert.Equal(t, 2, len(qt.quotaInfoMap))
+	assert.Equal(t, 1, len(qt.quotaHierarchyInfo["parent"]))
+}
+
+func TestQuotaTopology_ReservedQuotaMin(t *testing.T) {
+	qt := newFakeQuotaTopology()
+
+	systemQuota := MakeQuota(extension.SystemQuotaName).Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+		Min(MakeResourceList().CPU(64).Obj()).Obj()
+	assert.Nil(t, qt.fillQuotaDefaultInformation(systemQuota))
+	err := qt.ValidAddQuota(systemQuota)
+	assert.EqualError(t, err, fmt.Sprintf("reserved quota %v can not carry a non-zero min :%v", extension.SystemQuotaName, systemQuota.Spec.Min))
+
+	// zero min is allowed
+	defaultQuota := MakeQuota(extension.DefaultQuotaName).Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+		Min(MakeResourceList().CPU(0).Obj()).Obj()
+	assert.Nil(t, qt.fillQuotaDefaultInformation(defaultQuota))
+	assert.Nil(t, qt.ValidAddQuota(defaultQuota))
+
+	newDefaultQuota := defaultQuota.DeepCopy()
+	newDefaultQuota.Spec.Min = MakeResourceList().CPU(16).Obj()
+	err = qt.ValidUpdateQuota(defaultQuota, newDefaultQuota)
+	assert.EqualError(t, err, fmt.Sprintf("reserved quota %v can not carry a non-zero min :%v", extension.DefaultQuotaName, newDefaultQuota.Spec.Min))
+
+	newDefaultQuota.Spec.Max = MakeResourceList().CPU(200).Mem(1048576).Obj()
+	newDefaultQuota.Spec.Min = nil
+	assert.Nil(t, qt.ValidUpdateQuota(defaultQuota, newDefaultQuota))
 }
 
 func TestQuotaTopology_ValidUpdateQuota(t *testing.T) {
@@ -777,6 +1095,244 @@ func TestQuotaTopology_AnnotationNamespaces(t *testing.T) {
 	qt.lock.Unlock()
 }
 
+func TestQuotaTopology_MaxNamespacesPerQuota(t *testing.T) {
+	qt := newFakeQuotaTopology()
+	qt.maxNamespacesPerQuota = 2
+	client := fake.NewClientBuilder().WithIndex(&v1.Pod{}, "label.quotaName", func(object client.Object) []string {
+		return []string{object.(*v1.Pod).Labels["label.quotaName"]}
+	}).Build()
+	v1alpha1.AddToScheme(client.Scheme())
+	qt.client = client
+
+	tooMany := MakeQuota("too-many").Annotations(map[string]string{extension.AnnotationQuotaNamespaces: "[\"test1\",\"test2\",\"test3\"]"}).Obj()
+	err := qt.ValidAddQuota(tooMany)
+	assert.Equal(t, fmt.Errorf("quota too-many binds 3 namespaces, exceeds the limit 2"), err)
+	qt.lock.RLock()
+	assert.Empty(t, qt.namespaceToQuotaMap)
+	qt.lock.RUnlock()
+
+	atLimit := MakeQuota("at-limit").Annotations(map[string]string{extension.AnnotationQuotaNamespaces: "[\"test1\",\"test2\"]"}).Obj()
+	err = qt.ValidAddQuota(atLimit)
+	assert.Nil(t, err)
+
+	newQuota := atLimit.DeepCopy()
+	newQuota.Annotations[extension.AnnotationQuotaNamespaces] = "[\"test1\",\"test2\",\"test3\"]"
+	err = qt.ValidUpdateQuota(atLimit, newQuota)
+	assert.Equal(t, fmt.Errorf("quota at-limit binds 3 namespaces, exceeds the limit 2"), err)
+	qt.lock.RLock()
+	assert.Equal(t, map[string]string{"test1": "at-limit", "test2": "at-limit"}, qt.namespaceToQuotaMap)
+	qt.lock.RUnlock()
+
+	qt.maxNamespacesPerQuota = 0
+	err = qt.ValidUpdateQuota(atLimit, newQuota)
+	assert.Nil(t, err)
+}
+
+func TestQuotaTopology_InvalidAnnotationNamespaces(t *testing.T) {
+	qt := newFakeQuotaTopology()
+	client := fake.NewClientBuilder().WithIndex(&v1.Pod{}, "label.quotaName", func(object client.Object) []string {
+		return []string{object.(*v1.Pod).Labels["label.quotaName"]}
+	}).Build()
+	v1alpha1.AddToScheme(client.Scheme())
+	qt.client = client
+
+	tooLong := strings.Repeat("a", 64)
+
+	uppercase := MakeQuota("uppercase").Annotations(map[string]string{extension.AnnotationQuotaNamespaces: "[\"test1\",\"Test2\"]"}).Obj()
+	err := qt.ValidAddQuota(uppercase)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "quota uppercase's annotation namespace \"Test2\" is invalid")
+
+	overLength := MakeQuota("over-length").Annotations(map[string]string{extension.AnnotationQuotaNamespaces: "[\"" + tooLong + "\"]"}).Obj()
+	err = qt.ValidAddQuota(overLength)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "quota over-length's annotation namespace \""+tooLong+"\" is invalid")
+	qt.lock.RLock()
+	assert.Empty(t, qt.namespaceToQuotaMap)
+	qt.lock.RUnlock()
+
+	valid := MakeQuota("valid").Annotations(map[string]string{extension.AnnotationQuotaNamespaces: "[\"test1\",\"test-2\"]"}).Obj()
+	err = qt.ValidAddQuota(valid)
+	assert.Nil(t, err)
+
+	newQuota := valid.DeepCopy()
+	newQuota.Annotations[extension.AnnotationQuotaNamespaces] = "[\"test1\",\"Test2\"]"
+	err = qt.ValidUpdateQuota(valid, newQuota)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "quota valid's annotation namespace \"Test2\" is invalid")
+
+	newQuota.Annotations[extension.AnnotationQuotaNamespaces] = "[\"" + tooLong + "\"]"
+	err = qt.ValidUpdateQuota(valid, newQuota)
+	assert.NotNil(t, err)
+	qt.lock.RLock()
+	assert.Equal(t, map[string]string{"test1": "valid", "test-2": "valid"}, qt.namespaceToQuotaMap)
+	qt.lock.RUnlock()
+}
+
+func TestQuotaTopology_TempMax(t *testing.T) {
+	qt := newFakeQuotaTopology()
+	client := fake.NewClientBuilder().WithIndex(&v1.Pod{}, "label.quotaName", func(object client.Object) []string {
+		return []string{object.(*v1.Pod).Labels["label.quotaName"]}
+	}).Build()
+	v1alpha1.AddToScheme(client.Scheme())
+	qt.client = client
+
+	now := time.Now()
+	tempMaxAnnotation := func(cpu int64, expireTime time.Time) map[string]string {
+		return map[string]string{
+			extension.AnnotationQuotaTempMax: fmt.Sprintf(`{"max":{"cpu":"%d"},"expireTime":"%s"}`, cpu, expireTime.Format(time.RFC3339)),
+		}
+	}
+
+	lessThanMax := MakeQuota("less-than-max").Max(MakeResourceList().CPU(100).Mem(1048576).Obj()).
+		Annotations(tempMaxAnnotation(50, now.Add(time.Hour))).Obj()
+	err := qt.ValidAddQuota(lessThanMax)
+	assert.Equal(t, fmt.Errorf("resourceKey cpu of quota less-than-max temp max 50 < max 100"), err)
+
+	expired := MakeQuota("expired").Max(MakeResourceList().CPU(100).Mem(1048576).Obj()).
+		Annotations(tempMaxAnnotation(200, now.Add(-time.Hour))).Obj()
+	err = qt.ValidAddQuota(expired)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "is not in the future")
+
+	invalidExpireTime := MakeQuota("invalid-expire-time").Max(MakeResourceList().CPU(100).Mem(1048576).Obj()).
+		Annotations(map[string]string{extension.AnnotationQuotaTempMax: `{"max":{"cpu":"200"},"expireTime":"tomorrow"}`}).Obj()
+	err = qt.ValidAddQuota(invalidExpireTime)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid expireTime")
+
+	boosted := MakeQuota("boosted").Max(MakeResourceList().CPU(100).Mem(1048576).Obj()).
+		Annotations(tempMaxAnnotation(200, now.Add(time.Hour))).Obj()
+	err = qt.ValidAddQuota(boosted)
+	assert.Nil(t, err)
+
+	qt.lock.RLock()
+	quotaInfo := qt.quotaInfoMap["boosted"]
+	qt.lock.RUnlock()
+	activeMax := quotaInfo.GetEffectiveMax(now)
+	assert.True(t, activeMax.Cpu().Equal(*resource.NewQuantity(200, resource.DecimalSI)))
+	assert.True(t, activeMax.Memory().Equal(*resource.NewQuantity(1048576, resource.BinarySI)))
+	assert.True(t, quotaInfo.GetQuotaSummary().Max.Cpu().Equal(*resource.NewQuantity(200, resource.DecimalSI)))
+
+	expiredMax := quotaInfo.GetEffectiveMax(now.Add(2 * time.Hour))
+	assert.True(t, expiredMax.Cpu().Equal(*resource.NewQuantity(100, resource.DecimalSI)))
+	assert.True(t, quotaInfo.CalculateInfo.Max.Cpu().Equal(*resource.NewQuantity(100, resource.DecimalSI)))
+}
+
+func TestQuotaTopology_TreeCeiling(t *testing.T) {
+	qt := newFakeQuotaTopology()
+	newTreeRoot := func(treeID string, cpu int64) *v1alpha1.ElasticQuota {
+		quota := MakeQuota(treeID + "-root").Max(MakeResourceList().CPU(cpu).Mem(1048576).Obj()).
+			Min(MakeResourceList().CPU(cpu).Mem(1048576).Obj()).IsParent(true).IsRoot(true).TreeID(treeID).Obj()
+		qt.fillQuotaDefaultInformation(quota)
+		return quota
+	}
+	newTopLevel := func(name, treeID string, cpu int64) *v1alpha1.ElasticQuota {
+		quota := MakeQuota(name).ParentName(treeID + "-root").Max(MakeResourceList().CPU(cpu).Mem(1024).Obj()).
+			Min(MakeResourceList().CPU(10).Mem(1024).Obj()).IsParent(false).TreeID(treeID).Obj()
+		qt.fillQuotaDefaultInformation(quota)
+		return quota
+	}
+
+	t.Run("ceiling derived from the tree root", func(t *testing.T) {
+		qt = newFakeQuotaTopology()
+		qt.deriveTreeCeilingFromRoot = true
+
+		root := newTreeRoot("tree-1", 100)
+		assert.Nil(t, qt.ValidAddQuota(root))
+		assert.Nil(t, qt.ValidAddQuota(newTopLevel("a", "tree-1", 60)))
+
+		// over-allocated
+		err := qt.ValidAddQuota(newTopLevel("b", "tree-1", 50))
+		assert.Equal(t, fmt.Errorf("quota b over-allocates tree tree-1, the sum of the top-level quotas' max exceeds the ceiling, cpu: 110 > 100"), err)
+		qt.lock.RLock()
+		assert.NotContains(t, qt.quotaInfoMap, "b")
+		qt.lock.RUnlock()
+
+		// under-allocated
+		b := newTopLevel("b", "tree-1", 40)
+		assert.Nil(t, qt.ValidAddQuota(b))
+
+		// growing a top-level quota over the ceiling
+		newB := b.DeepCopy()
+		newB.Spec.Max = MakeResourceList().CPU(41).Mem(1024).Obj()
+		err = qt.ValidUpdateQuota(b, newB)
+		assert.Equal(t, fmt.Errorf("quota b over-allocates tree tree-1, the sum of the top-level quotas' max exceeds the ceiling, cpu: 101 > 100"), err)
+
+		// shrinking the tree root under the allocated
+		newRoot := root.DeepCopy()
+		newRoot.Spec.Max = MakeResourceList().CPU(90).Mem(1048576).Obj()
+		newRoot.Spec.Min = MakeResourceList().CPU(90).Mem(1048576).Obj()
+		err = qt.ValidUpdateQuota(root, newRoot)
+		assert.Equal(t, fmt.Errorf("quota tree-1-root over-allocates tree tree-1, the sum of the top-level quotas' max exceeds the ceiling, cpu: 100 > 90"), err)
+	})
+
+	t.Run("configured ceiling", func(t *testing.T) {
+		qt = newFakeQuotaTopology()
+		qt.treeCeilings = parseQuotaTreeCeilings(`{"tree-1":{"cpu":"80"}}`)
+
+		assert.Nil(t, qt.ValidAddQuota(newTreeRoot("tree-1", 100)))
+		assert.Nil(t, qt.ValidAddQuota(newTopLevel("a", "tree-1", 60)))
+		err := qt.ValidAddQuota(newTopLevel("b", "tree-1", 30))
+		assert.Equal(t, fmt.Errorf("quota b over-allocates tree tree-1, the sum of the top-level quotas' max exceeds the ceiling, cpu: 90 > 80"), err)
+		assert.Nil(t, qt.ValidAddQuota(newTopLevel("b", "tree-1", 20)))
+
+		// the trees without a ceiling are not limited
+		assert.Nil(t, qt.ValidAddQuota(newTreeRoot("tree-2", 100)))
+		assert.Nil(t, qt.ValidAddQuota(newTopLevel("c", "tree-2", 100)))
+		assert.Nil(t, qt.ValidAddQuota(newTopLevel("d", "tree-2", 100)))
+	})
+
+	t.Run("invalid configured ceilings are ignored", func(t *testing.T) {
+		assert.Nil(t, parseQuotaTreeCeilings(`{"tree-1":`))
+		assert.Nil(t, parseQuotaTreeCeilings(""))
+	})
+}
+
+func TestQuotaTopology_ForbidNamespacedParent(t *testing.T) {
+	namespacesAnnotation := map[string]string{extension.AnnotationQuotaNamespaces: "[\"test1\"]"}
+	newParent := func(name string, annotations map[string]string) *v1alpha1.ElasticQuota {
+		quota := MakeQuota(name).Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+			Min(MakeResourceList().CPU(64).Mem(51200).Obj()).IsParent(true).Annotations(annotations).Obj()
+		return quota
+	}
+
+	// the hybrid setup is allowed by default
+	qt := newFakeQuotaTopology()
+	quota := newParent("temp", namespacesAnnotation)
+	qt.fillQuotaDefaultInformation(quota)
+	assert.Nil(t, qt.ValidAddQuota(quota))
+
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, koordfeatures.ElasticQuotaForbidNamespacedParent, true)()
+
+	qt = newFakeQuotaTopology()
+	quota = newParent("temp", namespacesAnnotation)
+	qt.fillQuotaDefaultInformation(quota)
+	err := qt.ValidAddQuota(quota)
+	assert.Equal(t, fmt.Errorf("quota temp is bound to namespaces [test1] by annotation %v, isParent is forbidden to be true",
+		extension.AnnotationQuotaNamespaces), err)
+
+	quota = newParent("temp", map[string]string{})
+	qt.fillQuotaDefaultInformation(quota)
+	assert.Nil(t, qt.ValidAddQuota(quota))
+	sub1 := MakeQuota("sub-1").ParentName("temp").Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+		Min(MakeResourceList().CPU(16).Mem(12800).Obj()).IsParent(false).Annotations(namespacesAnnotation).Obj()
+	qt.fillQuotaDefaultInformation(sub1)
+	assert.Nil(t, qt.ValidAddQuota(sub1))
+
+	// the parent is being bound to namespaces
+	newQuota := quota.DeepCopy()
+	newQuota.Annotations[extension.AnnotationQuotaNamespaces] = "[\"test2\"]"
+	err = qt.ValidUpdateQuota(quota, newQuota)
+	assert.NotNil(t, err)
+
+	// a leaf quota bound to namespaces but still having children
+	leafInfo := NewQuotaInfo(false, true, "temp", extension.RootQuotaName)
+	err = qt.checkNamespacedParent(leafInfo, []string{"test2"})
+	assert.Equal(t, fmt.Errorf("quota temp is bound to namespaces [test2] by annotation %v, but it has children quotas",
+		extension.AnnotationQuotaNamespaces), err)
+}
+
 func TestQuotaTopology_ValidDeleteQuota(t *testing.T) {
 	qt := newFakeQuotaTopology()
 
@@ -1081,3 +1637,40 @@ func TestQuotaTopology_checkGuaranteeForMin(t *testing.T) {
 		})
 	}
 }
+
+func getQuotaAdmissionSampleCount(t *testing.T, operation string) uint64 {
+	m := &dto.Metric{}
+	assert.NoError(t, metrics.QuotaAdmissionDurationSeconds.WithLabelValues(operation).(prometheus.Histogram).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestQuotaTopology_AdmissionDurationMetrics(t *testing.T) {
+	qt := newFakeQuotaTopology()
+	client := fake.NewClientBuilder().WithIndex(&v1.Pod{}, "label.quotaName", func(object client.Object) []string {
+		return []string{object.(*v1.Pod).Labels[extension.LabelQuotaName]}
+	}).Build()
+	qt.client = client
+
+	addCount := getQuotaAdmissionSampleCount(t, metrics.QuotaAdmissionAdd)
+	updateCount := getQuotaAdmissionSampleCount(t, metrics.QuotaAdmissionUpdate)
+	deleteCount := getQuotaAdmissionSampleCount(t, metrics.QuotaAdmissionDelete)
+
+	quota := MakeQuota("temp").Max(MakeResourceList().CPU(120).Mem(1048576).Obj()).
+		Min(MakeResourceList().CPU(64).Mem(51200).Obj()).IsParent(false).Obj()
+	qt.fillQuotaDefaultInformation(quota)
+	assert.NoError(t, qt.ValidAddQuota(quota))
+	assert.Equal(t, addCount+1, getQuotaAdmissionSampleCount(t, metrics.QuotaAdmissionAdd))
+
+	newQuota := quota.DeepCopy()
+	newQuota.Spec.Max = MakeResourceList().CPU(200).Mem(1048576).Obj()
+	qt.fillQuotaDefaultInformation(newQuota)
+	assert.NoError(t, qt.ValidUpdateQuota(quota, newQuota))
+	assert.Equal(t, updateCount+1, getQuotaAdmissionSampleCount(t, metrics.QuotaAdmissionUpdate))
+
+	assert.NoError(t, qt.ValidDeleteQuota(newQuota))
+	assert.Equal(t, deleteCount+1, getQuotaAdmissionSampleCount(t, metrics.QuotaAdmissionDelete))
+
+	// the failed validations are observed too
+	assert.Error(t, qt.ValidDeleteQuota(newQuota))
+	assert.Equal(t, deleteCount+2, getQuotaAdmissionSampleCount(t, metrics.QuotaAdmissionDelete))
+}
@@ -17,10 +17,18 @@ limitations under the License.
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	v1 "k8s.io/api/core/v1"
 )
 
+const (
+	QuotaAdmissionAdd    = "add"
+	QuotaAdmissionUpdate = "update"
+	QuotaAdmissionDelete = "delete"
+)
+
 var (
 	quotaSharedWeight = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -30,8 +38,57 @@ var (
 		},
 		[]string{ElasticQuotaNameKey, ResourceNameKey},
 	)
+	quotaUsedRatio = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: KoordManagerWebhookSubsystem,
+			Name:      "quota_used_ratio",
+			Help:      "The ratio of the used resource to the max of the quota",
+		},
+		[]string{ElasticQuotaNameKey, ResourceNameKey},
+	)
+	quotaMinGuaranteeMet = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: KoordManagerWebhookSubsystem,
+			Name:      "quota_min_guarantee_met",
+			Help:      "Whether the runtime of the quota satisfies its min guarantee, 1 means met and 0 means not met",
+		},
+		[]string{ElasticQuotaNameKey, ResourceNameKey},
+	)
+	quotaTopologyManualResync = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: KoordManagerWebhookSubsystem,
+			Name:      "quota_topology_manual_resync_total",
+			Help:      "The number of manual resyncs of the quota topology",
+		},
+		[]string{StatusKey},
+	)
+
+	quotaTopologyResyncCorrections = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Subsystem: KoordManagerWebhookSubsystem,
+			Name:      "quota_topology_resync_corrections_total",
+			Help:      "The number of corrections made to the quota topology by manual resyncs",
+		},
+	)
+
+	// QuotaAdmissionDurationSeconds measures the time spent waiting for and holding the quota topology lock
+	// in the admission, including listing the pods of the quota for delete.
+	QuotaAdmissionDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "koord_quota_admission_duration_seconds",
+			Help:    "The duration of validating the elastic quota in the admission",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 2, 16),
+		},
+		[]string{OperationKey},
+	)
+
 	ElasticQuotaCollector = []prometheus.Collector{
 		quotaSharedWeight,
+		quotaUsedRatio,
+		quotaMinGuaranteeMet,
+		quotaTopologyManualResync,
+		quotaTopologyResyncCorrections,
+		QuotaAdmissionDurationSeconds,
 	}
 )
 
@@ -40,3 +97,54 @@ func RecordQuotaSharedWeight(quotaName string, max v1.ResourceList) {
 		quotaSharedWeight.WithLabelValues(quotaName, string(k)).Set(float64(v.Value()))
 	}
 }
+
+// RecordQuotaUsedRatio records the used/max ratio of the quota. Only the resources declared in max are recorded
+// to bound the cardinality.
+func RecordQuotaUsedRatio(quotaName string, used, max v1.ResourceList) {
+	for resourceName, maxQuantity := range max {
+		if maxQuantity.IsZero() {
+			continue
+		}
+		usedQuantity := used[resourceName]
+		quotaUsedRatio.WithLabelValues(quotaName, string(resourceName)).Set(float64(usedQuantity.MilliValue()) / float64(maxQuantity.MilliValue()))
+	}
+}
+
+// RecordQuotaMinGuaranteeMet records whether the runtime of the quota satisfies its min guarantee.
+// The guarantee is met if the runtime is not less than the min, or the request if the quota requests less than the min.
+// A nil request means the request is unknown and the min is used.
+func RecordQuotaMinGuaranteeMet(quotaName string, min, request, runtime v1.ResourceList) {
+	for resourceName, minQuantity := range min {
+		expected := minQuantity
+		if request != nil {
+			if requestQuantity := request[resourceName]; requestQuantity.Cmp(expected) < 0 {
+				expected = requestQuantity
+			}
+		}
+		met := 0.0
+		if runtimeQuantity := runtime[resourceName]; runtimeQuantity.Cmp(expected) >= 0 {
+			met = 1
+		}
+		quotaMinGuaranteeMet.WithLabelValues(quotaName, string(resourceName)).Set(met)
+	}
+}
+
+// ResetQuotaUsage deletes the usage metrics of the quota.
+func ResetQuotaUsage(quotaName string) {
+	quotaUsedRatio.DeletePartialMatch(prometheus.Labels{ElasticQuotaNameKey: quotaName})
+	quotaMinGuaranteeMet.DeletePartialMatch(prometheus.Labels{ElasticQuotaNameKey: quotaName})
+}
+
+func RecordQuotaTopologyManualResync(err error, corrections int) {
+	if err != nil {
+		quotaTopologyManualResync.WithLabelValues(StatusFailed).Inc()
+		return
+	}
+	quotaTopologyManualResync.WithLabelValues(StatusSucceeded).Inc()
+	quotaTopologyResyncCorrections.Add(float64(corrections))
+}
+
+// RecordQuotaAdmissionDuration records the admission duration of the operation since the start time.
+func RecordQuotaAdmissionDuration(operation string, start time.Time) {
+	QuotaAdmissionDurationSeconds.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -17,8 +17,11 @@ limitations under the License.
 package metrics
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -30,5 +33,46 @@ func TestElasticQuotaCollectors(t *testing.T) {
 	}
 	t.Run("test not panic", func(t *testing.T) {
 		RecordQuotaSharedWeight("test-quota", testingMaximum)
+		RecordQuotaTopologyManualResync(nil, 1)
+		RecordQuotaTopologyManualResync(fmt.Errorf("test error"), 0)
 	})
 }
+
+func TestRecordQuotaUsage(t *testing.T) {
+	max := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+		"nvidia.com/gpu":      resource.MustParse("0"),
+	}
+	used := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1500m"),
+		corev1.ResourceMemory: resource.MustParse("6Gi"),
+	}
+	min := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+	}
+	request := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("6Gi"),
+	}
+	runtime := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}
+
+	RecordQuotaUsedRatio("test-quota", used, max)
+	assert.Equal(t, 0.375, testutil.ToFloat64(quotaUsedRatio.WithLabelValues("test-quota", string(corev1.ResourceCPU))))
+	assert.Equal(t, 0.75, testutil.ToFloat64(quotaUsedRatio.WithLabelValues("test-quota", string(corev1.ResourceMemory))))
+	// the resource with zero max is not recorded
+	assert.Equal(t, 2, testutil.CollectAndCount(quotaUsedRatio))
+
+	RecordQuotaMinGuaranteeMet("test-quota", min, request, runtime)
+	// the quota requests less cpu than the min, and the runtime covers the request
+	assert.Equal(t, float64(1), testutil.ToFloat64(quotaMinGuaranteeMet.WithLabelValues("test-quota", string(corev1.ResourceCPU))))
+	assert.Equal(t, float64(0), testutil.ToFloat64(quotaMinGuaranteeMet.WithLabelValues("test-quota", string(corev1.ResourceMemory))))
+
+	ResetQuotaUsage("test-quota")
+	assert.Equal(t, 0, testutil.CollectAndCount(quotaUsedRatio))
+	assert.Equal(t, 0, testutil.CollectAndCount(quotaMinGuaranteeMet))
+}
@@ -25,6 +25,8 @@ const (
 	StatusKey                    = "status"
 	StatusAllowed                = "allowed"
 	StatusRejected               = "rejected"
+	StatusSucceeded              = "succeeded"
+	StatusFailed                 = "failed"
 	ObjectTypeKey                = "object_type"
 	WebhookTypeKey               = "webhook_type"
 	MutatingWebhook              = "mutate"
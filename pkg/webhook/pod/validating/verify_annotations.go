@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	"github.com/koordinator-sh/koordinator/pkg/util/reservation"
 )
 
@@ -47,6 +48,7 @@ func (h *PodValidatingHandler) clusterReservationValidatingPod(ctx context.Conte
 	}
 
 	allErrs = append(allErrs, forbidSpecialAnnotations(newPod)...)
+	allErrs = append(allErrs, validateMigrationAnnotations(newPod)...)
 	err := allErrs.ToAggregate()
 	allowed := true
 	reason := ""
@@ -74,3 +76,14 @@ func forbidSpecialAnnotations(pod *corev1.Pod) field.ErrorList {
 	}
 	return errorList
 }
+
+func validateMigrationAnnotations(pod *corev1.Pod) field.ErrorList {
+	value, ok := pod.Annotations[extension.AnnotationMigrationDisabled]
+	if !ok {
+		return nil
+	}
+	if _, err := extension.IsMigrationDisabled(pod.Annotations); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("annotations", extension.AnnotationMigrationDisabled), value, "must be a boolean")}
+	}
+	return nil
+}
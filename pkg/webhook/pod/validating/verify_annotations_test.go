@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	configv1alpha1 "github.com/koordinator-sh/koordinator/apis/config/v1alpha1"
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	"github.com/koordinator-sh/koordinator/pkg/util/reservation"
 )
@@ -62,6 +63,32 @@ func TestClusterReservationValidatingPod(t *testing.T) {
 			wantAllowed: false,
 			wantReason:  `annotations.scheduling.koordinator.sh/reserve-pod: Forbidden: cannot set in annotations`,
 		},
+		{
+			name:      "valid migration disabled annotation",
+			operation: admissionv1.Create,
+			newPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						extension.AnnotationMigrationDisabled: "true",
+					},
+				},
+			},
+			wantAllowed: true,
+		},
+		{
+			name:      "invalid migration disabled annotation",
+			operation: admissionv1.Create,
+			newPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						extension.AnnotationMigrationDisabled: "yes",
+					},
+				},
+			},
+			wantErr:     true,
+			wantAllowed: false,
+			wantReason:  `annotations.migration.koordinator.sh/disabled: Invalid value: "yes": must be a boolean`,
+		},
 	}
 
 	for _, tt := range tests {